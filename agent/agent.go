@@ -35,6 +35,10 @@ type Options struct {
 	HeartbeatInterval  time.Duration
 	AgentSleepInterval time.Duration
 	Cleanup            bool
+	// SpotInterruptionURL, if set, is polled while a task runs. A
+	// successful response means the host is about to be reclaimed.
+	SpotInterruptionURL      string
+	SpotInterruptionInterval time.Duration
 }
 
 type taskContext struct {
@@ -48,6 +52,7 @@ type taskContext struct {
 	taskDirectory  string
 	timeout        time.Duration
 	timedOut       bool
+	preempted      bool
 	sync.RWMutex
 }
 
@@ -235,6 +240,7 @@ func (a *Agent) runTask(ctx context.Context, cancel context.CancelFunc, tc *task
 	innerCtx, innerCancel := context.WithCancel(ctx)
 
 	go a.startIdleTimeoutWatch(ctx, tc, innerCancel)
+	go a.startSpotInterruptionWatch(ctx, tc, innerCancel)
 
 	complete := make(chan string)
 	go a.startTask(innerCtx, tc, complete)
@@ -301,8 +307,14 @@ func (a *Agent) finishTask(ctx context.Context, tc *taskContext, status string)
 		tc.logger.Task().Info("Task completed - SUCCESS.")
 		a.runPostTaskCommands(ctx, tc)
 	case evergreen.TaskFailed:
-		tc.logger.Task().Info("Task completed - FAILURE.")
-		a.runPostTaskCommands(ctx, tc)
+		if detail.Preempted {
+			// the host is about to go away, so send the logs and the
+			// final status without running the post-task commands
+			tc.logger.Task().Error("Task completed - PREEMPTED.")
+		} else {
+			tc.logger.Task().Info("Task completed - FAILURE.")
+			a.runPostTaskCommands(ctx, tc)
+		}
 	case evergreen.TaskUndispatched:
 		tc.logger.Task().Info("Task completed - ABORTED.")
 	case evergreen.TaskConflict:
@@ -326,6 +338,14 @@ func (a *Agent) finishTask(ctx context.Context, tc *taskContext, status string)
 }
 
 func (a *Agent) endTaskResponse(tc *taskContext, status string) *apimodels.TaskEndDetail {
+	if tc.wasPreempted() {
+		return &apimodels.TaskEndDetail{
+			Description: "host preempted",
+			Type:        model.SystemCommandType,
+			Preempted:   true,
+			Status:      evergreen.TaskFailed,
+		}
+	}
 	return &apimodels.TaskEndDetail{
 		Description: tc.getCurrentCommand().DisplayName(),
 		Type:        tc.getCurrentCommand().Type(),
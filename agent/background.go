@@ -2,10 +2,12 @@ package agent
 
 import (
 	"context"
+	"net/http"
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/rest/client"
+	"github.com/evergreen-ci/evergreen/util"
 	"github.com/mongodb/grip"
 	"github.com/mongodb/grip/recovery"
 	"github.com/pkg/errors"
@@ -94,6 +96,61 @@ func (a *Agent) startIdleTimeoutWatch(ctx context.Context, tc *taskContext, canc
 	}
 }
 
+// startSpotInterruptionWatch polls the spot interruption endpoint, if the
+// agent has one, and cancels the task as soon as the host is scheduled to be
+// reclaimed so that its logs and status reach the API server in time.
+func (a *Agent) startSpotInterruptionWatch(ctx context.Context, tc *taskContext, cancel context.CancelFunc) {
+	defer recovery.LogStackTraceAndContinue("spot interruption watcher")
+	if a.opts.SpotInterruptionURL == "" {
+		return
+	}
+	interval := defaultSpotInterruptionInterval
+	if a.opts.SpotInterruptionInterval != 0 {
+		interval = a.opts.SpotInterruptionInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			grip.Info("Spot interruption watch canceled")
+			return
+		case <-ticker.C:
+			if a.spotInterruptionScheduled(ctx) {
+				tc.logger.Execution().Error("Host is being reclaimed by the cloud provider, stopping task")
+				tc.reachPreempted()
+				cancel()
+				return
+			}
+		}
+	}
+}
+
+// spotInterruptionScheduled returns true if the spot interruption endpoint
+// reports that the host will be reclaimed. The endpoint returns 404 until an
+// interruption is scheduled; errors reaching it are logged and ignored.
+func (a *Agent) spotInterruptionScheduled(ctx context.Context) bool {
+	req, err := http.NewRequest(http.MethodGet, a.opts.SpotInterruptionURL, nil)
+	if err != nil {
+		grip.Warning(errors.Wrap(err, "problem building spot interruption request"))
+		return false
+	}
+	reqCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	client := util.GetHTTPClient()
+	defer util.PutHTTPClient(client)
+	resp, err := client.Do(req.WithContext(reqCtx))
+	if err != nil {
+		grip.Debug(errors.Wrap(err, "problem checking for spot interruption"))
+		return false
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode == http.StatusOK
+}
+
 func (a *Agent) startMaxExecTimeoutWatch(ctx context.Context, tc *taskContext, cancel context.CancelFunc) {
 	defer recovery.LogStackTraceAndContinue("exec timeout watcher")
 	defer cancel()
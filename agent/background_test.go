@@ -2,6 +2,8 @@ package agent
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -102,3 +104,45 @@ func (s *BackgroundSuite) TestGetCurrentTimeout() {
 func (s *BackgroundSuite) TestGetTimeoutDefault() {
 	s.Equal(defaultIdleTimeout, s.tc.getCurrentTimeout())
 }
+
+func (s *BackgroundSuite) TestSpotInterruptionWatch() {
+	interrupted := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !interrupted {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"action": "terminate"}`))
+	}))
+	defer srv.Close()
+
+	s.a.opts.SpotInterruptionURL = srv.URL
+	s.a.opts.SpotInterruptionInterval = time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.False(s.a.spotInterruptionScheduled(ctx))
+
+	interrupted = true
+	taskCtx, taskCancel := context.WithTimeout(ctx, 5*time.Second)
+	defer taskCancel()
+	s.a.startSpotInterruptionWatch(ctx, s.tc, taskCancel)
+	s.Equal(context.Canceled, taskCtx.Err())
+	s.True(s.tc.wasPreempted())
+
+	detail := s.a.endTaskResponse(s.tc, evergreen.TaskSucceeded)
+	s.True(detail.Preempted)
+	s.Equal(evergreen.TaskFailed, detail.Status)
+	s.Equal(model.SystemCommandType, detail.Type)
+	s.Equal("host preempted", detail.Description)
+}
+
+func (s *BackgroundSuite) TestSpotInterruptionWatchDisabled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	taskCtx, taskCancel := context.WithCancel(ctx)
+	defer taskCancel()
+	s.a.startSpotInterruptionWatch(ctx, s.tc, taskCancel)
+	s.NoError(taskCtx.Err())
+	s.False(s.tc.wasPreempted())
+}
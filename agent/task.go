@@ -226,6 +226,20 @@ func (tc *taskContext) hadTimedOut() bool {
 	return tc.timedOut
 }
 
+func (tc *taskContext) reachPreempted() {
+	tc.Lock()
+	defer tc.Unlock()
+
+	tc.preempted = true
+}
+
+func (tc *taskContext) wasPreempted() bool {
+	tc.RLock()
+	defer tc.RUnlock()
+
+	return tc.preempted
+}
+
 // makeTaskConfig fetches task configuration data required to run the task from the API server.
 func (a *Agent) makeTaskConfig(ctx context.Context, tc *taskContext) (*model.TaskConfig, error) {
 	tc.logger.Execution().Info("Fetching distro configuration.")
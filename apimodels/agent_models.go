@@ -19,6 +19,9 @@ type TaskEndDetail struct {
 	Type        string `bson:"type,omitempty" json:"type,omitempty"`
 	Description string `bson:"desc,omitempty" json:"desc,omitempty"`
 	TimedOut    bool   `bson:"timed_out,omitempty" json:"timed_out,omitempty"`
+	// Preempted is set when the task was interrupted because the cloud
+	// provider reclaimed its host.
+	Preempted bool `bson:"preempted,omitempty" json:"preempted,omitempty"`
 }
 
 type TaskEndDetails struct {
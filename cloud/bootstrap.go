@@ -0,0 +1,61 @@
+package cloud
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/util"
+)
+
+const (
+	// bootstrapScriptPath is where the user data script saves the setup
+	// script it fetches from the API server.
+	bootstrapScriptPath = "/tmp/evergreen-bootstrap.sh"
+
+	bootstrapFetchAttempts = 60
+	bootstrapFetchInterval = 10
+)
+
+// bootstrapUserData gives the host a bootstrap secret if it does not have one
+// and returns the user data script that provisions it. The script fetches the
+// host's setup script from the API server, authenticating with the secret,
+// and runs it as the distro's user. Any user data the distro already has is
+// run first, and must be a shell script.
+//
+// The API server may not know about the host until shortly after it is
+// created, so the script keeps trying to fetch the setup script for a while.
+func bootstrapUserData(h *host.Host, apiURL, userData string) string {
+	if h.BootstrapSecret == "" {
+		h.BootstrapSecret = util.RandomString()
+	}
+
+	lines := []string{"#!/bin/sh"}
+	if userData != "" {
+		if strings.HasPrefix(userData, "#!") {
+			userData = userData[strings.Index(userData+"\n", "\n")+1:]
+		}
+		lines = append(lines, userData)
+	}
+
+	fetch := fmt.Sprintf("curl -fsSL -H '%s: %s' '%s/api/2/bootstrap' -o %s",
+		evergreen.HostBootstrapHeader, h.BootstrapSecret, apiURL, bootstrapScriptPath)
+	lines = append(lines,
+		fmt.Sprintf("for i in $(seq 1 %d); do", bootstrapFetchAttempts),
+		fmt.Sprintf("  %s && break", fetch),
+		fmt.Sprintf("  sleep %d", bootstrapFetchInterval),
+		"done",
+	)
+
+	// containers run the script as their init process, which has to keep
+	// running, so it is never handed off to another user
+	if h.Distro.User != "" {
+		lines = append(lines, fmt.Sprintf(
+			`if [ "$$" -ne 1 ] && [ "$(id -u)" -eq 0 ] && id '%s' > /dev/null 2>&1; then exec su - '%s' -c 'sh %s'; fi`,
+			h.Distro.User, h.Distro.User, bootstrapScriptPath))
+	}
+	lines = append(lines, fmt.Sprintf("exec sh %s", bootstrapScriptPath))
+
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,42 @@
+package cloud
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBootstrapUserData(t *testing.T) {
+	assert := assert.New(t)
+
+	h := &host.Host{
+		Id: "h1",
+		Distro: distro.Distro{
+			Id:              "d1",
+			User:            "admin",
+			BootstrapMethod: distro.BootstrapMethodUserData,
+		},
+	}
+
+	script := bootstrapUserData(h, "https://evergreen.example.com", "#!/bin/bash\necho hello\n")
+	assert.NotEmpty(h.BootstrapSecret)
+	assert.True(strings.HasPrefix(script, "#!/bin/sh\necho hello\n"))
+	assert.Equal(1, strings.Count(script, "#!"))
+	assert.Contains(script, evergreen.HostBootstrapHeader+": "+h.BootstrapSecret)
+	assert.Contains(script, "'https://evergreen.example.com/api/2/bootstrap'")
+	assert.Contains(script, "exec su - 'admin'")
+	assert.True(strings.HasSuffix(script, "exec sh "+bootstrapScriptPath+"\n"))
+
+	// the secret is kept if the host already has one
+	secret := h.BootstrapSecret
+	script = bootstrapUserData(h, "https://evergreen.example.com", "")
+	assert.Equal(secret, h.BootstrapSecret)
+	assert.True(strings.HasPrefix(script, "#!/bin/sh\nfor i in"))
+
+	h.Distro.User = ""
+	assert.NotContains(bootstrapUserData(h, "https://evergreen.example.com", ""), "su -")
+}
@@ -52,6 +52,15 @@ type Manager interface {
 	// TimeTilNextPayment returns how long there is until the next payment
 	// is due for a particular host
 	TimeTilNextPayment(*host.Host) time.Duration
+
+	// StopInstance stops the host in the underlying provider without
+	// destroying it, and marks it as stopped. Providers that can't stop
+	// hosts return an error; see SupportsStopping.
+	StopInstance(context.Context, *host.Host, string) error
+
+	// StartInstance starts a stopped host in the underlying provider and
+	// marks it as running.
+	StartInstance(context.Context, *host.Host, string) error
 }
 
 // CostCalculator is an interface for cloud providers that can estimate what a span of time on a
@@ -66,6 +75,27 @@ type BatchManager interface {
 	GetInstanceStatuses(context.Context, []host.Host) ([]CloudStatus, error)
 }
 
+// VolumeManager is an interface for cloud providers that can create
+// persistent volumes that outlive the hosts they are attached to.
+type VolumeManager interface {
+	// CreateVolume creates a volume of the given size in the given
+	// location and returns it with its id set.
+	CreateVolume(context.Context, *host.Volume) (*host.Volume, error)
+
+	// AttachVolume attaches a volume to a host in the same location.
+	AttachVolume(context.Context, *host.Host, *host.Volume) error
+
+	// DetachVolume detaches a volume from the host it is attached to,
+	// returning once the volume can be attached to another host.
+	DetachVolume(context.Context, *host.Host, *host.Volume) error
+
+	// ModifyVolume grows a volume to the given size in GiB.
+	ModifyVolume(context.Context, *host.Volume, int) error
+
+	// DeleteVolume destroys a detached volume.
+	DeleteVolume(context.Context, *host.Volume) error
+}
+
 // GetManager returns an implementation of Manager for the given provider name.
 // It returns an error if the provider name doesn't have a known implementation.
 func GetManager(ctx context.Context, providerName string, settings *evergreen.Settings) (Manager, error) {
@@ -90,6 +120,12 @@ func GetManager(ctx context.Context, providerName string, settings *evergreen.Se
 		provider = &gceManager{}
 	case evergreen.ProviderNameVsphere:
 		provider = &vsphereManager{}
+	case evergreen.ProviderNameKubernetes:
+		provider = &kubernetesManager{}
+	case evergreen.ProviderNameLibvirt:
+		provider = &libvirtManager{}
+	case evergreen.ProviderNamePlugin:
+		provider = &pluginManager{}
 	default:
 		return nil, errors.Errorf("No known provider for '%v'", providerName)
 	}
@@ -100,3 +136,20 @@ func GetManager(ctx context.Context, providerName string, settings *evergreen.Se
 
 	return provider, nil
 }
+
+// errStoppingUnsupported is returned by the managers of providers whose
+// hosts can't be stopped and started again.
+func errStoppingUnsupported(providerName string) error {
+	return errors.Errorf("provider %s does not support stopping hosts", providerName)
+}
+
+// SupportsStopping returns whether hosts from the given provider can be
+// stopped and started again.
+func SupportsStopping(providerName string) bool {
+	switch providerName {
+	case evergreen.ProviderNameEc2OnDemand, evergreen.ProviderNameGce, evergreen.ProviderNameVsphere, evergreen.ProviderNameMock:
+		return true
+	default:
+		return false
+	}
+}
@@ -6,6 +6,10 @@ import (
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/subprocess"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
 )
 
 // HostOptions is a struct of options that are commonly passed around when creating a
@@ -27,6 +31,10 @@ type CloudHost struct {
 	Host     *host.Host
 	KeyPath  string
 	CloudMgr Manager
+
+	// ProxyKeyPath is the key used to log in to the distro's SSH proxy, if
+	// it has one.
+	ProxyKeyPath string
 }
 
 // GetCloudHost returns an instance of CloudHost wrapping the given model.Host,
@@ -41,17 +49,46 @@ func GetCloudHost(ctx context.Context, host *host.Host, settings *evergreen.Sett
 	if host.Distro.SSHKey != "" {
 		keyPath = settings.Keys[host.Distro.SSHKey]
 	}
-	return &CloudHost{host, keyPath, mgr}, nil
+	proxyKeyPath := keyPath
+	if host.Distro.SSHProxy != nil && host.Distro.SSHProxy.SSHKey != "" {
+		proxyKeyPath = settings.Keys[host.Distro.SSHProxy.SSHKey]
+	}
+	return &CloudHost{
+		Host:         host,
+		KeyPath:      keyPath,
+		CloudMgr:     mgr,
+		ProxyKeyPath: proxyKeyPath,
+	}, nil
 }
 
 func (cloudHost *CloudHost) IsUp(ctx context.Context) (bool, error) {
 	return cloudHost.CloudMgr.IsUp(ctx, cloudHost.Host)
 }
 
+// TerminateInstance terminates the host, first detaching its home volume
+// if it has one. The volume outlives the host either way, so failing to
+// detach it does not stop the host from being terminated.
 func (cloudHost *CloudHost) TerminateInstance(ctx context.Context, user string) error {
+	grip.Error(message.WrapError(cloudHost.DetachHomeVolume(ctx), message.Fields{
+		"message": "error detaching home volume before terminating host",
+		"host":    cloudHost.Host.Id,
+		"volume":  cloudHost.Host.HomeVolumeID,
+		"user":    user,
+	}))
 	return cloudHost.CloudMgr.TerminateInstance(ctx, cloudHost.Host, user)
 }
 
+// StopInstance stops the host, if its provider supports stopping hosts.
+func (cloudHost *CloudHost) StopInstance(ctx context.Context, user string) error {
+	return cloudHost.CloudMgr.StopInstance(ctx, cloudHost.Host, user)
+}
+
+// StartInstance starts the stopped host, if its provider supports stopping
+// hosts.
+func (cloudHost *CloudHost) StartInstance(ctx context.Context, user string) error {
+	return cloudHost.CloudMgr.StartInstance(ctx, cloudHost.Host, user)
+}
+
 func (cloudHost *CloudHost) GetInstanceStatus(ctx context.Context) (CloudStatus, error) {
 	return cloudHost.CloudMgr.GetInstanceStatus(ctx, cloudHost.Host)
 }
@@ -60,6 +97,30 @@ func (cloudHost *CloudHost) GetDNSName(ctx context.Context) (string, error) {
 	return cloudHost.CloudMgr.GetDNSName(ctx, cloudHost.Host)
 }
 
+// GetSSHOptions returns the options for ssh and scp commands run against the
+// host. If the host's distro has an SSH proxy, the options route connections
+// through it.
 func (cloudHost *CloudHost) GetSSHOptions() ([]string, error) {
-	return cloudHost.CloudMgr.GetSSHOptions(cloudHost.Host, cloudHost.KeyPath)
+	opts, err := cloudHost.CloudMgr.GetSSHOptions(cloudHost.Host, cloudHost.KeyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy := cloudHost.Host.Distro.SSHProxy
+	if proxy == nil || proxy.Host == "" {
+		return opts, nil
+	}
+
+	proxyOpts, err := (&subprocess.SSHProxy{
+		Hostname: proxy.Host,
+		Port:     proxy.Port,
+		User:     proxy.User,
+		KeyPath:  cloudHost.ProxyKeyPath,
+		HostKey:  proxy.HostKey,
+	}).Options()
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem configuring ssh proxy for host %s", cloudHost.Host.Id)
+	}
+
+	return append(proxyOpts, opts...), nil
 }
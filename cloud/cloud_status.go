@@ -1,5 +1,21 @@
 package cloud
 
+import (
+	"context"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	// statusWaitRetries and statusWaitInterval bound how long a manager
+	// waits for a host it has stopped or started to reach its new status.
+	statusWaitRetries  = 8
+	statusWaitInterval = 2 * time.Second
+)
+
 type CloudStatus int
 
 const (
@@ -43,3 +59,24 @@ func (stat CloudStatus) String() string {
 		return "unknown"
 	}
 }
+
+// waitForInstanceStatus polls the manager until the provider reports that the
+// host has the given status.
+func waitForInstanceStatus(ctx context.Context, m Manager, h *host.Host, status CloudStatus) error {
+	_, err := util.Retry(
+		func() (bool, error) {
+			if ctx.Err() != nil {
+				return false, ctx.Err()
+			}
+			current, err := m.GetInstanceStatus(ctx, h)
+			if err != nil {
+				return true, errors.Wrapf(err, "error getting status of host %s", h.Id)
+			}
+			if current != status {
+				return true, errors.Errorf("host %s is %s, not %s", h.Id, current, status)
+			}
+			return false, nil
+		}, statusWaitRetries, statusWaitInterval)
+
+	return err
+}
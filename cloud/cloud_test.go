@@ -2,10 +2,15 @@ package cloud
 
 import (
 	"context"
+	"strings"
 	"testing"
 
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/evergreen-ci/evergreen/testutil"
 	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetManager(t *testing.T) {
@@ -57,3 +62,42 @@ func TestGetManager(t *testing.T) {
 	})
 
 }
+
+func TestCloudHostSSHOptionsWithProxy(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	h := &host.Host{
+		Id:     "h",
+		Distro: distro.Distro{SSHOptions: []string{"BatchMode=yes"}},
+	}
+	cloudHost := &CloudHost{Host: h, KeyPath: "/keys/host", CloudMgr: &staticManager{}, ProxyKeyPath: "/keys/bastion"}
+
+	opts, err := cloudHost.GetSSHOptions()
+	require.NoError(err)
+	assert.Equal([]string{"-i", "/keys/host", "-o", "BatchMode=yes"}, opts)
+
+	h.Distro.SSHProxy = &distro.SSHProxy{Host: "bastion.example.com", HostKey: "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIB"}
+	opts, err = cloudHost.GetSSHOptions()
+	require.NoError(err)
+	require.Len(opts, 6)
+	assert.Equal("-o", opts[0])
+	assert.True(strings.HasPrefix(opts[1], "ProxyCommand=ssh -i '/keys/bastion' "))
+	assert.Equal([]string{"-i", "/keys/host", "-o", "BatchMode=yes"}, opts[2:])
+
+	h.Distro.SSHProxy.HostKey = ""
+	_, err = cloudHost.GetSSHOptions()
+	assert.Error(err)
+}
+
+func TestCloudHostStopUnsupported(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cloudHost := &CloudHost{Host: &host.Host{Id: "h"}, CloudMgr: &staticManager{}}
+
+	err := cloudHost.StopInstance(ctx, "user")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not support stopping hosts")
+	assert.Error(t, cloudHost.StartInstance(ctx, "user"))
+}
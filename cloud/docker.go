@@ -20,6 +20,7 @@ import (
 // dockerManager implements the Manager interface for Docker.
 type dockerManager struct {
 	client dockerClient
+	apiURL string
 }
 
 type portRange struct {
@@ -39,6 +40,10 @@ type dockerSettings struct {
 	ClientPort int `mapstructure:"client_port" json:"client_port" bson:"client_port"`
 	// PortRange specifies potential ports to bind new containers to for SSH connections.
 	PortRange *portRange `mapstructure:"port_range" json:"port_range" bson:"port_range"`
+
+	// bootstrapScript replaces the image's command for containers whose
+	// distro bootstraps through user data.
+	bootstrapScript string
 }
 
 // nolint
@@ -120,6 +125,10 @@ func (m *dockerManager) SpawnHost(ctx context.Context, h *host.Host) (*host.Host
 		"max_port":    settings.PortRange.MaxPort,
 	})
 
+	if h.Distro.BootstrapsWithUserData() {
+		settings.bootstrapScript = bootstrapUserData(h, m.apiURL, "")
+	}
+
 	// Create container
 	if err := m.client.CreateContainer(ctx, h.Id, h.Distro, settings); err != nil {
 		err = errors.Wrapf(err, "Failed to create container for host '%s'", settings.HostIP)
@@ -224,6 +233,8 @@ func (m *dockerManager) Configure(ctx context.Context, s *evergreen.Settings) er
 		return errors.Wrap(err, "Failed to initialize client connection")
 	}
 
+	m.apiURL = s.ApiUrl
+
 	return nil
 }
 
@@ -261,3 +272,15 @@ func (m *dockerManager) GetSSHOptions(h *host.Host, keyPath string) ([]string, e
 func (m *dockerManager) TimeTilNextPayment(_ *host.Host) time.Duration {
 	return time.Duration(0)
 }
+
+// StopInstance is not supported, since Docker hosts can't be stopped and
+// started again.
+func (m *dockerManager) StopInstance(_ context.Context, _ *host.Host, _ string) error {
+	return errStoppingUnsupported(evergreen.ProviderNameDocker)
+}
+
+// StartInstance is not supported, since Docker hosts can't be stopped and
+// started again.
+func (m *dockerManager) StartInstance(_ context.Context, _ *host.Host, _ string) error {
+	return errStoppingUnsupported(evergreen.ProviderNameDocker)
+}
@@ -121,6 +121,9 @@ func (c *dockerClientImpl) CreateContainer(ctx context.Context, id string, d dis
 		},
 		Image: s.ImageID,
 	}
+	if s.bootstrapScript != "" {
+		containerConf.Cmd = []string{"/bin/sh", "-c", s.bootstrapScript}
+	}
 	networkConf := &network.NetworkingConfig{}
 
 	grip.Info(message.Fields{
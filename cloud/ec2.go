@@ -118,6 +118,10 @@ const (
 	SpotStatusFailed   = "failed"
 
 	EC2ErrorSpotRequestNotFound = "InvalidSpotInstanceRequestID.NotFound"
+
+	// EC2SpotInterruptionURL is the instance metadata endpoint that only
+	// exists once a spot instance is scheduled to be reclaimed.
+	EC2SpotInterruptionURL = "http://169.254.169.254/latest/meta-data/spot/instance-action"
 )
 
 // EC2ManagerOptions are used to construct a new ec2Manager.
@@ -183,10 +187,20 @@ func (m *ec2Manager) spawnOnDemandHost(ctx context.Context, h *host.Host, ec2Set
 		}
 	} else {
 		input.SecurityGroups = ec2Settings.getSecurityGroups()
+
+		// a host that will have its owner's existing home volume attached
+		// must be in the volume's availability zone
+		zone, err := homeVolumeZone(h)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if zone != "" {
+			input.Placement = &ec2.Placement{AvailabilityZone: makeStringPtr(zone)}
+		}
 	}
 
-	if ec2Settings.UserData != "" {
-		expanded, err := m.expandUserData(ec2Settings.UserData)
+	if ec2Settings.UserData != "" || h.Distro.BootstrapsWithUserData() {
+		expanded, err := m.makeUserData(h, ec2Settings.UserData)
 		if err != nil {
 			return nil, errors.Wrap(err, "problem expanding user data")
 		}
@@ -304,8 +318,8 @@ func (m *ec2Manager) spawnSpotHost(ctx context.Context, h *host.Host, ec2Setting
 		spotRequest.LaunchSpecification.SecurityGroups = ec2Settings.getSecurityGroups()
 	}
 
-	if ec2Settings.UserData != "" {
-		expanded, err := m.expandUserData(ec2Settings.UserData)
+	if ec2Settings.UserData != "" || h.Distro.BootstrapsWithUserData() {
+		expanded, err := m.makeUserData(h, ec2Settings.UserData)
 		if err != nil {
 			return nil, errors.Wrap(err, "problem expanding user data")
 		}
@@ -640,6 +654,230 @@ func (m *ec2Manager) TerminateInstance(ctx context.Context, h *host.Host, user s
 	return errors.Wrap(h.Terminate(user), "failed to terminate instance in db")
 }
 
+// StopInstance stops an on-demand EC2 instance. Spot instances cannot be
+// stopped.
+func (m *ec2Manager) StopInstance(ctx context.Context, h *host.Host, user string) error {
+	if !isHostOnDemand(h) {
+		return errors.Errorf("can not stop %s - only on-demand instances can be stopped", h.Id)
+	}
+	if h.Status != evergreen.HostRunning {
+		return errors.Errorf("can not stop %s - host is %s, not running", h.Id, h.Status)
+	}
+	r, err := getRegion(h)
+	if err != nil {
+		return errors.Wrap(err, "problem getting region from host")
+	}
+	if err = m.client.Create(m.credentials, r); err != nil {
+		return errors.Wrap(err, "error creating client")
+	}
+	defer m.client.Close()
+
+	if _, err = m.client.StopInstances(ctx, &ec2.StopInstancesInput{
+		InstanceIds: []*string{makeStringPtr(h.Id)},
+	}); err != nil {
+		grip.Error(message.WrapError(err, message.Fields{
+			"message":       "error stopping instance",
+			"user":          user,
+			"host":          h.Id,
+			"host_provider": h.Distro.Provider,
+			"distro":        h.Distro.Id,
+		}))
+		return errors.Wrapf(err, "error stopping instance %s", h.Id)
+	}
+
+	if err = waitForInstanceStatus(ctx, m, h, StatusStopped); err != nil {
+		return errors.Wrapf(err, "instance %s did not stop", h.Id)
+	}
+
+	grip.Info(message.Fields{
+		"message":       "stopped instance",
+		"user":          user,
+		"host":          h.Id,
+		"host_provider": h.Distro.Provider,
+		"distro":        h.Distro.Id,
+	})
+
+	return errors.Wrap(h.SetStopped(user), "failed to mark instance as stopped in db")
+}
+
+// StartInstance starts a stopped EC2 instance. An instance gets a new public
+// DNS name when it starts, so the host's DNS name is updated as well.
+func (m *ec2Manager) StartInstance(ctx context.Context, h *host.Host, user string) error {
+	if h.Status != evergreen.HostStopped {
+		return errors.Errorf("can not start %s - host is %s, not stopped", h.Id, h.Status)
+	}
+	r, err := getRegion(h)
+	if err != nil {
+		return errors.Wrap(err, "problem getting region from host")
+	}
+	if err = m.client.Create(m.credentials, r); err != nil {
+		return errors.Wrap(err, "error creating client")
+	}
+	defer m.client.Close()
+
+	if _, err = m.client.StartInstances(ctx, &ec2.StartInstancesInput{
+		InstanceIds: []*string{makeStringPtr(h.Id)},
+	}); err != nil {
+		grip.Error(message.WrapError(err, message.Fields{
+			"message":       "error starting instance",
+			"user":          user,
+			"host":          h.Id,
+			"host_provider": h.Distro.Provider,
+			"distro":        h.Distro.Id,
+		}))
+		return errors.Wrapf(err, "error starting instance %s", h.Id)
+	}
+
+	if err = waitForInstanceStatus(ctx, m, h, StatusRunning); err != nil {
+		return errors.Wrapf(err, "instance %s did not start", h.Id)
+	}
+
+	instance, err := m.client.GetInstanceInfo(ctx, h.Id)
+	if err != nil {
+		return errors.Wrapf(err, "error getting instance info for %s", h.Id)
+	}
+	if instance.PublicDnsName != nil {
+		if err = h.UpdateDNSName(*instance.PublicDnsName); err != nil {
+			return errors.Wrapf(err, "error updating DNS name for %s", h.Id)
+		}
+	}
+
+	grip.Info(message.Fields{
+		"message":       "started instance",
+		"user":          user,
+		"host":          h.Id,
+		"host_provider": h.Distro.Provider,
+		"distro":        h.Distro.Id,
+	})
+
+	return errors.Wrap(h.SetStartedAfterStop(user), "failed to mark instance as running in db")
+}
+
+// CreateVolume creates an EBS volume in the volume's availability zone and
+// waits for it to become available.
+func (m *ec2Manager) CreateVolume(ctx context.Context, v *host.Volume) (*host.Volume, error) {
+	if v.Zone == "" {
+		return nil, errors.New("can not create a volume without an availability zone")
+	}
+	if err := m.client.Create(m.credentials, azToRegion(v.Zone)); err != nil {
+		return nil, errors.Wrap(err, "error creating client")
+	}
+	defer m.client.Close()
+
+	volume, err := m.client.CreateVolume(ctx, &ec2.CreateVolumeInput{
+		AvailabilityZone: makeStringPtr(v.Zone),
+		Size:             makeInt64Ptr(int64(v.Size)),
+		VolumeType:       makeStringPtr(ec2.VolumeTypeGp2),
+		TagSpecifications: []*ec2.TagSpecification{
+			{
+				ResourceType: makeStringPtr(ec2.ResourceTypeVolume),
+				Tags: []*ec2.Tag{
+					{Key: makeStringPtr("owner"), Value: makeStringPtr(v.CreatedBy)},
+					{Key: makeStringPtr("mode"), Value: makeStringPtr("testing")},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error creating volume for %s", v.CreatedBy)
+	}
+	v.ID = *volume.VolumeId
+
+	if err = m.waitForVolumeAvailable(ctx, v); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return v, nil
+}
+
+// waitForVolumeAvailable waits for an EBS volume to be available, that is
+// created and not attached to, or being detached from, any instance. The
+// client must already be created.
+func (m *ec2Manager) waitForVolumeAvailable(ctx context.Context, v *host.Volume) error {
+	_, err := util.Retry(func() (bool, error) {
+		resp, err := m.client.DescribeVolumes(ctx, &ec2.DescribeVolumesInput{
+			VolumeIds: []*string{makeStringPtr(v.ID)},
+		})
+		if err != nil {
+			return true, err
+		}
+		if len(resp.Volumes) == 0 || resp.Volumes[0].State == nil ||
+			*resp.Volumes[0].State != ec2.VolumeStateAvailable {
+			return true, errors.Errorf("volume %s is not available yet", v.ID)
+		}
+		return false, nil
+	}, statusWaitRetries, statusWaitInterval)
+	return errors.Wrapf(err, "volume %s did not become available", v.ID)
+}
+
+// AttachVolume attaches an EBS volume to an instance in the same
+// availability zone.
+func (m *ec2Manager) AttachVolume(ctx context.Context, h *host.Host, v *host.Volume) error {
+	if err := m.client.Create(m.credentials, azToRegion(v.Zone)); err != nil {
+		return errors.Wrap(err, "error creating client")
+	}
+	defer m.client.Close()
+
+	// a volume detached from a terminated instance may still be detaching
+	if err := m.waitForVolumeAvailable(ctx, v); err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err := m.client.AttachVolume(ctx, &ec2.AttachVolumeInput{
+		Device:     makeStringPtr(ec2VolumeDevice),
+		InstanceId: makeStringPtr(h.Id),
+		VolumeId:   makeStringPtr(v.ID),
+	})
+	return errors.Wrapf(err, "error attaching volume %s to instance %s", v.ID, h.Id)
+}
+
+// DetachVolume detaches an EBS volume from its instance and waits for the
+// detachment to finish, which happens asynchronously in EC2.
+func (m *ec2Manager) DetachVolume(ctx context.Context, h *host.Host, v *host.Volume) error {
+	if err := m.client.Create(m.credentials, azToRegion(v.Zone)); err != nil {
+		return errors.Wrap(err, "error creating client")
+	}
+	defer m.client.Close()
+
+	_, err := m.client.DetachVolume(ctx, &ec2.DetachVolumeInput{
+		InstanceId: makeStringPtr(h.Id),
+		VolumeId:   makeStringPtr(v.ID),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "error detaching volume %s from instance %s", v.ID, h.Id)
+	}
+
+	return errors.WithStack(m.waitForVolumeAvailable(ctx, v))
+}
+
+// ModifyVolume grows an EBS volume. The file system on the volume must be
+// grown separately to use the new space.
+func (m *ec2Manager) ModifyVolume(ctx context.Context, v *host.Volume, size int) error {
+	if err := m.client.Create(m.credentials, azToRegion(v.Zone)); err != nil {
+		return errors.Wrap(err, "error creating client")
+	}
+	defer m.client.Close()
+
+	_, err := m.client.ModifyVolume(ctx, &ec2.ModifyVolumeInput{
+		VolumeId: makeStringPtr(v.ID),
+		Size:     makeInt64Ptr(int64(size)),
+	})
+	return errors.Wrapf(err, "error resizing volume %s", v.ID)
+}
+
+// DeleteVolume deletes a detached EBS volume.
+func (m *ec2Manager) DeleteVolume(ctx context.Context, v *host.Volume) error {
+	if err := m.client.Create(m.credentials, azToRegion(v.Zone)); err != nil {
+		return errors.Wrap(err, "error creating client")
+	}
+	defer m.client.Close()
+
+	_, err := m.client.DeleteVolume(ctx, &ec2.DeleteVolumeInput{
+		VolumeId: makeStringPtr(v.ID),
+	})
+	return errors.Wrapf(err, "error deleting volume %s", v.ID)
+}
+
 func (m *ec2Manager) cancelSpotRequest(ctx context.Context, h *host.Host) (string, error) {
 	instanceId, err := m.client.GetSpotInstanceId(ctx, h)
 	if err != nil {
@@ -932,6 +1170,19 @@ func (m *ec2Manager) CostForDuration(ctx context.Context, h *host.Host, start, e
 	return total, nil
 }
 
+// makeUserData expands the distro's user data and, if the distro bootstraps
+// through user data, adds the script that provisions the host.
+func (m *ec2Manager) makeUserData(h *host.Host, userData string) (string, error) {
+	expanded, err := m.expandUserData(userData)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	if h.Distro.BootstrapsWithUserData() {
+		return bootstrapUserData(h, m.settings.ApiUrl, expanded), nil
+	}
+	return expanded, nil
+}
+
 func (m *ec2Manager) expandUserData(userData string) (string, error) {
 	exp := util.NewExpansions(m.settings.Expansions)
 	expanded, err := exp.ExpandString(userData)
@@ -41,6 +41,12 @@ type AWSClient interface {
 	// TerminateInstances is a wrapper for ec2.TerminateInstances.
 	TerminateInstances(context.Context, *ec2.TerminateInstancesInput) (*ec2.TerminateInstancesOutput, error)
 
+	// StopInstances is a wrapper for ec2.StopInstances.
+	StopInstances(context.Context, *ec2.StopInstancesInput) (*ec2.StopInstancesOutput, error)
+
+	// StartInstances is a wrapper for ec2.StartInstances.
+	StartInstances(context.Context, *ec2.StartInstancesInput) (*ec2.StartInstancesOutput, error)
+
 	// RequestSpotInstances is a wrapper for ec2.RequestSpotInstances.
 	RequestSpotInstances(context.Context, *ec2.RequestSpotInstancesInput) (*ec2.RequestSpotInstancesOutput, error)
 
@@ -59,6 +65,21 @@ type AWSClient interface {
 	// DescribeVolumes is a wrapper for ec2.DescribeVolumes.
 	DescribeVolumes(context.Context, *ec2.DescribeVolumesInput) (*ec2.DescribeVolumesOutput, error)
 
+	// CreateVolume is a wrapper for ec2.CreateVolume.
+	CreateVolume(context.Context, *ec2.CreateVolumeInput) (*ec2.Volume, error)
+
+	// AttachVolume is a wrapper for ec2.AttachVolume.
+	AttachVolume(context.Context, *ec2.AttachVolumeInput) (*ec2.VolumeAttachment, error)
+
+	// DetachVolume is a wrapper for ec2.DetachVolume.
+	DetachVolume(context.Context, *ec2.DetachVolumeInput) (*ec2.VolumeAttachment, error)
+
+	// ModifyVolume is a wrapper for ec2.ModifyVolume.
+	ModifyVolume(context.Context, *ec2.ModifyVolumeInput) (*ec2.ModifyVolumeOutput, error)
+
+	// DeleteVolume is a wrapper for ec2.DeleteVolume.
+	DeleteVolume(context.Context, *ec2.DeleteVolumeInput) (*ec2.DeleteVolumeOutput, error)
+
 	// DescribeSpotPriceHistory is a wrapper for ec2.DescribeSpotPriceHistory.
 	DescribeSpotPriceHistory(context.Context, *ec2.DescribeSpotPriceHistoryInput) (*ec2.DescribeSpotPriceHistoryOutput, error)
 
@@ -217,6 +238,52 @@ func (c *awsClientImpl) TerminateInstances(ctx context.Context, input *ec2.Termi
 	return output, nil
 }
 
+// StopInstances is a wrapper for ec2.StopInstances.
+func (c *awsClientImpl) StopInstances(ctx context.Context, input *ec2.StopInstancesInput) (*ec2.StopInstancesOutput, error) {
+	var output *ec2.StopInstancesOutput
+	var err error
+	msg := makeAWSLogMessage("StopInstances", fmt.Sprintf("%T", c), input)
+	_, err = util.Retry(
+		func() (bool, error) {
+			output, err = c.EC2.StopInstancesWithContext(ctx, input)
+			if err != nil {
+				if ec2err, ok := err.(awserr.Error); ok {
+					grip.Error(message.WrapError(ec2err, msg))
+				}
+				return true, err
+			}
+			grip.Info(msg)
+			return false, nil
+		}, awsClientImplRetries, awsClientImplStartPeriod)
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// StartInstances is a wrapper for ec2.StartInstances.
+func (c *awsClientImpl) StartInstances(ctx context.Context, input *ec2.StartInstancesInput) (*ec2.StartInstancesOutput, error) {
+	var output *ec2.StartInstancesOutput
+	var err error
+	msg := makeAWSLogMessage("StartInstances", fmt.Sprintf("%T", c), input)
+	_, err = util.Retry(
+		func() (bool, error) {
+			output, err = c.EC2.StartInstancesWithContext(ctx, input)
+			if err != nil {
+				if ec2err, ok := err.(awserr.Error); ok {
+					grip.Error(message.WrapError(ec2err, msg))
+				}
+				return true, err
+			}
+			grip.Info(msg)
+			return false, nil
+		}, awsClientImplRetries, awsClientImplStartPeriod)
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
 // RequestSpotInstances is a wrapper for ec2.RequestSpotInstances.
 func (c *awsClientImpl) RequestSpotInstances(ctx context.Context, input *ec2.RequestSpotInstancesInput) (*ec2.RequestSpotInstancesOutput, error) {
 	var output *ec2.RequestSpotInstancesOutput
@@ -365,6 +432,121 @@ func (c *awsClientImpl) DescribeVolumes(ctx context.Context, input *ec2.Describe
 	return output, nil
 }
 
+// CreateVolume is a wrapper for ec2.CreateVolume.
+func (c *awsClientImpl) CreateVolume(ctx context.Context, input *ec2.CreateVolumeInput) (*ec2.Volume, error) {
+	var output *ec2.Volume
+	var err error
+	msg := makeAWSLogMessage("CreateVolume", fmt.Sprintf("%T", c), input)
+	_, err = util.Retry(
+		func() (bool, error) {
+			output, err = c.EC2.CreateVolumeWithContext(ctx, input)
+			if err != nil {
+				if ec2err, ok := err.(awserr.Error); ok {
+					grip.Error(message.WrapError(ec2err, msg))
+				}
+				return true, err
+			}
+			grip.Info(msg)
+			return false, nil
+		}, awsClientImplRetries, awsClientImplStartPeriod)
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// AttachVolume is a wrapper for ec2.AttachVolume.
+func (c *awsClientImpl) AttachVolume(ctx context.Context, input *ec2.AttachVolumeInput) (*ec2.VolumeAttachment, error) {
+	var output *ec2.VolumeAttachment
+	var err error
+	msg := makeAWSLogMessage("AttachVolume", fmt.Sprintf("%T", c), input)
+	_, err = util.Retry(
+		func() (bool, error) {
+			output, err = c.EC2.AttachVolumeWithContext(ctx, input)
+			if err != nil {
+				if ec2err, ok := err.(awserr.Error); ok {
+					grip.Error(message.WrapError(ec2err, msg))
+				}
+				return true, err
+			}
+			grip.Info(msg)
+			return false, nil
+		}, awsClientImplRetries, awsClientImplStartPeriod)
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// DetachVolume is a wrapper for ec2.DetachVolume.
+func (c *awsClientImpl) DetachVolume(ctx context.Context, input *ec2.DetachVolumeInput) (*ec2.VolumeAttachment, error) {
+	var output *ec2.VolumeAttachment
+	var err error
+	msg := makeAWSLogMessage("DetachVolume", fmt.Sprintf("%T", c), input)
+	_, err = util.Retry(
+		func() (bool, error) {
+			output, err = c.EC2.DetachVolumeWithContext(ctx, input)
+			if err != nil {
+				if ec2err, ok := err.(awserr.Error); ok {
+					grip.Error(message.WrapError(ec2err, msg))
+				}
+				return true, err
+			}
+			grip.Info(msg)
+			return false, nil
+		}, awsClientImplRetries, awsClientImplStartPeriod)
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// ModifyVolume is a wrapper for ec2.ModifyVolume.
+func (c *awsClientImpl) ModifyVolume(ctx context.Context, input *ec2.ModifyVolumeInput) (*ec2.ModifyVolumeOutput, error) {
+	var output *ec2.ModifyVolumeOutput
+	var err error
+	msg := makeAWSLogMessage("ModifyVolume", fmt.Sprintf("%T", c), input)
+	_, err = util.Retry(
+		func() (bool, error) {
+			output, err = c.EC2.ModifyVolumeWithContext(ctx, input)
+			if err != nil {
+				if ec2err, ok := err.(awserr.Error); ok {
+					grip.Error(message.WrapError(ec2err, msg))
+				}
+				return true, err
+			}
+			grip.Info(msg)
+			return false, nil
+		}, awsClientImplRetries, awsClientImplStartPeriod)
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// DeleteVolume is a wrapper for ec2.DeleteVolume.
+func (c *awsClientImpl) DeleteVolume(ctx context.Context, input *ec2.DeleteVolumeInput) (*ec2.DeleteVolumeOutput, error) {
+	var output *ec2.DeleteVolumeOutput
+	var err error
+	msg := makeAWSLogMessage("DeleteVolume", fmt.Sprintf("%T", c), input)
+	_, err = util.Retry(
+		func() (bool, error) {
+			output, err = c.EC2.DeleteVolumeWithContext(ctx, input)
+			if err != nil {
+				if ec2err, ok := err.(awserr.Error); ok {
+					grip.Error(message.WrapError(ec2err, msg))
+				}
+				return true, err
+			}
+			grip.Info(msg)
+			return false, nil
+		}, awsClientImplRetries, awsClientImplStartPeriod)
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
 // DescribeSpotPriceHistory is a wrapper for ec2.DescribeSpotPriceHistory.
 func (c *awsClientImpl) DescribeSpotPriceHistory(ctx context.Context, input *ec2.DescribeSpotPriceHistoryInput) (*ec2.DescribeSpotPriceHistoryOutput, error) {
 	var output *ec2.DescribeSpotPriceHistoryOutput
@@ -467,16 +649,26 @@ type awsClientMock struct { //nolint
 	*ec2.DescribeInstancesInput
 	*ec2.CreateTagsInput
 	*ec2.TerminateInstancesInput
+	*ec2.StopInstancesInput
+	*ec2.StartInstancesInput
 	*ec2.RequestSpotInstancesInput
 	*ec2.DescribeSpotInstanceRequestsInput
 	*ec2.CancelSpotInstanceRequestsInput
 	*ec2.DescribeVolumesInput
+	*ec2.CreateVolumeInput
+	*ec2.AttachVolumeInput
+	*ec2.DetachVolumeInput
+	*ec2.ModifyVolumeInput
+	*ec2.DeleteVolumeInput
 	*ec2.DescribeSpotPriceHistoryInput
 	*ec2.DescribeSubnetsInput
 	*ec2.DescribeVpcsInput
 
 	*ec2.DescribeSpotInstanceRequestsOutput
 	*ec2.DescribeInstancesOutput
+
+	// true if the mock instance has been stopped and not started again
+	stopped bool
 }
 
 // Create a new mock client.
@@ -536,6 +728,20 @@ func (c *awsClientMock) TerminateInstances(ctx context.Context, input *ec2.Termi
 	return &ec2.TerminateInstancesOutput{}, nil
 }
 
+// StopInstances is a mock for ec2.StopInstances.
+func (c *awsClientMock) StopInstances(ctx context.Context, input *ec2.StopInstancesInput) (*ec2.StopInstancesOutput, error) {
+	c.StopInstancesInput = input
+	c.stopped = true
+	return &ec2.StopInstancesOutput{}, nil
+}
+
+// StartInstances is a mock for ec2.StartInstances.
+func (c *awsClientMock) StartInstances(ctx context.Context, input *ec2.StartInstancesInput) (*ec2.StartInstancesOutput, error) {
+	c.StartInstancesInput = input
+	c.stopped = false
+	return &ec2.StartInstancesOutput{}, nil
+}
+
 // RequestSpotInstances is a mock for ec2.RequestSpotInstances.
 func (c *awsClientMock) RequestSpotInstances(ctx context.Context, input *ec2.RequestSpotInstancesInput) (*ec2.RequestSpotInstancesOutput, error) {
 	c.RequestSpotInstancesInput = input
@@ -619,7 +825,49 @@ func (c *awsClientMock) CancelSpotInstanceRequests(ctx context.Context, input *e
 // DescribeVolumes is a mock for ec2.DescribeVolumes.
 func (c *awsClientMock) DescribeVolumes(ctx context.Context, input *ec2.DescribeVolumesInput) (*ec2.DescribeVolumesOutput, error) {
 	c.DescribeVolumesInput = input
-	return &ec2.DescribeVolumesOutput{}, nil
+	output := &ec2.DescribeVolumesOutput{}
+	for _, id := range input.VolumeIds {
+		output.Volumes = append(output.Volumes, &ec2.Volume{
+			VolumeId: id,
+			State:    makeStringPtr(ec2.VolumeStateAvailable),
+		})
+	}
+	return output, nil
+}
+
+// CreateVolume is a mock for ec2.CreateVolume.
+func (c *awsClientMock) CreateVolume(ctx context.Context, input *ec2.CreateVolumeInput) (*ec2.Volume, error) {
+	c.CreateVolumeInput = input
+	return &ec2.Volume{
+		VolumeId:         makeStringPtr("vol-123456"),
+		AvailabilityZone: input.AvailabilityZone,
+		Size:             input.Size,
+		State:            makeStringPtr(ec2.VolumeStateCreating),
+	}, nil
+}
+
+// AttachVolume is a mock for ec2.AttachVolume.
+func (c *awsClientMock) AttachVolume(ctx context.Context, input *ec2.AttachVolumeInput) (*ec2.VolumeAttachment, error) {
+	c.AttachVolumeInput = input
+	return &ec2.VolumeAttachment{}, nil
+}
+
+// DetachVolume is a mock for ec2.DetachVolume.
+func (c *awsClientMock) DetachVolume(ctx context.Context, input *ec2.DetachVolumeInput) (*ec2.VolumeAttachment, error) {
+	c.DetachVolumeInput = input
+	return &ec2.VolumeAttachment{}, nil
+}
+
+// ModifyVolume is a mock for ec2.ModifyVolume.
+func (c *awsClientMock) ModifyVolume(ctx context.Context, input *ec2.ModifyVolumeInput) (*ec2.ModifyVolumeOutput, error) {
+	c.ModifyVolumeInput = input
+	return &ec2.ModifyVolumeOutput{}, nil
+}
+
+// DeleteVolume is a mock for ec2.DeleteVolume.
+func (c *awsClientMock) DeleteVolume(ctx context.Context, input *ec2.DeleteVolumeInput) (*ec2.DeleteVolumeOutput, error) {
+	c.DeleteVolumeInput = input
+	return &ec2.DeleteVolumeOutput{}, nil
 }
 
 // DescribeSpotPriceHistory is a mock for ec2.DescribeSpotPriceHistory.
@@ -665,7 +913,10 @@ func (c *awsClientMock) GetInstanceInfo(ctx context.Context, id string) (*ec2.In
 	instance.LaunchTime = makeTimePtr(time.Now())
 	instance.PublicDnsName = makeStringPtr("public_dns_name")
 	instance.State = &ec2.InstanceState{}
-	instance.State.Name = makeStringPtr("running")
+	instance.State.Name = makeStringPtr(ec2.InstanceStateNameRunning)
+	if c.stopped {
+		instance.State.Name = makeStringPtr(ec2.InstanceStateNameStopped)
+	}
 	return instance, nil
 }
 
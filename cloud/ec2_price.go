@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"io/ioutil"
+	"math"
 	"strconv"
 	"strings"
 	"sync"
@@ -223,7 +224,15 @@ func (m *ec2Manager) getProvider(ctx context.Context, h *host.Host, ec2settings
 		if err != nil {
 			return 0, errors.Wrap(err, "error getting latest lowest spot price")
 		}
-		if spotPrice < onDemandPrice {
+		interruptionRate, err := host.SpotInterruptionRate(h.Distro.Id, time.Now().Add(-spotInterruptionRateWindow))
+		if err != nil {
+			grip.Warning(message.WrapError(err, message.Fields{
+				"message": "problem getting spot interruption rate, assuming none",
+				"distro":  h.Distro.Id,
+			}))
+			interruptionRate = 0
+		}
+		if spotPriceWithInterruptions(spotPrice, interruptionRate) < onDemandPrice {
 			ec2settings.BidPrice = onDemandPrice
 			if ec2settings.VpcName != "" {
 				subnetID, err := m.getSubnetForAZ(ctx, az, ec2settings.VpcName)
@@ -241,6 +250,20 @@ func (m *ec2Manager) getProvider(ctx context.Context, h *host.Host, ec2settings
 	return 0, errors.Errorf("provider is %d, expected %d, %d, or %d", m.provider, onDemandProvider, spotProvider, autoProvider)
 }
 
+// spotInterruptionRateWindow is how far back the spot interruption rate of a
+// distro is computed from.
+const spotInterruptionRateWindow = 24 * time.Hour
+
+// spotPriceWithInterruptions returns the effective price of a spot host when
+// the given fraction of spot hosts are reclaimed while running a task, since
+// the work done on them has to be repeated.
+func spotPriceWithInterruptions(price, interruptionRate float64) float64 {
+	if interruptionRate >= 1 {
+		return math.Inf(1)
+	}
+	return price / (1 - interruptionRate)
+}
+
 func (m *ec2Manager) getSubnetForAZ(ctx context.Context, azName, vpcName string) (string, error) {
 	vpcs, err := m.client.DescribeVpcs(ctx, &ec2.DescribeVpcsInput{
 		Filters: []*ec2.Filter{
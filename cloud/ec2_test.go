@@ -3,6 +3,7 @@ package cloud
 import (
 	"context"
 	"encoding/base64"
+	"math"
 	"strings"
 	"testing"
 	"time"
@@ -474,6 +475,84 @@ func (s *EC2Suite) TestTerminateInstance() {
 	s.NoError(err)
 }
 
+func (s *EC2Suite) TestStopAndStartInstance() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h := &host.Host{
+		Id:             "instance_id",
+		Status:         evergreen.HostRunning,
+		ExpirationTime: time.Now().Add(time.Hour),
+	}
+	h.Distro.Provider = evergreen.ProviderNameEc2OnDemand
+	s.NoError(h.Insert())
+
+	s.NoError(s.impl.StopInstance(ctx, h, evergreen.User))
+	mock, ok := s.impl.client.(*awsClientMock)
+	s.True(ok)
+	s.Require().NotNil(mock.StopInstancesInput)
+	s.Equal("instance_id", *mock.StopInstancesInput.InstanceIds[0])
+	found, err := host.FindOne(host.ById("instance_id"))
+	s.NoError(err)
+	s.Equal(evergreen.HostStopped, found.Status)
+
+	s.NoError(s.impl.StartInstance(ctx, h, evergreen.User))
+	s.Require().NotNil(mock.StartInstancesInput)
+	found, err = host.FindOne(host.ById("instance_id"))
+	s.NoError(err)
+	s.Equal(evergreen.HostRunning, found.Status)
+	s.Equal("public_dns_name", found.Host)
+
+	// spot instances cannot be stopped
+	h.Distro.Provider = evergreen.ProviderNameEc2Spot
+	s.Error(s.impl.StopInstance(ctx, h, evergreen.User))
+}
+
+func (s *EC2Suite) TestVolumeLifecycle() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h := &host.Host{Id: "instance_id", Zone: "us-east-1a"}
+	v, err := s.impl.CreateVolume(ctx, &host.Volume{
+		CreatedBy: "me",
+		Provider:  evergreen.ProviderNameEc2OnDemand,
+		Size:      32,
+		Zone:      h.Zone,
+	})
+	s.Require().NoError(err)
+	mock, ok := s.impl.client.(*awsClientMock)
+	s.True(ok)
+	s.Require().NotNil(mock.CreateVolumeInput)
+	s.Equal("us-east-1a", *mock.CreateVolumeInput.AvailabilityZone)
+	s.EqualValues(32, *mock.CreateVolumeInput.Size)
+	s.Equal("vol-123456", v.ID)
+
+	s.NoError(s.impl.AttachVolume(ctx, h, v))
+	s.Require().NotNil(mock.AttachVolumeInput)
+	s.Equal("instance_id", *mock.AttachVolumeInput.InstanceId)
+	s.Equal("vol-123456", *mock.AttachVolumeInput.VolumeId)
+	s.Equal(ec2VolumeDevice, *mock.AttachVolumeInput.Device)
+
+	s.NoError(s.impl.ModifyVolume(ctx, v, 64))
+	s.Require().NotNil(mock.ModifyVolumeInput)
+	s.EqualValues(64, *mock.ModifyVolumeInput.Size)
+
+	mock.DescribeVolumesInput = nil
+	s.NoError(s.impl.DetachVolume(ctx, h, v))
+	s.Require().NotNil(mock.DetachVolumeInput)
+	s.Equal("vol-123456", *mock.DetachVolumeInput.VolumeId)
+	// detaching waits for the volume to be available
+	s.Require().NotNil(mock.DescribeVolumesInput)
+	s.Equal("vol-123456", *mock.DescribeVolumesInput.VolumeIds[0])
+
+	s.NoError(s.impl.DeleteVolume(ctx, v))
+	s.Require().NotNil(mock.DeleteVolumeInput)
+	s.Equal("vol-123456", *mock.DeleteVolumeInput.VolumeId)
+
+	_, err = s.impl.CreateVolume(ctx, &host.Volume{Size: 32})
+	s.Error(err)
+}
+
 func (s *EC2Suite) TestIsUp() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -588,6 +667,58 @@ func (s *EC2Suite) TestGetProvider() {
 	s.Equal(onDemandProvider, provider)
 }
 
+func (s *EC2Suite) TestGetProviderWithSpotInterruptions() {
+	h := &host.Host{
+		Distro: distro.Distro{
+			Id:   "spotty",
+			Arch: "Linux/Unix",
+		},
+	}
+	pkgCachingPriceFetcher.ec2Prices = map[odInfo]float64{
+		odInfo{
+			os:       "Linux",
+			instance: "instance",
+			region:   "US East (N. Virginia)",
+		}: 23.2,
+	}
+	ec2Settings := &EC2ProviderSettings{
+		InstanceType: "instance",
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	manager, ok := s.autoManager.(*ec2Manager)
+	s.True(ok)
+
+	// every recent spot host of the distro was reclaimed, so spot is never cheaper
+	for _, id := range []string{"h1", "h2"} {
+		s.NoError((&host.Host{
+			Id:           id,
+			Distro:       distro.Distro{Id: "spotty", Provider: evergreen.ProviderNameEc2Spot},
+			StartedBy:    evergreen.User,
+			CreationTime: time.Now(),
+			Preempted:    true,
+		}).Insert())
+	}
+	provider, err := manager.getProvider(ctx, h, ec2Settings)
+	s.NoError(err)
+	s.Equal(onDemandProvider, provider)
+	s.Equal(evergreen.ProviderNameEc2OnDemand, h.Distro.Provider)
+
+	// other distros are unaffected
+	h.Distro.Id = "calm"
+	provider, err = manager.getProvider(ctx, h, ec2Settings)
+	s.NoError(err)
+	s.Equal(spotProvider, provider)
+}
+
+func (s *EC2Suite) TestSpotPriceWithInterruptions() {
+	s.Equal(1.0, spotPriceWithInterruptions(1.0, 0))
+	s.Equal(2.0, spotPriceWithInterruptions(1.0, 0.5))
+	s.True(math.IsInf(spotPriceWithInterruptions(1.0, 1), 1))
+}
+
 func (s *EC2Suite) TestPersistInstanceId() {
 	h := &host.Host{Id: "instance_id"}
 	_, err := s.onDemandManager.GetDNSName(context.Background(), h)
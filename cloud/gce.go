@@ -4,10 +4,12 @@ package cloud
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/util"
 	"github.com/mitchellh/mapstructure"
 	"github.com/mongodb/grip"
 	"github.com/mongodb/grip/message"
@@ -23,6 +25,7 @@ const (
 // gceManager implements the Manager interface for Google Compute Engine.
 type gceManager struct {
 	client gceClient
+	apiURL string
 }
 
 // GCESettings specifies the settings used to configure a host instance.
@@ -46,6 +49,9 @@ type GCESettings struct {
 	// By default, GCE uses project-wide SSH keys. Project-wide keys should be manually
 	// added to the project metadata. These SSH keys are optional instance-wide keys.
 	SSHKeys sshKeyGroup `mapstructure:"ssh_keys"`
+
+	// StartupScript is run when the instance boots.
+	StartupScript string `mapstructure:"startup_script"`
 }
 
 // Validate verifies a set of GCESettings.
@@ -95,6 +101,8 @@ func (m *gceManager) Configure(ctx context.Context, s *evergreen.Settings) error
 		return errors.Wrap(err, "Failed to initialize client connection")
 	}
 
+	m.apiURL = s.ApiUrl
+
 	return nil
 }
 
@@ -117,8 +125,9 @@ func (m *gceManager) Configure(ctx context.Context, s *evergreen.Settings) error
 //     - DiskSizeGB:  boot disk size, in base-2 GB
 //     - DiskType:    boot disk type i.e. pd-standard
 //
-//     - NetworkTags: (optional) security groups
-//     - SSHKeys:     username-key pairs
+//     - NetworkTags:   (optional) security groups
+//     - SSHKeys:       username-key pairs
+//     - StartupScript: (optional) script run when the instance boots
 func (m *gceManager) SpawnHost(ctx context.Context, h *host.Host) (*host.Host, error) {
 	if h.Distro.Provider != ProviderName {
 		return nil, errors.Errorf("Can't spawn instance of %s for distro %s: provider is %s",
@@ -143,6 +152,10 @@ func (m *gceManager) SpawnHost(ctx context.Context, h *host.Host) (*host.Host, e
 	h.Zone = s.Zone
 	h.Project = s.Project
 
+	if h.Distro.BootstrapsWithUserData() {
+		s.StartupScript = bootstrapUserData(h, m.apiURL, s.StartupScript)
+	}
+
 	// Start the instance, and remove the intent host document if unsuccessful.
 	if _, err := m.client.CreateInstance(h, s); err != nil {
 		if rmErr := h.Remove(); rmErr != nil {
@@ -183,6 +196,115 @@ func (m *gceManager) TerminateInstance(ctx context.Context, host *host.Host, use
 	return host.Terminate(user)
 }
 
+// StopInstance stops a running instance without deleting it.
+func (m *gceManager) StopInstance(ctx context.Context, host *host.Host, user string) error {
+	if host.Status != evergreen.HostRunning {
+		return errors.Errorf("Can not stop %s - host is %s, not running", host.Id, host.Status)
+	}
+
+	if err := m.client.StopInstance(host); err != nil {
+		return errors.Wrap(err, "API call to stop instance failed")
+	}
+
+	// Google Compute reports stopped instances as terminated.
+	if err := waitForInstanceStatus(ctx, m, host, StatusTerminated); err != nil {
+		return errors.Wrapf(err, "instance %s did not stop", host.Id)
+	}
+
+	return host.SetStopped(user)
+}
+
+// StartInstance starts a stopped instance. A started instance may be given a
+// new external IP address, so the host's DNS name is updated as well.
+func (m *gceManager) StartInstance(ctx context.Context, host *host.Host, user string) error {
+	if host.Status != evergreen.HostStopped {
+		return errors.Errorf("Can not start %s - host is %s, not stopped", host.Id, host.Status)
+	}
+
+	if err := m.client.StartInstance(host); err != nil {
+		return errors.Wrap(err, "API call to start instance failed")
+	}
+
+	if err := waitForInstanceStatus(ctx, m, host, StatusRunning); err != nil {
+		return errors.Wrapf(err, "instance %s did not start", host.Id)
+	}
+
+	dns, err := m.GetDNSName(ctx, host)
+	if err != nil {
+		return errors.Wrapf(err, "error getting DNS name for %s", host.Id)
+	}
+	if err = host.UpdateDNSName(dns); err != nil {
+		return errors.Wrapf(err, "error updating DNS name for %s", host.Id)
+	}
+
+	return host.SetStartedAfterStop(user)
+}
+
+// CreateVolume creates a persistent disk in the volume's zone and waits for
+// it to be ready.
+func (m *gceManager) CreateVolume(ctx context.Context, v *host.Volume) (*host.Volume, error) {
+	if v.Project == "" || v.Zone == "" {
+		return nil, errors.New("can not create a volume without a project and zone")
+	}
+	v.ID = fmt.Sprintf("volume-%s", util.RandomString())
+	if err := m.client.CreateDisk(v); err != nil {
+		return nil, errors.Wrap(err, "API call to create disk failed")
+	}
+
+	_, err := util.Retry(func() (bool, error) {
+		disk, err := m.client.GetDisk(v)
+		if err != nil {
+			return true, err
+		}
+		if disk.Status != "READY" {
+			return true, errors.Errorf("disk %s is %s, not ready", v.ID, disk.Status)
+		}
+		return false, nil
+	}, statusWaitRetries, statusWaitInterval)
+	if err != nil {
+		return nil, errors.Wrapf(err, "disk %s did not become ready", v.ID)
+	}
+
+	return v, nil
+}
+
+// AttachVolume attaches a persistent disk to an instance in the same zone.
+func (m *gceManager) AttachVolume(ctx context.Context, h *host.Host, v *host.Volume) error {
+	return errors.Wrapf(m.client.AttachDisk(h, v), "error attaching disk %s to instance %s", v.ID, h.Id)
+}
+
+// DetachVolume detaches a persistent disk from its instance.
+func (m *gceManager) DetachVolume(ctx context.Context, h *host.Host, v *host.Volume) error {
+	if err := m.client.DetachDisk(h); err != nil {
+		return errors.Wrapf(err, "error detaching disk %s from instance %s", v.ID, h.Id)
+	}
+
+	// the disk is detached asynchronously, and is in use until it has no
+	// more users
+	_, err := util.Retry(func() (bool, error) {
+		disk, err := m.client.GetDisk(v)
+		if err != nil {
+			return true, err
+		}
+		if len(disk.Users) > 0 {
+			return true, errors.Errorf("disk %s is still in use", v.ID)
+		}
+		return false, nil
+	}, statusWaitRetries, statusWaitInterval)
+	return errors.Wrapf(err, "disk %s was not detached", v.ID)
+}
+
+// ModifyVolume grows a persistent disk. The file system on the disk must be
+// grown separately to use the new space.
+func (m *gceManager) ModifyVolume(ctx context.Context, v *host.Volume, size int) error {
+	return errors.Wrapf(m.client.ResizeDisk(v, size), "error resizing disk %s", v.ID)
+}
+
+// DeleteVolume deletes a detached persistent disk.
+func (m *gceManager) DeleteVolume(ctx context.Context, v *host.Volume) error {
+	return errors.Wrapf(m.client.DeleteDisk(v), "error deleting disk %s", v.ID)
+}
+
 // IsUp checks whether the provisioned host is running.
 func (m *gceManager) IsUp(ctx context.Context, host *host.Host) (bool, error) {
 	status, err := m.GetInstanceStatus(ctx, host)
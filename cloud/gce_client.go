@@ -24,10 +24,19 @@ type gceClient interface {
 	CreateInstance(*host.Host, *GCESettings) (string, error)
 	GetInstance(*host.Host) (*compute.Instance, error)
 	DeleteInstance(*host.Host) error
+	StopInstance(*host.Host) error
+	StartInstance(*host.Host) error
+	CreateDisk(*host.Volume) error
+	GetDisk(*host.Volume) (*compute.Disk, error)
+	AttachDisk(*host.Host, *host.Volume) error
+	DetachDisk(*host.Host) error
+	ResizeDisk(*host.Volume, int) error
+	DeleteDisk(*host.Volume) error
 }
 
 type gceClientImpl struct {
 	InstancesService *compute.InstancesService
+	DisksService     *compute.DisksService
 }
 
 // Init establishes a connection to a Google Compute endpoint and creates a gceClient that
@@ -47,6 +56,7 @@ func (c *gceClientImpl) Init(ctx context.Context, config *jwt.Config) error {
 
 	// Get a handle to a specific service for instance configuration.
 	c.InstancesService = compute.NewInstancesService(service)
+	c.DisksService = compute.NewDisksService(service)
 
 	return nil
 }
@@ -111,6 +121,10 @@ func (c *gceClientImpl) CreateInstance(h *host.Host, s *GCESettings) (string, er
 			&compute.MetadataItems{Key: "ssh-keys", Value: &keys},
 		},
 	}
+	if s.StartupScript != "" {
+		instance.Metadata.Items = append(instance.Metadata.Items,
+			&compute.MetadataItems{Key: "startup-script", Value: &s.StartupScript})
+	}
 
 	grip.Debug(message.Fields{
 		"message":  "attaching metadata items",
@@ -144,3 +158,92 @@ func (c *gceClientImpl) DeleteInstance(h *host.Host) error {
 
 	return nil
 }
+
+// StopInstance requests an instance previously provisioned to be stopped.
+func (c *gceClientImpl) StopInstance(h *host.Host) error {
+	if _, err := c.InstancesService.Stop(h.Project, h.Zone, h.Id).Do(); err != nil {
+		return errors.Wrap(err, "API call to stop instance failed")
+	}
+
+	return nil
+}
+
+// StartInstance requests a stopped instance to be started again.
+func (c *gceClientImpl) StartInstance(h *host.Host) error {
+	if _, err := c.InstancesService.Start(h.Project, h.Zone, h.Id).Do(); err != nil {
+		return errors.Wrap(err, "API call to start instance failed")
+	}
+
+	return nil
+}
+
+// CreateDisk requests a persistent disk named after the volume's id.
+func (c *gceClientImpl) CreateDisk(v *host.Volume) error {
+	disk := &compute.Disk{
+		Name:   v.ID,
+		SizeGb: int64(v.Size),
+		Labels: makeVolumeLabels(v),
+	}
+	if _, err := c.DisksService.Insert(v.Project, v.Zone, disk).Do(); err != nil {
+		return errors.Wrap(err, "API call to insert disk failed")
+	}
+
+	return nil
+}
+
+// GetDisk requests details on a single persistent disk.
+func (c *gceClientImpl) GetDisk(v *host.Volume) (*compute.Disk, error) {
+	disk, err := c.DisksService.Get(v.Project, v.Zone, v.ID).Do()
+	if err != nil {
+		return nil, errors.Wrap(err, "API call to get disk failed")
+	}
+
+	return disk, nil
+}
+
+// AttachDisk requests a persistent disk to be attached to an instance in the
+// same zone.
+func (c *gceClientImpl) AttachDisk(h *host.Host, v *host.Volume) error {
+	disk, err := c.GetDisk(v)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	attached := &compute.AttachedDisk{
+		DeviceName: gceVolumeDevice,
+		Source:     disk.SelfLink,
+	}
+	if _, err = c.InstancesService.AttachDisk(h.Project, h.Zone, h.Id, attached).Do(); err != nil {
+		return errors.Wrap(err, "API call to attach disk failed")
+	}
+
+	return nil
+}
+
+// DetachDisk requests the home volume attached to an instance to be
+// detached.
+func (c *gceClientImpl) DetachDisk(h *host.Host) error {
+	if _, err := c.InstancesService.DetachDisk(h.Project, h.Zone, h.Id, gceVolumeDevice).Do(); err != nil {
+		return errors.Wrap(err, "API call to detach disk failed")
+	}
+
+	return nil
+}
+
+// ResizeDisk requests a persistent disk to be grown to the given size.
+func (c *gceClientImpl) ResizeDisk(v *host.Volume, size int) error {
+	req := &compute.DisksResizeRequest{SizeGb: int64(size)}
+	if _, err := c.DisksService.Resize(v.Project, v.Zone, v.ID, req).Do(); err != nil {
+		return errors.Wrap(err, "API call to resize disk failed")
+	}
+
+	return nil
+}
+
+// DeleteDisk requests a detached persistent disk to be deleted.
+func (c *gceClientImpl) DeleteDisk(v *host.Volume) error {
+	if _, err := c.DisksService.Delete(v.Project, v.Zone, v.ID).Do(); err != nil {
+		return errors.Wrap(err, "API call to delete disk failed")
+	}
+
+	return nil
+}
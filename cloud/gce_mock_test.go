@@ -15,10 +15,15 @@ type gceClientMock struct {
 	failCreate bool
 	failGet    bool
 	failDelete bool
+	failStop   bool
+	failStart  bool
+	failDisk   bool
 
 	// Other options
 	isActive        bool
+	isStopped       bool
 	hasAccessConfig bool
+	diskAttached    bool
 }
 
 func (c *gceClientMock) Init(context.Context, *jwt.Config) error {
@@ -52,6 +57,9 @@ func (c *gceClientMock) GetInstance(_ *host.Host) (*compute.Instance, error) {
 	if !c.isActive {
 		instance.Status = "STOPPING"
 	}
+	if c.isStopped {
+		instance.Status = "TERMINATED"
+	}
 
 	if c.hasAccessConfig {
 		instance.NetworkInterfaces = []*compute.NetworkInterface{&compute.NetworkInterface{
@@ -71,3 +79,75 @@ func (c *gceClientMock) DeleteInstance(_ *host.Host) error {
 
 	return nil
 }
+
+func (c *gceClientMock) StopInstance(_ *host.Host) error {
+	if c.failStop {
+		return errors.New("failed to stop instance")
+	}
+	c.isStopped = true
+
+	return nil
+}
+
+func (c *gceClientMock) StartInstance(_ *host.Host) error {
+	if c.failStart {
+		return errors.New("failed to start instance")
+	}
+	c.isStopped = false
+
+	return nil
+}
+
+func (c *gceClientMock) CreateDisk(_ *host.Volume) error {
+	if c.failDisk {
+		return errors.New("failed to create disk")
+	}
+
+	return nil
+}
+
+func (c *gceClientMock) GetDisk(v *host.Volume) (*compute.Disk, error) {
+	if c.failDisk {
+		return nil, errors.New("failed to get disk")
+	}
+
+	disk := &compute.Disk{Name: v.ID, SizeGb: int64(v.Size), Status: "READY"}
+	if c.diskAttached {
+		disk.Users = []string{"instance"}
+	}
+	return disk, nil
+}
+
+func (c *gceClientMock) AttachDisk(_ *host.Host, _ *host.Volume) error {
+	if c.failDisk {
+		return errors.New("failed to attach disk")
+	}
+	c.diskAttached = true
+
+	return nil
+}
+
+func (c *gceClientMock) DetachDisk(_ *host.Host) error {
+	if c.failDisk {
+		return errors.New("failed to detach disk")
+	}
+	c.diskAttached = false
+
+	return nil
+}
+
+func (c *gceClientMock) ResizeDisk(_ *host.Volume, _ int) error {
+	if c.failDisk {
+		return errors.New("failed to resize disk")
+	}
+
+	return nil
+}
+
+func (c *gceClientMock) DeleteDisk(_ *host.Volume) error {
+	if c.failDisk {
+		return errors.New("failed to delete disk")
+	}
+
+	return nil
+}
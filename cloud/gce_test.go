@@ -240,6 +240,70 @@ func (s *GCESuite) TestTerminateInstanceAPICall() {
 	s.Error(s.manager.TerminateInstance(ctx, hostB, evergreen.User))
 }
 
+func (s *GCESuite) TestStopAndStartInstance() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	myHost := NewIntent(s.distro, s.distro.GenerateName(), s.distro.Provider, s.hostOpts)
+	myHost.Status = evergreen.HostRunning
+	myHost.ExpirationTime = time.Now().Add(time.Hour)
+	s.NoError(myHost.Insert())
+
+	// a host can only be started once it has been stopped
+	s.Error(s.manager.StartInstance(ctx, myHost, evergreen.User))
+
+	s.NoError(s.manager.StopInstance(ctx, myHost, evergreen.User))
+	dbHost, err := host.FindOne(host.ById(myHost.Id))
+	s.NoError(err)
+	s.Equal(evergreen.HostStopped, dbHost.Status)
+	s.False(dbHost.StoppedTime.IsZero())
+
+	s.NoError(s.manager.StartInstance(ctx, myHost, evergreen.User))
+	dbHost, err = host.FindOne(host.ById(myHost.Id))
+	s.NoError(err)
+	s.Equal(evergreen.HostRunning, dbHost.Status)
+	s.Equal("0.0.0.0", dbHost.Host)
+	s.True(dbHost.StoppedTime.IsZero())
+	s.False(dbHost.ExpirationTime.Before(myHost.ExpirationTime))
+
+	mock, ok := s.client.(*gceClientMock)
+	s.True(ok)
+	mock.failStop = true
+	s.Error(s.manager.StopInstance(ctx, myHost, evergreen.User))
+}
+
+func (s *GCESuite) TestVolumeLifecycle() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h := &host.Host{Id: "instance", Project: "project", Zone: "zone"}
+	v, err := s.manager.CreateVolume(ctx, &host.Volume{
+		CreatedBy: "me",
+		Provider:  evergreen.ProviderNameGce,
+		Size:      32,
+		Project:   h.Project,
+		Zone:      h.Zone,
+	})
+	s.Require().NoError(err)
+	s.NotEmpty(v.ID)
+
+	mock, ok := s.client.(*gceClientMock)
+	s.Require().True(ok)
+	s.NoError(s.manager.AttachVolume(ctx, h, v))
+	s.True(mock.diskAttached)
+	s.NoError(s.manager.ModifyVolume(ctx, v, 64))
+	s.NoError(s.manager.DetachVolume(ctx, h, v))
+	s.False(mock.diskAttached)
+	s.NoError(s.manager.DeleteVolume(ctx, v))
+
+	_, err = s.manager.CreateVolume(ctx, &host.Volume{Size: 32})
+	s.Error(err)
+
+	mock.failDisk = true
+	s.Error(s.manager.AttachVolume(ctx, h, v))
+	s.Error(s.manager.DeleteVolume(ctx, v))
+}
+
 func (s *GCESuite) TestTerminateInstanceDB() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -133,3 +133,12 @@ func makeLabels(intent *host.Host) map[string]string {
 	}
 	return tags
 }
+
+// makeVolumeLabels returns the labels for a persistent disk.
+func makeVolumeLabels(v *host.Volume) map[string]string {
+	r := regexp.MustCompile("[^a-z0-9_-]+")
+	return map[string]string{
+		"owner": r.ReplaceAllString(strings.ToLower(v.CreatedBy), ""),
+		"mode":  "testing",
+	}
+}
@@ -0,0 +1,230 @@
+package cloud
+
+import (
+	"context"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mitchellh/mapstructure"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+// kubernetesManager implements the Manager interface for Kubernetes, where
+// each host is a single pod that runs the agent as its main process.
+type kubernetesManager struct {
+	client    kubernetesClient
+	namespace string
+	apiURL    string
+	clientURL string
+}
+
+// kubernetesSettings specifies the settings used to configure a pod.
+type kubernetesSettings struct {
+	// Namespace is the namespace in which to create pods. If blank, the
+	// namespace from the admin settings is used.
+	Namespace string `mapstructure:"namespace" json:"namespace" bson:"namespace"`
+	// Image is the image for the agent container. It may be omitted if
+	// the pod template already specifies the image for that container.
+	Image string `mapstructure:"image" json:"image" bson:"image"`
+	// AgentContainer is the name of the container in the template that runs
+	// the agent. If blank, the first container in the template is used.
+	AgentContainer string `mapstructure:"agent_container" json:"agent_container" bson:"agent_container"`
+	// PodTemplate is a Kubernetes pod manifest that new pods are based on.
+	PodTemplate map[string]interface{} `mapstructure:"pod_template" json:"pod_template" bson:"pod_template"`
+}
+
+// Validate checks that the settings from the distro are sane.
+func (s *kubernetesSettings) Validate() error {
+	if s.Image == "" && s.PodTemplate == nil {
+		return errors.New("either an image or a pod template must be specified")
+	}
+
+	if s.PodTemplate != nil {
+		if _, err := podFromTemplate(s.PodTemplate); err != nil {
+			return errors.Wrap(err, "invalid pod template")
+		}
+	}
+
+	return nil
+}
+
+// GetSettings returns an empty kubernetesSettings struct.
+func (m *kubernetesManager) GetSettings() ProviderSettings {
+	return &kubernetesSettings{}
+}
+
+// Configure loads the cluster credentials from the global config object.
+func (m *kubernetesManager) Configure(ctx context.Context, s *evergreen.Settings) error {
+	config := s.Providers.Kubernetes
+
+	if m.client == nil {
+		m.client = &kubernetesClientImpl{}
+	}
+
+	if err := m.client.Init(ctx, &config); err != nil {
+		return errors.Wrap(err, "Failed to initialize client connection")
+	}
+
+	m.namespace = config.Namespace
+	m.apiURL = s.ApiUrl
+	m.clientURL = s.Ui.Url
+
+	return nil
+}
+
+// SpawnHost creates a pod for the host. The pod runs the agent directly, so
+// the host is given its secret here rather than during SSH provisioning.
+func (m *kubernetesManager) SpawnHost(ctx context.Context, h *host.Host) (*host.Host, error) {
+	if h.Distro.Provider != evergreen.ProviderNameKubernetes {
+		return nil, errors.Errorf("Can't spawn instance of %s for distro %s: provider is %s",
+			evergreen.ProviderNameKubernetes, h.Distro.Id, h.Distro.Provider)
+	}
+
+	s := &kubernetesSettings{}
+	if h.Distro.ProviderSettings != nil {
+		if err := mapstructure.Decode(h.Distro.ProviderSettings, s); err != nil {
+			return nil, errors.Wrapf(err, "Error decoding params for distro %s", h.Distro.Id)
+		}
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "Invalid settings in distro %s", h.Distro.Id)
+	}
+
+	if h.Secret == "" {
+		h.Secret = util.RandomString()
+	}
+
+	pod, err := makePod(h, s, m.apiURL, m.clientURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not build pod for host %s", h.Id)
+	}
+
+	if err = m.client.CreatePod(ctx, m.getNamespace(s), pod); err != nil {
+		grip.Error(err)
+		return nil, errors.Wrapf(err, "Could not create pod for distro '%s'", h.Distro.Id)
+	}
+
+	grip.Debug(message.Fields{
+		"message":   "created pod",
+		"pod":       h.Id,
+		"namespace": m.getNamespace(s),
+		"distro":    h.Distro.Id,
+	})
+	event.LogHostStarted(h.Id)
+
+	return h, nil
+}
+
+// GetInstanceStatus returns a universal status code representing the phase
+// of the host's pod.
+func (m *kubernetesManager) GetInstanceStatus(ctx context.Context, h *host.Host) (CloudStatus, error) {
+	pod, err := m.client.GetPod(ctx, m.hostNamespace(h), h.Id)
+	if err != nil {
+		return StatusUnknown, errors.Wrapf(err, "Failed to get pod information for host '%s'", h.Id)
+	}
+
+	return kubernetesToEvgStatus(pod.Status.Phase), nil
+}
+
+// TerminateInstance deletes the host's pod.
+func (m *kubernetesManager) TerminateInstance(ctx context.Context, h *host.Host, user string) error {
+	if h.Status == evergreen.HostTerminated {
+		err := errors.Errorf("Can not terminate %s - already marked as terminated!", h.Id)
+		grip.Error(err)
+		return err
+	}
+
+	if err := m.client.DeletePod(ctx, m.hostNamespace(h), h.Id); err != nil {
+		return errors.Wrapf(err, "API call to delete pod %s failed", h.Id)
+	}
+
+	grip.Info(message.Fields{
+		"message": "deleted pod",
+		"pod":     h.Id,
+	})
+
+	// Set the host status as terminated and update its termination time
+	return h.Terminate(user)
+}
+
+// IsUp returns true if the pod is running.
+func (m *kubernetesManager) IsUp(ctx context.Context, h *host.Host) (bool, error) {
+	status, err := m.GetInstanceStatus(ctx, h)
+	if err != nil {
+		return false, err
+	}
+
+	return status == StatusRunning, nil
+}
+
+// OnUp does nothing.
+func (m *kubernetesManager) OnUp(context.Context, *host.Host) error {
+	return nil
+}
+
+// GetDNSName returns the IP address of the pod.
+func (m *kubernetesManager) GetDNSName(ctx context.Context, h *host.Host) (string, error) {
+	pod, err := m.client.GetPod(ctx, m.hostNamespace(h), h.Id)
+	if err != nil {
+		return "", errors.Wrapf(err, "Failed to get pod information for host '%s'", h.Id)
+	}
+
+	if pod.Status.PodIP == "" {
+		return "", errors.Errorf("pod %s does not have an IP address yet", h.Id)
+	}
+
+	return pod.Status.PodIP, nil
+}
+
+// GetSSHOptions returns an error because pods are not reachable over SSH.
+func (m *kubernetesManager) GetSSHOptions(h *host.Host, _ string) ([]string, error) {
+	return []string{}, errors.Errorf("pod host %s does not support SSH", h.Id)
+}
+
+// TimeTilNextPayment returns the amount of time until the next payment is due
+// for the host. For Kubernetes this is not relevant.
+func (m *kubernetesManager) TimeTilNextPayment(_ *host.Host) time.Duration {
+	return time.Duration(0)
+}
+
+// StopInstance is not supported, since Kubernetes hosts can't be stopped and
+// started again.
+func (m *kubernetesManager) StopInstance(_ context.Context, _ *host.Host, _ string) error {
+	return errStoppingUnsupported(evergreen.ProviderNameKubernetes)
+}
+
+// StartInstance is not supported, since Kubernetes hosts can't be stopped and
+// started again.
+func (m *kubernetesManager) StartInstance(_ context.Context, _ *host.Host, _ string) error {
+	return errStoppingUnsupported(evergreen.ProviderNameKubernetes)
+}
+
+func (m *kubernetesManager) getNamespace(s *kubernetesSettings) string {
+	if s.Namespace != "" {
+		return s.Namespace
+	}
+	if m.namespace != "" {
+		return m.namespace
+	}
+	return defaultKubernetesNamespace
+}
+
+// hostNamespace finds the namespace of an existing host's pod from its
+// distro, falling back to the globally configured namespace.
+func (m *kubernetesManager) hostNamespace(h *host.Host) string {
+	s := &kubernetesSettings{}
+	if h.Distro.ProviderSettings != nil {
+		grip.Warning(message.WrapError(mapstructure.Decode(h.Distro.ProviderSettings, s), message.Fields{
+			"message": "problem decoding distro settings for pod",
+			"host":    h.Id,
+			"distro":  h.Distro.Id,
+		}))
+	}
+	return m.getNamespace(s)
+}
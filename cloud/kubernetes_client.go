@@ -0,0 +1,189 @@
+package cloud
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+)
+
+// The kubernetesClient interface wraps interaction with the Kubernetes API server.
+type kubernetesClient interface {
+	Init(context.Context, *evergreen.KubernetesConfig) error
+	CreatePod(context.Context, string, *kubernetesPod) error
+	GetPod(context.Context, string, string) (*kubernetesPod, error)
+	DeletePod(context.Context, string, string) error
+}
+
+// kubernetesPod is the subset of the Kubernetes v1 Pod resource that
+// Evergreen reads and writes.
+type kubernetesPod struct {
+	APIVersion string               `json:"apiVersion,omitempty"`
+	Kind       string               `json:"kind,omitempty"`
+	Metadata   kubernetesObjectMeta `json:"metadata"`
+	Spec       kubernetesPodSpec    `json:"spec"`
+	Status     kubernetesPodStatus  `json:"status,omitempty"`
+}
+
+type kubernetesObjectMeta struct {
+	Name        string            `json:"name,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type kubernetesPodSpec struct {
+	Containers         []kubernetesContainer    `json:"containers"`
+	RestartPolicy      string                   `json:"restartPolicy,omitempty"`
+	NodeSelector       map[string]string        `json:"nodeSelector,omitempty"`
+	ServiceAccountName string                   `json:"serviceAccountName,omitempty"`
+	Tolerations        []map[string]interface{} `json:"tolerations,omitempty"`
+	Volumes            []map[string]interface{} `json:"volumes,omitempty"`
+}
+
+type kubernetesContainer struct {
+	Name         string                   `json:"name"`
+	Image        string                   `json:"image,omitempty"`
+	Command      []string                 `json:"command,omitempty"`
+	Args         []string                 `json:"args,omitempty"`
+	WorkingDir   string                   `json:"workingDir,omitempty"`
+	Env          []kubernetesEnvVar       `json:"env,omitempty"`
+	Resources    map[string]interface{}   `json:"resources,omitempty"`
+	VolumeMounts []map[string]interface{} `json:"volumeMounts,omitempty"`
+}
+
+type kubernetesEnvVar struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type kubernetesPodStatus struct {
+	Phase string `json:"phase,omitempty"`
+	PodIP string `json:"podIP,omitempty"`
+}
+
+type kubernetesClientImpl struct {
+	apiServer  string
+	token      string
+	httpClient *http.Client
+}
+
+// Init sets up an HTTP client that authenticates to the API server with a
+// bearer token, trusting the configured CA certificate if one is given.
+func (c *kubernetesClientImpl) Init(_ context.Context, config *evergreen.KubernetesConfig) error {
+	if config.APIServer == "" {
+		return errors.New("Kubernetes API server must not be blank")
+	}
+
+	c.apiServer = strings.TrimSuffix(config.APIServer, "/")
+	c.token = config.Token
+
+	if config.CACert == "" {
+		c.httpClient = util.GetHTTPClient()
+		return nil
+	}
+
+	// clusters usually sign their API server certificates with a private
+	// CA, so don't modify a pooled client to trust it.
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(config.CACert)) {
+		return errors.New("could not parse Kubernetes CA certificate")
+	}
+	c.httpClient = &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: pool},
+		},
+	}
+
+	return nil
+}
+
+// CreatePod creates the given pod in the namespace.
+func (c *kubernetesClientImpl) CreatePod(ctx context.Context, namespace string, pod *kubernetesPod) error {
+	payload, err := json.Marshal(pod)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal pod")
+	}
+
+	_, err = c.do(ctx, http.MethodPost, podsPath(namespace, ""), payload)
+	return errors.Wrapf(err, "problem creating pod %s", pod.Metadata.Name)
+}
+
+// GetPod retrieves the pod with the given name.
+func (c *kubernetesClientImpl) GetPod(ctx context.Context, namespace, name string) (*kubernetesPod, error) {
+	body, err := c.do(ctx, http.MethodGet, podsPath(namespace, name), nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem getting pod %s", name)
+	}
+
+	pod := &kubernetesPod{}
+	if err = json.Unmarshal(body, pod); err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal pod %s", name)
+	}
+
+	return pod, nil
+}
+
+// DeletePod deletes the pod with the given name. Deleting a pod that no
+// longer exists is not an error.
+func (c *kubernetesClientImpl) DeletePod(ctx context.Context, namespace, name string) error {
+	_, err := c.do(ctx, http.MethodDelete, podsPath(namespace, name), nil)
+	if errors.Cause(err) == errKubernetesNotFound {
+		grip.Infof("pod %s already deleted", name)
+		return nil
+	}
+	return errors.Wrapf(err, "problem deleting pod %s", name)
+}
+
+var errKubernetesNotFound = errors.New("resource not found")
+
+func podsPath(namespace, name string) string {
+	path := fmt.Sprintf("/api/v1/namespaces/%s/pods", url.PathEscape(namespace))
+	if name != "" {
+		path += "/" + url.PathEscape(name)
+	}
+	return path
+}
+
+func (c *kubernetesClientImpl) do(ctx context.Context, method, path string, payload []byte) ([]byte, error) {
+	req, err := http.NewRequest(method, c.apiServer+path, bytes.NewReader(payload))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not build request")
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set(evergreen.ContentTypeHeader, evergreen.ContentTypeValue)
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s %s failed", method, path)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read response body")
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errors.WithStack(errKubernetesNotFound)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, errors.Errorf("%s %s returned %d: %s", method, path, resp.StatusCode, string(body))
+	}
+
+	return body, nil
+}
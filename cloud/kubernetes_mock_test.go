@@ -0,0 +1,62 @@
+package cloud
+
+import (
+	"context"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/pkg/errors"
+)
+
+type kubernetesClientMock struct {
+	// API call options
+	failInit   bool
+	failCreate bool
+	failGet    bool
+	failDelete bool
+
+	// Other options
+	phase      string
+	hasIP      bool
+	createdPod *kubernetesPod
+}
+
+func (c *kubernetesClientMock) Init(context.Context, *evergreen.KubernetesConfig) error {
+	if c.failInit {
+		return errors.New("failed to initialize client")
+	}
+
+	return nil
+}
+
+func (c *kubernetesClientMock) CreatePod(_ context.Context, _ string, pod *kubernetesPod) error {
+	if c.failCreate {
+		return errors.New("failed to create pod")
+	}
+
+	c.createdPod = pod
+	return nil
+}
+
+func (c *kubernetesClientMock) GetPod(_ context.Context, _, name string) (*kubernetesPod, error) {
+	if c.failGet {
+		return nil, errors.New("failed to get pod")
+	}
+
+	pod := &kubernetesPod{
+		Metadata: kubernetesObjectMeta{Name: name},
+		Status:   kubernetesPodStatus{Phase: c.phase},
+	}
+	if c.hasIP {
+		pod.Status.PodIP = "10.0.0.1"
+	}
+
+	return pod, nil
+}
+
+func (c *kubernetesClientMock) DeletePod(context.Context, string, string) error {
+	if c.failDelete {
+		return errors.New("failed to delete pod")
+	}
+
+	return nil
+}
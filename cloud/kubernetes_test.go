@@ -0,0 +1,302 @@
+package cloud
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+type KubernetesSuite struct {
+	client   kubernetesClient
+	manager  *kubernetesManager
+	distro   distro.Distro
+	hostOpts HostOptions
+	suite.Suite
+}
+
+func TestKubernetesSuite(t *testing.T) {
+	suite.Run(t, new(KubernetesSuite))
+}
+
+func (s *KubernetesSuite) SetupSuite() {
+	db.SetGlobalSessionProvider(testutil.TestConfig().SessionFactory())
+}
+
+func (s *KubernetesSuite) SetupTest() {
+	s.client = &kubernetesClientMock{
+		phase: "Running",
+		hasIP: true,
+	}
+	s.manager = &kubernetesManager{
+		client:    s.client,
+		apiURL:    "https://evergreen.example.com",
+		clientURL: "https://evergreen.example.com",
+	}
+	s.distro = distro.Distro{
+		Id:       "pod",
+		Arch:     "linux_amd64",
+		WorkDir:  "/data/mci",
+		Provider: evergreen.ProviderNameKubernetes,
+		ProviderSettings: &map[string]interface{}{
+			"namespace": "evergreen",
+			"pod_template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"nodeSelector": map[string]interface{}{"pool": "ci"},
+					"containers": []interface{}{
+						map[string]interface{}{
+							"name":  "sidecar",
+							"image": "busybox",
+						},
+						map[string]interface{}{
+							"name":  "agent",
+							"image": "ubuntu:16.04",
+							"resources": map[string]interface{}{
+								"limits": map[string]interface{}{"cpu": "2"},
+							},
+						},
+					},
+				},
+			},
+			"agent_container": "agent",
+		},
+	}
+	s.hostOpts = HostOptions{}
+}
+
+func (s *KubernetesSuite) TestValidateSettings() {
+	s.NoError((&kubernetesSettings{Image: "ubuntu"}).Validate())
+	s.NoError((&kubernetesSettings{
+		PodTemplate: map[string]interface{}{"metadata": map[string]interface{}{"name": "template"}},
+	}).Validate())
+
+	// error when neither an image nor a template is given
+	s.Error((&kubernetesSettings{Namespace: "evergreen"}).Validate())
+
+	// error when the template does not look like a pod
+	s.Error((&kubernetesSettings{
+		PodTemplate: map[string]interface{}{"spec": "not a pod spec"},
+	}).Validate())
+}
+
+func (s *KubernetesSuite) TestConfigureAPICall() {
+	mock, ok := s.client.(*kubernetesClientMock)
+	s.True(ok)
+	s.False(mock.failInit)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	settings := &evergreen.Settings{}
+	settings.Providers.Kubernetes.Namespace = "ci"
+	s.NoError(s.manager.Configure(ctx, settings))
+	s.Equal("ci", s.manager.namespace)
+
+	mock.failInit = true
+	s.Error(s.manager.Configure(ctx, settings))
+}
+
+func (s *KubernetesSuite) TestSpawnInvalidSettings() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dProviderName := distro.Distro{Provider: "ec2"}
+	h := NewIntent(dProviderName, dProviderName.GenerateName(), dProviderName.Provider, s.hostOpts)
+	h, err := s.manager.SpawnHost(ctx, h)
+	s.Error(err)
+	s.Nil(h)
+
+	dSettingsNone := distro.Distro{Provider: evergreen.ProviderNameKubernetes}
+	h = NewIntent(dSettingsNone, dSettingsNone.GenerateName(), dSettingsNone.Provider, s.hostOpts)
+	h, err = s.manager.SpawnHost(ctx, h)
+	s.Error(err)
+	s.Nil(h)
+
+	dMissingContainer := distro.Distro{
+		Provider: evergreen.ProviderNameKubernetes,
+		ProviderSettings: &map[string]interface{}{
+			"image":           "ubuntu",
+			"agent_container": "missing",
+			"pod_template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{map[string]interface{}{"name": "other"}},
+				},
+			},
+		},
+	}
+	h = NewIntent(dMissingContainer, dMissingContainer.GenerateName(), dMissingContainer.Provider, s.hostOpts)
+	h, err = s.manager.SpawnHost(ctx, h)
+	s.Error(err)
+	s.Nil(h)
+}
+
+func (s *KubernetesSuite) TestSpawnAPICall() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mock, ok := s.client.(*kubernetesClientMock)
+	s.True(ok)
+	s.False(mock.failCreate)
+
+	h := NewIntent(s.distro, s.distro.GenerateName(), s.distro.Provider, s.hostOpts)
+	h, err := s.manager.SpawnHost(ctx, h)
+	s.NoError(err)
+	s.Require().NotNil(h)
+	s.NotEmpty(h.Secret)
+
+	mock.failCreate = true
+	h = NewIntent(s.distro, s.distro.GenerateName(), s.distro.Provider, s.hostOpts)
+	_, err = s.manager.SpawnHost(ctx, h)
+	s.Error(err)
+}
+
+func (s *KubernetesSuite) TestSpawnUsesTemplate() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mock, ok := s.client.(*kubernetesClientMock)
+	s.True(ok)
+
+	h := NewIntent(s.distro, s.distro.GenerateName(), s.distro.Provider, s.hostOpts)
+	h, err := s.manager.SpawnHost(ctx, h)
+	s.Require().NoError(err)
+
+	pod := mock.createdPod
+	s.Require().NotNil(pod)
+	s.Equal(h.Id, pod.Metadata.Name)
+	s.Equal(h.Id, pod.Metadata.Labels[kubernetesHostLabel])
+	s.Equal("Never", pod.Spec.RestartPolicy)
+	s.Equal("ci", pod.Spec.NodeSelector["pool"])
+	s.Require().Len(pod.Spec.Containers, 2)
+
+	sidecar := pod.Spec.Containers[0]
+	s.Empty(sidecar.Command)
+
+	agent := pod.Spec.Containers[1]
+	s.Equal("ubuntu:16.04", agent.Image)
+	s.Equal("/data/mci", agent.WorkingDir)
+	s.NotNil(agent.Resources["limits"])
+	s.Require().Len(agent.Command, 3)
+	s.Contains(agent.Command[2], "https://evergreen.example.com/clients/linux_amd64/evergreen")
+	s.Contains(agent.Command[2], "--host_id='"+h.Id+"'")
+	s.False(strings.Contains(agent.Command[2], h.Secret))
+	s.Require().Len(agent.Env, 1)
+	s.Equal(kubernetesHostSecretEnv, agent.Env[0].Name)
+	s.Equal(h.Secret, agent.Env[0].Value)
+}
+
+func (s *KubernetesSuite) TestAgentWorkDir() {
+	h := &host.Host{Id: "h", Distro: s.distro}
+	s.Contains(agentStartScript(h, "https://evergreen.example.com", "https://evergreen.example.com/clients"), "--working_directory='/data/mci'")
+
+	h.Distro.WorkDir = ""
+	s.Equal(defaultKubernetesWorkDir, podWorkDir(h))
+	script := agentStartScript(h, "https://evergreen.example.com", "https://evergreen.example.com/clients")
+	s.Contains(script, "--working_directory='"+defaultKubernetesWorkDir+"'")
+	s.NotContains(script, "--working_directory=''")
+}
+
+func (s *KubernetesSuite) TestGetNamespace() {
+	s.Equal("evergreen", s.manager.hostNamespace(&host.Host{Distro: s.distro}))
+	s.Equal(defaultKubernetesNamespace, s.manager.hostNamespace(&host.Host{}))
+
+	s.manager.namespace = "ci"
+	s.Equal("ci", s.manager.hostNamespace(&host.Host{}))
+}
+
+func (s *KubernetesSuite) TestIsUpStatuses() {
+	mock, ok := s.client.(*kubernetesClientMock)
+	s.True(ok)
+
+	h := &host.Host{Id: "pod"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	status, err := s.manager.GetInstanceStatus(ctx, h)
+	s.NoError(err)
+	s.Equal(StatusRunning, status)
+
+	active, err := s.manager.IsUp(ctx, h)
+	s.NoError(err)
+	s.True(active)
+
+	mock.phase = "Pending"
+	status, err = s.manager.GetInstanceStatus(ctx, h)
+	s.NoError(err)
+	s.Equal(StatusInitializing, status)
+
+	active, err = s.manager.IsUp(ctx, h)
+	s.NoError(err)
+	s.False(active)
+
+	mock.failGet = true
+	status, err = s.manager.GetInstanceStatus(ctx, h)
+	s.Error(err)
+	s.Equal(StatusUnknown, status)
+}
+
+func (s *KubernetesSuite) TestTerminateInstanceAPICall() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	hostA := NewIntent(s.distro, s.distro.GenerateName(), s.distro.Provider, s.hostOpts)
+	hostA, err := s.manager.SpawnHost(ctx, hostA)
+	s.NotNil(hostA)
+	s.NoError(err)
+	s.NoError(hostA.Insert())
+
+	hostB := NewIntent(s.distro, s.distro.GenerateName(), s.distro.Provider, s.hostOpts)
+	hostB, err = s.manager.SpawnHost(ctx, hostB)
+	s.NotNil(hostB)
+	s.NoError(err)
+	s.NoError(hostB.Insert())
+
+	mock, ok := s.client.(*kubernetesClientMock)
+	s.True(ok)
+	s.False(mock.failDelete)
+
+	s.NoError(s.manager.TerminateInstance(ctx, hostA, evergreen.User))
+	s.Error(s.manager.TerminateInstance(ctx, hostA, evergreen.User))
+
+	mock.failDelete = true
+	s.Error(s.manager.TerminateInstance(ctx, hostB, evergreen.User))
+}
+
+func (s *KubernetesSuite) TestGetDNSName() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mock, ok := s.client.(*kubernetesClientMock)
+	s.True(ok)
+
+	h := &host.Host{Id: "pod"}
+	dns, err := s.manager.GetDNSName(ctx, h)
+	s.NoError(err)
+	s.Equal("10.0.0.1", dns)
+
+	mock.hasIP = false
+	dns, err = s.manager.GetDNSName(ctx, h)
+	s.Error(err)
+	s.Empty(dns)
+}
+
+func (s *KubernetesSuite) TestGetSSHOptions() {
+	opts, err := s.manager.GetSSHOptions(&host.Host{Id: "pod"}, "key")
+	s.Error(err)
+	s.Empty(opts)
+}
+
+func (s *KubernetesSuite) TestUtilToEvgStatus() {
+	s.Equal(StatusInitializing, kubernetesToEvgStatus("Pending"))
+	s.Equal(StatusRunning, kubernetesToEvgStatus("Running"))
+	s.Equal(StatusTerminated, kubernetesToEvgStatus("Succeeded"))
+	s.Equal(StatusFailed, kubernetesToEvgStatus("Failed"))
+	s.Equal(StatusUnknown, kubernetesToEvgStatus("???"))
+}
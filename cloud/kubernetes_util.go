@@ -0,0 +1,135 @@
+package cloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultKubernetesNamespace = "default"
+	kubernetesHostSecretEnv    = "EVERGREEN_HOST_SECRET"
+	kubernetesHostLabel        = "evergreen-host"
+	kubernetesDistroLabel      = "evergreen-distro"
+	// defaultKubernetesWorkDir is the agent's working directory in pods
+	// whose distro doesn't set one.
+	defaultKubernetesWorkDir = "/data/mci"
+)
+
+// podFromTemplate converts a distro's pod template into a pod.
+func podFromTemplate(template map[string]interface{}) (*kubernetesPod, error) {
+	pod := &kubernetesPod{}
+	if template == nil {
+		return pod, nil
+	}
+
+	payload, err := json.Marshal(template)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal pod template")
+	}
+	if err = json.Unmarshal(payload, pod); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal pod template")
+	}
+
+	return pod, nil
+}
+
+// makePod builds the pod for a host from the distro's template. The agent
+// container downloads the agent binary from the Evergreen server and runs
+// it as its main process, so the pod exits when the agent does.
+func makePod(h *host.Host, s *kubernetesSettings, apiURL, clientURL string) (*kubernetesPod, error) {
+	pod, err := podFromTemplate(s.PodTemplate)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	pod.APIVersion = "v1"
+	pod.Kind = "Pod"
+	pod.Metadata.Name = h.Id
+	pod.Metadata.Namespace = ""
+	if pod.Metadata.Labels == nil {
+		pod.Metadata.Labels = map[string]string{}
+	}
+	pod.Metadata.Labels[kubernetesHostLabel] = h.Id
+	pod.Metadata.Labels[kubernetesDistroLabel] = h.Distro.Id
+	pod.Spec.RestartPolicy = "Never"
+	pod.Status = kubernetesPodStatus{}
+
+	idx := -1
+	for i, c := range pod.Spec.Containers {
+		if s.AgentContainer == "" || c.Name == s.AgentContainer {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		if s.AgentContainer != "" && len(pod.Spec.Containers) > 0 {
+			return nil, errors.Errorf("pod template has no container named '%s'", s.AgentContainer)
+		}
+		name := s.AgentContainer
+		if name == "" {
+			name = "agent"
+		}
+		pod.Spec.Containers = append(pod.Spec.Containers, kubernetesContainer{Name: name})
+		idx = len(pod.Spec.Containers) - 1
+	}
+
+	agent := &pod.Spec.Containers[idx]
+	if s.Image != "" {
+		agent.Image = s.Image
+	}
+	if agent.Image == "" {
+		return nil, errors.Errorf("no image specified for agent container '%s'", agent.Name)
+	}
+	agent.Command = []string{"/bin/sh", "-c", agentStartScript(h, apiURL, clientURL)}
+	agent.Args = nil
+	agent.WorkingDir = podWorkDir(h)
+	agent.Env = append(agent.Env, kubernetesEnvVar{Name: kubernetesHostSecretEnv, Value: h.Secret})
+
+	return pod, nil
+}
+
+// agentStartScript returns the shell script that a pod runs to fetch and
+// start the agent. The host secret is read from the environment so that it
+// does not appear in the pod's command.
+func agentStartScript(h *host.Host, apiURL, clientURL string) string {
+	binary := h.Distro.BinaryName()
+	parts := []string{
+		"exec", "./" + binary, "agent",
+		fmt.Sprintf("--api_server='%s'", apiURL),
+		fmt.Sprintf("--host_id='%s'", h.Id),
+		fmt.Sprintf(`--host_secret="$%s"`, kubernetesHostSecretEnv),
+		fmt.Sprintf("--working_directory='%s'", podWorkDir(h)),
+		"--cleanup",
+	}
+
+	return fmt.Sprintf("curl -LO '%s/clients/%s' && chmod +x %s && %s",
+		clientURL, h.Distro.ExecutableSubPath(), binary, strings.Join(parts, " "))
+}
+
+// podWorkDir returns the agent's working directory in the host's pod.
+func podWorkDir(h *host.Host) string {
+	if h.Distro.WorkDir == "" {
+		return defaultKubernetesWorkDir
+	}
+	return h.Distro.WorkDir
+}
+
+// kubernetesToEvgStatus converts a pod phase to an Evergreen cloud provider status.
+func kubernetesToEvgStatus(phase string) CloudStatus {
+	switch phase {
+	case "Pending":
+		return StatusInitializing
+	case "Running":
+		return StatusRunning
+	case "Succeeded":
+		return StatusTerminated
+	case "Failed":
+		return StatusFailed
+	default:
+		return StatusUnknown
+	}
+}
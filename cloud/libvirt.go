@@ -0,0 +1,311 @@
+package cloud
+
+import (
+	"context"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/mitchellh/mapstructure"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+// libvirtManager implements the Manager interface for libvirt/QEMU hypervisors.
+type libvirtManager struct {
+	client libvirtClient
+}
+
+// libvirtSettings specifies the settings used to configure a domain.
+type libvirtSettings struct {
+	// URI is the libvirt connection URI of the hypervisor,
+	// e.g. qemu+ssh://root@hypervisor.example.com/system
+	URI string `mapstructure:"uri" json:"uri" bson:"uri"`
+	// Pool is the storage pool containing the base volume.
+	Pool string `mapstructure:"pool" json:"pool" bson:"pool"`
+	// BaseVolume is the name of the volume that is cloned for each new domain.
+	BaseVolume string `mapstructure:"base_volume" json:"base_volume" bson:"base_volume"`
+	// Network is the libvirt network the domain is attached to. Its DHCP
+	// leases are used to find the domain's IP address.
+	Network string `mapstructure:"network" json:"network" bson:"network"`
+
+	NumCPUs  int `mapstructure:"num_cpus" json:"num_cpus" bson:"num_cpus"`
+	MemoryMB int `mapstructure:"memory_mb" json:"memory_mb" bson:"memory_mb"`
+}
+
+// Validate checks that the settings from the distro are sane, and fills in
+// defaults for optional settings.
+func (s *libvirtSettings) Validate() error {
+	if s.URI == "" {
+		return errors.New("connection URI must not be blank")
+	}
+
+	if s.BaseVolume == "" {
+		return errors.New("base volume must not be blank")
+	}
+
+	if s.Pool == "" {
+		s.Pool = "default"
+	}
+
+	if s.Network == "" {
+		s.Network = "default"
+	}
+
+	if s.NumCPUs < 0 {
+		return errors.New("number of CPUs must be non-negative")
+	}
+	if s.NumCPUs == 0 {
+		s.NumCPUs = 1
+	}
+
+	if s.MemoryMB < 0 {
+		return errors.New("memory in Mb must be non-negative")
+	}
+	if s.MemoryMB == 0 {
+		s.MemoryMB = 1024
+	}
+
+	return nil
+}
+
+// GetSettings returns an empty libvirtSettings struct.
+func (m *libvirtManager) GetSettings() ProviderSettings {
+	return &libvirtSettings{}
+}
+
+// Configure sets up the client with the settings from the global config object.
+func (m *libvirtManager) Configure(ctx context.Context, s *evergreen.Settings) error {
+	config := s.Providers.Libvirt
+
+	if m.client == nil {
+		m.client = &libvirtClientImpl{}
+	}
+
+	if err := m.client.Init(ctx, &config); err != nil {
+		return errors.Wrap(err, "Failed to initialize client connection")
+	}
+
+	return nil
+}
+
+// SpawnHost clones the base volume, then defines and starts a new domain that
+// boots from the clone. If any step fails, the resources created by earlier
+// steps are removed.
+func (m *libvirtManager) SpawnHost(ctx context.Context, h *host.Host) (*host.Host, error) {
+	if h.Distro.Provider != evergreen.ProviderNameLibvirt {
+		return nil, errors.Errorf("Can't spawn instance of %s for distro %s: provider is %s",
+			evergreen.ProviderNameLibvirt, h.Distro.Id, h.Distro.Provider)
+	}
+
+	s, err := getLibvirtSettings(h)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	domainXML, err := makeLibvirtDomainXML(h.Id, s)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	volume := libvirtVolumeName(h.Id)
+	if err = m.client.CloneVolume(ctx, s.URI, s.Pool, s.BaseVolume, volume); err != nil {
+		return nil, errors.Wrapf(err, "Could not clone volume '%s' for distro '%s'", s.BaseVolume, h.Distro.Id)
+	}
+
+	if err = m.client.DefineDomain(ctx, s.URI, domainXML); err != nil {
+		grip.Error(message.WrapError(m.client.DeleteVolume(ctx, s.URI, s.Pool, volume), message.Fields{
+			"message": "could not clean up volume",
+			"host":    h.Id,
+			"volume":  volume,
+		}))
+		return nil, errors.Wrapf(err, "Could not define domain for distro '%s'", h.Distro.Id)
+	}
+
+	if err = m.client.StartDomain(ctx, s.URI, h.Id); err != nil {
+		grip.Error(message.WrapError(m.removeDomain(ctx, s, h.Id), message.Fields{
+			"message": "could not clean up domain",
+			"host":    h.Id,
+		}))
+		return nil, errors.Wrapf(err, "Could not start domain for distro '%s'", h.Distro.Id)
+	}
+
+	grip.Debug(message.Fields{
+		"message":    "started libvirt domain",
+		"domain":     h.Id,
+		"uri":        s.URI,
+		"distro":     h.Distro.Id,
+		"base_image": s.BaseVolume,
+	})
+	event.LogHostStarted(h.Id)
+
+	return h, nil
+}
+
+// GetInstanceStatus returns the status of a single domain.
+func (m *libvirtManager) GetInstanceStatus(ctx context.Context, h *host.Host) (CloudStatus, error) {
+	statuses, err := m.GetInstanceStatuses(ctx, []host.Host{*h})
+	if err != nil {
+		return StatusUnknown, errors.WithStack(err)
+	}
+
+	return statuses[0], nil
+}
+
+// GetInstanceStatuses returns the statuses of many domains, listing the
+// domains of each hypervisor only once.
+func (m *libvirtManager) GetInstanceStatuses(ctx context.Context, hosts []host.Host) ([]CloudStatus, error) {
+	states := map[string]map[string]string{}
+	statuses := make([]CloudStatus, 0, len(hosts))
+
+	for i := range hosts {
+		s, err := getLibvirtSettings(&hosts[i])
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		domains, ok := states[s.URI]
+		if !ok {
+			domains, err = m.client.ListDomains(ctx, s.URI)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not list domains on '%s'", s.URI)
+			}
+			states[s.URI] = domains
+		}
+
+		state, ok := domains[hosts[i].Id]
+		if !ok {
+			statuses = append(statuses, StatusTerminated)
+			continue
+		}
+		statuses = append(statuses, libvirtToEvgStatus(state))
+	}
+
+	return statuses, nil
+}
+
+// TerminateInstance destroys the domain and removes its definition and volume.
+func (m *libvirtManager) TerminateInstance(ctx context.Context, h *host.Host, user string) error {
+	if h.Status == evergreen.HostTerminated {
+		err := errors.Errorf("Can not terminate %s - already marked as terminated!", h.Id)
+		grip.Error(err)
+		return err
+	}
+
+	s, err := getLibvirtSettings(h)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err = m.removeDomain(ctx, s, h.Id); err != nil {
+		return errors.Wrapf(err, "could not remove domain %s", h.Id)
+	}
+
+	// Set the host status as terminated and update its termination time
+	return errors.Wrapf(h.Terminate(user), "could not terminate host %s in db", h.Id)
+}
+
+// IsUp returns true if the domain is running.
+func (m *libvirtManager) IsUp(ctx context.Context, h *host.Host) (bool, error) {
+	status, err := m.GetInstanceStatus(ctx, h)
+	if err != nil {
+		return false, errors.Wrapf(err, "manager failed to get instance status for host %s", h.Id)
+	}
+
+	return status == StatusRunning, nil
+}
+
+// OnUp does nothing.
+func (m *libvirtManager) OnUp(context.Context, *host.Host) error {
+	return nil
+}
+
+// GetDNSName returns the IP address that the domain's network leased to it.
+func (m *libvirtManager) GetDNSName(ctx context.Context, h *host.Host) (string, error) {
+	s, err := getLibvirtSettings(h)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	leases, err := m.client.GetDHCPLeases(ctx, s.URI, s.Network)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not get DHCP leases for network '%s'", s.Network)
+	}
+
+	mac := libvirtMACAddress(h.Id)
+	for _, lease := range leases {
+		if lease.MAC == mac {
+			return lease.IP, nil
+		}
+	}
+
+	return "", errors.Errorf("no DHCP lease found for host %s", h.Id)
+}
+
+// GetSSHOptions generates the command line args to be
+// passed to SSH to allow connection to the machine.
+func (m *libvirtManager) GetSSHOptions(h *host.Host, keyPath string) ([]string, error) {
+	if keyPath == "" {
+		return []string{}, errors.Errorf("No key specified for host %s", h.Id)
+	}
+
+	opts := []string{"-i", keyPath}
+	for _, opt := range h.Distro.SSHOptions {
+		opts = append(opts, "-o", opt)
+	}
+
+	return opts, nil
+}
+
+// TimeTilNextPayment returns the amount of time until the next payment is due
+// for the host. For on-premise hypervisors this is not relevant.
+func (m *libvirtManager) TimeTilNextPayment(_ *host.Host) time.Duration {
+	return time.Duration(0)
+}
+
+// StopInstance is not supported, since libvirt hosts can't be stopped and
+// started again.
+func (m *libvirtManager) StopInstance(_ context.Context, _ *host.Host, _ string) error {
+	return errStoppingUnsupported(evergreen.ProviderNameLibvirt)
+}
+
+// StartInstance is not supported, since libvirt hosts can't be stopped and
+// started again.
+func (m *libvirtManager) StartInstance(_ context.Context, _ *host.Host, _ string) error {
+	return errStoppingUnsupported(evergreen.ProviderNameLibvirt)
+}
+
+// removeDomain stops and undefines a domain and deletes its volume.
+func (m *libvirtManager) removeDomain(ctx context.Context, s *libvirtSettings, name string) error {
+	if err := m.client.DestroyDomain(ctx, s.URI, name); err != nil {
+		return errors.Wrapf(err, "could not destroy domain %s", name)
+	}
+
+	if err := m.client.UndefineDomain(ctx, s.URI, name); err != nil {
+		return errors.Wrapf(err, "could not undefine domain %s", name)
+	}
+
+	volume := libvirtVolumeName(name)
+	if err := m.client.DeleteVolume(ctx, s.URI, s.Pool, volume); err != nil {
+		return errors.Wrapf(err, "could not delete volume %s", volume)
+	}
+
+	return nil
+}
+
+func getLibvirtSettings(h *host.Host) (*libvirtSettings, error) {
+	s := &libvirtSettings{}
+	if h.Distro.ProviderSettings != nil {
+		if err := mapstructure.Decode(h.Distro.ProviderSettings, s); err != nil {
+			return nil, errors.Wrapf(err, "Error decoding params for distro %s", h.Distro.Id)
+		}
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "Invalid settings in distro %s", h.Distro.Id)
+	}
+
+	return s, nil
+}
@@ -0,0 +1,171 @@
+package cloud
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/subprocess"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultVirshPath = "virsh"
+	virshTimeout     = 2 * time.Minute
+)
+
+// The libvirtClient interface wraps interaction with libvirt hypervisors.
+type libvirtClient interface {
+	Init(context.Context, *evergreen.LibvirtConfig) error
+	CloneVolume(ctx context.Context, uri, pool, base, name string) error
+	DeleteVolume(ctx context.Context, uri, pool, name string) error
+	DefineDomain(ctx context.Context, uri, xml string) error
+	StartDomain(ctx context.Context, uri, name string) error
+	DestroyDomain(ctx context.Context, uri, name string) error
+	UndefineDomain(ctx context.Context, uri, name string) error
+	ListDomains(ctx context.Context, uri string) (map[string]string, error)
+	GetDHCPLeases(ctx context.Context, uri, network string) ([]libvirtLease, error)
+}
+
+// libvirtLease is an entry in a libvirt network's DHCP lease table.
+type libvirtLease struct {
+	MAC      string
+	IP       string
+	Hostname string
+}
+
+// libvirtClientImpl manages hypervisors with the virsh command line tool,
+// which handles connecting to remote hypervisors through the URI.
+type libvirtClientImpl struct {
+	virshPath string
+}
+
+func (c *libvirtClientImpl) Init(_ context.Context, config *evergreen.LibvirtConfig) error {
+	c.virshPath = config.VirshPath
+	if c.virshPath == "" {
+		c.virshPath = defaultVirshPath
+	}
+
+	return nil
+}
+
+func (c *libvirtClientImpl) CloneVolume(ctx context.Context, uri, pool, base, name string) error {
+	_, err := c.virsh(ctx, uri, "vol-clone", "--pool", pool, base, name)
+	return errors.WithStack(err)
+}
+
+func (c *libvirtClientImpl) DeleteVolume(ctx context.Context, uri, pool, name string) error {
+	_, err := c.virsh(ctx, uri, "vol-delete", "--pool", pool, name)
+	return errors.WithStack(ignoreLibvirtMissing(err))
+}
+
+// DefineDomain defines a persistent domain from its XML description. virsh
+// reads the definition from a local file even for remote hypervisors.
+func (c *libvirtClientImpl) DefineDomain(ctx context.Context, uri, xml string) error {
+	file, err := ioutil.TempFile("", "libvirt-domain")
+	if err != nil {
+		return errors.Wrap(err, "error creating temporary domain file")
+	}
+	defer func() {
+		grip.Warning(message.WrapError(os.Remove(file.Name()), message.Fields{
+			"message": "problem removing temporary domain file",
+			"file":    file.Name(),
+		}))
+	}()
+
+	if _, err = file.WriteString(xml); err != nil {
+		grip.Warning(file.Close())
+		return errors.Wrap(err, "error writing temporary domain file")
+	}
+	if err = file.Close(); err != nil {
+		return errors.Wrap(err, "error closing temporary domain file")
+	}
+
+	_, err = c.virsh(ctx, uri, "define", file.Name())
+	return errors.WithStack(err)
+}
+
+func (c *libvirtClientImpl) StartDomain(ctx context.Context, uri, name string) error {
+	_, err := c.virsh(ctx, uri, "start", name)
+	return errors.WithStack(err)
+}
+
+// DestroyDomain forcefully stops a domain. Stopping a domain that is not
+// running is not an error.
+func (c *libvirtClientImpl) DestroyDomain(ctx context.Context, uri, name string) error {
+	_, err := c.virsh(ctx, uri, "destroy", name)
+	if err != nil && strings.Contains(err.Error(), "domain is not running") {
+		return nil
+	}
+	return errors.WithStack(ignoreLibvirtMissing(err))
+}
+
+func (c *libvirtClientImpl) UndefineDomain(ctx context.Context, uri, name string) error {
+	_, err := c.virsh(ctx, uri, "undefine", name)
+	return errors.WithStack(ignoreLibvirtMissing(err))
+}
+
+// ListDomains returns the state of every domain on the hypervisor, keyed by
+// domain name.
+func (c *libvirtClientImpl) ListDomains(ctx context.Context, uri string) (map[string]string, error) {
+	out, err := c.virsh(ctx, uri, "list", "--all")
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return parseVirshList(out), nil
+}
+
+func (c *libvirtClientImpl) GetDHCPLeases(ctx context.Context, uri, network string) ([]libvirtLease, error) {
+	out, err := c.virsh(ctx, uri, "net-dhcp-leases", network)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return parseVirshLeases(out), nil
+}
+
+func (c *libvirtClientImpl) virsh(ctx context.Context, uri string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, virshTimeout)
+	defer cancel()
+
+	cmd, err := subprocess.NewLocalExec(c.virshPath, append([]string{"--connect", uri}, args...), nil, "")
+	if err != nil {
+		return "", errors.Wrap(err, "could not create virsh command")
+	}
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	if err = cmd.SetOutput(subprocess.OutputOptions{Output: stdout, Error: stderr}); err != nil {
+		return "", errors.Wrap(err, "problem configuring virsh output")
+	}
+
+	if err = cmd.Run(ctx); err != nil {
+		return "", errors.Errorf("virsh %s failed: %s (%s)",
+			strings.Join(args, " "), strings.TrimSpace(stderr.String()), err.Error())
+	}
+
+	return stdout.String(), nil
+}
+
+// ignoreLibvirtMissing discards errors caused by the resource not existing,
+// so that cleaning up is idempotent.
+func ignoreLibvirtMissing(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	msg := err.Error()
+	if strings.Contains(msg, "failed to get domain") || strings.Contains(msg, "failed to get vol") ||
+		strings.Contains(msg, "Storage volume not found") || strings.Contains(msg, "Domain not found") {
+		return nil
+	}
+
+	return err
+}
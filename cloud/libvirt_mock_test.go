@@ -0,0 +1,97 @@
+package cloud
+
+import (
+	"context"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/pkg/errors"
+)
+
+type libvirtClientMock struct {
+	// API call options
+	failInit     bool
+	failClone    bool
+	failDefine   bool
+	failStart    bool
+	failDestroy  bool
+	failList     bool
+	failLeases   bool
+	listRequests int
+
+	// Other options
+	domains        map[string]string
+	leases         []libvirtLease
+	deletedVolumes []string
+	undefined      []string
+}
+
+func (c *libvirtClientMock) Init(context.Context, *evergreen.LibvirtConfig) error {
+	if c.failInit {
+		return errors.New("failed to initialize client")
+	}
+
+	return nil
+}
+
+func (c *libvirtClientMock) CloneVolume(context.Context, string, string, string, string) error {
+	if c.failClone {
+		return errors.New("failed to clone volume")
+	}
+
+	return nil
+}
+
+func (c *libvirtClientMock) DeleteVolume(_ context.Context, _, _, name string) error {
+	c.deletedVolumes = append(c.deletedVolumes, name)
+	return nil
+}
+
+func (c *libvirtClientMock) DefineDomain(context.Context, string, string) error {
+	if c.failDefine {
+		return errors.New("failed to define domain")
+	}
+
+	return nil
+}
+
+func (c *libvirtClientMock) StartDomain(_ context.Context, _, name string) error {
+	if c.failStart {
+		return errors.New("failed to start domain")
+	}
+
+	if c.domains == nil {
+		c.domains = map[string]string{}
+	}
+	c.domains[name] = "running"
+	return nil
+}
+
+func (c *libvirtClientMock) DestroyDomain(context.Context, string, string) error {
+	if c.failDestroy {
+		return errors.New("failed to destroy domain")
+	}
+
+	return nil
+}
+
+func (c *libvirtClientMock) UndefineDomain(_ context.Context, _, name string) error {
+	c.undefined = append(c.undefined, name)
+	return nil
+}
+
+func (c *libvirtClientMock) ListDomains(context.Context, string) (map[string]string, error) {
+	c.listRequests++
+	if c.failList {
+		return nil, errors.New("failed to list domains")
+	}
+
+	return c.domains, nil
+}
+
+func (c *libvirtClientMock) GetDHCPLeases(context.Context, string, string) ([]libvirtLease, error) {
+	if c.failLeases {
+		return nil, errors.New("failed to get leases")
+	}
+
+	return c.leases, nil
+}
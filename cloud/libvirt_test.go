@@ -0,0 +1,278 @@
+package cloud
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+type LibvirtSuite struct {
+	client   libvirtClient
+	manager  *libvirtManager
+	distro   distro.Distro
+	hostOpts HostOptions
+	suite.Suite
+}
+
+func TestLibvirtSuite(t *testing.T) {
+	suite.Run(t, new(LibvirtSuite))
+}
+
+func (s *LibvirtSuite) SetupSuite() {
+	db.SetGlobalSessionProvider(testutil.TestConfig().SessionFactory())
+}
+
+func (s *LibvirtSuite) SetupTest() {
+	s.client = &libvirtClientMock{}
+	s.manager = &libvirtManager{
+		client: s.client,
+	}
+	s.distro = distro.Distro{
+		Id:       "kvm",
+		Provider: evergreen.ProviderNameLibvirt,
+		ProviderSettings: &map[string]interface{}{
+			"uri":         "qemu+ssh://root@hv1.example.com/system",
+			"base_volume": "ubuntu1604.qcow2",
+			"memory_mb":   4096,
+		},
+	}
+	s.hostOpts = HostOptions{}
+}
+
+func (s *LibvirtSuite) TestImplements() {
+	s.Implements((*BatchManager)(nil), s.manager)
+}
+
+func (s *LibvirtSuite) TestValidateSettings() {
+	settingsOk := &libvirtSettings{URI: "qemu:///system", BaseVolume: "base"}
+	s.NoError(settingsOk.Validate())
+	s.Equal("default", settingsOk.Pool)
+	s.Equal("default", settingsOk.Network)
+	s.Equal(1, settingsOk.NumCPUs)
+	s.Equal(1024, settingsOk.MemoryMB)
+
+	s.Error((&libvirtSettings{BaseVolume: "base"}).Validate())
+	s.Error((&libvirtSettings{URI: "qemu:///system"}).Validate())
+	s.Error((&libvirtSettings{URI: "qemu:///system", BaseVolume: "base", NumCPUs: -1}).Validate())
+	s.Error((&libvirtSettings{URI: "qemu:///system", BaseVolume: "base", MemoryMB: -1}).Validate())
+}
+
+func (s *LibvirtSuite) TestConfigureAPICall() {
+	mock, ok := s.client.(*libvirtClientMock)
+	s.True(ok)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	settings := &evergreen.Settings{}
+	s.NoError(s.manager.Configure(ctx, settings))
+
+	mock.failInit = true
+	s.Error(s.manager.Configure(ctx, settings))
+}
+
+func (s *LibvirtSuite) TestSpawnInvalidSettings() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dProviderName := distro.Distro{Provider: "ec2"}
+	h := NewIntent(dProviderName, dProviderName.GenerateName(), dProviderName.Provider, s.hostOpts)
+	h, err := s.manager.SpawnHost(ctx, h)
+	s.Error(err)
+	s.Nil(h)
+
+	dSettingsNone := distro.Distro{Provider: evergreen.ProviderNameLibvirt}
+	h = NewIntent(dSettingsNone, dSettingsNone.GenerateName(), dSettingsNone.Provider, s.hostOpts)
+	h, err = s.manager.SpawnHost(ctx, h)
+	s.Error(err)
+	s.Nil(h)
+}
+
+func (s *LibvirtSuite) TestSpawnAPICall() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mock, ok := s.client.(*libvirtClientMock)
+	s.True(ok)
+
+	h := NewIntent(s.distro, s.distro.GenerateName(), s.distro.Provider, s.hostOpts)
+	h, err := s.manager.SpawnHost(ctx, h)
+	s.NoError(err)
+	s.NotNil(h)
+	s.Empty(mock.deletedVolumes)
+
+	mock.failClone = true
+	h = NewIntent(s.distro, s.distro.GenerateName(), s.distro.Provider, s.hostOpts)
+	_, err = s.manager.SpawnHost(ctx, h)
+	s.Error(err)
+	s.Empty(mock.deletedVolumes)
+}
+
+func (s *LibvirtSuite) TestSpawnCleansUpOnFailure() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mock, ok := s.client.(*libvirtClientMock)
+	s.True(ok)
+
+	mock.failDefine = true
+	h := NewIntent(s.distro, s.distro.GenerateName(), s.distro.Provider, s.hostOpts)
+	_, err := s.manager.SpawnHost(ctx, h)
+	s.Error(err)
+	s.Equal([]string{libvirtVolumeName(h.Id)}, mock.deletedVolumes)
+	s.Empty(mock.undefined)
+
+	mock.failDefine = false
+	mock.failStart = true
+	mock.deletedVolumes = nil
+	h = NewIntent(s.distro, s.distro.GenerateName(), s.distro.Provider, s.hostOpts)
+	_, err = s.manager.SpawnHost(ctx, h)
+	s.Error(err)
+	s.Equal([]string{libvirtVolumeName(h.Id)}, mock.deletedVolumes)
+	s.Equal([]string{h.Id}, mock.undefined)
+}
+
+func (s *LibvirtSuite) TestGetInstanceStatuses() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mock, ok := s.client.(*libvirtClientMock)
+	s.True(ok)
+	mock.domains = map[string]string{
+		"h1": "running",
+		"h2": "shut off",
+		"h3": "crashed",
+	}
+
+	hosts := []host.Host{}
+	for _, id := range []string{"h1", "h2", "h3", "h4"} {
+		hosts = append(hosts, host.Host{Id: id, Distro: s.distro})
+	}
+
+	statuses, err := s.manager.GetInstanceStatuses(ctx, hosts)
+	s.NoError(err)
+	s.Equal([]CloudStatus{StatusRunning, StatusStopped, StatusFailed, StatusTerminated}, statuses)
+	s.Equal(1, mock.listRequests)
+
+	status, err := s.manager.GetInstanceStatus(ctx, &hosts[0])
+	s.NoError(err)
+	s.Equal(StatusRunning, status)
+
+	up, err := s.manager.IsUp(ctx, &hosts[1])
+	s.NoError(err)
+	s.False(up)
+
+	mock.failList = true
+	_, err = s.manager.GetInstanceStatuses(ctx, hosts)
+	s.Error(err)
+}
+
+func (s *LibvirtSuite) TestGetDNSName() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mock, ok := s.client.(*libvirtClientMock)
+	s.True(ok)
+
+	h := &host.Host{Id: "h1", Distro: s.distro}
+	_, err := s.manager.GetDNSName(ctx, h)
+	s.Error(err)
+
+	mock.leases = []libvirtLease{
+		{MAC: libvirtMACAddress("other"), IP: "192.168.122.10"},
+		{MAC: libvirtMACAddress(h.Id), IP: "192.168.122.11"},
+	}
+	dns, err := s.manager.GetDNSName(ctx, h)
+	s.NoError(err)
+	s.Equal("192.168.122.11", dns)
+
+	mock.failLeases = true
+	_, err = s.manager.GetDNSName(ctx, h)
+	s.Error(err)
+}
+
+func (s *LibvirtSuite) TestTerminateInstance() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mock, ok := s.client.(*libvirtClientMock)
+	s.True(ok)
+
+	h := NewIntent(s.distro, s.distro.GenerateName(), s.distro.Provider, s.hostOpts)
+	h, err := s.manager.SpawnHost(ctx, h)
+	s.NoError(err)
+	s.NoError(h.Insert())
+
+	mock.failDestroy = true
+	s.Error(s.manager.TerminateInstance(ctx, h, evergreen.User))
+	s.Empty(mock.deletedVolumes)
+
+	mock.failDestroy = false
+	s.NoError(s.manager.TerminateInstance(ctx, h, evergreen.User))
+	s.Equal([]string{h.Id}, mock.undefined)
+	s.Equal([]string{libvirtVolumeName(h.Id)}, mock.deletedVolumes)
+
+	dbHost, err := host.FindOne(host.ById(h.Id))
+	s.NoError(err)
+	s.Equal(evergreen.HostTerminated, dbHost.Status)
+
+	s.Error(s.manager.TerminateInstance(ctx, h, evergreen.User))
+}
+
+func (s *LibvirtSuite) TestDomainXML() {
+	settings := &libvirtSettings{URI: "qemu:///system", BaseVolume: "base", NumCPUs: 4, MemoryMB: 8192}
+	s.NoError(settings.Validate())
+
+	out, err := makeLibvirtDomainXML("evg-kvm-1", settings)
+	s.NoError(err)
+	s.True(strings.HasPrefix(out, `<domain type="kvm">`))
+	s.Contains(out, "<name>evg-kvm-1</name>")
+	s.Contains(out, `<memory unit="MiB">8192</memory>`)
+	s.Contains(out, "<vcpu>4</vcpu>")
+	s.Contains(out, `<source pool="default" volume="evg-kvm-1.qcow2"></source>`)
+	s.Contains(out, `<mac address="`+libvirtMACAddress("evg-kvm-1")+`"></mac>`)
+	s.Contains(out, `<source network="default"></source>`)
+}
+
+func (s *LibvirtSuite) TestMACAddress() {
+	s.Equal(libvirtMACAddress("a"), libvirtMACAddress("a"))
+	s.NotEqual(libvirtMACAddress("a"), libvirtMACAddress("b"))
+	s.True(strings.HasPrefix(libvirtMACAddress("a"), "52:54:00:"))
+	s.Len(libvirtMACAddress("a"), 17)
+}
+
+func (s *LibvirtSuite) TestParseVirshOutput() {
+	list := ` Id    Name                           State
+----------------------------------------------------
+ 1     evg-kvm-1                      running
+ -     evg-kvm-2                      shut off
+
+`
+	s.Equal(map[string]string{"evg-kvm-1": "running", "evg-kvm-2": "shut off"}, parseVirshList(list))
+	s.Empty(parseVirshList(""))
+
+	leases := ` Expiry Time          MAC address        Protocol  IP address                Hostname        Client ID or DUID
+-------------------------------------------------------------------------------------------------------------------
+ 2018-06-15 10:00:00  52:54:00:AA:BB:CC  ipv4      192.168.122.50/24         evg-kvm-1       -
+ 2018-06-15 10:05:00  52:54:00:aa:bb:cd  ipv4      192.168.122.51/24         -               -
+`
+	s.Equal([]libvirtLease{
+		{MAC: "52:54:00:aa:bb:cc", IP: "192.168.122.50", Hostname: "evg-kvm-1"},
+		{MAC: "52:54:00:aa:bb:cd", IP: "192.168.122.51"},
+	}, parseVirshLeases(leases))
+}
+
+func (s *LibvirtSuite) TestUtilToEvgStatus() {
+	s.Equal(StatusRunning, libvirtToEvgStatus("running"))
+	s.Equal(StatusStopped, libvirtToEvgStatus("shut off"))
+	s.Equal(StatusStopped, libvirtToEvgStatus("paused"))
+	s.Equal(StatusFailed, libvirtToEvgStatus("crashed"))
+	s.Equal(StatusUnknown, libvirtToEvgStatus("???"))
+}
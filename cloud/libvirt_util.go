@@ -0,0 +1,180 @@
+package cloud
+
+import (
+	"crypto/md5"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// libvirtVolumeName returns the name of the volume cloned for a domain.
+func libvirtVolumeName(name string) string {
+	return name + ".qcow2"
+}
+
+// libvirtMACAddress deterministically derives a MAC address for a domain
+// from its name, so the domain's DHCP lease can be found without storing
+// the address. The prefix is the one QEMU reserves for virtual NICs.
+func libvirtMACAddress(name string) string {
+	sum := md5.Sum([]byte(name))
+	return fmt.Sprintf("52:54:00:%02x:%02x:%02x", sum[0], sum[1], sum[2])
+}
+
+type libvirtDomain struct {
+	XMLName xml.Name `xml:"domain"`
+	Type    string   `xml:"type,attr"`
+	Name    string   `xml:"name"`
+	Memory  struct {
+		Unit  string `xml:"unit,attr"`
+		Value int    `xml:",chardata"`
+	} `xml:"memory"`
+	VCPU int `xml:"vcpu"`
+	OS   struct {
+		Type string `xml:"type"`
+		Boot struct {
+			Dev string `xml:"dev,attr"`
+		} `xml:"boot"`
+	} `xml:"os"`
+	Features struct {
+		ACPI struct{} `xml:"acpi"`
+	} `xml:"features"`
+	Devices struct {
+		Disk struct {
+			Type   string `xml:"type,attr"`
+			Device string `xml:"device,attr"`
+			Driver struct {
+				Name string `xml:"name,attr"`
+				Type string `xml:"type,attr"`
+			} `xml:"driver"`
+			Source struct {
+				Pool   string `xml:"pool,attr"`
+				Volume string `xml:"volume,attr"`
+			} `xml:"source"`
+			Target struct {
+				Dev string `xml:"dev,attr"`
+				Bus string `xml:"bus,attr"`
+			} `xml:"target"`
+		} `xml:"disk"`
+		Interface struct {
+			Type string `xml:"type,attr"`
+			MAC  struct {
+				Address string `xml:"address,attr"`
+			} `xml:"mac"`
+			Source struct {
+				Network string `xml:"network,attr"`
+			} `xml:"source"`
+			Model struct {
+				Type string `xml:"type,attr"`
+			} `xml:"model"`
+		} `xml:"interface"`
+		Serial struct {
+			Type string `xml:"type,attr"`
+		} `xml:"serial"`
+	} `xml:"devices"`
+}
+
+// makeLibvirtDomainXML returns the XML description of a KVM domain that
+// boots from the volume cloned for it and has a single NIC on the
+// distro's network.
+func makeLibvirtDomainXML(name string, s *libvirtSettings) (string, error) {
+	d := libvirtDomain{Type: "kvm", Name: name, VCPU: s.NumCPUs}
+	d.Memory.Unit = "MiB"
+	d.Memory.Value = s.MemoryMB
+	d.OS.Type = "hvm"
+	d.OS.Boot.Dev = "hd"
+
+	d.Devices.Disk.Type = "volume"
+	d.Devices.Disk.Device = "disk"
+	d.Devices.Disk.Driver.Name = "qemu"
+	d.Devices.Disk.Driver.Type = "qcow2"
+	d.Devices.Disk.Source.Pool = s.Pool
+	d.Devices.Disk.Source.Volume = libvirtVolumeName(name)
+	d.Devices.Disk.Target.Dev = "vda"
+	d.Devices.Disk.Target.Bus = "virtio"
+
+	d.Devices.Interface.Type = "network"
+	d.Devices.Interface.MAC.Address = libvirtMACAddress(name)
+	d.Devices.Interface.Source.Network = s.Network
+	d.Devices.Interface.Model.Type = "virtio"
+
+	d.Devices.Serial.Type = "pty"
+
+	out, err := xml.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return "", errors.Wrapf(err, "could not marshal domain %s", name)
+	}
+
+	return string(out), nil
+}
+
+// parseVirshList parses the table printed by 'virsh list --all' into a map
+// of domain names to states.
+func parseVirshList(out string) map[string]string {
+	domains := map[string]string{}
+	for _, line := range virshTableRows(out) {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		domains[fields[1]] = strings.Join(fields[2:], " ")
+	}
+
+	return domains
+}
+
+// parseVirshLeases parses the table printed by 'virsh net-dhcp-leases'.
+func parseVirshLeases(out string) []libvirtLease {
+	leases := []libvirtLease{}
+	for _, line := range virshTableRows(out) {
+		// expiry date, expiry time, MAC, protocol, IP/prefix, hostname, client ID
+		fields := strings.Fields(line)
+		if len(fields) < 5 {
+			continue
+		}
+		lease := libvirtLease{
+			MAC: strings.ToLower(fields[2]),
+			IP:  strings.Split(fields[4], "/")[0],
+		}
+		if len(fields) > 5 && fields[5] != "-" {
+			lease.Hostname = fields[5]
+		}
+		leases = append(leases, lease)
+	}
+
+	return leases
+}
+
+// virshTableRows returns the non-empty rows of a table printed by virsh,
+// which follow a header and a line of dashes.
+func virshTableRows(out string) []string {
+	rows := []string{}
+	pastHeader := false
+	for _, line := range strings.Split(out, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if !pastHeader {
+			pastHeader = strings.HasPrefix(trimmed, "---")
+			continue
+		}
+		if trimmed != "" {
+			rows = append(rows, trimmed)
+		}
+	}
+
+	return rows
+}
+
+// libvirtToEvgStatus converts a domain state to an Evergreen cloud provider status.
+func libvirtToEvgStatus(state string) CloudStatus {
+	switch state {
+	case "running", "idle", "blocked":
+		return StatusRunning
+	case "paused", "shut off", "in shutdown", "pmsuspended":
+		return StatusStopped
+	case "crashed":
+		return StatusFailed
+	default:
+		return StatusUnknown
+	}
+}
@@ -2,11 +2,13 @@ package cloud
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/util"
 	"github.com/pkg/errors"
 )
 
@@ -29,6 +31,10 @@ type MockInstance struct {
 	TimeTilNextPayment time.Duration
 	DNSName            string
 	OnUpRan            bool
+	// the id of the volume attached to the instance, if any
+	Volume string
+	// what an hour of the instance's time costs
+	HourlyCost float64
 }
 
 type MockProvider interface {
@@ -187,6 +193,115 @@ func (mockMgr *mockManager) TerminateInstance(ctx context.Context, host *host.Ho
 	return errors.WithStack(host.Terminate(user))
 }
 
+// stop an instance
+func (mockMgr *mockManager) StopInstance(ctx context.Context, host *host.Host, user string) error {
+	l := mockMgr.mutex
+	l.Lock()
+	defer l.Unlock()
+	instance, ok := mockMgr.Instances[host.Id]
+	if !ok {
+		return errors.Errorf("unable to fetch host: %s", host.Id)
+	}
+	if host.Status != evergreen.HostRunning {
+		return errors.Errorf("Cannot stop %s; host is %s, not running", host.Id, host.Status)
+	}
+
+	instance.Status = StatusStopped
+	instance.IsUp = false
+	mockMgr.Instances[host.Id] = instance
+
+	return errors.WithStack(host.SetStopped(user))
+}
+
+// start a stopped instance
+func (mockMgr *mockManager) StartInstance(ctx context.Context, host *host.Host, user string) error {
+	l := mockMgr.mutex
+	l.Lock()
+	defer l.Unlock()
+	instance, ok := mockMgr.Instances[host.Id]
+	if !ok {
+		return errors.Errorf("unable to fetch host: %s", host.Id)
+	}
+	if host.Status != evergreen.HostStopped {
+		return errors.Errorf("Cannot start %s; host is %s, not stopped", host.Id, host.Status)
+	}
+
+	instance.Status = StatusRunning
+	instance.IsUp = true
+	mockMgr.Instances[host.Id] = instance
+
+	return errors.WithStack(host.SetStartedAfterStop(user))
+}
+
+// create a volume; mock volumes have no state of their own
+func (mockMgr *mockManager) CreateVolume(ctx context.Context, v *host.Volume) (*host.Volume, error) {
+	v.ID = fmt.Sprintf("mock-volume-%s", util.RandomString())
+	return v, nil
+}
+
+// attach a volume to an instance
+func (mockMgr *mockManager) AttachVolume(ctx context.Context, host *host.Host, v *host.Volume) error {
+	l := mockMgr.mutex
+	l.Lock()
+	defer l.Unlock()
+	instance, ok := mockMgr.Instances[host.Id]
+	if !ok {
+		return errors.Errorf("unable to fetch host: %s", host.Id)
+	}
+	if instance.Volume != "" {
+		return errors.Errorf("Cannot attach %s; host %s already has volume %s", v.ID, host.Id, instance.Volume)
+	}
+
+	instance.Volume = v.ID
+	mockMgr.Instances[host.Id] = instance
+
+	return nil
+}
+
+// detach a volume from an instance
+func (mockMgr *mockManager) DetachVolume(ctx context.Context, host *host.Host, v *host.Volume) error {
+	l := mockMgr.mutex
+	l.Lock()
+	defer l.Unlock()
+	instance, ok := mockMgr.Instances[host.Id]
+	if !ok {
+		return errors.Errorf("unable to fetch host: %s", host.Id)
+	}
+	if instance.Volume != v.ID {
+		return errors.Errorf("Cannot detach %s; it is not attached to host %s", v.ID, host.Id)
+	}
+
+	instance.Volume = ""
+	mockMgr.Instances[host.Id] = instance
+
+	return nil
+}
+
+func (mockMgr *mockManager) ModifyVolume(ctx context.Context, v *host.Volume, size int) error {
+	return nil
+}
+
+func (mockMgr *mockManager) DeleteVolume(ctx context.Context, v *host.Volume) error {
+	return nil
+}
+
+// CostForDuration returns the instance's hourly cost for the time between
+// start and end.
+func (mockMgr *mockManager) CostForDuration(ctx context.Context, h *host.Host, start, end time.Time) (float64, error) {
+	if end.Before(start) {
+		return 0, errors.New("task timing data is malformed")
+	}
+	l := mockMgr.mutex
+	l.RLock()
+	instance, ok := mockMgr.Instances[h.Id]
+	l.RUnlock()
+	if !ok {
+		return 0, errors.Errorf("unable to fetch host: %s", h.Id)
+	}
+
+	return instance.HourlyCost * end.Sub(start).Hours(), nil
+}
+
 func (mockMgr *mockManager) Configure(ctx context.Context, settings *evergreen.Settings) error {
 	//no-op. maybe will need to load something from settings in the future.
 	return nil
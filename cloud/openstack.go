@@ -19,6 +19,7 @@ type openStackManager struct {
 	authOptions  *gophercloud.AuthOptions
 	endpointOpts *gophercloud.EndpointOpts
 	client       openStackClient
+	apiURL       string
 }
 
 // ProviderSettings specifies the settings used to configure a host instance.
@@ -81,6 +82,8 @@ func (m *openStackManager) Configure(ctx context.Context, s *evergreen.Settings)
 		return errors.Wrap(err, "Failed to initialize client connection")
 	}
 
+	m.apiURL = s.ApiUrl
+
 	return nil
 }
 
@@ -111,6 +114,9 @@ func (m *openStackManager) SpawnHost(ctx context.Context, h *host.Host) (*host.H
 
 	// Start the instance, and remove the intent host document if unsuccessful.
 	opts := getSpawnOptions(h, settings)
+	if h.Distro.BootstrapsWithUserData() {
+		opts.UserData = []byte(bootstrapUserData(h, m.apiURL, ""))
+	}
 	server, err := m.client.CreateInstance(opts, settings.KeyName)
 	if err != nil {
 		grip.Error(err)
@@ -228,3 +234,15 @@ func (m *openStackManager) GetSSHOptions(host *host.Host, keyPath string) ([]str
 func (m *openStackManager) TimeTilNextPayment(host *host.Host) time.Duration {
 	return time.Duration(0)
 }
+
+// StopInstance is not supported, since OpenStack hosts can't be stopped and
+// started again.
+func (m *openStackManager) StopInstance(_ context.Context, _ *host.Host, _ string) error {
+	return errStoppingUnsupported(evergreen.ProviderNameOpenstack)
+}
+
+// StartInstance is not supported, since OpenStack hosts can't be stopped and
+// started again.
+func (m *openStackManager) StartInstance(_ context.Context, _ *host.Host, _ string) error {
+	return errStoppingUnsupported(evergreen.ProviderNameOpenstack)
+}
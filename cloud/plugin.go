@@ -0,0 +1,275 @@
+package cloud
+
+import (
+	"context"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mitchellh/mapstructure"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+// pluginManager implements the Manager interface by delegating to an
+// executable configured by an admin, so that new kinds of infrastructure can
+// be supported without changing Evergreen.
+//
+// For every call the executable is run with the configured arguments
+// followed by the method name, and is sent a single JSON request on standard
+// input:
+//
+//	{
+//	  "protocol_version": 1,
+//	  "method": "spawn_host",
+//	  "host": {"id": "...", "distro": "...", "dns_name": "...", ...},
+//	  "settings": {...the distro's provider settings...},
+//	  "start_time": "...", "end_time": "..."
+//	}
+//
+// It must exit zero after writing a single JSON response to standard output.
+// Failures are reported either with a non-zero exit, in which case standard
+// error is included in the error, or with a non-empty "error" field. The
+// methods and the response fields they use are:
+//
+//	spawn_host           "host", whose dns_name, external_identifier, zone and instance_type are stored
+//	get_instance_status  "status", one of pending, initializing, running, stopped, terminated or failed
+//	terminate_instance   none
+//	is_up                "up"
+//	on_up                none
+//	get_dns_name         "dns_name"
+//	cost_for_duration    "cost", for the time between start_time and end_time
+type pluginManager struct {
+	client  pluginClient
+	plugins map[string]evergreen.CloudPlugin
+}
+
+// pluginSettings specifies which plugin manages a distro's hosts. All of the
+// distro's provider settings, including these, are passed to the plugin.
+type pluginSettings struct {
+	Plugin string `mapstructure:"plugin" json:"plugin" bson:"plugin"`
+}
+
+// Validate checks that the settings from the distro are sane.
+func (s *pluginSettings) Validate() error {
+	if s.Plugin == "" {
+		return errors.New("plugin name must not be blank")
+	}
+
+	return nil
+}
+
+// GetSettings returns an empty pluginSettings struct.
+func (m *pluginManager) GetSettings() ProviderSettings {
+	return &pluginSettings{}
+}
+
+// Configure loads the plugins from the global config object.
+func (m *pluginManager) Configure(ctx context.Context, s *evergreen.Settings) error {
+	if m.client == nil {
+		m.client = &pluginClientImpl{}
+	}
+
+	m.plugins = map[string]evergreen.CloudPlugin{}
+	for _, p := range s.Providers.Plugins {
+		if err := p.ValidateAndDefault(); err != nil {
+			return errors.Wrap(err, "invalid cloud provider plugin")
+		}
+		m.plugins[p.Name] = p
+	}
+
+	return nil
+}
+
+// SpawnHost asks the plugin to create a host and stores the details the
+// plugin returns about it.
+func (m *pluginManager) SpawnHost(ctx context.Context, h *host.Host) (*host.Host, error) {
+	if h.Distro.Provider != evergreen.ProviderNamePlugin {
+		return nil, errors.Errorf("Can't spawn instance of %s for distro %s: provider is %s",
+			evergreen.ProviderNamePlugin, h.Distro.Id, h.Distro.Provider)
+	}
+
+	resp, err := m.call(ctx, pluginMethodSpawnHost, h, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Could not spawn host for distro '%s'", h.Distro.Id)
+	}
+
+	if resp.Host != nil {
+		h.Host = resp.Host.DNSName
+		h.ExternalIdentifier = resp.Host.ExternalIdentifier
+		h.Zone = resp.Host.Zone
+		h.InstanceType = resp.Host.InstanceType
+	}
+
+	grip.Debug(message.Fields{
+		"message": "spawned plugin host",
+		"host":    h.Id,
+		"distro":  h.Distro.Id,
+		"ext_id":  h.ExternalIdentifier,
+	})
+	event.LogHostStarted(h.Id)
+
+	return h, nil
+}
+
+// GetInstanceStatus returns the status the plugin reports for the host.
+func (m *pluginManager) GetInstanceStatus(ctx context.Context, h *host.Host) (CloudStatus, error) {
+	resp, err := m.call(ctx, pluginMethodGetInstanceStatus, h, nil)
+	if err != nil {
+		return StatusUnknown, errors.WithStack(err)
+	}
+
+	return pluginToEvgStatus(resp.Status), nil
+}
+
+// TerminateInstance asks the plugin to remove the host.
+func (m *pluginManager) TerminateInstance(ctx context.Context, h *host.Host, user string) error {
+	if h.Status == evergreen.HostTerminated {
+		err := errors.Errorf("Can not terminate %s - already marked as terminated!", h.Id)
+		grip.Error(err)
+		return err
+	}
+
+	if _, err := m.call(ctx, pluginMethodTerminateInstance, h, nil); err != nil {
+		return errors.Wrapf(err, "could not terminate host %s", h.Id)
+	}
+
+	// Set the host status as terminated and update its termination time
+	return errors.Wrapf(h.Terminate(user), "could not terminate host %s in db", h.Id)
+}
+
+// IsUp returns whether the plugin considers the host ready to be provisioned.
+func (m *pluginManager) IsUp(ctx context.Context, h *host.Host) (bool, error) {
+	resp, err := m.call(ctx, pluginMethodIsUp, h, nil)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	return resp.Up, nil
+}
+
+// OnUp notifies the plugin that the host is up.
+func (m *pluginManager) OnUp(ctx context.Context, h *host.Host) error {
+	_, err := m.call(ctx, pluginMethodOnUp, h, nil)
+	return errors.WithStack(err)
+}
+
+// GetDNSName returns the DNS name the plugin reports for the host.
+func (m *pluginManager) GetDNSName(ctx context.Context, h *host.Host) (string, error) {
+	resp, err := m.call(ctx, pluginMethodGetDNSName, h, nil)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	if resp.DNSName == "" {
+		return "", errors.Errorf("plugin returned no DNS name for host %s", h.Id)
+	}
+
+	return resp.DNSName, nil
+}
+
+// GetSSHOptions generates the command line args to be
+// passed to SSH to allow connection to the machine.
+func (m *pluginManager) GetSSHOptions(h *host.Host, keyPath string) ([]string, error) {
+	if keyPath == "" {
+		return []string{}, errors.Errorf("No key specified for host %s", h.Id)
+	}
+
+	opts := []string{"-i", keyPath}
+	for _, opt := range h.Distro.SSHOptions {
+		opts = append(opts, "-o", opt)
+	}
+
+	return opts, nil
+}
+
+// TimeTilNextPayment returns the amount of time until the next payment is due
+// for the host. Plugins report costs through CostForDuration instead.
+func (m *pluginManager) TimeTilNextPayment(_ *host.Host) time.Duration {
+	return time.Duration(0)
+}
+
+// StopInstance is not supported, since plugin hosts can't be stopped and
+// started again.
+func (m *pluginManager) StopInstance(_ context.Context, _ *host.Host, _ string) error {
+	return errStoppingUnsupported(evergreen.ProviderNamePlugin)
+}
+
+// StartInstance is not supported, since plugin hosts can't be stopped and
+// started again.
+func (m *pluginManager) StartInstance(_ context.Context, _ *host.Host, _ string) error {
+	return errStoppingUnsupported(evergreen.ProviderNamePlugin)
+}
+
+// CostForDuration returns the cost the plugin reports for running the host
+// over the given span of time.
+func (m *pluginManager) CostForDuration(ctx context.Context, h *host.Host, start, end time.Time) (float64, error) {
+	if end.Before(start) || util.IsZeroTime(start) || util.IsZeroTime(end) {
+		return 0, errors.New("task timing data is malformed")
+	}
+
+	resp, err := m.call(ctx, pluginMethodCostForDuration, h, func(req *pluginRequest) {
+		req.StartTime = &start
+		req.EndTime = &end
+	})
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	return resp.Cost, nil
+}
+
+// call sends a request for the host to the plugin configured in its distro.
+func (m *pluginManager) call(ctx context.Context, method string, h *host.Host, modify func(*pluginRequest)) (*pluginResponse, error) {
+	s := &pluginSettings{}
+	settings := map[string]interface{}{}
+	if h.Distro.ProviderSettings != nil {
+		settings = *h.Distro.ProviderSettings
+		if err := mapstructure.Decode(settings, s); err != nil {
+			return nil, errors.Wrapf(err, "Error decoding params for distro %s", h.Distro.Id)
+		}
+	}
+	if err := s.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "Invalid settings in distro %s", h.Distro.Id)
+	}
+
+	plugin, ok := m.plugins[s.Plugin]
+	if !ok {
+		return nil, errors.Errorf("cloud provider plugin '%s' is not configured", s.Plugin)
+	}
+
+	req := &pluginRequest{
+		Method: method,
+		Host: pluginHost{
+			Id:                 h.Id,
+			Distro:             h.Distro.Id,
+			User:               h.StartedBy,
+			DNSName:            h.Host,
+			ExternalIdentifier: h.ExternalIdentifier,
+			Zone:               h.Zone,
+			InstanceType:       h.InstanceType,
+			UserHost:           h.UserHost,
+		},
+		Settings: settings,
+	}
+	if modify != nil {
+		modify(req)
+	}
+
+	return m.client.Call(ctx, &plugin, req)
+}
+
+// pluginToEvgStatus converts a status reported by a plugin to an Evergreen
+// cloud provider status. Plugins use the same names as CloudStatus.String.
+func pluginToEvgStatus(status string) CloudStatus {
+	for _, s := range []CloudStatus{StatusPending, StatusInitializing, StatusRunning,
+		StatusStopped, StatusTerminated, StatusFailed} {
+		if s.String() == status {
+			return s
+		}
+	}
+
+	return StatusUnknown
+}
@@ -0,0 +1,108 @@
+package cloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/pkg/errors"
+)
+
+const pluginProtocolVersion = 1
+
+// The methods that a plugin executable must handle.
+const (
+	pluginMethodSpawnHost         = "spawn_host"
+	pluginMethodGetInstanceStatus = "get_instance_status"
+	pluginMethodTerminateInstance = "terminate_instance"
+	pluginMethodIsUp              = "is_up"
+	pluginMethodOnUp              = "on_up"
+	pluginMethodGetDNSName        = "get_dns_name"
+	pluginMethodCostForDuration   = "cost_for_duration"
+)
+
+// pluginRequest is written as JSON to the standard input of the plugin
+// executable. Exactly one request is sent for each invocation.
+type pluginRequest struct {
+	ProtocolVersion int                    `json:"protocol_version"`
+	Method          string                 `json:"method"`
+	Host            pluginHost             `json:"host"`
+	Settings        map[string]interface{} `json:"settings"`
+	StartTime       *time.Time             `json:"start_time,omitempty"`
+	EndTime         *time.Time             `json:"end_time,omitempty"`
+}
+
+// pluginHost is the subset of a host document that plugins may read and,
+// when spawning a host, set.
+type pluginHost struct {
+	Id                 string `json:"id"`
+	Distro             string `json:"distro"`
+	User               string `json:"user,omitempty"`
+	DNSName            string `json:"dns_name,omitempty"`
+	ExternalIdentifier string `json:"external_identifier,omitempty"`
+	Zone               string `json:"zone,omitempty"`
+	InstanceType       string `json:"instance_type,omitempty"`
+	UserHost           bool   `json:"user_host"`
+}
+
+// pluginResponse is read as JSON from the standard output of the plugin
+// executable. A non-empty Error fails the call; which of the other fields
+// are used depends on the method.
+type pluginResponse struct {
+	Error   string      `json:"error,omitempty"`
+	Host    *pluginHost `json:"host,omitempty"`
+	Status  string      `json:"status,omitempty"`
+	Up      bool        `json:"up,omitempty"`
+	DNSName string      `json:"dns_name,omitempty"`
+	Cost    float64     `json:"cost,omitempty"`
+}
+
+// The pluginClient interface wraps invoking a plugin executable.
+type pluginClient interface {
+	Call(context.Context, *evergreen.CloudPlugin, *pluginRequest) (*pluginResponse, error)
+}
+
+// pluginClientImpl runs the plugin executable once for every call, passing
+// the method name as its last argument as well as in the request.
+type pluginClientImpl struct{}
+
+func (c *pluginClientImpl) Call(ctx context.Context, plugin *evergreen.CloudPlugin, req *pluginRequest) (*pluginResponse, error) {
+	req.ProtocolVersion = pluginProtocolVersion
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not marshal %s request", req.Method)
+	}
+
+	timeout := time.Duration(plugin.TimeoutSecs) * time.Second
+	if timeout <= 0 {
+		timeout = time.Minute
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, plugin.Executable, append(append([]string{}, plugin.Args...), req.Method)...)
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	if err = cmd.Run(); err != nil {
+		return nil, errors.Errorf("plugin '%s' failed running %s: %s (%s)",
+			plugin.Name, req.Method, strings.TrimSpace(stderr.String()), err.Error())
+	}
+
+	resp := &pluginResponse{}
+	if err = json.Unmarshal(stdout.Bytes(), resp); err != nil {
+		return nil, errors.Wrapf(err, "plugin '%s' returned an invalid response to %s", plugin.Name, req.Method)
+	}
+	if resp.Error != "" {
+		return nil, errors.Errorf("plugin '%s' failed running %s: %s", plugin.Name, req.Method, resp.Error)
+	}
+
+	return resp, nil
+}
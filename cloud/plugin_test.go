@@ -0,0 +1,268 @@
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+// The plugin tests use the test binary itself as the plugin executable.
+// When pluginHelperEnv is set, TestPluginHelperProcess acts as a plugin whose
+// behavior is selected by the value of the variable.
+const pluginHelperEnv = "EVERGREEN_TEST_PLUGIN_HELPER"
+
+func TestPluginHelperProcess(t *testing.T) {
+	mode := os.Getenv(pluginHelperEnv)
+	if mode == "" {
+		return
+	}
+	defer os.Exit(0)
+
+	req := &pluginRequest{}
+	if err := json.NewDecoder(os.Stdin).Decode(req); err != nil {
+		fmt.Fprintf(os.Stderr, "bad request: %s", err.Error())
+		os.Exit(2)
+	}
+	if os.Args[len(os.Args)-1] != req.Method {
+		fmt.Fprint(os.Stderr, "method argument does not match request")
+		os.Exit(2)
+	}
+
+	resp := &pluginResponse{}
+	switch mode {
+	case "exit":
+		fmt.Fprint(os.Stderr, "plugin crashed")
+		os.Exit(1)
+	case "error":
+		resp.Error = "no capacity"
+	case "garbage":
+		fmt.Print("not json")
+		return
+	default:
+		if req.ProtocolVersion != pluginProtocolVersion || req.Settings["plugin"] != "fake" {
+			resp.Error = "unexpected request"
+			break
+		}
+		switch req.Method {
+		case pluginMethodSpawnHost:
+			resp.Host = &req.Host
+			resp.Host.DNSName = req.Host.Id + ".example.com"
+			resp.Host.ExternalIdentifier = "ext-" + req.Host.Id
+			resp.Host.Zone = fmt.Sprint(req.Settings["zone"])
+		case pluginMethodGetInstanceStatus:
+			resp.Status = mode
+		case pluginMethodIsUp:
+			resp.Up = mode == "running"
+		case pluginMethodGetDNSName:
+			resp.DNSName = req.Host.DNSName
+		case pluginMethodCostForDuration:
+			resp.Cost = req.EndTime.Sub(*req.StartTime).Hours()
+		}
+	}
+
+	if err := json.NewEncoder(os.Stdout).Encode(resp); err != nil {
+		os.Exit(2)
+	}
+}
+
+type PluginSuite struct {
+	manager  *pluginManager
+	distro   distro.Distro
+	hostOpts HostOptions
+	suite.Suite
+}
+
+func TestPluginSuite(t *testing.T) {
+	suite.Run(t, new(PluginSuite))
+}
+
+func (s *PluginSuite) SetupSuite() {
+	db.SetGlobalSessionProvider(testutil.TestConfig().SessionFactory())
+}
+
+func (s *PluginSuite) SetupTest() {
+	s.Require().NoError(os.Setenv(pluginHelperEnv, "running"))
+
+	settings := &evergreen.Settings{}
+	settings.Providers.Plugins = []evergreen.CloudPlugin{{
+		Name:       "fake",
+		Executable: os.Args[0],
+		Args:       []string{"-test.run=TestPluginHelperProcess", "--"},
+	}}
+	s.manager = &pluginManager{}
+	s.Require().NoError(s.manager.Configure(context.Background(), settings))
+
+	s.distro = distro.Distro{
+		Id:       "exotic",
+		Provider: evergreen.ProviderNamePlugin,
+		ProviderSettings: &map[string]interface{}{
+			"plugin": "fake",
+			"zone":   "rack-4",
+		},
+	}
+	s.hostOpts = HostOptions{}
+}
+
+func (s *PluginSuite) TearDownTest() {
+	s.NoError(os.Unsetenv(pluginHelperEnv))
+}
+
+func (s *PluginSuite) TestImplements() {
+	s.Implements((*CostCalculator)(nil), s.manager)
+}
+
+func (s *PluginSuite) TestConfigure() {
+	s.Equal(60, s.manager.plugins["fake"].TimeoutSecs)
+
+	settings := &evergreen.Settings{}
+	settings.Providers.Plugins = []evergreen.CloudPlugin{{Name: "broken"}}
+	s.Error(s.manager.Configure(context.Background(), settings))
+}
+
+func (s *PluginSuite) TestSpawnInvalidSettings() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dProviderName := distro.Distro{Provider: "ec2"}
+	h := NewIntent(dProviderName, dProviderName.GenerateName(), dProviderName.Provider, s.hostOpts)
+	h, err := s.manager.SpawnHost(ctx, h)
+	s.Error(err)
+	s.Nil(h)
+
+	dSettingsNone := distro.Distro{Provider: evergreen.ProviderNamePlugin}
+	h = NewIntent(dSettingsNone, dSettingsNone.GenerateName(), dSettingsNone.Provider, s.hostOpts)
+	h, err = s.manager.SpawnHost(ctx, h)
+	s.Error(err)
+	s.Nil(h)
+
+	dUnknownPlugin := distro.Distro{
+		Provider:         evergreen.ProviderNamePlugin,
+		ProviderSettings: &map[string]interface{}{"plugin": "unknown"},
+	}
+	h = NewIntent(dUnknownPlugin, dUnknownPlugin.GenerateName(), dUnknownPlugin.Provider, s.hostOpts)
+	h, err = s.manager.SpawnHost(ctx, h)
+	s.Error(err)
+	s.Nil(h)
+}
+
+func (s *PluginSuite) TestSpawnHost() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h := NewIntent(s.distro, s.distro.GenerateName(), s.distro.Provider, s.hostOpts)
+	h, err := s.manager.SpawnHost(ctx, h)
+	s.NoError(err)
+	s.Require().NotNil(h)
+	s.Equal(h.Id+".example.com", h.Host)
+	s.Equal("ext-"+h.Id, h.ExternalIdentifier)
+	s.Equal("rack-4", h.Zone)
+}
+
+func (s *PluginSuite) TestPluginFailures() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h := &host.Host{Id: "h1", Distro: s.distro}
+
+	s.NoError(os.Setenv(pluginHelperEnv, "exit"))
+	_, err := s.manager.GetDNSName(ctx, h)
+	s.Error(err)
+	s.Contains(err.Error(), "plugin crashed")
+
+	s.NoError(os.Setenv(pluginHelperEnv, "error"))
+	_, err = s.manager.IsUp(ctx, h)
+	s.Error(err)
+	s.Contains(err.Error(), "no capacity")
+
+	s.NoError(os.Setenv(pluginHelperEnv, "garbage"))
+	s.Error(s.manager.OnUp(ctx, h))
+}
+
+func (s *PluginSuite) TestStatuses() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h := &host.Host{Id: "h1", Distro: s.distro}
+
+	status, err := s.manager.GetInstanceStatus(ctx, h)
+	s.NoError(err)
+	s.Equal(StatusRunning, status)
+
+	up, err := s.manager.IsUp(ctx, h)
+	s.NoError(err)
+	s.True(up)
+
+	s.NoError(os.Setenv(pluginHelperEnv, "stopped"))
+	status, err = s.manager.GetInstanceStatus(ctx, h)
+	s.NoError(err)
+	s.Equal(StatusStopped, status)
+
+	up, err = s.manager.IsUp(ctx, h)
+	s.NoError(err)
+	s.False(up)
+
+	s.NoError(s.manager.OnUp(ctx, h))
+}
+
+func (s *PluginSuite) TestGetDNSName() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h := &host.Host{Id: "h1", Host: "h1.example.com", Distro: s.distro}
+	dns, err := s.manager.GetDNSName(ctx, h)
+	s.NoError(err)
+	s.Equal("h1.example.com", dns)
+
+	h.Host = ""
+	_, err = s.manager.GetDNSName(ctx, h)
+	s.Error(err)
+}
+
+func (s *PluginSuite) TestCostForDuration() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h := &host.Host{Id: "h1", Distro: s.distro}
+	start := time.Now()
+
+	cost, err := s.manager.CostForDuration(ctx, h, start, start.Add(90*time.Minute))
+	s.NoError(err)
+	s.InDelta(1.5, cost, 0.001)
+
+	_, err = s.manager.CostForDuration(ctx, h, start, start.Add(-time.Minute))
+	s.Error(err)
+}
+
+func (s *PluginSuite) TestTerminateInstance() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h := NewIntent(s.distro, s.distro.GenerateName(), s.distro.Provider, s.hostOpts)
+	h, err := s.manager.SpawnHost(ctx, h)
+	s.NoError(err)
+	s.NoError(h.Insert())
+
+	s.NoError(os.Setenv(pluginHelperEnv, "error"))
+	s.Error(s.manager.TerminateInstance(ctx, h, evergreen.User))
+
+	s.NoError(os.Setenv(pluginHelperEnv, "running"))
+	s.NoError(s.manager.TerminateInstance(ctx, h, evergreen.User))
+	s.Error(s.manager.TerminateInstance(ctx, h, evergreen.User))
+}
+
+func (s *PluginSuite) TestUtilToEvgStatus() {
+	s.Equal(StatusRunning, pluginToEvgStatus("running"))
+	s.Equal(StatusInitializing, pluginToEvgStatus("initializing"))
+	s.Equal(StatusTerminated, pluginToEvgStatus("terminated"))
+	s.Equal(StatusUnknown, pluginToEvgStatus("???"))
+}
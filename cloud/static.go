@@ -102,3 +102,15 @@ func (staticMgr *staticManager) GetSSHOptions(h *host.Host, keyPath string) (opt
 func (staticMgr *staticManager) TimeTilNextPayment(host *host.Host) time.Duration {
 	return time.Duration(0)
 }
+
+// StopInstance is not supported, since static hosts can't be stopped and
+// started again.
+func (staticMgr *staticManager) StopInstance(_ context.Context, _ *host.Host, _ string) error {
+	return errStoppingUnsupported(evergreen.ProviderNameStatic)
+}
+
+// StartInstance is not supported, since static hosts can't be stopped and
+// started again.
+func (staticMgr *staticManager) StartInstance(_ context.Context, _ *host.Host, _ string) error {
+	return errStoppingUnsupported(evergreen.ProviderNameStatic)
+}
@@ -0,0 +1,264 @@
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const (
+	// HomeVolumeMountDir is the directory in the home directory of a spawn
+	// host's user that the owner's home volume is mounted at.
+	HomeVolumeMountDir = "home_volume"
+
+	// ec2VolumeDevice is the device name that home volumes are attached as
+	// on EC2 instances.
+	ec2VolumeDevice = "/dev/sdf"
+	// gceVolumeDevice is the device name that home volumes are attached as
+	// on GCE instances.
+	gceVolumeDevice = "home-volume"
+)
+
+// SupportsVolumes returns whether hosts from the given provider can have a
+// home volume attached.
+func SupportsVolumes(providerName string) bool {
+	switch providerName {
+	case evergreen.ProviderNameEc2OnDemand, evergreen.ProviderNameGce, evergreen.ProviderNameMock:
+		return true
+	default:
+		return false
+	}
+}
+
+// GetVolumeManager returns the manager for the given provider, if it
+// supports volumes.
+func GetVolumeManager(ctx context.Context, providerName string, settings *evergreen.Settings) (VolumeManager, error) {
+	mgr, err := GetManager(ctx, providerName, settings)
+	if err != nil {
+		return nil, err
+	}
+	volumeMgr, ok := mgr.(VolumeManager)
+	if !ok {
+		return nil, errors.Errorf("provider %s does not support volumes", providerName)
+	}
+	return volumeMgr, nil
+}
+
+// VolumeDevicePaths returns the paths that an attached volume may appear at
+// on its host. Which one it appears at depends on the host's kernel and
+// hardware.
+func VolumeDevicePaths(v *host.Volume) []string {
+	switch v.Provider {
+	case evergreen.ProviderNameEc2OnDemand:
+		// instances with NVMe storage name EBS volumes after their id
+		return []string{
+			ec2VolumeDevice,
+			strings.Replace(ec2VolumeDevice, "/dev/sd", "/dev/xvd", 1),
+			fmt.Sprintf("/dev/disk/by-id/nvme-Amazon_Elastic_Block_Store_%s", strings.Replace(v.ID, "-", "", 1)),
+		}
+	case evergreen.ProviderNameGce:
+		return []string{fmt.Sprintf("/dev/disk/by-id/google-%s", gceVolumeDevice)}
+	default:
+		return []string{}
+	}
+}
+
+// HomeVolumeMountCommand returns a shell script that waits for the attached
+// volume's device to appear, formats the volume if it is new, mounts it in
+// the user's home directory and grows its file system to fill the volume in
+// case it has been resized. The volume is added to /etc/fstab so that it is
+// mounted again if the host is stopped and started.
+func HomeVolumeMountCommand(v *host.Volume) string {
+	mountDir := fmt.Sprintf("$HOME/%s", HomeVolumeMountDir)
+	return strings.Join([]string{
+		"set -o errexit",
+		`dev=""`,
+		"for i in $(seq 1 30); do",
+		fmt.Sprintf("  for candidate in %s; do", strings.Join(VolumeDevicePaths(v), " ")),
+		`    if [ -b "$candidate" ]; then dev="$candidate"; break 2; fi`,
+		"  done",
+		"  sleep 2",
+		"done",
+		`if [ -z "$dev" ]; then echo "home volume device did not appear" >&2; exit 1; fi`,
+		`sudo blkid "$dev" > /dev/null || sudo mkfs -t ext4 "$dev"`,
+		fmt.Sprintf(`mkdir -p "%s"`, mountDir),
+		fmt.Sprintf(`sudo mount "$dev" "%s"`, mountDir),
+		`sudo resize2fs "$dev"`,
+		fmt.Sprintf(`sudo chown "$(id -u):$(id -g)" "%s"`, mountDir),
+		fmt.Sprintf(`echo "UUID=$(sudo blkid -s UUID -o value "$dev") %s ext4 defaults,nofail 0 2" | sudo tee -a /etc/fstab > /dev/null`, mountDir),
+	}, "\n")
+}
+
+// HomeVolumeUnmountCommand returns a shell script that unmounts the home
+// volume, if it is mounted, and removes it from /etc/fstab, so that the
+// volume can be detached.
+func HomeVolumeUnmountCommand() string {
+	mountDir := fmt.Sprintf("$HOME/%s", HomeVolumeMountDir)
+	return strings.Join([]string{
+		"set -o errexit",
+		"sync",
+		fmt.Sprintf(`if mountpoint -q "%s"; then sudo umount "%s"; fi`, mountDir, mountDir),
+		fmt.Sprintf(`sudo sed -i "\| %s |d" /etc/fstab`, mountDir),
+	}, "\n")
+}
+
+// AttachHomeVolume attaches the home volume of the host's owner to the host,
+// creating the volume in the host's location if the owner does not have one
+// yet. The host must be running so that its location is known.
+func (cloudHost *CloudHost) AttachHomeVolume(ctx context.Context) (*host.Volume, error) {
+	h := cloudHost.Host
+	if h.ProvisionOptions == nil || !h.ProvisionOptions.HomeVolume {
+		return nil, errors.Errorf("host %s was not spawned with a home volume", h.Id)
+	}
+	volumeMgr, ok := cloudHost.CloudMgr.(VolumeManager)
+	if !ok {
+		return nil, errors.Errorf("provider %s does not support volumes", h.Provider)
+	}
+	owner := h.ProvisionOptions.OwnerId
+
+	v, err := host.FindHomeVolume(owner)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error finding home volume for %s", owner)
+	}
+	if v == nil {
+		v, err = volumeMgr.CreateVolume(ctx, &host.Volume{
+			CreatedBy:    owner,
+			Provider:     h.Provider,
+			Size:         h.ProvisionOptions.HomeVolumeSize,
+			Project:      h.Project,
+			Zone:         h.Zone,
+			Status:       host.VolumeAvailable,
+			CreationTime: time.Now(),
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "error creating home volume for %s", owner)
+		}
+		if err = v.Insert(); err != nil {
+			return nil, errors.Wrapf(err, "error recording home volume %s", v.ID)
+		}
+		grip.Info(message.Fields{
+			"message": "created home volume",
+			"volume":  v.ID,
+			"user":    owner,
+			"host":    h.Id,
+			"size":    v.Size,
+		})
+	}
+
+	if v.Provider != h.Provider {
+		return nil, errors.Errorf("home volume %s belongs to provider %s, not %s", v.ID, v.Provider, h.Provider)
+	}
+	if v.Zone != h.Zone || v.Project != h.Project {
+		return nil, errors.Errorf("home volume %s is in zone '%s', but host %s is in zone '%s'", v.ID, v.Zone, h.Id, h.Zone)
+	}
+	if err = releaseVolumeFromTerminatedHost(v); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if err = v.SetAttached(h.Id); err != nil {
+		return nil, errors.Wrapf(err, "home volume %s is in use by host %s", v.ID, v.Host)
+	}
+	if err = volumeMgr.AttachVolume(ctx, h, v); err != nil {
+		grip.Error(message.WrapError(v.SetDetached(), message.Fields{
+			"message": "error releasing volume that could not be attached",
+			"volume":  v.ID,
+			"host":    h.Id,
+		}))
+		return nil, errors.Wrapf(err, "error attaching home volume %s to host %s", v.ID, h.Id)
+	}
+	if err = h.SetHomeVolumeID(v.ID); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return v, nil
+}
+
+// DetachHomeVolume detaches the home volume attached to the host, if there
+// is one, so that it can be attached to the owner's next host.
+func (cloudHost *CloudHost) DetachHomeVolume(ctx context.Context) error {
+	h := cloudHost.Host
+	if h.HomeVolumeID == "" {
+		return nil
+	}
+	v, err := host.FindVolumeByID(h.HomeVolumeID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if v == nil || v.Host != h.Id {
+		return errors.WithStack(h.SetHomeVolumeID(""))
+	}
+
+	volumeMgr, ok := cloudHost.CloudMgr.(VolumeManager)
+	if !ok {
+		return errors.Errorf("provider %s does not support volumes", h.Provider)
+	}
+	if err = cloudHost.unmountHomeVolume(ctx); err != nil {
+		return errors.Wrapf(err, "error unmounting home volume %s from host %s", v.ID, h.Id)
+	}
+	if err = volumeMgr.DetachVolume(ctx, h, v); err != nil {
+		return errors.Wrapf(err, "error detaching home volume %s from host %s", v.ID, h.Id)
+	}
+	if err = v.SetDetached(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return errors.WithStack(h.SetHomeVolumeID(""))
+}
+
+// unmountHomeVolume unmounts the home volume on a running host, since a
+// volume can't be detached while it is mounted. A host that isn't running, or
+// has no address to reach it at, has nothing mounted.
+func (cloudHost *CloudHost) unmountHomeVolume(ctx context.Context) error {
+	h := cloudHost.Host
+	if h.Status != evergreen.HostRunning || h.Host == "" {
+		return nil
+	}
+	sshOptions, err := cloudHost.GetSSHOptions()
+	if err != nil {
+		return errors.Wrapf(err, "error getting ssh options for host %s", h.Id)
+	}
+	if logs, err := h.RunSSHCommand(ctx, HomeVolumeUnmountCommand(), sshOptions); err != nil {
+		return errors.Wrapf(err, "error running unmount command: %s", logs)
+	}
+	return nil
+}
+
+// homeVolumeZone returns the zone of the home volume that will be attached
+// to the host, if the host is to have one and its owner already has one.
+func homeVolumeZone(h *host.Host) (string, error) {
+	if h.ProvisionOptions == nil || !h.ProvisionOptions.HomeVolume {
+		return "", nil
+	}
+	v, err := host.FindHomeVolume(h.ProvisionOptions.OwnerId)
+	if err != nil {
+		return "", errors.Wrapf(err, "error finding home volume for %s", h.ProvisionOptions.OwnerId)
+	}
+	if v == nil {
+		return "", nil
+	}
+	return v.Zone, nil
+}
+
+// releaseVolumeFromTerminatedHost marks a volume as available if the host it
+// is recorded as attached to has since been terminated, which detaches it
+// in the cloud provider.
+func releaseVolumeFromTerminatedHost(v *host.Volume) error {
+	if v.Status != host.VolumeAttached {
+		return nil
+	}
+	attachedTo, err := host.FindOneId(v.Host)
+	if err != nil {
+		return errors.Wrapf(err, "error finding host %s", v.Host)
+	}
+	if attachedTo != nil && attachedTo.Status != evergreen.HostTerminated {
+		return nil
+	}
+	return errors.WithStack(v.SetDetached())
+}
@@ -0,0 +1,109 @@
+package cloud
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVolumeDevicePaths(t *testing.T) {
+	assert := assert.New(t)
+
+	paths := VolumeDevicePaths(&host.Volume{ID: "vol-0123abc", Provider: evergreen.ProviderNameEc2OnDemand})
+	assert.Contains(paths, "/dev/sdf")
+	assert.Contains(paths, "/dev/xvdf")
+	assert.Contains(paths, "/dev/disk/by-id/nvme-Amazon_Elastic_Block_Store_vol0123abc")
+
+	paths = VolumeDevicePaths(&host.Volume{ID: "volume-abc", Provider: evergreen.ProviderNameGce})
+	assert.Equal([]string{"/dev/disk/by-id/google-home-volume"}, paths)
+
+	assert.Empty(VolumeDevicePaths(&host.Volume{Provider: evergreen.ProviderNameStatic}))
+}
+
+func TestHomeVolumeUnmountCommand(t *testing.T) {
+	assert := assert.New(t)
+
+	cmd := HomeVolumeUnmountCommand()
+	assert.Contains(cmd, `sudo umount "$HOME/`+HomeVolumeMountDir+`"`)
+	assert.Contains(cmd, "/etc/fstab")
+	assert.True(strings.HasPrefix(cmd, "set -o errexit\n"))
+}
+
+func TestAttachAndDetachHomeVolume(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db.SetGlobalSessionProvider(testutil.TestConfig().SessionFactory())
+	require.NoError(db.ClearCollections(host.Collection, host.VolumesCollection))
+	mock := GetMockProvider()
+	mock.Reset()
+
+	newHost := func(id string) *CloudHost {
+		h := &host.Host{
+			Id:       id,
+			Provider: evergreen.ProviderNameMock,
+			Status:   evergreen.HostRunning,
+			Zone:     "zone",
+			ProvisionOptions: &host.ProvisionOptions{
+				OwnerId:        "me",
+				HomeVolume:     true,
+				HomeVolumeSize: 16,
+			},
+		}
+		require.NoError(h.Insert())
+		mock.Set(id, MockInstance{IsUp: true, Status: StatusRunning})
+		return &CloudHost{Host: h, CloudMgr: makeMockManager()}
+	}
+
+	// the first host creates the owner's volume
+	first := newHost("h1")
+	v, err := first.AttachHomeVolume(ctx)
+	require.NoError(err)
+	assert.Equal("me", v.CreatedBy)
+	assert.Equal(16, v.Size)
+	assert.Equal("zone", v.Zone)
+	assert.Equal(v.ID, mock.Get("h1").Volume)
+	dbHost, err := host.FindOneId("h1")
+	require.NoError(err)
+	assert.Equal(v.ID, dbHost.HomeVolumeID)
+
+	// the volume can't be attached to a second host while it is in use
+	second := newHost("h2")
+	_, err = second.AttachHomeVolume(ctx)
+	assert.Error(err)
+
+	require.NoError(first.DetachHomeVolume(ctx))
+	assert.Empty(mock.Get("h1").Volume)
+	assert.Empty(first.Host.HomeVolumeID)
+
+	reused, err := second.AttachHomeVolume(ctx)
+	require.NoError(err)
+	assert.Equal(v.ID, reused.ID)
+	volumes, err := host.FindVolumesByUser("me")
+	require.NoError(err)
+	require.Len(volumes, 1)
+	assert.Equal(host.VolumeAttached, volumes[0].Status)
+	assert.Equal("h2", volumes[0].Host)
+
+	// a volume left attached to a terminated host is released
+	require.NoError(second.Host.SetStatus(evergreen.HostTerminated, evergreen.User, ""))
+	third := newHost("h3")
+	_, err = third.AttachHomeVolume(ctx)
+	assert.NoError(err)
+
+	// a host in another zone can't use the volume
+	fourth := newHost("h4")
+	fourth.Host.Zone = "elsewhere"
+	require.NoError(third.DetachHomeVolume(ctx))
+	_, err = fourth.AttachHomeVolume(ctx)
+	assert.Error(err)
+}
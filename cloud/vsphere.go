@@ -154,6 +154,49 @@ func (m *vsphereManager) TerminateInstance(ctx context.Context, host *host.Host,
 	return nil
 }
 
+// StopInstance suspends a running instance, which keeps the state of its
+// memory so that it can be resumed later.
+func (m *vsphereManager) StopInstance(ctx context.Context, host *host.Host, user string) error {
+	if host.Status != evergreen.HostRunning {
+		return errors.Errorf("Can not stop %s - host is %s, not running", host.Id, host.Status)
+	}
+
+	if err := m.client.SuspendInstance(ctx, host); err != nil {
+		return errors.Wrapf(err, "API call to suspend instance %s failed", host.Id)
+	}
+
+	if err := host.SetStopped(user); err != nil {
+		return errors.Wrapf(err, "could not mark host %s as stopped in db", host.Id)
+	}
+
+	return nil
+}
+
+// StartInstance resumes a suspended instance.
+func (m *vsphereManager) StartInstance(ctx context.Context, host *host.Host, user string) error {
+	if host.Status != evergreen.HostStopped {
+		return errors.Errorf("Can not start %s - host is %s, not stopped", host.Id, host.Status)
+	}
+
+	if err := m.client.PowerOnInstance(ctx, host); err != nil {
+		return errors.Wrapf(err, "API call to power on instance %s failed", host.Id)
+	}
+
+	ip, err := m.GetDNSName(ctx, host)
+	if err != nil {
+		return errors.Wrapf(err, "could not get IP for host %s", host.Id)
+	}
+	if err = host.UpdateDNSName(ip); err != nil {
+		return errors.Wrapf(err, "could not update IP for host %s in db", host.Id)
+	}
+
+	if err = host.SetStartedAfterStop(user); err != nil {
+		return errors.Wrapf(err, "could not mark host %s as running in db", host.Id)
+	}
+
+	return nil
+}
+
 // IsUp checks whether the provisioned host is running.
 func (m *vsphereManager) IsUp(ctx context.Context, host *host.Host) (bool, error) {
 	status, err := m.GetInstanceStatus(ctx, host)
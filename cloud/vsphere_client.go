@@ -34,6 +34,8 @@ type vsphereClient interface {
 	GetPowerState(context.Context, *host.Host) (types.VirtualMachinePowerState, error)
 	CreateInstance(context.Context, *host.Host, *vsphereSettings) (string, error)
 	DeleteInstance(context.Context, *host.Host) error
+	SuspendInstance(context.Context, *host.Host) error
+	PowerOnInstance(context.Context, *host.Host) error
 }
 
 type vsphereClientImpl struct {
@@ -187,3 +189,42 @@ func (c *vsphereClientImpl) DeleteInstance(ctx context.Context, h *host.Host) er
 
 	return nil
 }
+
+// SuspendInstance suspends the instance, which keeps its memory as well as
+// its disks, so that it resumes where it left off when powered on again.
+func (c *vsphereClientImpl) SuspendInstance(ctx context.Context, h *host.Host) error {
+	vm, err := c.getInstance(ctx, h.Id)
+	if err != nil {
+		return errors.Wrap(err, "API call to get instance failed")
+	}
+
+	task, err := vm.Suspend(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to create suspend task")
+	}
+
+	if err = task.Wait(ctx); err != nil {
+		return errors.Wrap(err, "suspend task failed to execute")
+	}
+
+	return nil
+}
+
+// PowerOnInstance powers on a suspended or powered off instance.
+func (c *vsphereClientImpl) PowerOnInstance(ctx context.Context, h *host.Host) error {
+	vm, err := c.getInstance(ctx, h.Id)
+	if err != nil {
+		return errors.Wrap(err, "API call to get instance failed")
+	}
+
+	task, err := vm.PowerOn(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to create power on task")
+	}
+
+	if err = task.Wait(ctx); err != nil {
+		return errors.Wrap(err, "power on task failed to execute")
+	}
+
+	return nil
+}
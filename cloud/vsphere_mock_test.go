@@ -17,6 +17,8 @@ type vsphereClientMock struct {
 	failPowerState bool
 	failCreate     bool
 	failDelete     bool
+	failSuspend    bool
+	failPowerOn    bool
 
 	// Other options
 	isActive bool
@@ -66,3 +68,21 @@ func (c *vsphereClientMock) DeleteInstance(context.Context, *host.Host) error {
 
 	return nil
 }
+
+func (c *vsphereClientMock) SuspendInstance(context.Context, *host.Host) error {
+	if c.failSuspend {
+		return errors.New("failed to suspend instance")
+	}
+	c.isActive = false
+
+	return nil
+}
+
+func (c *vsphereClientMock) PowerOnInstance(context.Context, *host.Host) error {
+	if c.failPowerOn {
+		return errors.New("failed to power on instance")
+	}
+	c.isActive = true
+
+	return nil
+}
@@ -198,6 +198,34 @@ func (s *VSphereSuite) TestTerminateInstanceDB() {
 	s.Error(err)
 }
 
+func (s *VSphereSuite) TestStopAndStartInstance() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	myHost := NewIntent(s.distro, s.distro.GenerateName(), s.distro.Provider, s.hostOpts)
+	myHost.Status = evergreen.HostRunning
+	s.NoError(myHost.Insert())
+
+	s.NoError(s.manager.StopInstance(ctx, myHost, evergreen.User))
+	status, err := s.manager.GetInstanceStatus(ctx, myHost)
+	s.NoError(err)
+	s.Equal(StatusStopped, status)
+	dbHost, err := host.FindOne(host.ById(myHost.Id))
+	s.NoError(err)
+	s.Equal(evergreen.HostStopped, dbHost.Status)
+
+	// stopping an already stopped host fails
+	s.Error(s.manager.StopInstance(ctx, myHost, evergreen.User))
+
+	s.NoError(s.manager.StartInstance(ctx, myHost, evergreen.User))
+	status, err = s.manager.GetInstanceStatus(ctx, myHost)
+	s.NoError(err)
+	s.Equal(StatusRunning, status)
+	dbHost, err = host.FindOne(host.ById(myHost.Id))
+	s.NoError(err)
+	s.Equal(evergreen.HostRunning, dbHost.Status)
+}
+
 func (s *VSphereSuite) TestGetDNSNameAPICall() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
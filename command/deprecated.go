@@ -7,6 +7,22 @@ import (
 	"github.com/evergreen-ci/evergreen/rest/client"
 )
 
+// deprecatedCommands maps the names of deprecated commands, which are still
+// accepted but no longer do anything, to notes on what replaces them.
+var deprecatedCommands = map[string]string{
+	"expansions.fetch_vars": "project variables are always available as expansions",
+	"git.apply_patch":       "patches are applied in git.get_project",
+	"shell.cleanup":         "process cleanup is enabled by default",
+	"shell.track":           "process tracking is enabled by default",
+}
+
+// IsDeprecated returns whether the named command is deprecated, and if it
+// is, a note on what replaces it.
+func IsDeprecated(name string) (string, bool) {
+	note, ok := deprecatedCommands[name]
+	return note, ok
+}
+
 // gitApplyPatch is deprecated. Its functionality is now a part of GitGetProjectCommand.
 type gitApplyPatch struct{ base }
 
@@ -65,15 +65,24 @@ func (c *gitFetchProject) ParseParams(params map[string]interface{}) error {
 	return nil
 }
 
+// githubTokenFlag returns the git flag that sends the token to GitHub as
+// the credentials for the location, which must be on GitHub.
+func githubTokenFlag(location *url.URL, token string) (string, error) {
+	if token == "" {
+		return "", nil
+	}
+	if location.Host != "github.com" {
+		return "", errors.Errorf("Token support is only for Github, refusing to send token to '%s'", location.Host)
+	}
+	return fmt.Sprintf("-c 'credential.%s://%s.username=%s'", location.Scheme, location.Host, token), nil
+}
+
 func buildHTTPCloneCommand(location *url.URL, branch, dir, token string) ([]string, error) {
 	location.Scheme = "https"
 
-	tokenFlag := ""
-	if token != "" {
-		if location.Host != "github.com" {
-			return nil, errors.Errorf("Token support is only for Github, refusing to send token to '%s'", location.Host)
-		}
-		tokenFlag = fmt.Sprintf("-c 'credential.%s://%s.username=%s'", location.Scheme, location.Host, token)
+	tokenFlag, err := githubTokenFlag(location, token)
+	if err != nil {
+		return nil, err
 	}
 
 	clone := fmt.Sprintf("GIT_ASKPASS='true' git %s clone '%s' '%s'", tokenFlag, location.String(), dir)
@@ -408,6 +417,47 @@ func getPatchCommands(modulePatch patch.ModulePatch, dir, patchPath string) []st
 	}...)
 }
 
+// getHeadCommands returns the commands that check out the commit that a
+// patch references instead of a diff, fetching it from the fork it was pushed
+// to if it isn't in the repository that was cloned.
+func (c *gitFetchProject) getHeadCommands(head *patch.PatchHead, dir string) ([]string, error) {
+	if err := head.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid head commit")
+	}
+
+	headCommands := []string{
+		"set -o xtrace",
+		"set -o errexit",
+		fmt.Sprintf("cd '%s'", dir),
+	}
+
+	if !head.IsFork() {
+		headCommands = append(headCommands, fmt.Sprintf("git fetch origin '%s'", head.HeadHash))
+	} else if c.Token == "" {
+		headCommands = append(headCommands,
+			fmt.Sprintf("git fetch 'git@github.com:%s/%s.git' '%s'", head.Owner, head.Repo, head.HeadHash))
+	} else {
+		location := &url.URL{
+			Scheme: "https",
+			Host:   "github.com",
+			Path:   fmt.Sprintf("/%s/%s.git", head.Owner, head.Repo),
+		}
+		tokenFlag, err := githubTokenFlag(location, c.Token)
+		if err != nil {
+			return nil, err
+		}
+		fetch := fmt.Sprintf("GIT_ASKPASS='true' git %s fetch '%s' '%s'", tokenFlag, location.String(), head.HeadHash)
+		headCommands = append(headCommands,
+			"set +o xtrace",
+			fmt.Sprintf(`echo %s`, strconv.Quote(strings.Replace(fetch, tokenFlag, "-c '[redacted oauth token]'", -1))),
+			fetch,
+			"set -o xtrace",
+		)
+	}
+
+	return append(headCommands, fmt.Sprintf("git reset --hard '%s'", head.HeadHash)), nil
+}
+
 // applyPatch is used by the agent to copy patch data onto disk
 // and then call the necessary git commands to apply the patch file
 func (c *gitFetchProject) applyPatch(ctx context.Context, logger client.LoggerProducer,
@@ -452,6 +502,22 @@ func (c *gitFetchProject) applyPatch(ctx context.Context, logger client.LoggerPr
 			dir = filepath.Join(c.Directory, module.Prefix, module.Name)
 		}
 
+		if patchPart.Head != nil {
+			logger.Execution().Infof("Checking out commit '%s' instead of applying a patch...", patchPart.Head.HeadHash)
+			headCommands, err := c.getHeadCommands(patchPart.Head, dir)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			headCmd := subprocess.NewLocalCommand(strings.Join(headCommands, "\n"), conf.WorkDir, "bash", nil, true)
+			if err = headCmd.SetOutput(output); err != nil {
+				return errors.WithStack(err)
+			}
+			if err = headCmd.Run(ctx); err != nil {
+				return errors.WithStack(err)
+			}
+			continue
+		}
+
 		if len(patchPart.PatchSet.Patch) == 0 {
 			logger.Execution().Info("Skipping empty patch file...")
 			continue
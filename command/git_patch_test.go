@@ -156,3 +156,48 @@ func TestGetPatchCommands(t *testing.T) {
 	assert.Len(cmds, 6)
 	assert.Equal("git apply --stat '/tmp/bestest.patch' || true", cmds[5])
 }
+
+func TestGetHeadCommands(t *testing.T) {
+	assert := assert.New(t)
+	c := &gitFetchProject{}
+	head := &patch.PatchHead{Ref: "feature", HeadHash: "a4aa03d0472d8503380479b76aef96c044182822"}
+
+	cmds, err := c.getHeadCommands(head, "/teapot")
+	assert.NoError(err)
+	assert.Equal([]string{
+		"set -o xtrace",
+		"set -o errexit",
+		"cd '/teapot'",
+		"git fetch origin 'a4aa03d0472d8503380479b76aef96c044182822'",
+		"git reset --hard 'a4aa03d0472d8503380479b76aef96c044182822'",
+	}, cmds)
+
+	// commits on forks are fetched from the fork
+	head.Owner = "me"
+	head.Repo = "fork"
+	cmds, err = c.getHeadCommands(head, "/teapot")
+	assert.NoError(err)
+	assert.Len(cmds, 5)
+	assert.Equal("git fetch 'git@github.com:me/fork.git' 'a4aa03d0472d8503380479b76aef96c044182822'", cmds[3])
+
+	// the token isn't logged
+	c.Token = "secret"
+	cmds, err = c.getHeadCommands(head, "/teapot")
+	assert.NoError(err)
+	assert.Len(cmds, 8)
+	assert.Equal("set +o xtrace", cmds[3])
+	assert.NotContains(cmds[4], "secret")
+	assert.Contains(cmds[5], "https://github.com/me/fork.git")
+	assert.Contains(cmds[5], "secret")
+	assert.Equal("set -o xtrace", cmds[6])
+	assert.Equal("git reset --hard 'a4aa03d0472d8503380479b76aef96c044182822'", cmds[7])
+
+	// heads that could break out of the commands are rejected
+	head.Repo = "fork.git' && curl evil"
+	_, err = c.getHeadCommands(head, "/teapot")
+	assert.Error(err)
+	head.Repo = "fork"
+	head.HeadHash = "HEAD; reboot"
+	_, err = c.getHeadCommands(head, "/teapot")
+	assert.Error(err)
+}
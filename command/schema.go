@@ -0,0 +1,47 @@
+package command
+
+import (
+	"reflect"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/util"
+)
+
+// paramsSchemaProvider is implemented by commands whose params can't be
+// described by the mapstructure tags of their fields.
+type paramsSchemaProvider interface {
+	ParamsSchema() *util.JSONSchema
+}
+
+// paramsReflector describes params as they're written, before their
+// expansions are expanded, so every scalar may be a string. Params that
+// commands don't know are ignored rather than rejected.
+var paramsReflector = &util.JSONSchemaReflector{
+	Tag:           "mapstructure",
+	StringScalars: true,
+	OpenStructs:   true,
+	Overrides: map[reflect.Type]*util.JSONSchema{
+		reflect.TypeOf(util.StringOrBool("")): {Type: []string{"string", "boolean"}},
+	},
+}
+
+// ParamsSchema returns a JSON Schema for the params of the commands that the
+// factory makes.
+func (f CommandFactory) ParamsSchema() *util.JSONSchema {
+	cmd := f()
+	if p, ok := cmd.(paramsSchemaProvider); ok {
+		return p.ParamsSchema()
+	}
+	return paramsReflector.Reflect(reflect.TypeOf(cmd))
+}
+
+// ProjectSchema returns a JSON Schema for project configuration files,
+// including the params of each registered command.
+func ProjectSchema() *util.JSONSchema {
+	params := map[string]*util.JSONSchema{}
+	for _, name := range RegisteredCommandNames() {
+		factory, _ := GetCommandFactory(name)
+		params[name] = factory.ParamsSchema()
+	}
+	return model.ProjectSchema(params)
+}
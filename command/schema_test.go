@@ -0,0 +1,44 @@
+package command
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectSchema(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	schema := ProjectSchema()
+	require.NotNil(schema)
+	assert.Equal(util.JSONSchemaDraft, schema.Schema)
+	for _, key := range []string{"tasks", "buildvariants", "functions", "pre", "post", "timeout"} {
+		assert.Contains(schema.Properties, key)
+	}
+	_, err := json.Marshal(schema)
+	assert.NoError(err)
+
+	cmd := schema.Definitions["command"]
+	require.NotNil(cmd)
+	for _, name := range RegisteredCommandNames() {
+		assert.Contains(cmd.Properties["command"].Enum, name)
+	}
+	assert.Len(cmd.AllOf, len(RegisteredCommandNames()))
+
+	params := map[string]*util.JSONSchema{}
+	for _, c := range cmd.AllOf {
+		params[c.If.Properties["command"].Const.(string)] = c.Then.Properties["params"]
+	}
+	require.Contains(params, "shell.exec")
+	assert.Contains(params["shell.exec"].Properties, "script")
+	assert.Contains(params["shell.exec"].Properties, "working_dir")
+	assert.Nil(params["shell.exec"].AdditionalProperties)
+	assert.Equal([]string{"boolean", "string"}, params["shell.exec"].Properties["background"].Type)
+
+	require.Contains(params, "timeout.update")
+	assert.Contains(params["timeout.update"].Properties["exec_timeout_secs"].Type, "string")
+}
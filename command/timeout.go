@@ -32,6 +32,19 @@ type timeoutStr struct {
 func timeoutUpdateFactory() Command { return &timeout{} }
 func (c *timeout) Name() string     { return "timeout.update" }
 
+// ParamsSchema allows the timeouts to be given as strings, so that they can
+// be expansions.
+func (c *timeout) ParamsSchema() *util.JSONSchema {
+	secs := &util.JSONSchema{Type: []string{"integer", "string"}}
+	return &util.JSONSchema{
+		Type: "object",
+		Properties: map[string]*util.JSONSchema{
+			"timeout_secs":      secs,
+			"exec_timeout_secs": secs,
+		},
+	}
+}
+
 // ParseParams parses the params into the the timeout struct.
 func (c *timeout) ParseParams(params map[string]interface{}) error {
 	c.params = params
@@ -2,17 +2,21 @@ package evergreen
 
 import (
 	"github.com/evergreen-ci/evergreen/db"
+	"github.com/mongodb/grip"
 	"github.com/pkg/errors"
 	"gopkg.in/mgo.v2/bson"
 )
 
 // CloudProviders stores configuration settings for the supported cloud host providers.
 type CloudProviders struct {
-	AWS       AWSConfig       `bson:"aws" json:"aws" yaml:"aws"`
-	Docker    DockerConfig    `bson:"docker" json:"docker" yaml:"docker"`
-	GCE       GCEConfig       `bson:"gce" json:"gce" yaml:"gce"`
-	OpenStack OpenStackConfig `bson:"openstack" json:"openstack" yaml:"openstack"`
-	VSphere   VSphereConfig   `bson:"vsphere" json:"vsphere" yaml:"vsphere"`
+	AWS        AWSConfig        `bson:"aws" json:"aws" yaml:"aws"`
+	Docker     DockerConfig     `bson:"docker" json:"docker" yaml:"docker"`
+	GCE        GCEConfig        `bson:"gce" json:"gce" yaml:"gce"`
+	OpenStack  OpenStackConfig  `bson:"openstack" json:"openstack" yaml:"openstack"`
+	VSphere    VSphereConfig    `bson:"vsphere" json:"vsphere" yaml:"vsphere"`
+	Kubernetes KubernetesConfig `bson:"kubernetes" json:"kubernetes" yaml:"kubernetes"`
+	Libvirt    LibvirtConfig    `bson:"libvirt" json:"libvirt" yaml:"libvirt"`
+	Plugins    []CloudPlugin    `bson:"plugins" json:"plugins" yaml:"plugins"`
 }
 
 func (c *CloudProviders) SectionId() string { return "providers" }
@@ -29,17 +33,31 @@ func (c *CloudProviders) Get() error {
 func (c *CloudProviders) Set() error {
 	_, err := db.Upsert(ConfigCollection, byId(c.SectionId()), bson.M{
 		"$set": bson.M{
-			"aws":       c.AWS,
-			"docker":    c.Docker,
-			"gce":       c.GCE,
-			"openstack": c.OpenStack,
-			"vsphere":   c.VSphere,
+			"aws":        c.AWS,
+			"docker":     c.Docker,
+			"gce":        c.GCE,
+			"openstack":  c.OpenStack,
+			"vsphere":    c.VSphere,
+			"kubernetes": c.Kubernetes,
+			"libvirt":    c.Libvirt,
+			"plugins":    c.Plugins,
 		},
 	})
 	return errors.Wrapf(err, "error updating section %s", c.SectionId())
 }
 
-func (c *CloudProviders) ValidateAndDefault() error { return nil }
+func (c *CloudProviders) ValidateAndDefault() error {
+	catcher := grip.NewSimpleCatcher()
+	names := map[string]bool{}
+	for i := range c.Plugins {
+		catcher.Add(c.Plugins[i].ValidateAndDefault())
+		if names[c.Plugins[i].Name] {
+			catcher.Add(errors.Errorf("duplicate cloud provider plugin '%s'", c.Plugins[i].Name))
+		}
+		names[c.Plugins[i].Name] = true
+	}
+	return catcher.Resolve()
+}
 
 // AWSConfig stores auth info for Amazon Web Services.
 type AWSConfig struct {
@@ -84,3 +102,45 @@ type VSphereConfig struct {
 	Username string `bson:"username" json:"username" yaml:"username"`
 	Password string `bson:"password" json:"password" yaml:"password"`
 }
+
+// KubernetesConfig stores connection info for the API server of the
+// Kubernetes cluster in which pod hosts are created.
+type KubernetesConfig struct {
+	APIServer string `bson:"api_server" json:"api_server" yaml:"api_server"`
+	Token     string `bson:"token" json:"token" yaml:"token"`
+	CACert    string `bson:"ca_cert" json:"ca_cert" yaml:"ca_cert"`
+	Namespace string `bson:"namespace" json:"namespace" yaml:"namespace"`
+}
+
+// LibvirtConfig stores settings for managing domains on libvirt hypervisors.
+// Hypervisors are reached with virsh through the connection URI in each
+// distro, so the server must be able to authenticate to them.
+type LibvirtConfig struct {
+	VirshPath string `bson:"virsh_path" json:"virsh_path" yaml:"virsh_path"`
+}
+
+// CloudPlugin is an executable that manages hosts on behalf of the "plugin"
+// provider. Distros choose a plugin by name, so only executables that an
+// admin has configured can be run.
+type CloudPlugin struct {
+	Name        string   `bson:"name" json:"name" yaml:"name"`
+	Executable  string   `bson:"executable" json:"executable" yaml:"executable"`
+	Args        []string `bson:"args,omitempty" json:"args,omitempty" yaml:"args,omitempty"`
+	TimeoutSecs int      `bson:"timeout_secs,omitempty" json:"timeout_secs,omitempty" yaml:"timeout_secs,omitempty"`
+}
+
+func (p *CloudPlugin) ValidateAndDefault() error {
+	if p.Name == "" {
+		return errors.New("cloud provider plugin name must not be empty")
+	}
+	if p.Executable == "" {
+		return errors.Errorf("cloud provider plugin '%s' must specify an executable", p.Name)
+	}
+	if p.TimeoutSecs < 0 {
+		return errors.Errorf("cloud provider plugin '%s' has a negative timeout", p.Name)
+	}
+	if p.TimeoutSecs == 0 {
+		p.TimeoutSecs = 60
+	}
+	return nil
+}
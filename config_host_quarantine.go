@@ -0,0 +1,67 @@
+package evergreen
+
+import (
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// HostQuarantineConfig holds the thresholds the quarantine monitor uses to
+// decide that a host is causing tasks to fail.
+type HostQuarantineConfig struct {
+	// ConsecutiveSystemFailures is the number of tasks in a row that must
+	// fail with a system failure on a host.
+	ConsecutiveSystemFailures int `bson:"consecutive_system_failures" json:"consecutive_system_failures" yaml:"consecutive_system_failures"`
+	// ConsecutiveSetupFailures is the number of tasks in a row that must
+	// fail during setup on a host.
+	ConsecutiveSetupFailures int `bson:"consecutive_setup_failures" json:"consecutive_setup_failures" yaml:"consecutive_setup_failures"`
+	// DistinctProjectFailures is the number of different projects that must
+	// have a task fail on a host within the project failure window.
+	DistinctProjectFailures     int `bson:"distinct_project_failures" json:"distinct_project_failures" yaml:"distinct_project_failures"`
+	ProjectFailureWindowMinutes int `bson:"project_failure_window_minutes" json:"project_failure_window_minutes" yaml:"project_failure_window_minutes"`
+}
+
+func (c *HostQuarantineConfig) SectionId() string { return "host_quarantine" }
+
+func (c *HostQuarantineConfig) Get() error {
+	err := db.FindOneQ(ConfigCollection, db.Query(byId(c.SectionId())), c)
+	if err != nil && err.Error() == errNotFound {
+		*c = HostQuarantineConfig{}
+		return nil
+	}
+	return errors.Wrapf(err, "error retrieving section %s", c.SectionId())
+}
+
+func (c *HostQuarantineConfig) Set() error {
+	_, err := db.Upsert(ConfigCollection, byId(c.SectionId()), bson.M{
+		"$set": bson.M{
+			"consecutive_system_failures":    c.ConsecutiveSystemFailures,
+			"consecutive_setup_failures":     c.ConsecutiveSetupFailures,
+			"distinct_project_failures":      c.DistinctProjectFailures,
+			"project_failure_window_minutes": c.ProjectFailureWindowMinutes,
+		},
+	})
+	return errors.Wrapf(err, "error updating section %s", c.SectionId())
+}
+
+func (c *HostQuarantineConfig) ValidateAndDefault() error {
+	if c.ConsecutiveSystemFailures < 0 || c.ConsecutiveSetupFailures < 0 ||
+		c.DistinctProjectFailures < 0 || c.ProjectFailureWindowMinutes < 0 {
+		return errors.New("host quarantine thresholds must not be negative")
+	}
+
+	if c.ConsecutiveSystemFailures == 0 {
+		c.ConsecutiveSystemFailures = 5
+	}
+	if c.ConsecutiveSetupFailures == 0 {
+		c.ConsecutiveSetupFailures = 3
+	}
+	if c.DistinctProjectFailures == 0 {
+		c.DistinctProjectFailures = 3
+	}
+	if c.ProjectFailureWindowMinutes == 0 {
+		c.ProjectFailureWindowMinutes = 60
+	}
+
+	return nil
+}
@@ -37,6 +37,7 @@ func resetRegistry() error {
 		&AuthConfig{},
 		&CloudProviders{},
 		&HostInitConfig{},
+		&HostQuarantineConfig{},
 		&JiraConfig{},
 		&LoggerConfig{},
 		&NotifyConfig{},
@@ -44,6 +45,7 @@ func resetRegistry() error {
 		&SchedulerConfig{},
 		&ServiceFlags{},
 		&SlackConfig{},
+		&SpawnHostConfig{},
 		&UIConfig{},
 		&Settings{},
 	}
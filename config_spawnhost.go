@@ -0,0 +1,173 @@
+package evergreen
+
+import (
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// defaultMaxSpawnHostsPerUser is the number of spawn hosts a user may have
+// at once if the admin settings do not say otherwise.
+const defaultMaxSpawnHostsPerUser = 3
+
+// SpawnHostConfig holds the limits on what users may create for their spawn
+// hosts.
+type SpawnHostConfig struct {
+	// HomeVolumeDefaultSizeGB is the size of a new home volume when the user
+	// does not ask for a particular size.
+	HomeVolumeDefaultSizeGB int `bson:"home_volume_default_size_gb" json:"home_volume_default_size_gb" yaml:"home_volume_default_size_gb"`
+	// HomeVolumeQuotaGB is the combined size of the volumes each user may
+	// have, unless the user has a quota of their own.
+	HomeVolumeQuotaGB    int               `bson:"home_volume_quota_gb" json:"home_volume_quota_gb" yaml:"home_volume_quota_gb"`
+	UserHomeVolumeQuotas []UserVolumeQuota `bson:"user_home_volume_quotas" json:"user_home_volume_quotas" yaml:"user_home_volume_quotas"`
+
+	// DefaultQuota limits the spawn hosts of users who have no quota of
+	// their own.
+	DefaultQuota SpawnHostQuota `bson:"default_quota" json:"default_quota" yaml:"default_quota"`
+	// Quotas override the default quota for a user, or limit the hosts
+	// that users may spawn from a distro.
+	Quotas []SpawnHostQuota `bson:"quotas" json:"quotas" yaml:"quotas"`
+	// QuotaWarningPercent is how much of a quota a user must have used
+	// before they are warned that they are approaching it.
+	QuotaWarningPercent int `bson:"quota_warning_percent" json:"quota_warning_percent" yaml:"quota_warning_percent"`
+}
+
+// SpawnHostQuota limits how many spawn hosts a user may have at once, and
+// how many host-hours and how much money their spawn hosts may use each
+// month. A quota that names a distro only counts hosts from that distro; one
+// that names no user applies to every user without a quota of their own.
+// Limits of zero are unlimited.
+type SpawnHostQuota struct {
+	User                 string  `bson:"user,omitempty" json:"user,omitempty" yaml:"user,omitempty"`
+	Distro               string  `bson:"distro,omitempty" json:"distro,omitempty" yaml:"distro,omitempty"`
+	MaxHosts             int     `bson:"max_hosts" json:"max_hosts" yaml:"max_hosts"`
+	MaxHostHoursPerMonth int     `bson:"max_host_hours_per_month" json:"max_host_hours_per_month" yaml:"max_host_hours_per_month"`
+	MaxSpendPerMonth     float64 `bson:"max_spend_per_month" json:"max_spend_per_month" yaml:"max_spend_per_month"`
+}
+
+// UserVolumeQuota overrides the home volume quota for a single user.
+type UserVolumeQuota struct {
+	User    string `bson:"user" json:"user" yaml:"user"`
+	QuotaGB int    `bson:"quota_gb" json:"quota_gb" yaml:"quota_gb"`
+}
+
+func (c *SpawnHostConfig) SectionId() string { return "spawnhost" }
+
+func (c *SpawnHostConfig) Get() error {
+	err := db.FindOneQ(ConfigCollection, db.Query(byId(c.SectionId())), c)
+	if err != nil && err.Error() == errNotFound {
+		*c = SpawnHostConfig{}
+		return nil
+	}
+	return errors.Wrapf(err, "error retrieving section %s", c.SectionId())
+}
+
+func (c *SpawnHostConfig) Set() error {
+	_, err := db.Upsert(ConfigCollection, byId(c.SectionId()), bson.M{
+		"$set": bson.M{
+			"home_volume_default_size_gb": c.HomeVolumeDefaultSizeGB,
+			"home_volume_quota_gb":        c.HomeVolumeQuotaGB,
+			"user_home_volume_quotas":     c.UserHomeVolumeQuotas,
+			"default_quota":               c.DefaultQuota,
+			"quotas":                      c.Quotas,
+			"quota_warning_percent":       c.QuotaWarningPercent,
+		},
+	})
+	return errors.Wrapf(err, "error updating section %s", c.SectionId())
+}
+
+func (c *SpawnHostConfig) ValidateAndDefault() error {
+	if c.HomeVolumeDefaultSizeGB < 0 || c.HomeVolumeQuotaGB < 0 {
+		return errors.New("home volume sizes must not be negative")
+	}
+	for _, q := range c.UserHomeVolumeQuotas {
+		if q.User == "" {
+			return errors.New("home volume quotas must name a user")
+		}
+		if q.QuotaGB < 0 {
+			return errors.Errorf("home volume quota for %s must not be negative", q.User)
+		}
+	}
+
+	if c.DefaultQuota.User != "" || c.DefaultQuota.Distro != "" {
+		return errors.New("the default spawn host quota must not name a user or distro")
+	}
+	if err := c.DefaultQuota.validate(); err != nil {
+		return errors.Wrap(err, "invalid default spawn host quota")
+	}
+	seen := map[SpawnHostQuota]bool{}
+	for _, q := range c.Quotas {
+		if q.User == "" && q.Distro == "" {
+			return errors.New("spawn host quotas must name a user or a distro")
+		}
+		key := SpawnHostQuota{User: q.User, Distro: q.Distro}
+		if seen[key] {
+			return errors.Errorf("duplicate spawn host quota for user '%s' and distro '%s'", q.User, q.Distro)
+		}
+		seen[key] = true
+		if err := q.validate(); err != nil {
+			return errors.Wrapf(err, "invalid spawn host quota for user '%s' and distro '%s'", q.User, q.Distro)
+		}
+	}
+	if c.QuotaWarningPercent < 0 || c.QuotaWarningPercent > 100 {
+		return errors.New("quota warning percent must be between 0 and 100")
+	}
+
+	if c.HomeVolumeDefaultSizeGB == 0 {
+		c.HomeVolumeDefaultSizeGB = 64
+	}
+	if c.HomeVolumeQuotaGB == 0 {
+		c.HomeVolumeQuotaGB = 256
+	}
+	if c.DefaultQuota.MaxHosts == 0 {
+		c.DefaultQuota.MaxHosts = defaultMaxSpawnHostsPerUser
+	}
+	if c.QuotaWarningPercent == 0 {
+		c.QuotaWarningPercent = 80
+	}
+
+	return nil
+}
+
+// HomeVolumeQuota returns the combined size in GiB of the volumes the user
+// may have.
+func (c *SpawnHostConfig) HomeVolumeQuota(user string) int {
+	for _, q := range c.UserHomeVolumeQuotas {
+		if q.User == user {
+			return q.QuotaGB
+		}
+	}
+	return c.HomeVolumeQuotaGB
+}
+
+// QuotasFor returns the quotas that limit the user spawning a host from the
+// distro: the user's overall quota, followed by a quota for the distro if
+// there is one. A quota for the user takes precedence over one for every
+// user. If no distro is given, only the overall quota is returned.
+func (c *SpawnHostConfig) QuotasFor(user, distro string) []SpawnHostQuota {
+	overall := c.DefaultQuota
+	var forDistro *SpawnHostQuota
+	for i, q := range c.Quotas {
+		switch {
+		case q.User == user && q.Distro == "":
+			overall = q
+		case distro != "" && q.Distro == distro && q.User == user:
+			forDistro = &c.Quotas[i]
+		case distro != "" && q.Distro == distro && q.User == "" && forDistro == nil:
+			forDistro = &c.Quotas[i]
+		}
+	}
+
+	quotas := []SpawnHostQuota{overall}
+	if forDistro != nil {
+		quotas = append(quotas, *forDistro)
+	}
+	return quotas
+}
+
+func (q *SpawnHostQuota) validate() error {
+	if q.MaxHosts < 0 || q.MaxHostHoursPerMonth < 0 || q.MaxSpendPerMonth < 0 {
+		return errors.New("limits must not be negative")
+	}
+	return nil
+}
@@ -281,6 +281,81 @@ func (s *AdminSuite) TestHostinitConfig() {
 	s.Equal(config, settings.HostInit)
 }
 
+func (s *AdminSuite) TestHostQuarantineConfig() {
+	config := HostQuarantineConfig{
+		ConsecutiveSystemFailures:   4,
+		ConsecutiveSetupFailures:    2,
+		DistinctProjectFailures:     5,
+		ProjectFailureWindowMinutes: 30,
+	}
+
+	err := config.Set()
+	s.NoError(err)
+	settings, err := GetConfig()
+	s.NoError(err)
+	s.NotNil(settings)
+	s.Equal(config, settings.HostQuarantine)
+
+	defaulted := HostQuarantineConfig{}
+	s.NoError(defaulted.ValidateAndDefault())
+	s.Equal(5, defaulted.ConsecutiveSystemFailures)
+	s.Equal(60, defaulted.ProjectFailureWindowMinutes)
+
+	s.Error((&HostQuarantineConfig{DistinctProjectFailures: -1}).ValidateAndDefault())
+}
+
+func (s *AdminSuite) TestSpawnHostConfig() {
+	config := SpawnHostConfig{
+		HomeVolumeDefaultSizeGB: 16,
+		HomeVolumeQuotaGB:       64,
+		UserHomeVolumeQuotas: []UserVolumeQuota{
+			{User: "big", QuotaGB: 1024},
+		},
+		DefaultQuota: SpawnHostQuota{MaxHosts: 2},
+		Quotas: []SpawnHostQuota{
+			{User: "big", MaxHosts: 10, MaxSpendPerMonth: 500},
+			{Distro: "gpu", MaxHosts: 1, MaxHostHoursPerMonth: 40},
+			{User: "big", Distro: "gpu", MaxHosts: 4},
+		},
+		QuotaWarningPercent: 90,
+	}
+
+	err := config.Set()
+	s.NoError(err)
+	settings, err := GetConfig()
+	s.NoError(err)
+	s.NotNil(settings)
+	s.Equal(config, settings.SpawnHost)
+
+	s.Equal(1024, config.HomeVolumeQuota("big"))
+	s.Equal(64, config.HomeVolumeQuota("small"))
+
+	quotas := config.QuotasFor("small", "gpu")
+	s.Require().Len(quotas, 2)
+	s.Equal(2, quotas[0].MaxHosts)
+	s.Equal(40, quotas[1].MaxHostHoursPerMonth)
+	quotas = config.QuotasFor("big", "gpu")
+	s.Require().Len(quotas, 2)
+	s.Equal(10, quotas[0].MaxHosts)
+	s.Equal(4, quotas[1].MaxHosts)
+	s.Len(config.QuotasFor("big", "linux"), 1)
+	s.Len(config.QuotasFor("big", ""), 1)
+
+	defaulted := SpawnHostConfig{}
+	s.NoError(defaulted.ValidateAndDefault())
+	s.Equal(64, defaulted.HomeVolumeDefaultSizeGB)
+	s.Equal(256, defaulted.HomeVolumeQuotaGB)
+	s.Equal(3, defaulted.DefaultQuota.MaxHosts)
+	s.Equal(80, defaulted.QuotaWarningPercent)
+
+	s.Error((&SpawnHostConfig{HomeVolumeQuotaGB: -1}).ValidateAndDefault())
+	s.Error((&SpawnHostConfig{UserHomeVolumeQuotas: []UserVolumeQuota{{QuotaGB: 1}}}).ValidateAndDefault())
+	s.Error((&SpawnHostConfig{DefaultQuota: SpawnHostQuota{User: "me"}}).ValidateAndDefault())
+	s.Error((&SpawnHostConfig{Quotas: []SpawnHostQuota{{MaxHosts: 1}}}).ValidateAndDefault())
+	s.Error((&SpawnHostConfig{Quotas: []SpawnHostQuota{{User: "me", MaxSpendPerMonth: -1}}}).ValidateAndDefault())
+	s.Error((&SpawnHostConfig{Quotas: []SpawnHostQuota{{User: "me"}, {User: "me"}}}).ValidateAndDefault())
+}
+
 func (s *AdminSuite) TestJiraConfig() {
 	config := JiraConfig{
 		Host:           "host",
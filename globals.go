@@ -24,6 +24,7 @@ const (
 	HostProvisionFailed = "provision failed"
 	HostQuarantined     = "quarantined"
 	HostDecommissioned  = "decommissioned"
+	HostStopped         = "stopped"
 
 	HostStatusSuccess = "success"
 	HostStatusFailed  = "failed"
@@ -124,6 +125,7 @@ const (
 	TaskSecretHeader    = "Task-Secret"
 	HostHeader          = "Host-Id"
 	HostSecretHeader    = "Host-Secret"
+	HostBootstrapHeader = "Host-Bootstrap-Secret"
 	ContentTypeHeader   = "Content-Type"
 	ContentTypeValue    = "application/json"
 	ContentLengthHeader = "Content-Length"
@@ -143,6 +145,9 @@ const (
 	ProviderNameStatic        = "static"
 	ProviderNameOpenstack     = "openstack"
 	ProviderNameVsphere       = "vsphere"
+	ProviderNameKubernetes    = "kubernetes"
+	ProviderNameLibvirt       = "libvirt"
+	ProviderNamePlugin        = "plugin"
 	ProviderNameMock          = "mock"
 
 	// TODO: This can be removed when no more hosts with provider ec2 are running.
@@ -160,6 +165,25 @@ var (
 		ProviderNameGce,
 		ProviderNameOpenstack,
 		ProviderNameVsphere,
+		ProviderNameKubernetes,
+		ProviderNameLibvirt,
+		ProviderNamePlugin,
+	}
+
+	// Providers whose hosts start the agent themselves when they boot, so
+	// they are neither set up nor sent an agent over SSH.
+	ProviderAgentSelfStarting = []string{
+		ProviderNameKubernetes,
+	}
+
+	// Providers that can bootstrap hosts through user data instead of SSH.
+	ProviderUserDataBootstrap = []string{
+		ProviderNameEc2Auto,
+		ProviderNameEc2OnDemand,
+		ProviderNameEc2Spot,
+		ProviderNameGce,
+		ProviderNameOpenstack,
+		ProviderNameDocker,
 	}
 )
 
@@ -176,6 +200,7 @@ const (
 	PatchVersionRequester       = "patch_request"
 	GithubPRRequester           = "github_pull_request"
 	RepotrackerVersionRequester = "gitter_request"
+	PeriodicBuildRequester      = "periodic_build_request"
 )
 
 type SenderKey int
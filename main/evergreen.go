@@ -49,6 +49,8 @@ func buildApp() *cli.App {
 
 		// Top-level commands.
 		operations.Keys(),
+		operations.Volume(),
+		operations.CommitQueue(),
 		operations.Fetch(),
 		operations.Evaluate(),
 		operations.Validate(),
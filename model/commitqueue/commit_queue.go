@@ -0,0 +1,202 @@
+package commitqueue
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/mongodb/anser/bsonutil"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	// Collection is the name of the MongoDB collection that stores the
+	// commit queues, one per project.
+	Collection = "commit_queue"
+
+	// PRItemType is the type of items that are GitHub pull requests, which
+	// are identified by their numbers.
+	PRItemType = "pr"
+	// RefItemType is the type of items that are branches or commits pushed
+	// to the project's repository, which are enqueued from the CLI.
+	RefItemType = "ref"
+	// PatchItemType is the type of items that are patches from the CLI
+	// that check out a commit pushed to the project's repository, which are
+	// identified by their patch ids.
+	PatchItemType = "patch"
+)
+
+// CommitQueue is a project's queue of changes waiting to be tested on the
+// tip of the project's branch and merged. Only the item at the head of the
+// queue is tested at a time.
+type CommitQueue struct {
+	ProjectID string            `bson:"_id" json:"project_id"`
+	Queue     []CommitQueueItem `bson:"queue" json:"queue"`
+}
+
+// CommitQueueItem is a change in a commit queue. For pull requests,
+// ApprovedHash is the head commit that was approved when the pull request
+// was enqueued, which is the only commit of it that may be merged. Once the
+// change is being tested, PatchID is the patch testing it and HeadHash is
+// the commit that is merged if the patch succeeds.
+type CommitQueueItem struct {
+	Issue        string    `bson:"issue" json:"issue"`
+	Type         string    `bson:"type" json:"type"`
+	Author       string    `bson:"author" json:"author"`
+	EnqueueTime  time.Time `bson:"enqueue_time" json:"enqueue_time"`
+	ApprovedHash string    `bson:"approved_hash,omitempty" json:"approved_hash,omitempty"`
+	PatchID      string    `bson:"patch_id,omitempty" json:"patch_id,omitempty"`
+	HeadHash     string    `bson:"head_hash,omitempty" json:"head_hash,omitempty"`
+}
+
+var (
+	ProjectIDKey = bsonutil.MustHaveTag(CommitQueue{}, "ProjectID")
+	QueueKey     = bsonutil.MustHaveTag(CommitQueue{}, "Queue")
+
+	ItemIssueKey    = bsonutil.MustHaveTag(CommitQueueItem{}, "Issue")
+	ItemPatchIDKey  = bsonutil.MustHaveTag(CommitQueueItem{}, "PatchID")
+	ItemHeadHashKey = bsonutil.MustHaveTag(CommitQueueItem{}, "HeadHash")
+)
+
+// Validate checks that the item has a known type and an issue.
+func (item *CommitQueueItem) Validate() error {
+	if item.Issue == "" {
+		return errors.New("commit queue item must have an issue")
+	}
+	switch item.Type {
+	case PRItemType, RefItemType, PatchItemType:
+	default:
+		return errors.Errorf("commit queue item has unknown type '%s'", item.Type)
+	}
+	return nil
+}
+
+// FindOneId returns the commit queue of the given project, or nil if the
+// project has never had anything enqueued.
+func FindOneId(projectID string) (*CommitQueue, error) {
+	cq := &CommitQueue{}
+	err := db.FindOneQ(Collection, db.Query(bson.M{ProjectIDKey: projectID}), cq)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	}
+	return cq, errors.Wrapf(err, "error finding commit queue for project '%s'", projectID)
+}
+
+// Next returns the item at the head of the queue.
+func (cq *CommitQueue) Next() (CommitQueueItem, bool) {
+	if len(cq.Queue) == 0 {
+		return CommitQueueItem{}, false
+	}
+	return cq.Queue[0], true
+}
+
+// FindItem returns the position of the item with the given issue in the
+// queue, or -1 if it isn't in the queue.
+func (cq *CommitQueue) FindItem(issue string) int {
+	for i, item := range cq.Queue {
+		if item.Issue == issue {
+			return i
+		}
+	}
+	return -1
+}
+
+// Enqueue adds the item to the end of the project's commit queue, creating
+// the queue if the project doesn't have one, and returns the item's
+// position in the queue. An item can only be in the queue once.
+func Enqueue(projectID string, item CommitQueueItem) (int, error) {
+	if err := item.Validate(); err != nil {
+		return 0, err
+	}
+	if item.EnqueueTime.IsZero() {
+		item.EnqueueTime = time.Now()
+	}
+	item.PatchID = ""
+	item.HeadHash = ""
+
+	_, err := db.Upsert(
+		Collection,
+		bson.M{
+			ProjectIDKey: projectID,
+			bsonutil.GetDottedKeyName(QueueKey, ItemIssueKey): bson.M{"$ne": item.Issue},
+		},
+		bson.M{"$push": bson.M{QueueKey: item}},
+	)
+	if mgo.IsDup(err) {
+		// the upsert tried to create a second queue for the project,
+		// because the item is already in the queue
+		return 0, errors.Errorf("'%s' is already in the commit queue for project '%s'", item.Issue, projectID)
+	}
+	if err != nil {
+		return 0, errors.Wrapf(err, "error adding '%s' to the commit queue for project '%s'", item.Issue, projectID)
+	}
+
+	cq, err := FindOneId(projectID)
+	if err != nil {
+		return 0, err
+	}
+	if cq == nil {
+		return 0, errors.Errorf("commit queue for project '%s' was not created", projectID)
+	}
+	return cq.FindItem(item.Issue), nil
+}
+
+// Remove removes the item with the given issue from the queue, returning
+// false if the item wasn't in the queue.
+func (cq *CommitQueue) Remove(issue string) (bool, error) {
+	i := cq.FindItem(issue)
+	if i < 0 {
+		return false, nil
+	}
+	err := db.Update(
+		Collection,
+		bson.M{ProjectIDKey: cq.ProjectID},
+		bson.M{"$pull": bson.M{QueueKey: bson.M{ItemIssueKey: issue}}},
+	)
+	if err == mgo.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "error removing '%s' from the commit queue for project '%s'", issue, cq.ProjectID)
+	}
+	cq.Queue = append(cq.Queue[:i], cq.Queue[i+1:]...)
+	return true, nil
+}
+
+// SetProcessing records that the item at the head of the queue is being
+// tested by the given patch, which tests the given commit. The item is only
+// updated if its patch is still the one in cq, so it returns false if the
+// item has been started since the queue was read, such as by an overlapping
+// job.
+func (cq *CommitQueue) SetProcessing(issue, patchID, headHash string) (bool, error) {
+	item, ok := cq.Next()
+	if !ok || item.Issue != issue {
+		return false, errors.Errorf("'%s' is not at the head of the commit queue for project '%s'", issue, cq.ProjectID)
+	}
+	var currentPatchID interface{} = item.PatchID
+	if item.PatchID == "" {
+		currentPatchID = bson.M{"$exists": false}
+	}
+	err := db.Update(
+		Collection,
+		bson.M{
+			ProjectIDKey: cq.ProjectID,
+			bsonutil.GetDottedKeyName(QueueKey, "0", ItemIssueKey):   issue,
+			bsonutil.GetDottedKeyName(QueueKey, "0", ItemPatchIDKey): currentPatchID,
+		},
+		bson.M{"$set": bson.M{
+			bsonutil.GetDottedKeyName(QueueKey, "0", ItemPatchIDKey):  patchID,
+			bsonutil.GetDottedKeyName(QueueKey, "0", ItemHeadHashKey): headHash,
+		}},
+	)
+	if err == mgo.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "error updating '%s' in the commit queue for project '%s'", issue, cq.ProjectID)
+	}
+	cq.Queue[0].PatchID = patchID
+	cq.Queue[0].HeadHash = headHash
+	return true, nil
+}
@@ -0,0 +1,104 @@
+package commitqueue
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	db.SetGlobalSessionProvider(testutil.TestConfig().SessionFactory())
+}
+
+func TestCommitQueueItemValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError((&CommitQueueItem{Issue: "12", Type: PRItemType}).Validate())
+	assert.NoError((&CommitQueueItem{Issue: "my-branch", Type: RefItemType}).Validate())
+	assert.Error((&CommitQueueItem{Issue: "12"}).Validate())
+	assert.Error((&CommitQueueItem{Issue: "12", Type: "patch"}).Validate())
+	assert.Error((&CommitQueueItem{Type: PRItemType}).Validate())
+}
+
+func TestCommitQueue(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	require.NoError(db.Clear(Collection))
+
+	cq, err := FindOneId("proj")
+	require.NoError(err)
+	assert.Nil(cq)
+
+	pos, err := Enqueue("proj", CommitQueueItem{Issue: "12", Type: PRItemType, Author: "me"})
+	require.NoError(err)
+	assert.Equal(0, pos)
+	pos, err = Enqueue("proj", CommitQueueItem{Issue: "my-branch", Type: RefItemType, Author: "you"})
+	require.NoError(err)
+	assert.Equal(1, pos)
+	_, err = Enqueue("proj", CommitQueueItem{Issue: "12", Type: PRItemType, Author: "you"})
+	assert.Error(err)
+	_, err = Enqueue("proj", CommitQueueItem{Issue: "13", Type: "branch"})
+	assert.Error(err)
+
+	// queues are per project
+	pos, err = Enqueue("other", CommitQueueItem{Issue: "12", Type: PRItemType, Author: "me"})
+	require.NoError(err)
+	assert.Equal(0, pos)
+
+	cq, err = FindOneId("proj")
+	require.NoError(err)
+	require.NotNil(cq)
+	require.Len(cq.Queue, 2)
+	head, ok := cq.Next()
+	require.True(ok)
+	assert.Equal("12", head.Issue)
+	assert.Equal("me", head.Author)
+	assert.False(head.EnqueueTime.IsZero())
+
+	// only the head of the queue can be processed
+	_, err = cq.SetProcessing("my-branch", "p1", "abcdef")
+	assert.Error(err)
+	stale := &CommitQueue{ProjectID: cq.ProjectID, Queue: append([]CommitQueueItem{}, cq.Queue...)}
+	set, err := cq.SetProcessing("12", "p1", "abcdef")
+	require.NoError(err)
+	assert.True(set)
+	cq, err = FindOneId("proj")
+	require.NoError(err)
+	assert.Equal("p1", cq.Queue[0].PatchID)
+	assert.Equal("abcdef", cq.Queue[0].HeadHash)
+	assert.Empty(cq.Queue[1].PatchID)
+
+	// an item that's been started since the queue was read isn't updated
+	set, err = stale.SetProcessing("12", "p2", "012345")
+	require.NoError(err)
+	assert.False(set)
+	set, err = cq.SetProcessing("12", "p2", "012345")
+	require.NoError(err)
+	assert.True(set)
+	cq, err = FindOneId("proj")
+	require.NoError(err)
+	assert.Equal("p2", cq.Queue[0].PatchID)
+
+	removed, err := cq.Remove("12")
+	require.NoError(err)
+	assert.True(removed)
+	removed, err = cq.Remove("12")
+	require.NoError(err)
+	assert.False(removed)
+	assert.Equal(-1, cq.FindItem("12"))
+
+	cq, err = FindOneId("proj")
+	require.NoError(err)
+	require.Len(cq.Queue, 1)
+	head, ok = cq.Next()
+	require.True(ok)
+	assert.Equal("my-branch", head.Issue)
+
+	// a removed item can be enqueued again
+	pos, err = Enqueue("proj", CommitQueueItem{Issue: "12", Type: PRItemType, Author: "me"})
+	require.NoError(err)
+	assert.Equal(1, pos)
+}
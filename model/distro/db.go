@@ -9,20 +9,26 @@ import (
 
 var (
 	// bson fields for the Distro struct
-	IdKey               = bsonutil.MustHaveTag(Distro{}, "Id")
-	ArchKey             = bsonutil.MustHaveTag(Distro{}, "Arch")
-	PoolSizeKey         = bsonutil.MustHaveTag(Distro{}, "PoolSize")
-	ProviderKey         = bsonutil.MustHaveTag(Distro{}, "Provider")
-	ProviderSettingsKey = bsonutil.MustHaveTag(Distro{}, "ProviderSettings")
-	SetupAsSudoKey      = bsonutil.MustHaveTag(Distro{}, "SetupAsSudo")
-	SetupKey            = bsonutil.MustHaveTag(Distro{}, "Setup")
-	UserKey             = bsonutil.MustHaveTag(Distro{}, "User")
-	SSHKeyKey           = bsonutil.MustHaveTag(Distro{}, "SSHKey")
-	SSHOptionsKey       = bsonutil.MustHaveTag(Distro{}, "SSHOptions")
-	WorkDirKey          = bsonutil.MustHaveTag(Distro{}, "WorkDir")
-	SpawnAllowedKey     = bsonutil.MustHaveTag(Distro{}, "SpawnAllowed")
-	ExpansionsKey       = bsonutil.MustHaveTag(Distro{}, "Expansions")
-	DisabledKey         = bsonutil.MustHaveTag(Distro{}, "Disabled")
+	IdKey                    = bsonutil.MustHaveTag(Distro{}, "Id")
+	ArchKey                  = bsonutil.MustHaveTag(Distro{}, "Arch")
+	PoolSizeKey              = bsonutil.MustHaveTag(Distro{}, "PoolSize")
+	ProviderKey              = bsonutil.MustHaveTag(Distro{}, "Provider")
+	ProviderSettingsKey      = bsonutil.MustHaveTag(Distro{}, "ProviderSettings")
+	SetupAsSudoKey           = bsonutil.MustHaveTag(Distro{}, "SetupAsSudo")
+	SetupKey                 = bsonutil.MustHaveTag(Distro{}, "Setup")
+	UserKey                  = bsonutil.MustHaveTag(Distro{}, "User")
+	SSHKeyKey                = bsonutil.MustHaveTag(Distro{}, "SSHKey")
+	SSHOptionsKey            = bsonutil.MustHaveTag(Distro{}, "SSHOptions")
+	SSHProxyKey              = bsonutil.MustHaveTag(Distro{}, "SSHProxy")
+	WorkDirKey               = bsonutil.MustHaveTag(Distro{}, "WorkDir")
+	SpawnAllowedKey          = bsonutil.MustHaveTag(Distro{}, "SpawnAllowed")
+	ExpansionsKey            = bsonutil.MustHaveTag(Distro{}, "Expansions")
+	DisabledKey              = bsonutil.MustHaveTag(Distro{}, "Disabled")
+	MinHostsKey              = bsonutil.MustHaveTag(Distro{}, "MinHosts")
+	HotStandbyKey            = bsonutil.MustHaveTag(Distro{}, "HotStandby")
+	HealthCheckKey           = bsonutil.MustHaveTag(Distro{}, "HealthCheck")
+	BootstrapMethodKey       = bsonutil.MustHaveTag(Distro{}, "BootstrapMethod")
+	ReprovisionAfterTasksKey = bsonutil.MustHaveTag(Distro{}, "ReprovisionAfterTasks")
 )
 
 const Collection = "distro"
@@ -20,18 +20,67 @@ type Distro struct {
 	Provider         string                  `bson:"provider" json:"provider,omitempty" mapstructure:"provider,omitempty"`
 	ProviderSettings *map[string]interface{} `bson:"settings" json:"settings,omitempty" mapstructure:"settings,omitempty"`
 
-	SetupAsSudo bool     `bson:"setup_as_sudo,omitempty" json:"setup_as_sudo,omitempty" mapstructure:"setup_as_sudo,omitempty"`
-	Setup       string   `bson:"setup,omitempty" json:"setup,omitempty" mapstructure:"setup,omitempty"`
-	Teardown    string   `bson:"teardown,omitempty" json:"teardown,omitempty" mapstructure:"teardown,omitempty"`
-	User        string   `bson:"user,omitempty" json:"user,omitempty" mapstructure:"user,omitempty"`
-	SSHKey      string   `bson:"ssh_key,omitempty" json:"ssh_key,omitempty" mapstructure:"ssh_key,omitempty"`
-	SSHOptions  []string `bson:"ssh_options,omitempty" json:"ssh_options,omitempty" mapstructure:"ssh_options,omitempty"`
+	SetupAsSudo     bool      `bson:"setup_as_sudo,omitempty" json:"setup_as_sudo,omitempty" mapstructure:"setup_as_sudo,omitempty"`
+	Setup           string    `bson:"setup,omitempty" json:"setup,omitempty" mapstructure:"setup,omitempty"`
+	Teardown        string    `bson:"teardown,omitempty" json:"teardown,omitempty" mapstructure:"teardown,omitempty"`
+	HealthCheck     string    `bson:"health_check,omitempty" json:"health_check,omitempty" mapstructure:"health_check,omitempty"`
+	BootstrapMethod string    `bson:"bootstrap_method,omitempty" json:"bootstrap_method,omitempty" mapstructure:"bootstrap_method,omitempty"`
+	User            string    `bson:"user,omitempty" json:"user,omitempty" mapstructure:"user,omitempty"`
+	SSHKey          string    `bson:"ssh_key,omitempty" json:"ssh_key,omitempty" mapstructure:"ssh_key,omitempty"`
+	SSHOptions      []string  `bson:"ssh_options,omitempty" json:"ssh_options,omitempty" mapstructure:"ssh_options,omitempty"`
+	SSHProxy        *SSHProxy `bson:"ssh_proxy,omitempty" json:"ssh_proxy,omitempty" mapstructure:"ssh_proxy,omitempty"`
 
 	SpawnAllowed bool        `bson:"spawn_allowed" json:"spawn_allowed,omitempty" mapstructure:"spawn_allowed,omitempty"`
 	Expansions   []Expansion `bson:"expansions,omitempty" json:"expansions,omitempty" mapstructure:"expansions,omitempty"`
 	Disabled     bool        `bson:"disabled,omitempty" json:"disabled,omitempty" mapstructure:"disabled,omitempty"`
 
 	MaxContainers int `bson:"max_containers,omitempty" json:"max_containers,omitempty" mapstructure:"max_containers,omitempty"`
+
+	MinHosts   int        `bson:"min_hosts,omitempty" json:"min_hosts,omitempty" mapstructure:"min_hosts,omitempty"`
+	HotStandby HotStandby `bson:"hot_standby,omitempty" json:"hot_standby,omitempty" mapstructure:"hot_standby,omitempty"`
+
+	// ReprovisionAfterTasks, if set, is the number of tasks a static host
+	// runs before it is torn down and set up again.
+	ReprovisionAfterTasks int `bson:"reprovision_after_tasks,omitempty" json:"reprovision_after_tasks,omitempty" mapstructure:"reprovision_after_tasks,omitempty"`
+}
+
+// HotStandby describes a number of idle hosts that are kept ready for new
+// tasks during part of each day. The hours are in UTC; the window starts at
+// StartHour and ends before EndHour, wrapping past midnight if EndHour is
+// earlier than StartHour. If the hours are equal the window lasts all day.
+type HotStandby struct {
+	NumHosts  int `bson:"num_hosts,omitempty" json:"num_hosts,omitempty" mapstructure:"num_hosts,omitempty"`
+	StartHour int `bson:"start_hour,omitempty" json:"start_hour,omitempty" mapstructure:"start_hour,omitempty"`
+	EndHour   int `bson:"end_hour,omitempty" json:"end_hour,omitempty" mapstructure:"end_hour,omitempty"`
+}
+
+const (
+	// BootstrapMethodSSH is the default way of provisioning hosts, in which
+	// the setup script and agent are copied to and started on the host over
+	// SSH.
+	BootstrapMethodSSH = "ssh"
+	// BootstrapMethodUserData provisions hosts through the user data passed
+	// to the cloud provider when the host is created. The host fetches its
+	// setup script and agent from the API server and reports back when it is
+	// done, so it does not need to accept inbound SSH connections.
+	BootstrapMethodUserData = "user-data"
+)
+
+// ValidBootstrapMethods are the bootstrap methods a distro may use.
+var ValidBootstrapMethods = []string{BootstrapMethodSSH, BootstrapMethodUserData}
+
+// SSHProxy is a bastion host through which Evergreen makes its SSH
+// connections to the distro's hosts. HostKey is the bastion's public host key,
+// in the format used by known_hosts files, and the bastion is only trusted if
+// it presents that key. SSHKey is the name of the key, from the admin
+// settings, used to log in to the bastion; the distro's own key is used if it
+// is not set.
+type SSHProxy struct {
+	Host    string `bson:"host,omitempty" json:"host,omitempty" mapstructure:"host,omitempty"`
+	Port    int    `bson:"port,omitempty" json:"port,omitempty" mapstructure:"port,omitempty"`
+	User    string `bson:"user,omitempty" json:"user,omitempty" mapstructure:"user,omitempty"`
+	SSHKey  string `bson:"ssh_key,omitempty" json:"ssh_key,omitempty" mapstructure:"ssh_key,omitempty"`
+	HostKey string `bson:"host_key,omitempty" json:"host_key,omitempty" mapstructure:"host_key,omitempty"`
 }
 
 type ValidateFormat string
@@ -84,6 +133,37 @@ func (d *Distro) IsEphemeral() bool {
 	return util.StringSliceContains(evergreen.ProviderSpawnable, d.Provider)
 }
 
+// HasWarmPool returns true if the distro keeps hosts around when there are
+// no tasks for them to run.
+func (d *Distro) HasWarmPool() bool {
+	return d.MinHosts > 0 || d.HotStandby.NumHosts > 0
+}
+
+// StandbyHosts returns the number of idle hosts that should be kept ready
+// at the given time.
+func (d *Distro) StandbyHosts(now time.Time) int {
+	if d.HotStandby.NumHosts <= 0 {
+		return 0
+	}
+
+	start, end, hour := d.HotStandby.StartHour, d.HotStandby.EndHour, now.UTC().Hour()
+	switch {
+	case start == end:
+	case start < end && (hour < start || hour >= end):
+		return 0
+	case start > end && hour < start && hour >= end:
+		return 0
+	}
+
+	return d.HotStandby.NumHosts
+}
+
+// BootstrapsWithUserData returns true if hosts of this distro set themselves
+// up through provider user data rather than over SSH.
+func (d *Distro) BootstrapsWithUserData() bool {
+	return d.BootstrapMethod == BootstrapMethodUserData
+}
+
 func (d *Distro) BinaryName() string {
 	name := "evergreen"
 	if d.IsWindows() {
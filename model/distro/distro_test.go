@@ -4,6 +4,7 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
@@ -99,3 +100,35 @@ func TestFindActive(t *testing.T) {
 	assert.NoError(err)
 	assert.Len(active, 2)
 }
+
+func TestStandbyHosts(t *testing.T) {
+	assert := assert.New(t)
+
+	at := func(hour int) time.Time {
+		return time.Date(2018, 6, 1, hour, 30, 0, 0, time.UTC)
+	}
+
+	d := Distro{}
+	assert.False(d.HasWarmPool())
+	assert.Equal(0, d.StandbyHosts(at(12)))
+
+	d.MinHosts = 2
+	assert.True(d.HasWarmPool())
+	assert.Equal(0, d.StandbyHosts(at(12)))
+
+	d.HotStandby = HotStandby{NumHosts: 3}
+	assert.Equal(3, d.StandbyHosts(at(0)))
+	assert.Equal(3, d.StandbyHosts(at(23)))
+
+	d.HotStandby = HotStandby{NumHosts: 3, StartHour: 8, EndHour: 18}
+	assert.Equal(0, d.StandbyHosts(at(7)))
+	assert.Equal(3, d.StandbyHosts(at(8)))
+	assert.Equal(3, d.StandbyHosts(at(17)))
+	assert.Equal(0, d.StandbyHosts(at(18)))
+
+	d.HotStandby = HotStandby{NumHosts: 3, StartHour: 22, EndHour: 6}
+	assert.Equal(3, d.StandbyHosts(at(23)))
+	assert.Equal(3, d.StandbyHosts(at(2)))
+	assert.Equal(0, d.StandbyHosts(at(6)))
+	assert.Equal(0, d.StandbyHosts(at(12)))
+}
@@ -36,6 +36,16 @@ const (
 	EventTaskFinished             = "HOST_TASK_FINISHED"
 	EventHostTeardown             = "HOST_TEARDOWN"
 	EventHostTerminatedExternally = "HOST_TERMINATED_EXTERNALLY"
+	EventHostPreempted            = "HOST_PREEMPTED"
+	EventHostQuarantined          = "HOST_QUARANTINED"
+	EventHostQuarantineReleased   = "HOST_QUARANTINE_RELEASED"
+	EventHostReprovisionRequested = "HOST_REPROVISION_REQUESTED"
+	EventHostReprovisioning       = "HOST_REPROVISIONING"
+	EventHostReprovisionFailed    = "HOST_REPROVISION_FAILED"
+	EventHostStopFailed           = "HOST_STOP_FAILED"
+	EventHostStartFailed          = "HOST_START_FAILED"
+	EventHostVolumeAttached       = "HOST_VOLUME_ATTACHED"
+	EventHostVolumeFailed         = "HOST_VOLUME_FAILED"
 )
 
 // implements EventData
@@ -49,6 +59,7 @@ type HostEventData struct {
 	TaskExecution int           `bson:"t_execution,omitempty" json:"task_execution,omitempty"`
 	TaskPid       string        `bson:"t_pid,omitempty" json:"task_pid,omitempty"`
 	TaskStatus    string        `bson:"t_st,omitempty" json:"task_status,omitempty"`
+	VolumeId      string        `bson:"vol,omitempty" json:"volume_id,omitempty"`
 	Execution     string        `bson:"execution,omitempty" json:"execution,omitempty"`
 	MonitorOp     string        `bson:"monitor_op,omitempty" json:"monitor,omitempty"`
 	User          string        `bson:"usr" json:"user,omitempty"`
@@ -99,6 +110,46 @@ func LogHostTerminatedExternally(hostId string) {
 	LogHostEvent(hostId, EventHostStatusChanged, HostEventData{NewStatus: EventHostTerminatedExternally})
 }
 
+func LogHostPreempted(hostId, taskId string) {
+	LogHostEvent(hostId, EventHostPreempted, HostEventData{TaskId: taskId})
+}
+
+func LogHostQuarantined(hostId, evidence string) {
+	LogHostEvent(hostId, EventHostQuarantined, HostEventData{Logs: evidence})
+}
+
+func LogHostQuarantineReleased(hostId, logs string) {
+	LogHostEvent(hostId, EventHostQuarantineReleased, HostEventData{Logs: logs})
+}
+
+func LogHostReprovisionRequested(hostId, user, reason string) {
+	LogHostEvent(hostId, EventHostReprovisionRequested, HostEventData{User: user, Logs: reason})
+}
+
+func LogHostReprovisioning(hostId, logs string) {
+	LogHostEvent(hostId, EventHostReprovisioning, HostEventData{Logs: logs})
+}
+
+func LogHostReprovisionFailed(hostId, logs string) {
+	LogHostEvent(hostId, EventHostReprovisionFailed, HostEventData{Logs: logs})
+}
+
+func LogHostStopFailed(hostId, user, logs string) {
+	LogHostEvent(hostId, EventHostStopFailed, HostEventData{User: user, Logs: logs})
+}
+
+func LogHostStartFailed(hostId, user, logs string) {
+	LogHostEvent(hostId, EventHostStartFailed, HostEventData{User: user, Logs: logs})
+}
+
+func LogHostVolumeAttached(hostId, volumeId string) {
+	LogHostEvent(hostId, EventHostVolumeAttached, HostEventData{VolumeId: volumeId})
+}
+
+func LogHostVolumeFailed(hostId, logs string) {
+	LogHostEvent(hostId, EventHostVolumeFailed, HostEventData{Logs: logs})
+}
+
 func LogHostStatusChanged(hostId, oldStatus, newStatus, user string, logs string) {
 	if oldStatus == newStatus {
 		return
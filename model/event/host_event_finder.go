@@ -1,6 +1,8 @@
 package event
 
 import (
+	"time"
+
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/mongodb/anser/bsonutil"
 	"gopkg.in/mgo.v2/bson"
@@ -60,3 +62,28 @@ func AllRecentHostEventsMatchStatus(hostId string, n int, status string) bool {
 	return true
 
 }
+
+// RecentHostTaskStatuses returns the statuses of, at most, the last n tasks
+// to finish on the host after the given time, most recent first.
+func RecentHostTaskStatuses(hostId string, n int, since time.Time) ([]string, error) {
+	query := resourceTypeKeyIs(ResourceTypeHost)
+	query[TypeKey] = EventTaskFinished
+	query[ResourceIdKey] = hostId
+	query[TimestampKey] = bson.M{"$gt": since}
+
+	events, err := Find(AllLogCollection, db.Query(query).Sort([]string{"-" + TimestampKey}).Limit(n))
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]string, 0, len(events))
+	for _, e := range events {
+		data, ok := e.Data.(*HostEventData)
+		if !ok {
+			continue
+		}
+		statuses = append(statuses, data.TaskStatus)
+	}
+
+	return statuses, nil
+}
@@ -3,7 +3,11 @@ package event
 import (
 	"time"
 
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/mongodb/anser/bsonutil"
 	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
 )
 
 func init() {
@@ -24,10 +28,23 @@ type TaskQueueInfo struct {
 	ExpectedDuration time.Duration `bson:"ex_d" json:"expected_duration,"`
 }
 
+// WarmPoolInfo records the state of a distro's warm pool when the scheduler
+// ran. A burst is a run with queued tasks; it is absorbed when every queued
+// task could start on a host that was already up.
+type WarmPoolInfo struct {
+	MinHosts      int  `bson:"min_h,omitempty" json:"min_hosts"`
+	StandbyHosts  int  `bson:"sb_h,omitempty" json:"standby_hosts"`
+	NumReadyHosts int  `bson:"ready_h,omitempty" json:"num_ready_hosts"`
+	NumNewHosts   int  `bson:"new_h,omitempty" json:"num_new_hosts"`
+	Burst         bool `bson:"burst,omitempty" json:"burst"`
+	Absorbed      bool `bson:"absorbed,omitempty" json:"absorbed"`
+}
+
 // implements EventData
 type SchedulerEventData struct {
 	TaskQueueInfo TaskQueueInfo `bson:"tq_info" json:"task_queue_info"`
 	DistroId      string        `bson:"d_id" json:"distro_id"`
+	WarmPool      *WarmPoolInfo `bson:"warm_pool,omitempty" json:"warm_pool,omitempty"`
 }
 
 // LogSchedulerEvent takes care of logging the statistics about the scheduler at a given time.
@@ -46,3 +63,71 @@ func LogSchedulerEvent(eventData SchedulerEventData) {
 		grip.Errorf("Error logging host event: %+v", err)
 	}
 }
+
+var (
+	schedulerDataWarmPoolKey = bsonutil.MustHaveTag(SchedulerEventData{}, "WarmPool")
+	warmPoolNewHostsKey      = bsonutil.MustHaveTag(WarmPoolInfo{}, "NumNewHosts")
+	warmPoolBurstKey         = bsonutil.MustHaveTag(WarmPoolInfo{}, "Burst")
+	warmPoolAbsorbedKey      = bsonutil.MustHaveTag(WarmPoolInfo{}, "Absorbed")
+)
+
+// WarmPoolStats summarizes how a distro's warm pool behaved over a span of
+// scheduler runs.
+type WarmPoolStats struct {
+	Distro   string `bson:"distro" json:"distro"`
+	Runs     int    `bson:"runs" json:"runs"`
+	Bursts   int    `bson:"bursts" json:"bursts"`
+	Absorbed int    `bson:"absorbed" json:"absorbed"`
+	NewHosts int    `bson:"new_hosts" json:"new_hosts"`
+}
+
+// AbsorbedRate returns the fraction of bursts that the warm pool absorbed.
+func (s *WarmPoolStats) AbsorbedRate() float64 {
+	if s.Bursts == 0 {
+		return 0
+	}
+	return float64(s.Absorbed) / float64(s.Bursts)
+}
+
+// GetWarmPoolStats returns the warm pool stats for every distro with a warm
+// pool, based on the scheduler runs since the given time.
+func GetWarmPoolStats(since time.Time) ([]WarmPoolStats, error) {
+	query := resourceTypeKeyIs(ResourceTypeScheduler)
+	query[TimestampKey] = bson.M{"$gte": since}
+	query[bsonutil.GetDottedKeyName(DataKey, schedulerDataWarmPoolKey)] = bson.M{"$exists": true}
+
+	countIf := func(key string) bson.M {
+		return bson.M{"$sum": bson.M{"$cond": []interface{}{
+			bson.M{"$eq": []interface{}{"$" + bsonutil.GetDottedKeyName(DataKey, schedulerDataWarmPoolKey, key), true}}, 1, 0,
+		}}}
+	}
+
+	pipeline := []bson.M{
+		{"$match": query},
+		{"$group": bson.M{
+			"_id":      "$" + ResourceIdKey,
+			"runs":     bson.M{"$sum": 1},
+			"bursts":   countIf(warmPoolBurstKey),
+			"absorbed": countIf(warmPoolAbsorbedKey),
+			"new_hosts": bson.M{
+				"$sum": "$" + bsonutil.GetDottedKeyName(DataKey, schedulerDataWarmPoolKey, warmPoolNewHostsKey),
+			},
+		}},
+		{"$project": bson.M{
+			"_id":       0,
+			"distro":    "$_id",
+			"runs":      1,
+			"bursts":    1,
+			"absorbed":  1,
+			"new_hosts": 1,
+		}},
+		{"$sort": bson.M{"distro": 1}},
+	}
+
+	out := []WarmPoolStats{}
+	if err := db.Aggregate(AllLogCollection, pipeline, &out); err != nil {
+		return nil, errors.Wrap(err, "problem running pipeline")
+	}
+
+	return out, nil
+}
@@ -38,6 +38,10 @@ type TaskEventData struct {
 	Status    string `bson:"s,omitempty" json:"status,omitempty"`
 	JiraIssue string `bson:"jira,omitempty" json:"jira,omitempty"`
 
+	// SupersededBy is the patch that superseded the task's patch, when
+	// that's why the task was aborted.
+	SupersededBy string `bson:"superseded_by,omitempty" json:"superseded_by,omitempty"`
+
 	Timestamp time.Time `bson:"ts,omitempty" json:"timestamp,omitempty"`
 	Priority  int64     `bson:"pri,omitempty" json:"priority,omitempty"`
 }
@@ -135,6 +139,13 @@ func LogManyTaskAbortRequests(taskIds []string, userId string) {
 		TaskEventData{UserId: userId})
 }
 
+// LogManyTaskAbortRequestsForSupersededPatch records that the tasks were
+// aborted because a newer patch superseded theirs.
+func LogManyTaskAbortRequestsForSupersededPatch(taskIds []string, userId, patchId string) {
+	logManyTaskEvents(taskIds, TaskAbortRequest,
+		TaskEventData{UserId: userId, SupersededBy: patchId})
+}
+
 func LogTaskScheduled(taskId string, execution int, scheduledTime time.Time) {
 	logTaskEvent(taskId, TaskScheduled,
 		TaskEventData{Execution: execution, Timestamp: scheduledTime})
@@ -69,6 +69,17 @@ var (
 	SpawnOptionsKey            = bsonutil.MustHaveTag(Host{}, "SpawnOptions")
 	SpawnOptionsTaskIDKey      = bsonutil.MustHaveTag(SpawnOptions{}, "TaskID")
 	SpawnOptionsBuildIDKey     = bsonutil.MustHaveTag(SpawnOptions{}, "BuildID")
+	PreemptedKey               = bsonutil.MustHaveTag(Host{}, "Preempted")
+	AutoQuarantinedKey         = bsonutil.MustHaveTag(Host{}, "AutoQuarantined")
+	QuarantineReleaseTimeKey   = bsonutil.MustHaveTag(Host{}, "QuarantineReleaseTime")
+	BootstrapSecretKey         = bsonutil.MustHaveTag(Host{}, "BootstrapSecret")
+	NeedsReprovisionKey        = bsonutil.MustHaveTag(Host{}, "NeedsReprovision")
+	ReprovisionTaskCountKey    = bsonutil.MustHaveTag(Host{}, "ReprovisionTaskCount")
+	StoppedTimeKey             = bsonutil.MustHaveTag(Host{}, "StoppedTime")
+	SleepScheduleKey           = bsonutil.MustHaveTag(Host{}, "SleepSchedule")
+	HomeVolumeIDKey            = bsonutil.MustHaveTag(Host{}, "HomeVolumeID")
+	UsageRecordedUntilKey      = bsonutil.MustHaveTag(Host{}, "UsageRecordedUntil")
+	SleepScheduleAsleepKey     = bsonutil.MustHaveTag(SleepSchedule{}, "Asleep")
 )
 
 // === Queries ===
@@ -116,6 +127,75 @@ func AllIdleEphemeral() ([]Host, error) {
 	return Find(query)
 }
 
+// IdleByDistroId produces a query that returns the running hosts of the given
+// distro that are not running a task.
+func IdleByDistroId(distroId string) db.Q {
+	return db.Query(bson.M{
+		bsonutil.GetDottedKeyName(DistroKey, distro.IdKey): distroId,
+		RunningTaskKey: bson.M{"$exists": false},
+		StartedByKey:   evergreen.User,
+		StatusKey:      evergreen.HostRunning,
+	})
+}
+
+// SpotInterruptionRate returns the fraction of the spot hosts of the given
+// distro created since the given time that were reclaimed by EC2 while
+// running a task. It returns 0 if no spot hosts were created.
+func SpotInterruptionRate(distroId string, since time.Time) (float64, error) {
+	query := bson.M{
+		bsonutil.GetDottedKeyName(DistroKey, distro.IdKey):       distroId,
+		bsonutil.GetDottedKeyName(DistroKey, distro.ProviderKey): evergreen.ProviderNameEc2Spot,
+		CreateTimeKey: bson.M{"$gte": since},
+		StartedByKey:  evergreen.User,
+	}
+	numHosts, err := Count(db.Query(query))
+	if err != nil {
+		return 0, errors.Wrapf(err, "error counting spot hosts for distro %s", distroId)
+	}
+	if numHosts == 0 {
+		return 0, nil
+	}
+
+	query[PreemptedKey] = true
+	numPreempted, err := Count(db.Query(query))
+	if err != nil {
+		return 0, errors.Wrapf(err, "error counting preempted hosts for distro %s", distroId)
+	}
+
+	return float64(numPreempted) / float64(numHosts), nil
+}
+
+// NeedsQuarantineCheck produces a query that returns the running hosts that
+// the quarantine monitor may quarantine, as well as the hosts it already
+// quarantined that may be released.
+func NeedsQuarantineCheck() db.Q {
+	return db.Query(bson.M{
+		StartedByKey: evergreen.User,
+		"$or": []bson.M{
+			{StatusKey: evergreen.HostRunning},
+			{
+				StatusKey:          evergreen.HostQuarantined,
+				AutoQuarantinedKey: true,
+			},
+		},
+	})
+}
+
+// NeedsReprovisionCheck produces a query that returns the running static
+// hosts that have been marked to be reprovisioned, or whose distro
+// reprovisions its hosts after a number of tasks.
+func NeedsReprovisionCheck() db.Q {
+	return db.Query(bson.M{
+		StartedByKey: evergreen.User,
+		StatusKey:    evergreen.HostRunning,
+		ProviderKey:  evergreen.ProviderNameStatic,
+		"$or": []bson.M{
+			{NeedsReprovisionKey: true},
+			{bsonutil.GetDottedKeyName(DistroKey, distro.ReprovisionAfterTasksKey): bson.M{"$gt": 0}},
+		},
+	})
+}
+
 // ByUnprovisionedSince produces a query that returns all hosts
 // Evergreen never finished setting up that were created before
 // the given time.
@@ -222,6 +302,12 @@ func ById(id string) db.Q {
 	return db.Query(bson.D{{Name: IdKey, Value: id}})
 }
 
+// ByBootstrapSecret produces a query that returns the host that was given
+// the bootstrap secret.
+func ByBootstrapSecret(secret string) db.Q {
+	return db.Query(bson.M{BootstrapSecretKey: secret})
+}
+
 // ByIds produces a query that returns all hosts in the given list of ids.
 func ByIds(ids []string) db.Q {
 	return db.Query(bson.D{
@@ -299,24 +385,66 @@ func ByExpiringBetween(lowerBound time.Time, upperBound time.Time) db.Q {
 	return db.Query(bson.M{
 		StartedByKey: bson.M{"$ne": evergreen.User},
 		StatusKey: bson.M{
-			"$nin": []string{evergreen.HostTerminated, evergreen.HostQuarantined},
+			"$nin": []string{evergreen.HostTerminated, evergreen.HostQuarantined, evergreen.HostStopped},
 		},
 		ExpirationTimeKey: bson.M{"$gte": lowerBound, "$lte": upperBound},
 	})
 }
 
+// NeedsSleepScheduleCheck returns the spawn hosts with a sleep schedule that
+// are running or stopped, which the schedule may need to stop or start.
+func NeedsSleepScheduleCheck() db.Q {
+	return db.Query(bson.M{
+		StartedByKey:     bson.M{"$ne": evergreen.User},
+		StatusKey:        bson.M{"$in": []string{evergreen.HostRunning, evergreen.HostStopped}},
+		SleepScheduleKey: bson.M{"$exists": true},
+	})
+}
+
+// NeedsUsageRecorded returns spawn hosts whose running time and cost may
+// need to be added to their owners' usage: hosts that are up or stopped, and
+// hosts terminated since the given time.
+func NeedsUsageRecorded(terminatedSince time.Time) db.Q {
+	return db.Query(bson.M{
+		UserHostKey: true,
+		"$or": []bson.M{
+			{StatusKey: bson.M{"$in": append([]string{evergreen.HostStopped}, evergreen.ActiveStatus...)}},
+			{
+				StatusKey:          evergreen.HostTerminated,
+				TerminationTimeKey: bson.M{"$gte": terminatedSince},
+			},
+		},
+	})
+}
+
 // NeedsNewAgent returns hosts that are running and need a new agent, have no Last Commmunication Time,
 // or have one that exists that is greater than the MaxLTCInterval duration away from the current time.
+// Hosts whose provider starts the agent itself are never returned, and hosts
+// that bootstrap through user data are only returned when flagged.
 func NeedsNewAgent(currentTime time.Time) db.Q {
 	cutoffTime := currentTime.Add(-MaxLCTInterval)
+	bootstrapMethodKey := bsonutil.GetDottedKeyName(DistroKey, distro.BootstrapMethodKey)
 	return db.Query(bson.M{
 		StatusKey:    evergreen.HostRunning,
 		StartedByKey: evergreen.User,
+		ProviderKey:  bson.M{"$nin": evergreen.ProviderAgentSelfStarting},
+		NeedsReprovisionKey: bson.M{"$ne": true},
 		"$or": []bson.M{
-			{LastCommunicationTimeKey: util.ZeroTime},
-			{LastCommunicationTimeKey: bson.M{"$lte": cutoffTime}},
-			{LastCommunicationTimeKey: bson.M{"$exists": false}},
-			{NeedsNewAgentKey: true},
+			{
+				bootstrapMethodKey: bson.M{"$ne": distro.BootstrapMethodUserData},
+				"$or": []bson.M{
+					{LastCommunicationTimeKey: util.ZeroTime},
+					{LastCommunicationTimeKey: bson.M{"$lte": cutoffTime}},
+					{LastCommunicationTimeKey: bson.M{"$exists": false}},
+					{NeedsNewAgentKey: true},
+				},
+			},
+			// hosts that bootstrap through user data restart their own
+			// agents, so they're only returned once flagged for a new one
+			{
+				bootstrapMethodKey: distro.BootstrapMethodUserData,
+				NeedsNewAgentKey:   true,
+			},
 		},
 	})
 }
@@ -41,6 +41,10 @@ type Host struct {
 
 	ProvisionOptions *ProvisionOptions `bson:"provision_options,omitempty" json:"provision_options,omitempty"`
 
+	// one-time secret that a host bootstrapping through user data uses to
+	// fetch its setup script and report whether provisioning succeeded
+	BootstrapSecret string `bson:"bootstrap_secret,omitempty" json:"-"`
+
 	// the task that is currently running on the host
 	RunningTask             string `bson:"running_task,omitempty" json:"running_task,omitempty"`
 	RunningTaskGroup        string `bson:"running_task_group,omitempty" json:"running_task_group,omitempty"`
@@ -104,6 +108,32 @@ type Host struct {
 
 	// SpawnOptions holds data which the monitor uses to determine when to terminate hosts spawned by tasks.
 	SpawnOptions SpawnOptions `bson:"spawn_options,omitempty" json:"spawn_options,omitempty"`
+
+	// true if the cloud provider reclaimed the host while it was running a task
+	Preempted bool `bson:"preempted,omitempty" json:"preempted,omitempty"`
+
+	// true if the host was quarantined by the quarantine monitor rather than
+	// by a user, in which case it may be released by its distro's health check
+	AutoQuarantined bool `bson:"auto_quarantined,omitempty" json:"auto_quarantined,omitempty"`
+	// task failures before this time are not held against the host
+	QuarantineReleaseTime time.Time `bson:"quarantine_release_time,omitempty" json:"quarantine_release_time,omitempty"`
+
+	// true if the host should stop taking tasks so that it can be torn down
+	// and set up again
+	NeedsReprovision bool `bson:"needs_reprovision,omitempty" json:"needs_reprovision,omitempty"`
+	// the host's task count when it was last reprovisioned
+	ReprovisionTaskCount int `bson:"reprovision_task_count,omitempty" json:"reprovision_task_count,omitempty"`
+
+	// when a stopped host was stopped; time spent stopped does not count
+	// toward a spawn host's expiration
+	StoppedTime time.Time `bson:"stopped_time,omitempty" json:"stopped_time,omitempty"`
+	// when a spawn host should be stopped and started again automatically
+	SleepSchedule *SleepSchedule `bson:"sleep_schedule,omitempty" json:"sleep_schedule,omitempty"`
+	// the id of the owner's home volume, if it is attached to the host
+	HomeVolumeID string `bson:"home_volume_id,omitempty" json:"home_volume_id,omitempty"`
+	// the time up to which a spawn host's running time and cost have been
+	// added to its owner's usage
+	UsageRecordedUntil time.Time `bson:"usage_recorded_until,omitempty" json:"usage_recorded_until,omitempty"`
 }
 
 // ProvisionOptions is struct containing options about how a new host should be set up.
@@ -118,6 +148,12 @@ type ProvisionOptions struct {
 
 	// Owner is the user associated with the host used to populate any necessary metadata.
 	OwnerId string `bson:"owner_id" json:"owner_id"`
+
+	// HomeVolume indicates that the owner's home volume should be attached
+	// to the host and mounted, creating a volume of HomeVolumeSize GiB if
+	// the owner does not have one yet.
+	HomeVolume     bool `bson:"home_volume,omitempty" json:"home_volume,omitempty"`
+	HomeVolumeSize int  `bson:"home_volume_size,omitempty" json:"home_volume_size,omitempty"`
 }
 
 // SpawnOptions holds data which the monitor uses to determine when to terminate hosts spawned by tasks.
@@ -178,17 +214,26 @@ func (h *Host) SetStatus(status, user string, logs string) error {
 
 	event.LogHostStatusChanged(h.Id, h.Status, status, user, logs)
 
-	h.Status = status
-	return UpdateOne(
-		bson.M{
-			IdKey: h.Id,
-		},
-		bson.M{
-			"$set": bson.M{
-				StatusKey: status,
-			},
+	update := bson.M{
+		"$set": bson.M{
+			StatusKey: status,
 		},
-	)
+	}
+	// failures from before a host leaves quarantine, however it's released,
+	// are no longer held against it
+	released := h.Status == evergreen.HostQuarantined && status != evergreen.HostQuarantined
+	now := time.Now()
+	if released {
+		update["$set"].(bson.M)[QuarantineReleaseTimeKey] = now
+		update["$unset"] = bson.M{AutoQuarantinedKey: 1}
+	}
+
+	h.Status = status
+	if released {
+		h.AutoQuarantined = false
+		h.QuarantineReleaseTime = now
+	}
+	return UpdateOne(bson.M{IdKey: h.Id}, update)
 }
 
 // SetProvisioning marks the host as initializing. Only allow this
@@ -211,6 +256,185 @@ func (h *Host) SetDecommissioned(user string, logs string) error {
 	return h.SetStatus(evergreen.HostDecommissioned, user, logs)
 }
 
+// SetStatusIfCurrent atomically changes the host's status, but only if it
+// still has the given current status. It returns false if the status had
+// already changed.
+func (h *Host) SetStatusIfCurrent(current, status, user string, logs string) (bool, error) {
+	err := UpdateOne(
+		bson.M{
+			IdKey:     h.Id,
+			StatusKey: current,
+		},
+		bson.M{
+			"$set": bson.M{
+				StatusKey: status,
+			},
+		},
+	)
+	if err == mgo.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	event.LogHostStatusChanged(h.Id, current, status, user, logs)
+	h.Status = status
+	return true, nil
+}
+
+// SetPreempted records that the cloud provider is reclaiming the host while it
+// runs the given task, and decommissions it so that it is given no more work.
+func (h *Host) SetPreempted(taskId, user string) error {
+	event.LogHostPreempted(h.Id, taskId)
+
+	err := UpdateOne(
+		bson.M{
+			IdKey: h.Id,
+		},
+		bson.M{
+			"$set": bson.M{
+				PreemptedKey: true,
+			},
+		},
+	)
+	if err != nil {
+		return errors.Wrapf(err, "error marking host %s as preempted", h.Id)
+	}
+	h.Preempted = true
+
+	return errors.WithStack(h.SetDecommissioned(user, "host preempted"))
+}
+
+// AutoQuarantine quarantines the host on behalf of the quarantine monitor,
+// recording the evidence against it.
+func (h *Host) AutoQuarantine(user, evidence string) error {
+	if err := h.SetQuarantined(user, evidence); err != nil {
+		return errors.WithStack(err)
+	}
+
+	err := UpdateOne(
+		bson.M{
+			IdKey: h.Id,
+		},
+		bson.M{
+			"$set": bson.M{
+				AutoQuarantinedKey: true,
+			},
+		},
+	)
+	if err != nil {
+		return errors.Wrapf(err, "error marking host %s as automatically quarantined", h.Id)
+	}
+	h.AutoQuarantined = true
+	event.LogHostQuarantined(h.Id, evidence)
+
+	return nil
+}
+
+// ReleaseFromQuarantine returns an automatically quarantined host to the
+// pool. Failures from before its release are no longer held against it.
+func (h *Host) ReleaseFromQuarantine(user, logs string) error {
+	now := time.Now()
+	err := UpdateOne(
+		bson.M{
+			IdKey:              h.Id,
+			StatusKey:          evergreen.HostQuarantined,
+			AutoQuarantinedKey: true,
+		},
+		bson.M{
+			"$set": bson.M{
+				StatusKey:                evergreen.HostRunning,
+				QuarantineReleaseTimeKey: now,
+			},
+			"$unset": bson.M{
+				AutoQuarantinedKey: 1,
+			},
+		},
+	)
+	if err != nil {
+		return errors.Wrapf(err, "error releasing host %s from quarantine", h.Id)
+	}
+
+	event.LogHostStatusChanged(h.Id, h.Status, evergreen.HostRunning, user, logs)
+	event.LogHostQuarantineReleased(h.Id, logs)
+	h.Status = evergreen.HostRunning
+	h.AutoQuarantined = false
+	h.QuarantineReleaseTime = now
+
+	return nil
+}
+
+// ReachedReprovisionTaskLimit returns true if the host's distro reprovisions
+// its hosts after a number of tasks and the host has run at least that many
+// since it was last reprovisioned.
+func (h *Host) ReachedReprovisionTaskLimit() bool {
+	limit := h.Distro.ReprovisionAfterTasks
+	return limit > 0 && h.TaskCount-h.ReprovisionTaskCount >= limit
+}
+
+// SetNeedsReprovision marks the host to stop taking new tasks so that it can
+// be torn down and set up again once its current task finishes.
+func (h *Host) SetNeedsReprovision(user, reason string) error {
+	err := UpdateOne(
+		bson.M{
+			IdKey: h.Id,
+		},
+		bson.M{
+			"$set": bson.M{
+				NeedsReprovisionKey: true,
+			},
+		},
+	)
+	if err != nil {
+		return errors.Wrapf(err, "error marking host %s as needing to be reprovisioned", h.Id)
+	}
+	h.NeedsReprovision = true
+	event.LogHostReprovisionRequested(h.Id, user, reason)
+
+	return nil
+}
+
+// StartReprovisioning returns a drained host that has been torn down to the
+// provisioning state, where it is set up and given an agent the same way as
+// a new host. It fails if the host has started another task in the meantime.
+func (h *Host) StartReprovisioning(user, logs string) error {
+	err := UpdateOne(
+		bson.M{
+			IdKey:               h.Id,
+			StatusKey:           h.Status,
+			NeedsReprovisionKey: true,
+			RunningTaskKey:      bson.M{"$exists": false},
+		},
+		bson.M{
+			"$set": bson.M{
+				StatusKey:               evergreen.HostProvisioning,
+				ProvisionedKey:          false,
+				ProvisionAttemptsKey:    0,
+				NeedsNewAgentKey:        true,
+				ReprovisionTaskCountKey: h.TaskCount,
+			},
+			"$unset": bson.M{
+				NeedsReprovisionKey: 1,
+			},
+		},
+	)
+	if err != nil {
+		return errors.Wrapf(err, "error starting to reprovision host %s", h.Id)
+	}
+
+	event.LogHostStatusChanged(h.Id, h.Status, evergreen.HostProvisioning, user, logs)
+	event.LogHostReprovisioning(h.Id, logs)
+	h.Status = evergreen.HostProvisioning
+	h.Provisioned = false
+	h.ProvisionAttempts = 0
+	h.NeedsNewAgent = true
+	h.NeedsReprovision = false
+	h.ReprovisionTaskCount = h.TaskCount
+
+	return nil
+}
+
 func (h *Host) SetRunning(user string) error {
 	return h.SetStatus(evergreen.HostRunning, user, "")
 }
@@ -219,6 +443,65 @@ func (h *Host) SetTerminated(user string) error {
 	return h.SetStatus(evergreen.HostTerminated, user, "")
 }
 
+// SetStopped marks the host as stopped in its cloud provider and records when
+// it was stopped.
+func (h *Host) SetStopped(user string) error {
+	if err := h.SetStatus(evergreen.HostStopped, user, ""); err != nil {
+		return err
+	}
+	h.StoppedTime = time.Now()
+	return UpdateOne(
+		bson.M{
+			IdKey: h.Id,
+		},
+		bson.M{
+			"$set": bson.M{
+				StoppedTimeKey: h.StoppedTime,
+			},
+		},
+	)
+}
+
+// SetStartedAfterStop marks a stopped host as running again. The host's
+// expiration time is pushed back by however long it was stopped, since a
+// stopped host does not use up its time.
+func (h *Host) SetStartedAfterStop(user string) error {
+	if h.Status != evergreen.HostStopped {
+		return errors.Errorf("host %s is %s, not stopped", h.Id, h.Status)
+	}
+
+	expiration := h.ExpirationTime
+	if !util.IsZeroTime(expiration) && !util.IsZeroTime(h.StoppedTime) {
+		expiration = expiration.Add(time.Since(h.StoppedTime))
+	}
+
+	err := UpdateOne(
+		bson.M{
+			IdKey:     h.Id,
+			StatusKey: evergreen.HostStopped,
+		},
+		bson.M{
+			"$set": bson.M{
+				StatusKey:         evergreen.HostRunning,
+				ExpirationTimeKey: expiration,
+			},
+			"$unset": bson.M{
+				StoppedTimeKey: 1,
+			},
+		},
+	)
+	if err != nil {
+		return errors.Wrapf(err, "error marking host %s as started", h.Id)
+	}
+	event.LogHostStatusChanged(h.Id, h.Status, evergreen.HostRunning, user, "")
+
+	h.Status = evergreen.HostRunning
+	h.ExpirationTime = expiration
+	h.StoppedTime = time.Time{}
+
+	return nil
+}
+
 func (h *Host) SetUnprovisioned() error {
 	return UpdateOne(
 		bson.M{
@@ -252,6 +535,39 @@ func (h *Host) CreateSecret() error {
 	return nil
 }
 
+// RotateBootstrapSecret atomically replaces the bootstrap secret that the
+// host was created with by a new one, so that the secret in the host's user
+// data, which anyone who can read the instance metadata can see, only works
+// once. It returns false if the secret had already been replaced or cleared.
+func (h *Host) RotateBootstrapSecret(current string) (bool, error) {
+	secret := util.RandomString()
+	err := UpdateOne(
+		bson.M{
+			IdKey:              h.Id,
+			BootstrapSecretKey: current,
+		},
+		bson.M{"$set": bson.M{BootstrapSecretKey: secret}},
+	)
+	if err == mgo.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	h.BootstrapSecret = secret
+	return true, nil
+}
+
+// ClearBootstrapSecret removes the host's bootstrap secret so that it cannot
+// be used again once the host has reported the result of provisioning.
+func (h *Host) ClearBootstrapSecret() error {
+	h.BootstrapSecret = ""
+	return UpdateOne(
+		bson.M{IdKey: h.Id},
+		bson.M{"$unset": bson.M{BootstrapSecretKey: 1}},
+	)
+}
+
 // UpdateLastCommunicated sets the host's last communication time to the current time.
 func (h *Host) UpdateLastCommunicated() error {
 	now := time.Now()
@@ -321,6 +637,61 @@ func (h *Host) SetDNSName(dnsName string) error {
 	return err
 }
 
+// UpdateDNSName sets the host's DNS name, which some providers change when a
+// stopped host is started again.
+func (h *Host) UpdateDNSName(dnsName string) error {
+	if h.Host == dnsName {
+		return nil
+	}
+	err := UpdateOne(
+		bson.M{
+			IdKey: h.Id,
+		},
+		bson.M{
+			"$set": bson.M{
+				DNSKey: dnsName,
+			},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	h.Host = dnsName
+	event.LogHostDNSNameSet(h.Id, dnsName)
+
+	return nil
+}
+
+// SetHomeVolumeID records the id of the home volume attached to the host, or
+// clears it if the id is empty.
+func (h *Host) SetHomeVolumeID(volumeID string) error {
+	update := bson.M{"$set": bson.M{HomeVolumeIDKey: volumeID}}
+	if volumeID == "" {
+		update = bson.M{"$unset": bson.M{HomeVolumeIDKey: 1}}
+	}
+	if err := UpdateOne(bson.M{IdKey: h.Id}, update); err != nil {
+		return errors.Wrapf(err, "error setting home volume for host %s", h.Id)
+	}
+	h.HomeVolumeID = volumeID
+
+	return nil
+}
+
+// SetUsageRecordedUntil records that the spawn host's usage has been added
+// to its owner's usage up to the given time.
+func (h *Host) SetUsageRecordedUntil(t time.Time) error {
+	err := UpdateOne(
+		bson.M{IdKey: h.Id},
+		bson.M{"$set": bson.M{UsageRecordedUntilKey: t}},
+	)
+	if err != nil {
+		return errors.Wrapf(err, "error recording usage time for host %s", h.Id)
+	}
+	h.UsageRecordedUntil = t
+
+	return nil
+}
+
 func (h *Host) MarkAsProvisioned() error {
 	event.LogHostProvisioned(h.Id)
 	h.Status = evergreen.HostRunning
@@ -722,7 +1093,7 @@ func FindHostsToTerminate() ([]Host, error) {
 			{ // host.ByExpiredSince(time.Now())
 				StartedByKey: bson.M{"$ne": evergreen.User},
 				StatusKey: bson.M{
-					"$nin": []string{evergreen.HostTerminated, evergreen.HostQuarantined},
+					"$nin": []string{evergreen.HostTerminated, evergreen.HostQuarantined, evergreen.HostStopped},
 				},
 				ExpirationTimeKey: bson.M{"$lte": now},
 			},
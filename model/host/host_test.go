@@ -8,6 +8,7 @@ import (
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/testutil"
 	"github.com/evergreen-ci/evergreen/util"
@@ -790,9 +791,62 @@ func TestFindNeedsNewAgent(t *testing.T) {
 			So(len(hosts), ShouldEqual, 1)
 			So(hosts[0].Id, ShouldEqual, "h")
 		})
+		Convey("with hosts that bootstrap through user data", func() {
+			d := distro.Distro{Id: "d", BootstrapMethod: distro.BootstrapMethodUserData}
+			silent := Host{
+				Id:        "silent",
+				Distro:    d,
+				Status:    evergreen.HostRunning,
+				StartedBy: evergreen.User,
+			}
+			So(silent.Insert(), ShouldBeNil)
+			flagged := Host{
+				Id:                    "flagged",
+				Distro:                d,
+				Status:                evergreen.HostRunning,
+				StartedBy:             evergreen.User,
+				LastCommunicationTime: now,
+				NeedsNewAgent:         true,
+			}
+			So(flagged.Insert(), ShouldBeNil)
+
+			// only the host flagged for a new agent is returned
+			hosts, err := Find(NeedsNewAgent(now))
+			So(err, ShouldBeNil)
+			So(len(hosts), ShouldEqual, 1)
+			So(hosts[0].Id, ShouldEqual, "flagged")
+		})
 	})
 }
 
+func TestRotateBootstrapSecret(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	require.NoError(db.ClearCollections(Collection))
+
+	h := &Host{Id: "h1", BootstrapSecret: "user-data-secret"}
+	require.NoError(h.Insert())
+
+	rotated, err := h.RotateBootstrapSecret("user-data-secret")
+	require.NoError(err)
+	assert.True(rotated)
+	assert.NotEmpty(h.BootstrapSecret)
+	assert.NotEqual("user-data-secret", h.BootstrapSecret)
+
+	// the secret from the user data only works once
+	rotated, err = h.RotateBootstrapSecret("user-data-secret")
+	require.NoError(err)
+	assert.False(rotated)
+
+	dbHost, err := FindOne(ByBootstrapSecret("user-data-secret"))
+	require.NoError(err)
+	assert.Nil(dbHost)
+	dbHost, err = FindOne(ByBootstrapSecret(h.BootstrapSecret))
+	require.NoError(err)
+	require.NotNil(dbHost)
+	assert.Equal(h.Id, dbHost.Id)
+}
+
 func TestHostElapsedCommTime(t *testing.T) {
 	assert := assert.New(t)
 	now := time.Now()
@@ -1835,3 +1889,131 @@ func TestFindAllRunningParentsByDistro(t *testing.T) {
 	assert.NoError(err)
 	assert.Equal(2, len(parents))
 }
+
+func TestReprovisioning(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	require.NoError(db.ClearCollections(Collection, event.AllLogCollection))
+
+	h := &Host{
+		Id:        "h1",
+		Status:    evergreen.HostRunning,
+		Provider:  evergreen.ProviderNameStatic,
+		StartedBy: evergreen.User,
+		TaskCount: 9,
+		Distro: distro.Distro{
+			Id:                    "static",
+			Provider:              evergreen.ProviderNameStatic,
+			ReprovisionAfterTasks: 10,
+		},
+		Provisioned: true,
+	}
+	require.NoError(h.Insert())
+	assert.False(h.ReachedReprovisionTaskLimit())
+
+	hosts, err := Find(NeedsReprovisionCheck())
+	require.NoError(err)
+	assert.Len(hosts, 1)
+
+	h.TaskCount = 10
+	assert.True(h.ReachedReprovisionTaskLimit())
+
+	// the host can't be reprovisioned until it has been drained
+	assert.Error(h.StartReprovisioning(evergreen.User, "torn down"))
+	require.NoError(h.SetNeedsReprovision("admin", "requested"))
+
+	hosts, err = Find(NeedsNewAgent(time.Now()))
+	require.NoError(err)
+	assert.Len(hosts, 0)
+
+	require.NoError(h.StartReprovisioning(evergreen.User, "torn down"))
+	assert.Equal(evergreen.HostProvisioning, h.Status)
+	assert.False(h.ReachedReprovisionTaskLimit())
+
+	dbHost, err := FindOneId(h.Id)
+	require.NoError(err)
+	require.NotNil(dbHost)
+	assert.Equal(evergreen.HostProvisioning, dbHost.Status)
+	assert.False(dbHost.Provisioned)
+	assert.False(dbHost.NeedsReprovision)
+	assert.True(dbHost.NeedsNewAgent)
+	assert.Equal(10, dbHost.ReprovisionTaskCount)
+}
+
+func TestStopAndStartHost(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	require.NoError(db.ClearCollections(Collection, event.AllLogCollection))
+
+	now := time.Now()
+	h := &Host{
+		Id:             "h1",
+		Host:           "old.example.com",
+		Status:         evergreen.HostRunning,
+		StartedBy:      "me",
+		ExpirationTime: now.Add(time.Hour),
+		SleepSchedule:  &SleepSchedule{StopHour: 20, StartHour: 8},
+	}
+	require.NoError(h.Insert())
+
+	// a running host can't be started
+	assert.Error(h.SetStartedAfterStop("me"))
+
+	require.NoError(h.SetStopped("me"))
+	assert.Equal(evergreen.HostStopped, h.Status)
+
+	// stopped hosts don't expire
+	hosts, err := Find(ByExpiringBetween(now, now.Add(2*time.Hour)))
+	require.NoError(err)
+	assert.Len(hosts, 0)
+	hosts, err = Find(NeedsSleepScheduleCheck())
+	require.NoError(err)
+	assert.Len(hosts, 1)
+
+	// the host was stopped for an hour, so it expires an hour later
+	h.StoppedTime = now.Add(-time.Hour)
+	require.NoError(h.SetStartedAfterStop("me"))
+	require.NoError(h.UpdateDNSName("new.example.com"))
+	require.NoError(h.SetAsleepBySchedule(true))
+
+	dbHost, err := FindOneId(h.Id)
+	require.NoError(err)
+	require.NotNil(dbHost)
+	assert.Equal(evergreen.HostRunning, dbHost.Status)
+	assert.Equal("new.example.com", dbHost.Host)
+	assert.True(dbHost.StoppedTime.IsZero())
+	assert.WithinDuration(now.Add(2*time.Hour), dbHost.ExpirationTime, time.Minute)
+	require.NotNil(dbHost.SleepSchedule)
+	assert.True(dbHost.SleepSchedule.Asleep)
+
+	require.NoError(h.SetSleepSchedule(nil))
+	hosts, err = Find(NeedsSleepScheduleCheck())
+	require.NoError(err)
+	assert.Len(hosts, 0)
+}
+
+func TestSetStatusIfCurrent(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	require.NoError(db.ClearCollections(Collection, event.AllLogCollection))
+
+	h := &Host{Id: "h1", Status: evergreen.HostRunning}
+	require.NoError(h.Insert())
+
+	changed, err := h.SetStatusIfCurrent(evergreen.HostRunning, evergreen.HostDecommissioned, evergreen.User, "")
+	require.NoError(err)
+	assert.True(changed)
+	assert.Equal(evergreen.HostDecommissioned, h.Status)
+
+	// the host is no longer running, so a second caller loses the race
+	other := &Host{Id: "h1", Status: evergreen.HostRunning}
+	changed, err = other.SetStatusIfCurrent(evergreen.HostRunning, evergreen.HostDecommissioned, evergreen.User, "")
+	require.NoError(err)
+	assert.False(changed)
+	assert.Equal(evergreen.HostRunning, other.Status)
+
+	dbHost, err := FindOneId(h.Id)
+	require.NoError(err)
+	require.NotNil(dbHost)
+	assert.Equal(evergreen.HostDecommissioned, dbHost.Status)
+}
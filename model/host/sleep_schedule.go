@@ -0,0 +1,115 @@
+package host
+
+import (
+	"time"
+
+	"github.com/mongodb/anser/bsonutil"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// SleepSchedule describes when a spawn host should be stopped and started
+// again automatically. The host sleeps every day from StopHour until
+// StartHour, and for the whole of each day in WholeDaysOff. Hours are in the
+// schedule's time zone. A schedule whose StopHour and StartHour are equal
+// only puts the host to sleep on its days off.
+type SleepSchedule struct {
+	StopHour     int            `bson:"stop_hour" json:"stop_hour"`
+	StartHour    int            `bson:"start_hour" json:"start_hour"`
+	WholeDaysOff []time.Weekday `bson:"whole_days_off,omitempty" json:"whole_days_off,omitempty"`
+	// an IANA time zone name, such as "America/New_York"; defaults to UTC
+	TimeZone string `bson:"time_zone,omitempty" json:"time_zone,omitempty"`
+
+	// true if the schedule has stopped the host and not yet started it
+	// again. The schedule only acts when it changes, so a user can start a
+	// host during its sleep or stop it during its waking hours.
+	Asleep bool `bson:"asleep,omitempty" json:"asleep"`
+}
+
+// Validate checks that the schedule's hours, days and time zone are valid.
+func (s *SleepSchedule) Validate() error {
+	catcher := grip.NewBasicCatcher()
+	if s.StopHour < 0 || s.StopHour > 23 {
+		catcher.Add(errors.Errorf("stop hour %d must be between 0 and 23", s.StopHour))
+	}
+	if s.StartHour < 0 || s.StartHour > 23 {
+		catcher.Add(errors.Errorf("start hour %d must be between 0 and 23", s.StartHour))
+	}
+	for _, day := range s.WholeDaysOff {
+		if day < time.Sunday || day > time.Saturday {
+			catcher.Add(errors.Errorf("%d is not a day of the week", day))
+		}
+	}
+	if len(s.WholeDaysOff) >= 7 {
+		catcher.Add(errors.New("a host cannot sleep every day of the week"))
+	}
+	if _, err := time.LoadLocation(s.TimeZone); err != nil {
+		catcher.Add(errors.Wrapf(err, "invalid time zone '%s'", s.TimeZone))
+	}
+
+	return catcher.Resolve()
+}
+
+// ShouldBeAsleep returns whether the schedule calls for the host to be
+// stopped at the given time.
+func (s *SleepSchedule) ShouldBeAsleep(now time.Time) (bool, error) {
+	loc, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		return false, errors.Wrapf(err, "invalid time zone '%s'", s.TimeZone)
+	}
+	now = now.In(loc)
+
+	for _, day := range s.WholeDaysOff {
+		if now.Weekday() == day {
+			return true, nil
+		}
+	}
+
+	hour := now.Hour()
+	switch {
+	case s.StopHour == s.StartHour:
+		return false, nil
+	case s.StopHour > s.StartHour:
+		// the host sleeps overnight, e.g. from 22:00 until 08:00
+		return hour >= s.StopHour || hour < s.StartHour, nil
+	default:
+		return hour >= s.StopHour && hour < s.StartHour, nil
+	}
+}
+
+// SetSleepSchedule sets the host's sleep schedule, or removes it if the
+// schedule is nil.
+func (h *Host) SetSleepSchedule(schedule *SleepSchedule) error {
+	update := bson.M{"$set": bson.M{SleepScheduleKey: schedule}}
+	if schedule == nil {
+		update = bson.M{"$unset": bson.M{SleepScheduleKey: 1}}
+	}
+	if err := UpdateOne(bson.M{IdKey: h.Id}, update); err != nil {
+		return errors.Wrapf(err, "error setting sleep schedule for host %s", h.Id)
+	}
+	h.SleepSchedule = schedule
+
+	return nil
+}
+
+// SetAsleepBySchedule records whether the host's sleep schedule has put it
+// to sleep.
+func (h *Host) SetAsleepBySchedule(asleep bool) error {
+	if h.SleepSchedule == nil {
+		return errors.Errorf("host %s has no sleep schedule", h.Id)
+	}
+
+	err := UpdateOne(
+		bson.M{IdKey: h.Id},
+		bson.M{"$set": bson.M{
+			bsonutil.GetDottedKeyName(SleepScheduleKey, SleepScheduleAsleepKey): asleep,
+		}},
+	)
+	if err != nil {
+		return errors.Wrapf(err, "error updating sleep schedule for host %s", h.Id)
+	}
+	h.SleepSchedule.Asleep = asleep
+
+	return nil
+}
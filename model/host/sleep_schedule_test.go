@@ -0,0 +1,78 @@
+package host
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSleepScheduleValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError((&SleepSchedule{StopHour: 22, StartHour: 8}).Validate())
+	assert.NoError((&SleepSchedule{
+		StopHour:     18,
+		StartHour:    9,
+		WholeDaysOff: []time.Weekday{time.Saturday, time.Sunday},
+		TimeZone:     "America/New_York",
+	}).Validate())
+
+	assert.Error((&SleepSchedule{StopHour: 24, StartHour: 8}).Validate())
+	assert.Error((&SleepSchedule{StopHour: 22, StartHour: -1}).Validate())
+	assert.Error((&SleepSchedule{WholeDaysOff: []time.Weekday{7}}).Validate())
+	assert.Error((&SleepSchedule{WholeDaysOff: []time.Weekday{0, 1, 2, 3, 4, 5, 6}}).Validate())
+	assert.Error((&SleepSchedule{TimeZone: "Not/A_Zone"}).Validate())
+}
+
+func TestSleepScheduleShouldBeAsleep(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// 2018-06-04 was a Monday
+	at := func(day, hour int) time.Time {
+		return time.Date(2018, time.June, day, hour, 30, 0, 0, time.UTC)
+	}
+
+	for name, test := range map[string]struct {
+		schedule SleepSchedule
+		awake    []time.Time
+		asleep   []time.Time
+	}{
+		"Overnight": {
+			schedule: SleepSchedule{StopHour: 22, StartHour: 8},
+			awake:    []time.Time{at(4, 8), at(4, 12), at(4, 21)},
+			asleep:   []time.Time{at(4, 22), at(4, 23), at(5, 0), at(5, 7)},
+		},
+		"SameDay": {
+			schedule: SleepSchedule{StopHour: 1, StartHour: 6},
+			awake:    []time.Time{at(4, 0), at(4, 6), at(4, 23)},
+			asleep:   []time.Time{at(4, 1), at(4, 5)},
+		},
+		"DaysOffOnly": {
+			schedule: SleepSchedule{WholeDaysOff: []time.Weekday{time.Saturday, time.Sunday}},
+			awake:    []time.Time{at(4, 0), at(8, 23)},
+			asleep:   []time.Time{at(9, 0), at(10, 12)},
+		},
+		"TimeZone": {
+			// 22:00-08:00 in New York is 02:00-12:00 UTC in June
+			schedule: SleepSchedule{StopHour: 22, StartHour: 8, TimeZone: "America/New_York"},
+			awake:    []time.Time{at(4, 12), at(4, 1)},
+			asleep:   []time.Time{at(4, 2), at(4, 11)},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for _, now := range test.awake {
+				asleep, err := test.schedule.ShouldBeAsleep(now)
+				require.NoError(err)
+				assert.False(asleep, now.String())
+			}
+			for _, now := range test.asleep {
+				asleep, err := test.schedule.ShouldBeAsleep(now)
+				require.NoError(err)
+				assert.True(asleep, now.String())
+			}
+		})
+	}
+}
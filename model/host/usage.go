@@ -0,0 +1,84 @@
+package host
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/mongodb/anser/bsonutil"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// SpawnHostUsageCollection is the name of the MongoDB collection that
+// stores how much each user's spawn hosts have run and cost.
+const SpawnHostUsageCollection = "spawnhost_usage"
+
+// SpawnHostUsage is the time and money that a user's spawn hosts from one
+// distro have used in a month.
+type SpawnHostUsage struct {
+	ID     string `bson:"_id" json:"id"`
+	User   string `bson:"user" json:"user"`
+	Distro string `bson:"distro" json:"distro"`
+	// the start of the month, in UTC
+	Month     time.Time `bson:"month" json:"month"`
+	HostHours float64   `bson:"host_hours" json:"host_hours"`
+	Cost      float64   `bson:"cost" json:"cost"`
+}
+
+var (
+	SpawnHostUsageUserKey      = bsonutil.MustHaveTag(SpawnHostUsage{}, "User")
+	SpawnHostUsageDistroKey    = bsonutil.MustHaveTag(SpawnHostUsage{}, "Distro")
+	SpawnHostUsageMonthKey     = bsonutil.MustHaveTag(SpawnHostUsage{}, "Month")
+	SpawnHostUsageHostHoursKey = bsonutil.MustHaveTag(SpawnHostUsage{}, "HostHours")
+	SpawnHostUsageCostKey      = bsonutil.MustHaveTag(SpawnHostUsage{}, "Cost")
+)
+
+// MonthStart returns the start of the month, in UTC, that the given time
+// falls in.
+func MonthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// IncSpawnHostUsage adds host-hours and cost to the user's usage of the
+// distro in the month that the given time falls in.
+func IncSpawnHostUsage(user, distro string, at time.Time, hours, cost float64) error {
+	month := MonthStart(at)
+	_, err := db.Upsert(SpawnHostUsageCollection,
+		bson.M{"_id": fmt.Sprintf("%s.%s.%s", user, distro, month.Format("2006-01"))},
+		bson.M{
+			"$set": bson.M{
+				SpawnHostUsageUserKey:   user,
+				SpawnHostUsageDistroKey: distro,
+				SpawnHostUsageMonthKey:  month,
+			},
+			"$inc": bson.M{
+				SpawnHostUsageHostHoursKey: hours,
+				SpawnHostUsageCostKey:      cost,
+			},
+		},
+	)
+	return errors.Wrapf(err, "error recording spawn host usage for %s", user)
+}
+
+// FindSpawnHostUsage returns the user's usage of each distro in the month
+// that the given time falls in.
+func FindSpawnHostUsage(user string, at time.Time) ([]SpawnHostUsage, error) {
+	usage := []SpawnHostUsage{}
+	err := db.FindAllQ(SpawnHostUsageCollection, db.Query(bson.M{
+		SpawnHostUsageUserKey:  user,
+		SpawnHostUsageMonthKey: MonthStart(at),
+	}).Sort([]string{SpawnHostUsageDistroKey}), &usage)
+	return usage, errors.Wrapf(err, "error finding spawn host usage for %s", user)
+}
+
+// FindSpawnHostUsageForMonth returns every user's usage of each distro in
+// the month that the given time falls in.
+func FindSpawnHostUsageForMonth(at time.Time) ([]SpawnHostUsage, error) {
+	usage := []SpawnHostUsage{}
+	err := db.FindAllQ(SpawnHostUsageCollection, db.Query(bson.M{
+		SpawnHostUsageMonthKey: MonthStart(at),
+	}).Sort([]string{SpawnHostUsageUserKey, SpawnHostUsageDistroKey}), &usage)
+	return usage, errors.Wrap(err, "error finding spawn host usage")
+}
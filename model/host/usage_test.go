@@ -0,0 +1,42 @@
+package host
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpawnHostUsage(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	require.NoError(db.Clear(SpawnHostUsageCollection))
+
+	now := time.Date(2018, time.June, 15, 12, 0, 0, 0, time.UTC)
+	lastMonth := time.Date(2018, time.May, 31, 23, 0, 0, 0, time.UTC)
+	require.NoError(IncSpawnHostUsage("me", "linux", now, 2, 0.5))
+	require.NoError(IncSpawnHostUsage("me", "linux", now.Add(time.Hour), 1.5, 0.25))
+	require.NoError(IncSpawnHostUsage("me", "gpu", now, 1, 3))
+	require.NoError(IncSpawnHostUsage("me", "linux", lastMonth, 10, 10))
+	require.NoError(IncSpawnHostUsage("you", "linux", now, 4, 1))
+
+	usage, err := FindSpawnHostUsage("me", now)
+	require.NoError(err)
+	require.Len(usage, 2)
+	assert.Equal("gpu", usage[0].Distro)
+	assert.Equal("linux", usage[1].Distro)
+	assert.Equal(3.5, usage[1].HostHours)
+	assert.Equal(0.75, usage[1].Cost)
+	assert.True(usage[1].Month.Equal(time.Date(2018, time.June, 1, 0, 0, 0, 0, time.UTC)))
+
+	usage, err = FindSpawnHostUsage("me", lastMonth)
+	require.NoError(err)
+	require.Len(usage, 1)
+	assert.Equal(10.0, usage[0].HostHours)
+
+	usage, err = FindSpawnHostUsageForMonth(now)
+	require.NoError(err)
+	assert.Len(usage, 3)
+}
@@ -0,0 +1,197 @@
+package host
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/mongodb/anser/bsonutil"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	// VolumesCollection is the name of the MongoDB collection that stores
+	// the persistent volumes attached to spawn hosts.
+	VolumesCollection = "volumes"
+
+	// VolumeAvailable is the status of a volume that is not attached to a
+	// host.
+	VolumeAvailable = "available"
+	// VolumeAttached is the status of a volume that is attached, or being
+	// attached, to a host.
+	VolumeAttached = "attached"
+	// VolumeDeleted is the status of a volume that has been deleted from
+	// its cloud provider.
+	VolumeDeleted = "deleted"
+)
+
+// Volume is a persistent disk belonging to a user that is attached to each
+// spawn host the user creates with a home volume, so that the user's files
+// outlive the hosts.
+type Volume struct {
+	// the volume's id in its cloud provider
+	ID        string `bson:"_id" json:"id"`
+	CreatedBy string `bson:"created_by" json:"created_by"`
+	Provider  string `bson:"provider" json:"provider"`
+	// the size of the volume in GiB
+	Size int `bson:"size" json:"size"`
+	// physical location of the volume, which must match the location of
+	// any host it is attached to
+	Project string `bson:"project,omitempty" json:"project,omitempty"`
+	Zone    string `bson:"zone" json:"zone"`
+
+	Status string `bson:"status" json:"status"`
+	// the host the volume is attached to
+	Host string `bson:"host,omitempty" json:"host,omitempty"`
+
+	CreationTime time.Time `bson:"creation_time" json:"creation_time"`
+	DeletionTime time.Time `bson:"deletion_time,omitempty" json:"deletion_time,omitempty"`
+}
+
+var (
+	VolumeIDKey           = bsonutil.MustHaveTag(Volume{}, "ID")
+	VolumeCreatedByKey    = bsonutil.MustHaveTag(Volume{}, "CreatedBy")
+	VolumeSizeGBKey       = bsonutil.MustHaveTag(Volume{}, "Size")
+	VolumeStatusKey       = bsonutil.MustHaveTag(Volume{}, "Status")
+	VolumeHostKey         = bsonutil.MustHaveTag(Volume{}, "Host")
+	VolumeCreationTimeKey = bsonutil.MustHaveTag(Volume{}, "CreationTime")
+	VolumeDeletionTimeKey = bsonutil.MustHaveTag(Volume{}, "DeletionTime")
+)
+
+// Insert inserts the volume into the volumes collection.
+func (v *Volume) Insert() error {
+	return db.Insert(VolumesCollection, v)
+}
+
+// FindVolumeByID returns the volume with the given id, or nil if there is
+// none.
+func FindVolumeByID(id string) (*Volume, error) {
+	v := &Volume{}
+	err := db.FindOneQ(VolumesCollection, db.Query(bson.M{VolumeIDKey: id}), v)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	}
+	return v, errors.Wrapf(err, "error finding volume %s", id)
+}
+
+// FindVolumesByUser returns the volumes the user has created that have not
+// been deleted.
+func FindVolumesByUser(user string) ([]Volume, error) {
+	volumes := []Volume{}
+	err := db.FindAllQ(VolumesCollection, db.Query(bson.M{
+		VolumeCreatedByKey: user,
+		VolumeStatusKey:    bson.M{"$ne": VolumeDeleted},
+	}).Sort([]string{VolumeCreationTimeKey}), &volumes)
+	return volumes, errors.Wrapf(err, "error finding volumes for user %s", user)
+}
+
+// FindHomeVolume returns the user's home volume, or nil if the user does not
+// have one.
+func FindHomeVolume(user string) (*Volume, error) {
+	volumes, err := FindVolumesByUser(user)
+	if err != nil {
+		return nil, err
+	}
+	if len(volumes) == 0 {
+		return nil, nil
+	}
+	return &volumes[0], nil
+}
+
+// TotalVolumeSize returns the combined size in GiB of the volumes the user
+// has created that have not been deleted.
+func TotalVolumeSize(user string) (int, error) {
+	volumes, err := FindVolumesByUser(user)
+	if err != nil {
+		return 0, err
+	}
+	total := 0
+	for _, v := range volumes {
+		total += v.Size
+	}
+	return total, nil
+}
+
+// SetAttached claims the volume for the host. It fails if the volume is not
+// available, so two hosts cannot be given the same volume.
+func (v *Volume) SetAttached(hostID string) error {
+	err := db.Update(VolumesCollection,
+		bson.M{
+			VolumeIDKey:     v.ID,
+			VolumeStatusKey: VolumeAvailable,
+		},
+		bson.M{"$set": bson.M{
+			VolumeStatusKey: VolumeAttached,
+			VolumeHostKey:   hostID,
+		}},
+	)
+	if err == mgo.ErrNotFound {
+		return errors.Errorf("volume %s is not available", v.ID)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "error attaching volume %s", v.ID)
+	}
+	v.Status = VolumeAttached
+	v.Host = hostID
+
+	return nil
+}
+
+// SetDetached marks the volume as no longer attached to a host.
+func (v *Volume) SetDetached() error {
+	err := db.Update(VolumesCollection,
+		bson.M{VolumeIDKey: v.ID},
+		bson.M{
+			"$set":   bson.M{VolumeStatusKey: VolumeAvailable},
+			"$unset": bson.M{VolumeHostKey: 1},
+		},
+	)
+	if err != nil {
+		return errors.Wrapf(err, "error detaching volume %s", v.ID)
+	}
+	v.Status = VolumeAvailable
+	v.Host = ""
+
+	return nil
+}
+
+// SetSize records the volume's new size in GiB.
+func (v *Volume) SetSize(size int) error {
+	err := db.Update(VolumesCollection,
+		bson.M{VolumeIDKey: v.ID},
+		bson.M{"$set": bson.M{VolumeSizeGBKey: size}},
+	)
+	if err != nil {
+		return errors.Wrapf(err, "error setting size of volume %s", v.ID)
+	}
+	v.Size = size
+
+	return nil
+}
+
+// SetDeleted marks the volume as deleted. The record is kept so that the
+// history of a user's volumes can be seen.
+func (v *Volume) SetDeleted() error {
+	now := time.Now()
+	err := db.Update(VolumesCollection,
+		bson.M{
+			VolumeIDKey:     v.ID,
+			VolumeStatusKey: VolumeAvailable,
+		},
+		bson.M{"$set": bson.M{
+			VolumeStatusKey:       VolumeDeleted,
+			VolumeDeletionTimeKey: now,
+		}},
+	)
+	if err == mgo.ErrNotFound {
+		return errors.Errorf("volume %s is not available", v.ID)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "error deleting volume %s", v.ID)
+	}
+	v.Status = VolumeDeleted
+	v.DeletionTime = now
+
+	return nil
+}
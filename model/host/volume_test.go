@@ -0,0 +1,63 @@
+package host
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVolumeLifecycle(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	require.NoError(db.Clear(VolumesCollection))
+
+	now := time.Now()
+	home := &Volume{ID: "v1", CreatedBy: "me", Size: 32, Status: VolumeAvailable, CreationTime: now}
+	other := &Volume{ID: "v2", CreatedBy: "me", Size: 16, Status: VolumeAvailable, CreationTime: now.Add(time.Hour)}
+	require.NoError(home.Insert())
+	require.NoError(other.Insert())
+	require.NoError((&Volume{ID: "v3", CreatedBy: "you", Size: 8, Status: VolumeAvailable}).Insert())
+
+	found, err := FindHomeVolume("me")
+	require.NoError(err)
+	require.NotNil(found)
+	assert.Equal("v1", found.ID)
+	total, err := TotalVolumeSize("me")
+	require.NoError(err)
+	assert.Equal(48, total)
+
+	// a volume can only be attached to one host at a time
+	require.NoError(home.SetAttached("h1"))
+	assert.Error(home.SetAttached("h2"))
+	// and can't be deleted while attached
+	assert.Error(home.SetDeleted())
+	found, err = FindVolumeByID("v1")
+	require.NoError(err)
+	assert.Equal(VolumeAttached, found.Status)
+	assert.Equal("h1", found.Host)
+
+	require.NoError(home.SetDetached())
+	require.NoError(home.SetSize(64))
+	found, err = FindVolumeByID("v1")
+	require.NoError(err)
+	assert.Equal(VolumeAvailable, found.Status)
+	assert.Empty(found.Host)
+	assert.Equal(64, found.Size)
+
+	// deleted volumes don't count toward the user's total
+	require.NoError(other.SetDeleted())
+	volumes, err := FindVolumesByUser("me")
+	require.NoError(err)
+	require.Len(volumes, 1)
+	assert.Equal("v1", volumes[0].ID)
+	total, err = TotalVolumeSize("me")
+	require.NoError(err)
+	assert.Equal(64, total)
+
+	found, err = FindVolumeByID("nonexistent")
+	assert.NoError(err)
+	assert.Nil(found)
+}
@@ -62,15 +62,29 @@ func SetVersionActivation(versionId string, active bool, caller string) error {
 // SetBuildActivation updates the "active" state of this build and all associated tasks.
 // It also updates the task cache for the build document.
 func SetBuildActivation(buildId string, active bool, caller string) error {
+	return setBuildActivation(buildId, active, caller, nil)
+}
+
+// ActivateBuildSkippingTasks activates the build and all of its tasks other
+// than the skipped ones, which are left as they are.
+func ActivateBuildSkippingTasks(buildId string, skipped []string, caller string) error {
+	return setBuildActivation(buildId, true, caller, skipped)
+}
+
+func setBuildActivation(buildId string, active bool, caller string, skipped []string) error {
 	var err error
 
 	// If activating a task, set the ActivatedBy field to be the caller
 	if active {
+		query := bson.M{
+			task.BuildIdKey: buildId,
+			task.StatusKey:  evergreen.TaskUndispatched,
+		}
+		if len(skipped) > 0 {
+			query[task.DisplayNameKey] = bson.M{"$nin": skipped}
+		}
 		_, err = task.UpdateAll(
-			bson.M{
-				task.BuildIdKey: buildId,
-				task.StatusKey:  evergreen.TaskUndispatched,
-			},
+			query,
 			bson.M{"$set": bson.M{task.ActivatedKey: active, task.ActivatedByKey: caller}},
 		)
 	} else {
@@ -122,6 +136,19 @@ func AbortBuild(buildId string, caller string) error {
 // AbortVersion sets the abort flag on all tasks associated with the version which are in an
 // abortable state
 func AbortVersion(versionId, caller string) error {
+	ids, err := abortVersionTasks(versionId)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if len(ids) > 0 {
+		event.LogManyTaskAbortRequests(ids, caller)
+	}
+	return nil
+}
+
+// abortVersionTasks sets the abort flag on the version's abortable tasks
+// and returns the ids of all of the version's tasks.
+func abortVersionTasks(versionId string) ([]string, error) {
 	_, err := task.UpdateAll(
 		bson.M{
 			task.VersionKey: versionId,
@@ -130,16 +157,13 @@ func AbortVersion(versionId, caller string) error {
 		bson.M{"$set": bson.M{task.AbortedKey: true}},
 	)
 	if err != nil {
-		return errors.Wrap(err, "error setting aborted statuses")
+		return nil, errors.Wrap(err, "error setting aborted statuses")
 	}
 	ids, err := task.FindAllTaskIDsFromVersion(versionId)
 	if err != nil {
-		return errors.Wrap(err, "error finding tasks by version id")
+		return nil, errors.Wrap(err, "error finding tasks by version id")
 	}
-	if len(ids) > 0 {
-		event.LogManyTaskAbortRequests(ids, caller)
-	}
-	return nil
+	return ids, nil
 }
 
 func MarkVersionStarted(versionId string, startTime time.Time) error {
@@ -547,6 +571,8 @@ func CreateTasksFromGroup(in BuildVariantTaskUnit, proj *Project) []BuildVariant
 			Distros:         in.Distros,
 			ExecTimeoutSecs: in.ExecTimeoutSecs,
 			Stepback:        in.Stepback,
+			Paths:           in.Paths,
+			IgnorePaths:     in.IgnorePaths,
 		}
 		bvt.Populate(taskMap[t])
 		tasks = append(tasks, bvt)
@@ -49,6 +49,10 @@ type cliIntent struct {
 	// BaseHash is the base hash of the patch.
 	BaseHash string `bson:"base_hash"`
 
+	// Head is the commit that the patch checks out instead of applying
+	// PatchContent, if the patch was created from a branch or commit range.
+	Head *PatchHead `bson:"head,omitempty"`
+
 	// CreatedAt is the time that this intent was stored in the database
 	CreatedAt time.Time `bson:"created_at"`
 
@@ -63,6 +67,12 @@ type cliIntent struct {
 
 	// alias defines the variants and tasks to run this patch on.
 	Alias string `bson:"alias"`
+
+	// LocalBranch is the branch of the user's repository that the patch was
+	// submitted from, and KeepPrevious is whether the patch leaves the
+	// earlier patches from that branch running.
+	LocalBranch  string `bson:"local_branch,omitempty"`
+	KeepPrevious bool   `bson:"keep_previous,omitempty"`
 }
 
 // BSON fields for the patches
@@ -78,21 +88,27 @@ var (
 	cliUserKey          = bsonutil.MustHaveTag(cliIntent{}, "User")
 	cliProjectIDKey     = bsonutil.MustHaveTag(cliIntent{}, "ProjectID")
 	cliBaseHashKey      = bsonutil.MustHaveTag(cliIntent{}, "BaseHash")
+	cliHeadKey          = bsonutil.MustHaveTag(cliIntent{}, "Head")
 	cliCreatedAtKey     = bsonutil.MustHaveTag(cliIntent{}, "CreatedAt")
 	cliProcessedKey     = bsonutil.MustHaveTag(cliIntent{}, "Processed")
 	cliProcessedAtKey   = bsonutil.MustHaveTag(cliIntent{}, "ProcessedAt")
 	cliIntentTypeKey    = bsonutil.MustHaveTag(cliIntent{}, "IntentType")
 	cliAliasKey         = bsonutil.MustHaveTag(cliIntent{}, "Alias")
+	cliLocalBranchKey   = bsonutil.MustHaveTag(cliIntent{}, "LocalBranch")
+	cliKeepPreviousKey  = bsonutil.MustHaveTag(cliIntent{}, "KeepPrevious")
 )
 
 func (c *cliIntent) Insert() error {
-	patchFileID := bson.NewObjectId()
-	if err := db.WriteGridFile(GridFSPrefix, patchFileID.Hex(), strings.NewReader(c.PatchContent)); err != nil {
-		return err
+	// patches that check out a commit have no diff to store
+	if c.Head == nil {
+		patchFileID := bson.NewObjectId()
+		if err := db.WriteGridFile(GridFSPrefix, patchFileID.Hex(), strings.NewReader(c.PatchContent)); err != nil {
+			return err
+		}
+		c.PatchFileID = patchFileID
 	}
 
 	c.PatchContent = ""
-	c.PatchFileID = patchFileID
 	c.CreatedAt = time.Now().Round(time.Millisecond)
 
 	if err := db.Insert(IntentCollection, c); err != nil {
@@ -137,6 +153,10 @@ func (g *cliIntent) RequesterIdentity() string {
 
 // NewPatch creates a patch from the intent
 func (c *cliIntent) NewPatch() *Patch {
+	patchFileID := ""
+	if c.Head == nil {
+		patchFileID = c.PatchFileID.Hex()
+	}
 	return &Patch{
 		Description:   c.Description,
 		Author:        c.User,
@@ -146,55 +166,96 @@ func (c *cliIntent) NewPatch() *Patch {
 		BuildVariants: c.BuildVariants,
 		Alias:         c.Alias,
 		Tasks:         c.Tasks,
+		LocalBranch:   c.LocalBranch,
+		KeepPrevious:  c.KeepPrevious,
 		Patches: []ModulePatch{
 			{
 				ModuleName: c.Module,
 				Githash:    c.BaseHash,
 				PatchSet: PatchSet{
-					PatchFileId: c.PatchFileID.Hex(),
+					PatchFileId: patchFileID,
 				},
+				Head: c.Head,
 			},
 		},
 	}
 }
 
-func NewCliIntent(user, project, baseHash, module, patchContent, description string, finalize bool, variants, tasks []string, alias string) (Intent, error) {
-	if user == "" {
-		return nil, errors.New("no user provided")
+func NewCliIntent(user, project, baseHash, module, patchContent, description string, finalize bool, variants, tasks []string, alias, localBranch string, keepPrevious bool) (Intent, error) {
+	if err := validateCliIntent(user, project, baseHash, finalize, variants, tasks, alias); err != nil {
+		return nil, err
 	}
-	if project == "" {
-		return nil, errors.New("no project provided")
-	}
-	if baseHash == "" {
-		return nil, errors.New("no base hash provided")
+
+	return &cliIntent{
+		DocumentID:    bson.NewObjectId().Hex(),
+		IntentType:    CliIntentType,
+		PatchContent:  patchContent,
+		Description:   description,
+		BuildVariants: variants,
+		Tasks:         tasks,
+		User:          user,
+		ProjectID:     project,
+		BaseHash:      baseHash,
+		Finalize:      finalize,
+		Module:        module,
+		Alias:         alias,
+		LocalBranch:   localBranch,
+		KeepPrevious:  keepPrevious,
+	}, nil
+}
+
+// NewCliHeadIntent returns an intent for a patch that checks out the given
+// commit instead of applying a diff to the base hash.
+func NewCliHeadIntent(user, project, baseHash, module string, head PatchHead, description string, finalize bool, variants, tasks []string, alias, localBranch string, keepPrevious bool) (Intent, error) {
+	if err := validateCliIntent(user, project, baseHash, finalize, variants, tasks, alias); err != nil {
+		return nil, err
 	}
-	if finalize {
-		if alias == "" {
-			if len(variants) == 0 {
-				return nil, errors.New("no variants provided")
-			}
-			if len(tasks) == 0 {
-				return nil, errors.New("no tasks provided")
-			}
-		}
+	if err := head.Validate(); err != nil {
+		return nil, err
 	}
 
 	return &cliIntent{
 		DocumentID:    bson.NewObjectId().Hex(),
 		IntentType:    CliIntentType,
-		PatchContent:  patchContent,
 		Description:   description,
 		BuildVariants: variants,
 		Tasks:         tasks,
 		User:          user,
 		ProjectID:     project,
 		BaseHash:      baseHash,
+		Head:          &head,
 		Finalize:      finalize,
 		Module:        module,
 		Alias:         alias,
+		LocalBranch:   localBranch,
+		KeepPrevious:  keepPrevious,
 	}, nil
 }
 
+func validateCliIntent(user, project, baseHash string, finalize bool, variants, tasks []string, alias string) error {
+	if user == "" {
+		return errors.New("no user provided")
+	}
+	if project == "" {
+		return errors.New("no project provided")
+	}
+	if baseHash == "" {
+		return errors.New("no base hash provided")
+	}
+	if finalize {
+		if alias == "" {
+			if len(variants) == 0 {
+				return errors.New("no variants provided")
+			}
+			if len(tasks) == 0 {
+				return errors.New("no tasks provided")
+			}
+		}
+	}
+
+	return nil
+}
+
 func (c *cliIntent) GetAlias() string {
 	return c.Alias
 }
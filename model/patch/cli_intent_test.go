@@ -47,7 +47,7 @@ func (s *CliIntentSuite) SetupTest() {
 }
 
 func (s *CliIntentSuite) TestNewCliIntent() {
-	intent, err := NewCliIntent(s.user, s.projectID, s.hash, s.module, s.patchContent, s.description, true, s.variants, s.tasks, s.alias)
+	intent, err := NewCliIntent(s.user, s.projectID, s.hash, s.module, s.patchContent, s.description, true, s.variants, s.tasks, s.alias, "", false)
 	s.NotNil(intent)
 	s.NoError(err)
 	s.Implements((*Intent)(nil), intent)
@@ -72,7 +72,7 @@ func (s *CliIntentSuite) TestNewCliIntent() {
 	s.Equal(cIntent.DocumentID, intent.ID())
 	s.Equal(s.alias, cIntent.Alias)
 
-	intent, err = NewCliIntent(s.user, s.projectID, s.hash, "", s.patchContent, "", false, []string{}, []string{}, "")
+	intent, err = NewCliIntent(s.user, s.projectID, s.hash, "", s.patchContent, "", false, []string{}, []string{}, "", "", false)
 	s.NotNil(intent)
 	s.NoError(err)
 
@@ -84,35 +84,35 @@ func (s *CliIntentSuite) TestNewCliIntent() {
 	s.Empty(cIntent.Module)
 	s.Empty(cIntent.Alias)
 
-	intent, err = NewCliIntent(s.user, s.projectID, s.hash, s.module, "", s.description, true, s.variants, s.tasks, s.alias)
+	intent, err = NewCliIntent(s.user, s.projectID, s.hash, s.module, "", s.description, true, s.variants, s.tasks, s.alias, "", false)
 	s.NotNil(intent)
 	s.NoError(err)
 }
 
 func (s *CliIntentSuite) TestNewCliIntentRejectsInvalidIntents() {
-	intent, err := NewCliIntent("", s.projectID, s.hash, s.module, s.patchContent, s.description, true, s.variants, s.tasks, s.alias)
+	intent, err := NewCliIntent("", s.projectID, s.hash, s.module, s.patchContent, s.description, true, s.variants, s.tasks, s.alias, "", false)
 	s.Nil(intent)
 	s.Error(err)
 
-	intent, err = NewCliIntent(s.user, "", s.hash, s.module, s.patchContent, s.description, true, s.variants, s.tasks, s.alias)
+	intent, err = NewCliIntent(s.user, "", s.hash, s.module, s.patchContent, s.description, true, s.variants, s.tasks, s.alias, "", false)
 	s.Nil(intent)
 	s.Error(err)
 
-	intent, err = NewCliIntent(s.user, s.projectID, "", s.module, s.patchContent, s.description, true, s.variants, s.tasks, s.alias)
+	intent, err = NewCliIntent(s.user, s.projectID, "", s.module, s.patchContent, s.description, true, s.variants, s.tasks, s.alias, "", false)
 	s.Nil(intent)
 	s.Error(err)
 
-	intent, err = NewCliIntent(s.user, s.projectID, s.hash, s.module, s.patchContent, s.description, true, []string{}, s.tasks, "")
+	intent, err = NewCliIntent(s.user, s.projectID, s.hash, s.module, s.patchContent, s.description, true, []string{}, s.tasks, "", "", false)
 	s.Nil(intent)
 	s.Error(err)
 
-	intent, err = NewCliIntent(s.user, s.projectID, s.hash, s.module, s.patchContent, s.description, true, s.variants, []string{}, "")
+	intent, err = NewCliIntent(s.user, s.projectID, s.hash, s.module, s.patchContent, s.description, true, s.variants, []string{}, "", "", false)
 	s.Nil(intent)
 	s.Error(err)
 }
 
 func (s *CliIntentSuite) TestFindIntentSpecifically() {
-	intent, err := NewCliIntent(s.user, s.projectID, s.hash, s.module, "", s.description, true, s.variants, s.tasks, s.alias)
+	intent, err := NewCliIntent(s.user, s.projectID, s.hash, s.module, "", s.description, true, s.variants, s.tasks, s.alias, "", false)
 	s.NoError(err)
 	s.NotNil(intent)
 	s.NoError(intent.Insert())
@@ -126,7 +126,7 @@ func (s *CliIntentSuite) TestFindIntentSpecifically() {
 }
 
 func (s *CliIntentSuite) TestInsert() {
-	intent, err := NewCliIntent(s.user, s.projectID, s.hash, s.module, s.patchContent, s.description, true, s.variants, s.tasks, s.alias)
+	intent, err := NewCliIntent(s.user, s.projectID, s.hash, s.module, s.patchContent, s.description, true, s.variants, s.tasks, s.alias, "", false)
 	s.NoError(err)
 	s.NotNil(intent)
 
@@ -140,7 +140,7 @@ func (s *CliIntentSuite) TestInsert() {
 }
 
 func (s *CliIntentSuite) TestSetProcessed() {
-	intent, err := NewCliIntent(s.user, s.projectID, s.hash, s.module, s.patchContent, s.description, true, s.variants, s.tasks, s.alias)
+	intent, err := NewCliIntent(s.user, s.projectID, s.hash, s.module, s.patchContent, s.description, true, s.variants, s.tasks, s.alias, "", false)
 	s.NoError(err)
 	s.NotNil(intent)
 	s.NoError(intent.Insert())
@@ -166,7 +166,7 @@ func findCliIntents(processed bool) ([]*cliIntent, error) {
 }
 
 func (s *CliIntentSuite) TestNewPatch() {
-	intent, err := NewCliIntent(s.user, s.projectID, s.hash, s.module, s.patchContent, s.description, true, s.variants, s.tasks, s.alias)
+	intent, err := NewCliIntent(s.user, s.projectID, s.hash, s.module, s.patchContent, s.description, true, s.variants, s.tasks, s.alias, "feature", true)
 	s.NoError(err)
 	s.NotNil(intent)
 
@@ -193,4 +193,40 @@ func (s *CliIntentSuite) TestNewPatch() {
 	s.Empty(patchDoc.PatchedConfig)
 	s.Equal(s.alias, patchDoc.Alias)
 	s.Zero(patchDoc.GithubPatchData)
+	s.Equal("feature", patchDoc.LocalBranch)
+	s.True(patchDoc.KeepPrevious)
+}
+
+func (s *CliIntentSuite) TestNewCliHeadIntent() {
+	head := PatchHead{Owner: "me", Repo: "fork", Ref: "feature", HeadHash: "0123456789abcdef0123456789abcdef01234567"}
+	intent, err := NewCliHeadIntent(s.user, s.projectID, s.hash, s.module, head, s.description, true, s.variants, s.tasks, s.alias, "", false)
+	s.NoError(err)
+	s.Require().NotNil(intent)
+	s.NoError(intent.Insert())
+
+	// no patch file is stored
+	cIntent, ok := intent.(*cliIntent)
+	s.True(ok)
+	s.Empty(cIntent.PatchFileID)
+
+	found, err := FindIntent(intent.ID(), intent.GetType())
+	s.NoError(err)
+	s.Require().NotNil(found)
+
+	patchDoc := found.NewPatch()
+	s.Require().Len(patchDoc.Patches, 1)
+	s.Equal(s.hash, patchDoc.Patches[0].Githash)
+	s.Empty(patchDoc.Patches[0].PatchSet.PatchFileId)
+	s.Equal(&head, patchDoc.Patches[0].Head)
+
+	_, err = NewCliHeadIntent(s.user, s.projectID, s.hash, s.module, PatchHead{Ref: "feature"}, s.description, true, s.variants, s.tasks, s.alias, "", false)
+	s.Error(err)
+	_, err = NewCliHeadIntent(s.user, s.projectID, s.hash, s.module, PatchHead{Owner: "me", HeadHash: head.HeadHash}, s.description, true, s.variants, s.tasks, s.alias, "", false)
+	s.Error(err)
+	_, err = NewCliHeadIntent(s.user, s.projectID, s.hash, s.module, PatchHead{HeadHash: "abcdef"}, s.description, true, s.variants, s.tasks, s.alias, "", false)
+	s.Error(err)
+	_, err = NewCliHeadIntent(s.user, s.projectID, s.hash, s.module, PatchHead{Owner: "me", Repo: "fork'; reboot; '", HeadHash: head.HeadHash}, s.description, true, s.variants, s.tasks, s.alias, "", false)
+	s.Error(err)
+	_, err = NewCliHeadIntent(s.user, s.projectID, "", s.module, head, s.description, true, s.variants, s.tasks, s.alias, "", false)
+	s.Error(err)
 }
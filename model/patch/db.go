@@ -3,6 +3,7 @@ package patch
 import (
 	"time"
 
+	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/mongodb/anser/bsonutil"
 	"gopkg.in/mgo.v2"
@@ -35,6 +36,8 @@ var (
 	ActivatedKey       = bsonutil.MustHaveTag(Patch{}, "Activated")
 	PatchedConfigKey   = bsonutil.MustHaveTag(Patch{}, "PatchedConfig")
 	githubPatchDataKey = bsonutil.MustHaveTag(Patch{}, "GithubPatchData")
+	LocalBranchKey     = bsonutil.MustHaveTag(Patch{}, "LocalBranch")
+	KeepPreviousKey    = bsonutil.MustHaveTag(Patch{}, "KeepPrevious")
 
 	// BSON fields for the module patch struct
 	ModulePatchNameKey    = bsonutil.MustHaveTag(ModulePatch{}, "ModuleName")
@@ -207,3 +210,23 @@ func ByGithubPRAndCreatedBefore(t time.Time, owner, repo string, prNumber int) d
 		bsonutil.GetDottedKeyName(githubPatchDataKey, githubPatchPRNumberKey):  prNumber,
 	})
 }
+
+// SupersededBy returns a query for the patches that the given patch from
+// the command line supersedes: the finalized, unfinished patches created
+// before it by the same author from the same branch for the same project.
+// It returns false if the patch can't supersede any. Pull requests' earlier
+// patches are aborted when a newer one is created instead.
+func SupersededBy(p *Patch) (db.Q, bool) {
+	if p.IsGithubPRPatch() || p.LocalBranch == "" {
+		return db.Q{}, false
+	}
+	return db.Query(bson.M{
+		IdKey:          bson.M{"$ne": p.Id},
+		CreateTimeKey:  bson.M{"$lt": p.CreateTime},
+		ActivatedKey:   true,
+		StatusKey:      bson.M{"$in": []string{evergreen.PatchCreated, evergreen.PatchStarted}},
+		ProjectKey:     p.Project,
+		AuthorKey:      p.Author,
+		LocalBranchKey: p.LocalBranch,
+	}), true
+}
@@ -2,10 +2,12 @@ package patch
 
 import (
 	"io/ioutil"
+	"regexp"
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
+	"github.com/pkg/errors"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 )
@@ -46,8 +48,19 @@ type Patch struct {
 	PatchedConfig   string         `bson:"patched_config"`
 	Alias           string         `bson:"alias"`
 	GithubPatchData GithubPatch    `bson:"github_patch_data,omitempty"`
+
+	// LocalBranch is the branch of the author's repository that a patch
+	// from the command line was submitted from.
+	LocalBranch string `bson:"local_branch,omitempty"`
+	// KeepPrevious, if true, exempts the patch from aborting the earlier
+	// patches it supersedes.
+	KeepPrevious bool `bson:"keep_previous,omitempty"`
 }
 
+// KeepPreviousPatchesLabel is the pull request label that exempts the pull
+// request's new patches from aborting its earlier ones.
+const KeepPreviousPatchesLabel = "evergreen-keep-previous"
+
 // GithubPatch stores patch data for patches create from GitHub pull requests
 type GithubPatch struct {
 	PRNumber   int    `bson:"pr_number"`
@@ -63,9 +76,53 @@ type GithubPatch struct {
 
 // ModulePatch stores request details for a patch
 type ModulePatch struct {
-	ModuleName string   `bson:"name"`
-	Githash    string   `bson:"githash"`
-	PatchSet   PatchSet `bson:"patch_set"`
+	ModuleName string     `bson:"name"`
+	Githash    string     `bson:"githash"`
+	PatchSet   PatchSet   `bson:"patch_set"`
+	Head       *PatchHead `bson:"head,omitempty"`
+}
+
+// PatchHead is a commit that a patch checks out instead of applying a diff,
+// for changes that diffs can't carry, like binary files and renames. The
+// commit is on the repository of the project or module, or on a fork of it
+// if Owner and Repo are set. The patch's Githash is the base that the commit
+// is compared to.
+type PatchHead struct {
+	Owner string `bson:"owner,omitempty"`
+	Repo  string `bson:"repo,omitempty"`
+	// Ref is the branch or commit that the patch was created from, and
+	// HeadHash is the commit that it referred to.
+	Ref      string `bson:"ref"`
+	HeadHash string `bson:"head_hash"`
+}
+
+var (
+	headHashRegexp   = regexp.MustCompile("^[0-9a-fA-F]{40}$")
+	githubNameRegexp = regexp.MustCompile("^[A-Za-z0-9._-]+$")
+)
+
+// IsFork returns true if the commit is on a fork of the tracked repository.
+func (h *PatchHead) IsFork() bool {
+	return h.Owner != "" && h.Repo != ""
+}
+
+// Validate returns an error if the head isn't a full commit hash, on the
+// tracked repository or on a fork given by both its owner and repo. The
+// hash, owner, and repo end up in the commands that check out the commit.
+func (h *PatchHead) Validate() error {
+	if h.HeadHash == "" {
+		return errors.New("no head hash provided")
+	}
+	if !headHashRegexp.MatchString(h.HeadHash) {
+		return errors.Errorf("head hash '%s' is not a full commit hash", h.HeadHash)
+	}
+	if (h.Owner == "") != (h.Repo == "") {
+		return errors.New("a fork needs both an owner and a repo")
+	}
+	if h.IsFork() && (!githubNameRegexp.MatchString(h.Owner) || !githubNameRegexp.MatchString(h.Repo)) {
+		return errors.Errorf("'%s/%s' is not a valid repository", h.Owner, h.Repo)
+	}
+	return nil
 }
 
 // PatchSet stores information about the actual patch
@@ -253,6 +310,21 @@ func (p *Patch) ConfigChanged(remotePath string) bool {
 	return false
 }
 
+// FilesChanged returns the names of the files in the project's repository
+// that the patch changes, not including changes to modules.
+func (p *Patch) FilesChanged() []string {
+	files := []string{}
+	for _, patchPart := range p.Patches {
+		if patchPart.ModuleName != "" {
+			continue
+		}
+		for _, summary := range patchPart.PatchSet.Summary {
+			files = append(files, summary.Name)
+		}
+	}
+	return files
+}
+
 // SetActivated sets the patch to activated in the db
 func (p *Patch) SetActivated(versionId string) error {
 	p.Version = versionId
@@ -287,7 +359,7 @@ func (p *Patch) SetActivation(activated bool) error {
 func (p *Patch) UpdateModulePatch(modulePatch ModulePatch) error {
 	// check that a patch for this module exists
 	query := bson.M{
-		IdKey: p.Id,
+		IdKey:                                 p.Id,
 		PatchesKey + "." + ModulePatchNameKey: modulePatch.ModuleName,
 	}
 	update := bson.M{
@@ -328,3 +400,34 @@ func (p *Patch) RemoveModulePatch(moduleName string) error {
 func (p *Patch) IsGithubPRPatch() bool {
 	return p.GithubPatchData.PRNumber != 0
 }
+
+// GetHead returns the commit that the patch checks out in the project's
+// repository instead of applying a diff, or nil if it applies a diff.
+func (p *Patch) GetHead() *PatchHead {
+	for _, patchPart := range p.Patches {
+		if patchPart.ModuleName == "" {
+			return patchPart.Head
+		}
+	}
+	return nil
+}
+
+// MergeableHead returns the commit that the patch checks out in the
+// project's repository, which the commit queue can merge into the project's
+// branch. Patches that apply a diff, check out a commit on a fork, or change
+// modules can't be merged.
+func (p *Patch) MergeableHead() (*PatchHead, error) {
+	for _, patchPart := range p.Patches {
+		if patchPart.ModuleName != "" {
+			return nil, errors.Errorf("patch changes module '%s'", patchPart.ModuleName)
+		}
+	}
+	head := p.GetHead()
+	if head == nil {
+		return nil, errors.New("patch applies a diff instead of checking out a pushed commit")
+	}
+	if head.IsFork() {
+		return nil, errors.Errorf("patch checks out a commit on fork '%s/%s'", head.Owner, head.Repo)
+	}
+	return head, nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/evergreen-ci/evergreen/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
+	"gopkg.in/mgo.v2/bson"
 )
 
 func TestConfigChanged(t *testing.T) {
@@ -32,6 +33,83 @@ func TestConfigChanged(t *testing.T) {
 	assert.False(p.ConfigChanged(remoteConfigPath))
 }
 
+func TestFilesChanged(t *testing.T) {
+	p := &Patch{
+		Patches: []ModulePatch{
+			{
+				PatchSet: PatchSet{
+					Summary: []Summary{{Name: "src/a.go"}, {Name: "README.md"}},
+				},
+			},
+			{
+				ModuleName: "enterprise",
+				PatchSet: PatchSet{
+					Summary: []Summary{{Name: "src/b.go"}},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"src/a.go", "README.md"}, p.FilesChanged())
+}
+
+func TestGetHead(t *testing.T) {
+	assert := assert.New(t)
+	head := &PatchHead{Ref: "feature", HeadHash: "abcdef"}
+	p := &Patch{
+		Patches: []ModulePatch{
+			{ModuleName: "enterprise", Head: &PatchHead{Ref: "other", HeadHash: "123456"}},
+		},
+	}
+	assert.Nil(p.GetHead())
+
+	p.Patches = append(p.Patches, ModulePatch{Head: head})
+	assert.Equal(head, p.GetHead())
+	assert.False(p.GetHead().IsFork())
+
+	head.Owner = "me"
+	head.Repo = "fork"
+	assert.True(p.GetHead().IsFork())
+}
+
+func TestMergeableHead(t *testing.T) {
+	assert := assert.New(t)
+	head := &PatchHead{Ref: "feature", HeadHash: "a4aa03d0472d8503380479b76aef96c044182822"}
+	p := &Patch{Patches: []ModulePatch{{PatchSet: PatchSet{Patch: "diff"}}}}
+	_, err := p.MergeableHead()
+	assert.Error(err)
+
+	p.Patches[0].Head = head
+	mergeable, err := p.MergeableHead()
+	assert.NoError(err)
+	assert.Equal(head, mergeable)
+
+	p.Patches = append(p.Patches, ModulePatch{ModuleName: "enterprise", Head: head})
+	_, err = p.MergeableHead()
+	assert.Error(err)
+
+	p.Patches = p.Patches[:1]
+	head.Owner = "me"
+	head.Repo = "fork"
+	_, err = p.MergeableHead()
+	assert.Error(err)
+}
+
+func TestPatchHeadValidate(t *testing.T) {
+	assert := assert.New(t)
+	hash := "a4aa03d0472d8503380479b76aef96c044182822"
+
+	assert.NoError((&PatchHead{Ref: "feature", HeadHash: hash}).Validate())
+	assert.NoError((&PatchHead{Owner: "me", Repo: "my-fork.go_1", HeadHash: hash}).Validate())
+
+	assert.Error((&PatchHead{Ref: "feature"}).Validate())
+	assert.Error((&PatchHead{HeadHash: "a4aa03d"}).Validate())
+	assert.Error((&PatchHead{HeadHash: hash + "'; rm -rf /; '"}).Validate())
+	assert.Error((&PatchHead{Owner: "me", HeadHash: hash}).Validate())
+	assert.Error((&PatchHead{Owner: "me", Repo: "fork.git' && curl evil", HeadHash: hash}).Validate())
+	assert.Error((&PatchHead{Owner: "me/other", Repo: "fork", HeadHash: hash}).Validate())
+}
+
 type patchSuite struct {
 	suite.Suite
 	testConfig *evergreen.Settings
@@ -132,3 +210,49 @@ func (s *patchSuite) TestByGithubPRAndCreatedBefore() {
 	s.NoError(err)
 	s.Len(patches, 1)
 }
+
+func TestSupersededBy(t *testing.T) {
+	assert := assert.New(t)
+	db.SetGlobalSessionProvider(testutil.TestConfig().SessionFactory())
+	assert.NoError(db.ClearCollections(Collection))
+
+	now := time.Now()
+	pr := GithubPatch{PRNumber: 9001, BaseOwner: "evergreen-ci", BaseRepo: "evergreen"}
+	patches := []*Patch{
+		// superseded
+		{Id: bson.NewObjectId(), CreateTime: now.Add(-time.Hour), Activated: true, Status: evergreen.PatchStarted, GithubPatchData: pr},
+		// already finished
+		{Id: bson.NewObjectId(), CreateTime: now.Add(-time.Hour), Activated: true, Status: evergreen.PatchFailed, GithubPatchData: pr},
+		// never finalized
+		{Id: bson.NewObjectId(), CreateTime: now.Add(-time.Hour), Status: evergreen.PatchCreated, GithubPatchData: pr},
+		// a different pull request
+		{Id: bson.NewObjectId(), CreateTime: now.Add(-time.Hour), Activated: true, Status: evergreen.PatchStarted,
+			GithubPatchData: GithubPatch{PRNumber: 9002, BaseOwner: "evergreen-ci", BaseRepo: "evergreen"}},
+		// the superseding patch
+		{Id: bson.NewObjectId(), CreateTime: now, Activated: true, Status: evergreen.PatchCreated, GithubPatchData: pr},
+		// superseded from the command line
+		{Id: bson.NewObjectId(), CreateTime: now.Add(-time.Hour), Activated: true, Status: evergreen.PatchCreated,
+			Project: "mci", Author: "me", LocalBranch: "feature"},
+		// another user's branch with the same name
+		{Id: bson.NewObjectId(), CreateTime: now.Add(-time.Hour), Activated: true, Status: evergreen.PatchCreated,
+			Project: "mci", Author: "you", LocalBranch: "feature"},
+	}
+	for _, p := range patches {
+		assert.NoError(p.Insert())
+	}
+
+	// pull requests' patches are aborted when they're created instead
+	_, ok := SupersededBy(patches[4])
+	assert.False(ok)
+
+	query, ok := SupersededBy(&Patch{Id: bson.NewObjectId(), CreateTime: now, Project: "mci", Author: "me", LocalBranch: "feature"})
+	assert.True(ok)
+	found, err := Find(query)
+	assert.NoError(err)
+	if assert.Len(found, 1) {
+		assert.Equal(patches[5].Id, found[0].Id)
+	}
+
+	_, ok = SupersededBy(&Patch{Id: bson.NewObjectId(), CreateTime: now, Project: "mci", Author: "me"})
+	assert.False(ok)
+}
@@ -11,7 +11,9 @@ import (
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/version"
 	"github.com/evergreen-ci/evergreen/subprocess"
 	"github.com/evergreen-ci/evergreen/thirdparty"
@@ -21,6 +23,7 @@ import (
 	"github.com/mongodb/grip/message"
 	"github.com/mongodb/grip/send"
 	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
 )
 
 type TaskVariantPairs struct {
@@ -106,9 +109,24 @@ func IncludePatchDependencies(project *Project, tvpairs []TVPair) []TVPair {
 
 // MakePatchedConfig takes in the path to a remote configuration a stringified version
 // of the current project and returns an unmarshalled version of the project
-// with the patch applied
-func MakePatchedConfig(ctx context.Context, p *patch.Patch, remoteConfigPath, projectConfig string) (
-	*Project, error) {
+// with the patch applied. Files the configuration includes are read with the
+// given fetcher.
+func MakePatchedConfig(ctx context.Context, p *patch.Patch, remoteConfigPath, projectConfig string,
+	includes IncludeFetcher) (*Project, error) {
+	data, err := MakePatchedFile(ctx, p, remoteConfigPath, projectConfig)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	project := &Project{}
+	if err = LoadProjectWithIncludes(ctx, data, p.Project, includes, project); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return project, nil
+}
+
+// MakePatchedFile takes in the path to a file in the project's repository
+// and its current contents and returns its contents with the patch applied.
+func MakePatchedFile(ctx context.Context, p *patch.Patch, remotePath, contents string) ([]byte, error) {
 	for _, patchPart := range p.Patches {
 		// we only need to patch the main project and not any other modules
 		if patchPart.ModuleName != "" {
@@ -141,10 +159,10 @@ func MakePatchedConfig(ctx context.Context, p *patch.Patch, remoteConfigPath, pr
 		}
 
 		defer os.Remove(patchFilePath) //nolint: evg
-		// write project configuration
-		configFilePath, err := util.WriteToTempFile(projectConfig)
+		// write the current file
+		configFilePath, err := util.WriteToTempFile(contents)
 		if err != nil {
-			return nil, errors.Wrap(err, "could not write config file")
+			return nil, errors.Wrap(err, "could not write file")
 		}
 		defer os.Remove(configFilePath) //nolint: evg
 
@@ -152,10 +170,10 @@ func MakePatchedConfig(ctx context.Context, p *patch.Patch, remoteConfigPath, pr
 		workingDirectory := filepath.Dir(patchFilePath)
 		localConfigPath := filepath.Join(
 			workingDirectory,
-			remoteConfigPath,
+			remotePath,
 		)
 		parentDir := strings.Split(
-			remoteConfigPath,
+			remotePath,
 			string(os.PathSeparator),
 		)[0]
 		err = os.RemoveAll(filepath.Join(workingDirectory, parentDir))
@@ -165,9 +183,9 @@ func MakePatchedConfig(ctx context.Context, p *patch.Patch, remoteConfigPath, pr
 		if err = os.MkdirAll(filepath.Dir(localConfigPath), 0755); err != nil {
 			return nil, errors.WithStack(err)
 		}
-		// rename the temporary config file name to the remote config
-		// file path if we are patching an existing remote config
-		if len(projectConfig) > 0 {
+		// rename the temporary file to the remote file path if we
+		// are patching an existing remote file
+		if len(contents) > 0 {
 			if err = os.Rename(configFilePath, localConfigPath); err != nil {
 				return nil, errors.Wrapf(err, "could not rename file '%v' to '%v'",
 					configFilePath, localConfigPath)
@@ -175,12 +193,12 @@ func MakePatchedConfig(ctx context.Context, p *patch.Patch, remoteConfigPath, pr
 			defer os.Remove(localConfigPath)
 		}
 
-		// selectively apply the patch to the config file
+		// selectively apply the patch to the file
 		patchCommandStrings := []string{
 			fmt.Sprintf("set -o xtrace"),
 			fmt.Sprintf("set -o errexit"),
 			fmt.Sprintf("git apply --whitespace=fix --include=%v < '%v'",
-				remoteConfigPath, patchFilePath),
+				remotePath, patchFilePath),
 		}
 
 		stderr := send.MakeWriterSender(grip.GetSender(), level.Error)
@@ -203,16 +221,12 @@ func MakePatchedConfig(ctx context.Context, p *patch.Patch, remoteConfigPath, pr
 		if err = patchCmd.Run(ctx); err != nil {
 			return nil, errors.Errorf("could not run patch command: %v", err)
 		}
-		// read in the patched config file
+		// read in the patched file
 		data, err := ioutil.ReadFile(localConfigPath)
 		if err != nil {
-			return nil, errors.Wrap(err, "could not read patched config file")
-		}
-		project := &Project{}
-		if err = LoadProjectInto(data, p.Project, project); err != nil {
-			return nil, errors.WithStack(err)
+			return nil, errors.Wrap(err, "could not read patched file")
 		}
-		return project, nil
+		return data, nil
 	}
 	return nil, errors.New("no patch on project")
 }
@@ -281,6 +295,7 @@ func FinalizePatch(ctx context.Context, p *patch.Patch, requester string, github
 	}
 
 	taskIds := NewPatchTaskIdTable(project, patchVersion, tasks)
+	changedFiles := p.FilesChanged()
 	variantsProcessed := map[string]bool{}
 	for _, vt := range p.VariantsTasks {
 		if _, ok := variantsProcessed[vt.Variant]; ok {
@@ -297,12 +312,19 @@ func FinalizePatch(ctx context.Context, p *patch.Patch, requester string, github
 		if err != nil {
 			return nil, errors.WithStack(err)
 		}
+		// tasks the patch's changes don't activate are created, but left
+		// inactive so they can still be scheduled by hand
+		skipped := project.TasksSkippedByChanges(vt.Variant, changedFiles)
+		if err = deactivateSkippedTasks(buildId, skipped); err != nil {
+			return nil, errors.WithStack(err)
+		}
 		patchVersion.BuildIds = append(patchVersion.BuildIds, buildId)
 		patchVersion.BuildVariants = append(patchVersion.BuildVariants,
 			version.BuildStatus{
 				BuildVariant: vt.Variant,
 				Activated:    true,
 				BuildId:      buildId,
+				SkippedTasks: skipped,
 			},
 		)
 	}
@@ -313,9 +335,72 @@ func FinalizePatch(ctx context.Context, p *patch.Patch, requester string, github
 	if err = p.SetActivated(patchVersion.Id); err != nil {
 		return nil, errors.WithStack(err)
 	}
+
+	if projectRef.AbortSupersededPatches {
+		grip.Error(message.WrapError(AbortSupersededPatches(p), message.Fields{
+			"message":  "problem aborting superseded patches",
+			"patch_id": p.Id.Hex(),
+			"project":  p.Project,
+		}))
+	}
 	return patchVersion, nil
 }
 
+// AbortSupersededPatches cancels the earlier patches from the command line
+// that the patch supersedes, recording the patch in the tasks' abort events.
+// Patches created to keep the earlier ones running don't supersede them.
+func AbortSupersededPatches(p *patch.Patch) error {
+	if p.KeepPrevious {
+		return nil
+	}
+	query, ok := patch.SupersededBy(p)
+	if !ok {
+		return nil
+	}
+	patches, err := patch.Find(query)
+	if err != nil {
+		return errors.Wrapf(err, "error finding patches superseded by patch %s", p.Id.Hex())
+	}
+
+	catcher := grip.NewSimpleCatcher()
+	for i := range patches {
+		superseded := &patches[i]
+		if superseded.Version == "" {
+			continue
+		}
+		if err = cancelSupersededPatch(superseded, p.Id.Hex()); err != nil {
+			catcher.Add(errors.Wrapf(err, "error aborting patch %s", superseded.Id.Hex()))
+			continue
+		}
+		grip.Info(message.Fields{
+			"message":       "aborted superseded patch",
+			"patch_id":      superseded.Id.Hex(),
+			"superseded_by": p.Id.Hex(),
+			"project":       p.Project,
+		})
+	}
+	return catcher.Resolve()
+}
+
+// deactivateSkippedTasks deactivates the tasks in the build that are
+// skipped because the changes don't touch their paths.
+func deactivateSkippedTasks(buildId string, skipped []string) error {
+	if len(skipped) == 0 {
+		return nil
+	}
+	_, err := task.UpdateAll(
+		bson.M{
+			task.BuildIdKey:     buildId,
+			task.DisplayNameKey: bson.M{"$in": skipped},
+		},
+		bson.M{"$set": bson.M{task.ActivatedKey: false}},
+	)
+	if err != nil {
+		return errors.Wrapf(err, "error deactivating skipped tasks in build %s", buildId)
+	}
+	return errors.WithStack(RefreshTasksCache(buildId))
+}
+
 func CancelPatch(p *patch.Patch, caller string) error {
 	if p.Version != "" {
 		if err := SetVersionActivation(p.Version, false, caller); err != nil {
@@ -327,11 +412,45 @@ func CancelPatch(p *patch.Patch, caller string) error {
 	return errors.WithStack(patch.Remove(patch.ById(p.Id)))
 }
 
+// cancelSupersededPatch deactivates the patch's version and aborts its
+// tasks, recording the superseding patch in the tasks' abort events.
+func cancelSupersededPatch(p *patch.Patch, supersededBy string) error {
+	if err := SetVersionActivation(p.Version, false, evergreen.User); err != nil {
+		return errors.WithStack(err)
+	}
+	ids, err := abortVersionTasks(p.Version)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if len(ids) > 0 {
+		event.LogManyTaskAbortRequestsForSupersededPatch(ids, evergreen.User, supersededBy)
+	}
+	return nil
+}
+
 // AbortPatchesWithGithubPatchData runs CancelPatch on patches created before
 // the given time, with the same pr number, and base repository. Tasks which
 // are abortable (see model/task.IsAbortable()) will be aborted, while
-// dispatched/running/completed tasks will not be affected
-func AbortPatchesWithGithubPatchData(createdBefore time.Time, owner, repo string, prNumber int) error {
+// dispatched/running/completed tasks will not be affected.
+//
+// If supersededBy is not nil, it's the pull request's newer patch. The
+// earlier patches are then aborted only if the project aborts superseded
+// patches and the newer patch wasn't created to keep them running, and the
+// tasks' abort events record the newer patch.
+func AbortPatchesWithGithubPatchData(createdBefore time.Time, owner, repo string, prNumber int, supersededBy *patch.Patch) error {
+	if supersededBy != nil {
+		if supersededBy.KeepPrevious {
+			return nil
+		}
+		projectRef, err := FindOneProjectRef(supersededBy.Project)
+		if err != nil {
+			return errors.Wrapf(err, "error finding project '%s'", supersededBy.Project)
+		}
+		if projectRef == nil || !projectRef.AbortSupersededPatches {
+			return nil
+		}
+	}
+
 	patches, err := patch.Find(patch.ByGithubPRAndCreatedBefore(createdBefore, owner, repo, prNumber))
 	if err != nil {
 		return errors.Wrap(err, "initial patch fetch failed")
@@ -348,7 +467,12 @@ func AbortPatchesWithGithubPatchData(createdBefore time.Time, owner, repo string
 	catcher := grip.NewSimpleCatcher()
 	for i, _ := range patches {
 		if patches[i].Version != "" {
-			if err = CancelPatch(&patches[i], evergreen.GithubPRRequester); err != nil {
+			if supersededBy != nil {
+				err = cancelSupersededPatch(&patches[i], supersededBy.Id.Hex())
+			} else {
+				err = CancelPatch(&patches[i], evergreen.GithubPRRequester)
+			}
+			if err != nil {
 				grip.Error(message.WrapError(err, message.Fields{
 					"source":         "github hook",
 					"created_before": createdBefore.String(),
@@ -12,6 +12,7 @@ import (
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/version"
@@ -19,6 +20,8 @@ import (
 	"github.com/mongodb/grip"
 	. "github.com/smartystreets/goconvey/convey"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/mgo.v2/bson"
 )
 
 func TestMakePatchedConfig(t *testing.T) {
@@ -51,7 +54,7 @@ func TestMakePatchedConfig(t *testing.T) {
 			}
 			projectBytes, err := ioutil.ReadFile(filepath.Join(cwd, "testdata", "project.config"))
 			So(err, ShouldBeNil)
-			project, err := MakePatchedConfig(ctx, p, remoteConfigPath, string(projectBytes))
+			project, err := MakePatchedConfig(ctx, p, remoteConfigPath, string(projectBytes), nil)
 			So(err, ShouldBeNil)
 			So(project, ShouldNotBeNil)
 			So(len(project.Tasks), ShouldEqual, 2)
@@ -70,7 +73,7 @@ func TestMakePatchedConfig(t *testing.T) {
 				}},
 			}
 
-			project, err := MakePatchedConfig(ctx, p, remoteConfigPath, "")
+			project, err := MakePatchedConfig(ctx, p, remoteConfigPath, "", nil)
 			So(err, ShouldBeNil)
 			So(project, ShouldNotBeNil)
 			So(len(project.Tasks), ShouldEqual, 1)
@@ -405,3 +408,118 @@ func TestAddNewPatch(t *testing.T) {
 	assert.Equal(dbTasks[2].DisplayName, "task2")
 	assert.Equal(dbTasks[3].DisplayName, "task3")
 }
+
+func TestAbortSupersededPatches(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	require.NoError(db.ClearCollections(patch.Collection, version.Collection, build.Collection, task.Collection, event.AllLogCollection))
+
+	older := &patch.Patch{
+		Id:          bson.NewObjectId(),
+		Version:     "older",
+		Project:     "project",
+		Author:      "me",
+		LocalBranch: "feature",
+		Activated:   true,
+		Status:      evergreen.PatchStarted,
+		CreateTime:  time.Now().Add(-time.Hour),
+	}
+	require.NoError(older.Insert())
+	require.NoError((&version.Version{Id: "older", BuildIds: []string{"b"}}).Insert())
+	require.NoError((&build.Build{Id: "b", Version: "older", Activated: true}).Insert())
+	tasks := []task.Task{
+		{Id: "running", Version: "older", BuildId: "b", Activated: true, Status: evergreen.TaskStarted},
+		{Id: "waiting", Version: "older", BuildId: "b", Activated: true, Status: evergreen.TaskUndispatched},
+		{Id: "done", Version: "older", BuildId: "b", Activated: true, Status: evergreen.TaskSucceeded},
+	}
+	for _, tsk := range tasks {
+		require.NoError(tsk.Insert())
+	}
+
+	newer := &patch.Patch{
+		Id:           bson.NewObjectId(),
+		Project:      "project",
+		Author:       "me",
+		LocalBranch:  "feature",
+		KeepPrevious: true,
+		CreateTime:   time.Now(),
+	}
+	require.NoError(AbortSupersededPatches(newer))
+	running, err := task.FindOneId("running")
+	require.NoError(err)
+	assert.False(running.Aborted)
+
+	newer.KeepPrevious = false
+	require.NoError(AbortSupersededPatches(newer))
+	running, err = task.FindOneId("running")
+	require.NoError(err)
+	assert.True(running.Aborted)
+	assert.False(running.Activated)
+	waiting, err := task.FindOneId("waiting")
+	require.NoError(err)
+	assert.False(waiting.Activated)
+	done, err := task.FindOneId("done")
+	require.NoError(err)
+	assert.False(done.Aborted)
+
+	events, err := event.Find(event.AllLogCollection, event.TaskEventsForId("running"))
+	require.NoError(err)
+	require.Len(events, 1)
+	assert.Equal(newer.Id.Hex(), events[0].Data.(*event.TaskEventData).SupersededBy)
+}
+
+func TestAbortPatchesWithGithubPatchDataSupersededBy(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	require.NoError(db.ClearCollections(patch.Collection, version.Collection, build.Collection, task.Collection,
+		ProjectRefCollection, event.AllLogCollection))
+
+	projectRef := &ProjectRef{Identifier: "project"}
+	require.NoError(projectRef.Insert())
+	githubPatchData := patch.GithubPatch{BaseOwner: "evergreen-ci", BaseRepo: "evergreen", PRNumber: 1}
+	older := &patch.Patch{
+		Id:              bson.NewObjectId(),
+		Version:         "older",
+		Project:         "project",
+		Activated:       true,
+		Status:          evergreen.PatchStarted,
+		CreateTime:      time.Now().Add(-time.Hour),
+		GithubPatchData: githubPatchData,
+	}
+	require.NoError(older.Insert())
+	require.NoError((&version.Version{Id: "older", BuildIds: []string{"b"}}).Insert())
+	require.NoError((&build.Build{Id: "b", Version: "older", Activated: true}).Insert())
+	require.NoError((&task.Task{Id: "running", Version: "older", BuildId: "b", Activated: true, Status: evergreen.TaskStarted}).Insert())
+
+	newer := &patch.Patch{
+		Id:              bson.NewObjectId(),
+		Project:         "project",
+		CreateTime:      time.Now(),
+		GithubPatchData: githubPatchData,
+	}
+	abort := func() *task.Task {
+		require.NoError(AbortPatchesWithGithubPatchData(newer.CreateTime, "evergreen-ci", "evergreen", 1, newer))
+		running, err := task.FindOneId("running")
+		require.NoError(err)
+		return running
+	}
+
+	// the project doesn't abort superseded patches
+	assert.False(abort().Aborted)
+
+	// the pull request is labeled to keep its earlier patches
+	projectRef.AbortSupersededPatches = true
+	require.NoError(projectRef.Upsert())
+	newer.KeepPrevious = true
+	assert.False(abort().Aborted)
+
+	newer.KeepPrevious = false
+	running := abort()
+	assert.True(running.Aborted)
+	assert.False(running.Activated)
+
+	events, err := event.Find(event.AllLogCollection, event.TaskEventsForId("running"))
+	require.NoError(err)
+	require.Len(events, 1)
+	assert.Equal(newer.Id.Hex(), events[0].Data.(*event.TaskEventData).SupersededBy)
+}
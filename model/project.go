@@ -80,6 +80,12 @@ type BuildVariantTaskUnit struct {
 	// currently unsupported (TODO EVG-578)
 	ExecTimeoutSecs int   `yaml:"exec_timeout_secs,omitempty" bson:"exec_timeout_secs"`
 	Stepback        *bool `yaml:"stepback,omitempty" bson:"stepback,omitempty"`
+
+	// Paths and IgnorePaths limit the changes that activate the task to
+	// those that touch files matching Paths, other than those that only
+	// touch files matching IgnorePaths.
+	Paths       []string `yaml:"paths,omitempty" bson:"paths,omitempty"`
+	IgnorePaths []string `yaml:"ignore_paths,omitempty" bson:"ignore_paths,omitempty"`
 }
 
 func (b BuildVariant) Get(name string) (BuildVariantTaskUnit, error) {
@@ -136,6 +142,12 @@ func (bvt *BuildVariantTaskUnit) Populate(pt ProjectTask) {
 	if bvt.Stepback == nil {
 		bvt.Stepback = pt.Stepback
 	}
+	if len(bvt.Paths) == 0 {
+		bvt.Paths = pt.Paths
+	}
+	if len(bvt.IgnorePaths) == 0 {
+		bvt.IgnorePaths = pt.IgnorePaths
+	}
 }
 
 // UnmarshalYAML allows tasks to be referenced as single selector strings.
@@ -184,6 +196,15 @@ type BuildVariant struct {
 	// provided for the task
 	RunOn []string `yaml:"run_on,omitempty" bson:"run_on"`
 
+	// the name of the variant this variant extends, which has already been
+	// merged into this variant
+	Extends string `yaml:"extends,omitempty" bson:"extends,omitempty"`
+
+	// the changes that activate the variant's tasks, in addition to the
+	// changes that activate each task
+	Paths       []string `yaml:"paths,omitempty" bson:"paths,omitempty"`
+	IgnorePaths []string `yaml:"ignore_paths,omitempty" bson:"ignore_paths,omitempty"`
+
 	// all of the tasks/groups to be run on the build variant, compile through tests.
 	Tasks        []BuildVariantTaskUnit `yaml:"tasks,omitempty" bson:"tasks"`
 	DisplayTasks []DisplayTask          `yaml:"display_tasks,omitempty" bson:"display_tasks,omitempty"`
@@ -337,6 +358,10 @@ type ProjectTask struct {
 	//   3. false = overriding the project setting with false
 	Patchable *bool `yaml:"patchable,omitempty" bson:"patchable,omitempty"`
 	Stepback  *bool `yaml:"stepback,omitempty" bson:"stepback,omitempty"`
+
+	// the changes that activate the task, unless the variant overrides them
+	Paths       []string `yaml:"paths,omitempty" bson:"paths,omitempty"`
+	IgnorePaths []string `yaml:"ignore_paths,omitempty" bson:"ignore_paths,omitempty"`
 }
 
 // TaskIdTable is a map of [variant, task display name]->[task id].
@@ -606,6 +631,53 @@ func populateExpansions(d *distro.Distro, v *version.Version, bv *BuildVariant,
 	return expansions
 }
 
+// PopulateExpansions returns the expansions the given task runs with on the
+// given distro, including the variables of its project that are not private.
+// Private variables are left out, since the expansions are given to users who
+// may not be allowed to see them.
+func PopulateExpansions(t *task.Task, d *distro.Distro) (*util.Expansions, error) {
+	v, err := version.FindOne(version.ById(t.Version))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error finding version for task %s", t.Id)
+	}
+	if v == nil {
+		return nil, errors.Errorf("could not find version %s for task %s", t.Version, t.Id)
+	}
+
+	proj := &Project{}
+	if err = LoadProjectInto([]byte(v.Config), v.Identifier, proj); err != nil {
+		return nil, errors.Wrapf(err, "error loading project for version %s", v.Id)
+	}
+	bv := proj.FindBuildVariant(t.BuildVariant)
+	if bv == nil {
+		return nil, errors.Errorf("could not find build variant %s for task %s", t.BuildVariant, t.Id)
+	}
+
+	var p *patch.Patch
+	if evergreen.IsPatchRequester(v.Requester) {
+		p, err = patch.FindOne(patch.ByVersion(v.Id))
+		if err != nil {
+			return nil, errors.Wrapf(err, "error finding patch for version %s", v.Id)
+		}
+	}
+
+	expansions := populateExpansions(d, v, bv, t, p)
+
+	projectVars, err := FindOneProjectVars(t.Project)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error finding variables for project %s", t.Project)
+	}
+	if projectVars != nil {
+		for k, val := range projectVars.Vars {
+			if !projectVars.PrivateVars[k] {
+				expansions.Put(k, val)
+			}
+		}
+	}
+
+	return expansions, nil
+}
+
 // GetSpecForTask returns a ProjectTask spec for the given name.
 // Returns an empty ProjectTask if none exists.
 func (p Project) GetSpecForTask(name string) ProjectTask {
@@ -974,6 +1046,137 @@ func (p *Project) IgnoresAllFiles(files []string) bool {
 	return true
 }
 
+// changesActivate returns whether the changed files activate something
+// whose activating changes are limited by the given paths and ignored paths:
+// at least one file must match paths, if any are given, and not every file
+// may match ignorePaths. Everything is activated when the changed files
+// aren't known.
+func changesActivate(paths, ignorePaths, files []string) bool {
+	if len(files) == 0 {
+		return true
+	}
+	if len(paths) > 0 {
+		// CompileIgnoreLines has a silly API: it always returns a nil error.
+		matcher, _ := ignore.CompileIgnoreLines(paths...)
+		matched := false
+		for _, f := range files {
+			if matcher.MatchesPath(f) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if len(ignorePaths) > 0 {
+		ignorer, _ := ignore.CompileIgnoreLines(ignorePaths...)
+		for _, f := range files {
+			if !ignorer.MatchesPath(f) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// HasPathFilters returns whether any of the project's variants, tasks or
+// variant tasks limits the changes that activate it.
+func (p *Project) HasPathFilters() bool {
+	for _, t := range p.Tasks {
+		if len(t.Paths) > 0 || len(t.IgnorePaths) > 0 {
+			return true
+		}
+	}
+	for _, bv := range p.BuildVariants {
+		if len(bv.Paths) > 0 || len(bv.IgnorePaths) > 0 {
+			return true
+		}
+		for _, bvt := range bv.Tasks {
+			if len(bvt.Paths) > 0 || len(bvt.IgnorePaths) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// TasksSkippedByChanges returns the names of the tasks in the variant that
+// the changed files don't activate, because of the paths and ignored paths
+// of the variant or of the tasks. Tasks that an activated task depends on,
+// directly or transitively, are never skipped, since the activated task
+// could not run without them.
+func (p *Project) TasksSkippedByChanges(variant string, files []string) []string {
+	bv := p.FindBuildVariant(variant)
+	if bv == nil || len(files) == 0 {
+		return nil
+	}
+
+	order := []TVPair{}
+	skipped := map[TVPair]bool{}
+	for _, v := range p.BuildVariants {
+		variantActivated := changesActivate(v.Paths, v.IgnorePaths, files)
+		for _, bvt := range v.Tasks {
+			units := []BuildVariantTaskUnit{bvt}
+			if p.FindTaskGroup(bvt.Name) != nil {
+				units = CreateTasksFromGroup(bvt, p)
+			} else {
+				units[0].Populate(p.GetSpecForTask(bvt.Name))
+			}
+			for _, unit := range units {
+				pair := TVPair{TaskName: unit.Name, Variant: v.Name}
+				if v.Name == variant {
+					order = append(order, pair)
+				}
+				skipped[pair] = !variantActivated || !changesActivate(unit.Paths, unit.IgnorePaths, files)
+			}
+		}
+	}
+
+	// keep the dependencies of every activated task, following the
+	// dependencies of the tasks that are kept in turn
+	di := &dependencyIncluder{Project: p}
+	queue := []TVPair{}
+	for pair, skip := range skipped {
+		if !skip {
+			queue = append(queue, pair)
+		}
+	}
+	for len(queue) > 0 {
+		pair := queue[0]
+		queue = queue[1:]
+		unit := p.FindTaskForVariant(pair.TaskName, pair.Variant)
+		if unit == nil {
+			continue
+		}
+		// dependencies that are optional in patches are still required
+		// when they're part of the version
+		depends := make([]TaskUnitDependency, 0, len(unit.DependsOn))
+		for _, d := range unit.DependsOn {
+			d.PatchOptional = false
+			depends = append(depends, d)
+		}
+		for _, dep := range di.expandDependencies(pair, depends) {
+			if skipped[dep] {
+				skipped[dep] = false
+				queue = append(queue, dep)
+			}
+		}
+	}
+
+	names := []string{}
+	for _, pair := range order {
+		if skipped[pair] {
+			names = append(names, pair.TaskName)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	return names
+}
+
 func (p *Project) BuildProjectTVPairs(patchDoc *patch.Patch, alias string) {
 	//expand tasks and build variants and include dependencies
 	if len(patchDoc.BuildVariants) == 1 && patchDoc.BuildVariants[0] == "all" {
@@ -1147,6 +1350,31 @@ func (p *Project) BuildProjectTVPairsWithAlias(alias string) ([]TVPair, []TVPair
 	return pairs, displayTaskPairs, err
 }
 
+// TaskVariantPairsForAlias returns the tasks and display tasks of a project
+// alias, along with the execution tasks of the display tasks and the tasks
+// they all depend on.
+func (p *Project) TaskVariantPairsForAlias(alias string) (TaskVariantPairs, error) {
+	pairs, displayTaskPairs, err := p.BuildProjectTVPairsWithAlias(alias)
+	if err != nil {
+		return TaskVariantPairs{}, errors.Wrapf(err, "error getting tasks for alias '%s'", alias)
+	}
+
+	variants := []string{}
+	taskNames := []string{}
+	for _, pair := range append(pairs, displayTaskPairs...) {
+		if !util.StringSliceContains(variants, pair.Variant) {
+			variants = append(variants, pair.Variant)
+		}
+		if !util.StringSliceContains(taskNames, pair.TaskName) {
+			taskNames = append(taskNames, pair.TaskName)
+		}
+	}
+
+	tasks := extractDisplayTasks(pairs, taskNames, variants, p)
+	tasks.ExecTasks = IncludePatchDependencies(p, tasks.ExecTasks)
+	return tasks, nil
+}
+
 // FetchVersionsAndAssociatedBuilds is a helper function to fetch a group of versions and their associated builds.
 // Returns the versions themselves, as well as a map of version id -> the
 // builds that are a part of the version (unsorted).
@@ -0,0 +1,351 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ProjectDiff is the difference between two translated project
+// configurations.
+type ProjectDiff struct {
+	AddedVariants   []string      `json:"added_variants,omitempty"`
+	RemovedVariants []string      `json:"removed_variants,omitempty"`
+	ChangedVariants []VariantDiff `json:"changed_variants,omitempty"`
+	AddedTasks      []string      `json:"added_tasks,omitempty"`
+	RemovedTasks    []string      `json:"removed_tasks,omitempty"`
+	ChangedTasks    []TaskDiff    `json:"changed_tasks,omitempty"`
+}
+
+// VariantDiff is the difference between two definitions of a variant. The
+// distros and dependencies of its tasks are those the variant sets for
+// them, not those inherited from the task definitions.
+type VariantDiff struct {
+	Name              string            `json:"name"`
+	AddedTasks        []string          `json:"added_tasks,omitempty"`
+	RemovedTasks      []string          `json:"removed_tasks,omitempty"`
+	ChangedTasks      []VariantTaskDiff `json:"changed_tasks,omitempty"`
+	AddedDistros      []string          `json:"added_distros,omitempty"`
+	RemovedDistros    []string          `json:"removed_distros,omitempty"`
+	ChangedExpansions []string          `json:"changed_expansions,omitempty"`
+}
+
+// VariantTaskDiff is the difference between two definitions of a task in a
+// variant.
+type VariantTaskDiff struct {
+	Name                string   `json:"name"`
+	AddedDistros        []string `json:"added_distros,omitempty"`
+	RemovedDistros      []string `json:"removed_distros,omitempty"`
+	AddedDependencies   []string `json:"added_dependencies,omitempty"`
+	RemovedDependencies []string `json:"removed_dependencies,omitempty"`
+}
+
+// TaskDiff is the difference between two definitions of a task. Commands
+// is a diff of the task's commands, with function calls expanded, where
+// each line is a command prefixed with "+ " if it was added or "- " if it
+// was removed.
+type TaskDiff struct {
+	Name                string   `json:"name"`
+	AddedDependencies   []string `json:"added_dependencies,omitempty"`
+	RemovedDependencies []string `json:"removed_dependencies,omitempty"`
+	Commands            []string `json:"commands,omitempty"`
+}
+
+// IsEmpty returns true if the projects are the same.
+func (d *ProjectDiff) IsEmpty() bool {
+	return len(d.AddedVariants) == 0 && len(d.RemovedVariants) == 0 && len(d.ChangedVariants) == 0 &&
+		len(d.AddedTasks) == 0 && len(d.RemovedTasks) == 0 && len(d.ChangedTasks) == 0
+}
+
+func (d *VariantDiff) isEmpty() bool {
+	return len(d.AddedTasks) == 0 && len(d.RemovedTasks) == 0 && len(d.ChangedTasks) == 0 &&
+		len(d.AddedDistros) == 0 && len(d.RemovedDistros) == 0 && len(d.ChangedExpansions) == 0
+}
+
+func (d *VariantTaskDiff) isEmpty() bool {
+	return len(d.AddedDistros) == 0 && len(d.RemovedDistros) == 0 &&
+		len(d.AddedDependencies) == 0 && len(d.RemovedDependencies) == 0
+}
+
+func (d *TaskDiff) isEmpty() bool {
+	return len(d.AddedDependencies) == 0 && len(d.RemovedDependencies) == 0 && len(d.Commands) == 0
+}
+
+// DiffProjects returns the difference between two translated projects.
+// Added and changed variants and tasks are in the order of the new project,
+// and removed ones are in the order of the old project.
+func DiffProjects(oldProject, newProject *Project) *ProjectDiff {
+	diff := &ProjectDiff{}
+
+	for _, bv := range newProject.BuildVariants {
+		oldBV := oldProject.FindBuildVariant(bv.Name)
+		if oldBV == nil {
+			diff.AddedVariants = append(diff.AddedVariants, bv.Name)
+			continue
+		}
+		if bvDiff := diffVariants(oldBV, &bv); !bvDiff.isEmpty() {
+			diff.ChangedVariants = append(diff.ChangedVariants, bvDiff)
+		}
+	}
+	for _, bv := range oldProject.BuildVariants {
+		if newProject.FindBuildVariant(bv.Name) == nil {
+			diff.RemovedVariants = append(diff.RemovedVariants, bv.Name)
+		}
+	}
+
+	for _, t := range newProject.Tasks {
+		oldTask := oldProject.FindProjectTask(t.Name)
+		if oldTask == nil {
+			diff.AddedTasks = append(diff.AddedTasks, t.Name)
+			continue
+		}
+		taskDiff := TaskDiff{Name: t.Name}
+		taskDiff.AddedDependencies, taskDiff.RemovedDependencies = diffStringSets(
+			dependencyStrings(oldTask.DependsOn), dependencyStrings(t.DependsOn))
+		taskDiff.Commands = diffLines(
+			expandedCommandStrings(oldTask.Commands, oldProject.Functions),
+			expandedCommandStrings(t.Commands, newProject.Functions))
+		if !taskDiff.isEmpty() {
+			diff.ChangedTasks = append(diff.ChangedTasks, taskDiff)
+		}
+	}
+	for _, t := range oldProject.Tasks {
+		if newProject.FindProjectTask(t.Name) == nil {
+			diff.RemovedTasks = append(diff.RemovedTasks, t.Name)
+		}
+	}
+
+	return diff
+}
+
+func diffVariants(oldBV, newBV *BuildVariant) VariantDiff {
+	diff := VariantDiff{Name: newBV.Name}
+	diff.AddedDistros, diff.RemovedDistros = diffStringSets(oldBV.RunOn, newBV.RunOn)
+
+	keys := map[string]bool{}
+	for k := range oldBV.Expansions {
+		keys[k] = true
+	}
+	for k := range newBV.Expansions {
+		keys[k] = true
+	}
+	for k := range keys {
+		oldValue, inOld := oldBV.Expansions[k]
+		newValue, inNew := newBV.Expansions[k]
+		if inOld != inNew || oldValue != newValue {
+			diff.ChangedExpansions = append(diff.ChangedExpansions, k)
+		}
+	}
+	sort.Strings(diff.ChangedExpansions)
+
+	oldTasks := map[string]BuildVariantTaskUnit{}
+	for _, t := range oldBV.Tasks {
+		oldTasks[t.Name] = t
+	}
+	newTasks := map[string]bool{}
+	for _, t := range newBV.Tasks {
+		newTasks[t.Name] = true
+		oldTask, ok := oldTasks[t.Name]
+		if !ok {
+			diff.AddedTasks = append(diff.AddedTasks, t.Name)
+			continue
+		}
+		taskDiff := VariantTaskDiff{Name: t.Name}
+		taskDiff.AddedDistros, taskDiff.RemovedDistros = diffStringSets(oldTask.Distros, t.Distros)
+		taskDiff.AddedDependencies, taskDiff.RemovedDependencies = diffStringSets(
+			dependencyStrings(oldTask.DependsOn), dependencyStrings(t.DependsOn))
+		if !taskDiff.isEmpty() {
+			diff.ChangedTasks = append(diff.ChangedTasks, taskDiff)
+		}
+	}
+	for _, t := range oldBV.Tasks {
+		if !newTasks[t.Name] {
+			diff.RemovedTasks = append(diff.RemovedTasks, t.Name)
+		}
+	}
+
+	return diff
+}
+
+// diffStringSets returns the strings that are only in the new list and
+// those that are only in the old list.
+func diffStringSets(oldList, newList []string) ([]string, []string) {
+	inOld := map[string]bool{}
+	for _, s := range oldList {
+		inOld[s] = true
+	}
+	inNew := map[string]bool{}
+	for _, s := range newList {
+		inNew[s] = true
+	}
+
+	var added, removed []string
+	for _, s := range newList {
+		if !inOld[s] {
+			added = append(added, s)
+		}
+	}
+	for _, s := range oldList {
+		if !inNew[s] {
+			removed = append(removed, s)
+		}
+	}
+	return added, removed
+}
+
+func dependencyStrings(deps []TaskUnitDependency) []string {
+	out := []string{}
+	for _, d := range deps {
+		qualifiers := []string{}
+		if d.Variant != "" {
+			qualifiers = append(qualifiers, "variant: "+d.Variant)
+		}
+		if d.Status != "" {
+			qualifiers = append(qualifiers, "status: "+d.Status)
+		}
+		if d.PatchOptional {
+			qualifiers = append(qualifiers, "patch_optional")
+		}
+		if len(qualifiers) == 0 {
+			out = append(out, d.Name)
+			continue
+		}
+		out = append(out, fmt.Sprintf("%s (%s)", d.Name, strings.Join(qualifiers, ", ")))
+	}
+	return out
+}
+
+// expandedCommandStrings returns the commands with function calls replaced
+// by the commands in the functions, as the agent runs them, each as a line
+// of JSON.
+func expandedCommandStrings(cmds []PluginCommandConf, fns map[string]*YAMLCommandSet) []string {
+	out := []string{}
+	for _, cmd := range cmds {
+		if cmd.Function == "" {
+			out = append(out, commandString(cmd))
+			continue
+		}
+		fn, ok := fns[cmd.Function]
+		if !ok {
+			out = append(out, commandString(cmd))
+			continue
+		}
+		for _, c := range fn.List() {
+			if c.Type == "" {
+				c.Type = cmd.Type
+			}
+			if c.TimeoutSecs == 0 {
+				c.TimeoutSecs = cmd.TimeoutSecs
+			}
+			if len(cmd.Vars) > 0 {
+				vars := map[string]string{}
+				for k, v := range c.Vars {
+					vars[k] = v
+				}
+				for k, v := range cmd.Vars {
+					vars[k] = v
+				}
+				c.Vars = vars
+			}
+			c.Function = cmd.Function
+			out = append(out, commandString(c))
+		}
+	}
+	return out
+}
+
+func commandString(cmd PluginCommandConf) string {
+	params, _ := jsonCompatible(cmd.Params).(map[string]interface{})
+	out, err := json.Marshal(struct {
+		Function    string                 `json:"func,omitempty"`
+		Command     string                 `json:"command,omitempty"`
+		Type        string                 `json:"type,omitempty"`
+		DisplayName string                 `json:"display_name,omitempty"`
+		Variants    []string               `json:"variants,omitempty"`
+		TimeoutSecs int                    `json:"timeout_secs,omitempty"`
+		Params      map[string]interface{} `json:"params,omitempty"`
+		Vars        map[string]string      `json:"vars,omitempty"`
+	}{
+		Function:    cmd.Function,
+		Command:     cmd.Command,
+		Type:        cmd.Type,
+		DisplayName: cmd.DisplayName,
+		Variants:    cmd.Variants,
+		TimeoutSecs: cmd.TimeoutSecs,
+		Params:      params,
+		Vars:        cmd.Vars,
+	})
+	if err != nil {
+		return fmt.Sprintf("%+v", cmd)
+	}
+	return string(out)
+}
+
+// jsonCompatible converts the maps YAML is unmarshaled into, which have
+// keys of any type, into maps with string keys, so they can be marshaled
+// as JSON.
+func jsonCompatible(in interface{}) interface{} {
+	switch v := in.(type) {
+	case map[string]interface{}:
+		out := map[string]interface{}{}
+		for k, value := range v {
+			out[k] = jsonCompatible(value)
+		}
+		return out
+	case map[interface{}]interface{}:
+		out := map[string]interface{}{}
+		for k, value := range v {
+			out[fmt.Sprint(k)] = jsonCompatible(value)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, value := range v {
+			out[i] = jsonCompatible(value)
+		}
+		return out
+	default:
+		return in
+	}
+}
+
+// diffLines returns the lines that were removed, prefixed with "- ", and
+// added, prefixed with "+ ", to turn the old lines into the new ones, in
+// order, using their longest common subsequence.
+func diffLines(oldLines, newLines []string) []string {
+	// lcs[i][j] is the length of the longest common subsequence of
+	// oldLines[i:] and newLines[j:]
+	lcs := make([][]int, len(oldLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(newLines)+1)
+	}
+	for i := len(oldLines) - 1; i >= 0; i-- {
+		for j := len(newLines) - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var out []string
+	i, j := 0, 0
+	for i < len(oldLines) || j < len(newLines) {
+		switch {
+		case i < len(oldLines) && j < len(newLines) && oldLines[i] == newLines[j]:
+			i++
+			j++
+		case j < len(newLines) && (i == len(oldLines) || lcs[i][j+1] > lcs[i+1][j]):
+			out = append(out, "+ "+newLines[j])
+			j++
+		default:
+			out = append(out, "- "+oldLines[i])
+			i++
+		}
+	}
+	return out
+}
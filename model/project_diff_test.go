@@ -0,0 +1,134 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffProjects(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	oldYml := `
+functions:
+  run:
+    - command: shell.exec
+      params:
+        script: make ${target}
+tasks:
+  - name: compile
+    commands:
+      - func: run
+        vars:
+          target: all
+  - name: test
+    depends_on:
+      - name: compile
+    commands:
+      - command: git.get_project
+      - func: run
+  - name: lint
+buildvariants:
+  - name: linux
+    run_on: [ubuntu]
+    expansions:
+      goos: linux
+    tasks:
+      - name: compile
+      - name: test
+      - name: lint
+  - name: windows
+    run_on: [windows]
+    tasks:
+      - name: compile
+`
+	newYml := `
+functions:
+  run:
+    - command: shell.exec
+      params:
+        script: make ${target}
+    - command: attach.results
+      params:
+        file_location: results.json
+tasks:
+  - name: compile
+    commands:
+      - func: run
+        vars:
+          target: all
+  - name: test
+    depends_on:
+      - name: compile
+        status: "*"
+    commands:
+      - command: git.get_project
+      - func: run
+  - name: docs
+buildvariants:
+  - name: linux
+    run_on: [ubuntu, ubuntu-large]
+    expansions:
+      goos: linux
+      goarch: amd64
+    tasks:
+      - name: compile
+        distros: [ubuntu-large]
+      - name: test
+      - name: docs
+  - name: macos
+    run_on: [macos]
+    tasks:
+      - name: compile
+`
+	oldProject := &Project{}
+	require.NoError(LoadProjectInto([]byte(oldYml), "", oldProject))
+	newProject := &Project{}
+	require.NoError(LoadProjectInto([]byte(newYml), "", newProject))
+
+	diff := DiffProjects(oldProject, newProject)
+	assert.False(diff.IsEmpty())
+	assert.Equal([]string{"macos"}, diff.AddedVariants)
+	assert.Equal([]string{"windows"}, diff.RemovedVariants)
+	assert.Equal([]string{"docs"}, diff.AddedTasks)
+	assert.Equal([]string{"lint"}, diff.RemovedTasks)
+
+	require.Len(diff.ChangedVariants, 1)
+	linux := diff.ChangedVariants[0]
+	assert.Equal("linux", linux.Name)
+	assert.Equal([]string{"ubuntu-large"}, linux.AddedDistros)
+	assert.Empty(linux.RemovedDistros)
+	assert.Equal([]string{"goarch"}, linux.ChangedExpansions)
+	assert.Equal([]string{"docs"}, linux.AddedTasks)
+	assert.Equal([]string{"lint"}, linux.RemovedTasks)
+	require.Len(linux.ChangedTasks, 1)
+	assert.Equal("compile", linux.ChangedTasks[0].Name)
+	assert.Equal([]string{"ubuntu-large"}, linux.ChangedTasks[0].AddedDistros)
+
+	require.Len(diff.ChangedTasks, 2)
+	compile := diff.ChangedTasks[0]
+	assert.Equal("compile", compile.Name)
+	assert.Equal([]string{`+ {"func":"run","command":"attach.results","params":{"file_location":"results.json"},"vars":{"target":"all"}}`},
+		compile.Commands)
+
+	test := diff.ChangedTasks[1]
+	assert.Equal("test", test.Name)
+	assert.Equal([]string{"compile (status: *)"}, test.AddedDependencies)
+	assert.Equal([]string{"compile"}, test.RemovedDependencies)
+	assert.Equal([]string{`+ {"func":"run","command":"attach.results","params":{"file_location":"results.json"}}`},
+		test.Commands)
+
+	assert.True(DiffProjects(newProject, newProject).IsEmpty())
+}
+
+func TestDiffLines(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Empty(diffLines([]string{"a", "b"}, []string{"a", "b"}))
+	assert.Equal([]string{"+ a", "+ b"}, diffLines(nil, []string{"a", "b"}))
+	assert.Equal([]string{"- a", "- b"}, diffLines([]string{"a", "b"}, nil))
+	assert.Equal([]string{"- b", "+ x", "+ d"}, diffLines([]string{"a", "b", "c"}, []string{"a", "x", "c", "d"}))
+	assert.Equal([]string{"- a", "+ a"}, diffLines([]string{"a", "b"}, []string{"b", "a"}))
+}
@@ -0,0 +1,151 @@
+package model
+
+import (
+	"github.com/evergreen-ci/evergreen/util"
+)
+
+// evaluateVariantExtends merges each variant that extends another variant
+// with the variant it extends, and that variant with the one it extends,
+// and so on. Variants that extend a variant that doesn't exist, or that
+// extend themselves through their ancestors, are left as they are, for the
+// validator to report.
+func evaluateVariantExtends(pbvs []parserBV) []parserBV {
+	byName := map[string]int{}
+	for i, pbv := range pbvs {
+		byName[pbv.Name] = i
+	}
+
+	resolved := map[string]bool{}
+	var resolve func(i int, visiting map[string]bool) bool
+	resolve = func(i int, visiting map[string]bool) bool {
+		pbv := &pbvs[i]
+		if pbv.Extends == "" || resolved[pbv.Name] {
+			return true
+		}
+		if visiting[pbv.Name] {
+			return false
+		}
+		parent, ok := byName[pbv.Extends]
+		if !ok {
+			return false
+		}
+		visiting[pbv.Name] = true
+		if !resolve(parent, visiting) {
+			return false
+		}
+		*pbv = mergeParserBV(pbvs[parent], *pbv)
+		resolved[pbv.Name] = true
+		return true
+	}
+
+	for i := range pbvs {
+		resolve(i, map[string]bool{})
+	}
+	return pbvs
+}
+
+// mergeParserBV returns the child variant with the fields it doesn't set
+// taken from the parent variant. Expansions are merged key by key, tasks
+// and display tasks are merged by name, and tags and modules are combined,
+// with the child's values taking precedence. A variant extending a disabled
+// variant isn't disabled itself.
+func mergeParserBV(parent, child parserBV) parserBV {
+	merged := child
+
+	if merged.DisplayName == "" {
+		merged.DisplayName = parent.DisplayName
+	}
+	if len(parent.Expansions) > 0 {
+		merged.Expansions = util.Expansions{}
+		for k, v := range parent.Expansions {
+			merged.Expansions[k] = v
+		}
+		for k, v := range child.Expansions {
+			merged.Expansions[k] = v
+		}
+	}
+	if len(parent.Tags) > 0 {
+		merged.Tags = util.UniqueStrings(append(append([]string{}, parent.Tags...), child.Tags...))
+	}
+	if len(parent.Modules) > 0 {
+		merged.Modules = util.UniqueStrings(append(append([]string{}, parent.Modules...), child.Modules...))
+	}
+	merged.Push = child.Push || parent.Push
+	if merged.BatchTime == nil {
+		merged.BatchTime = parent.BatchTime
+	}
+	if merged.Stepback == nil {
+		merged.Stepback = parent.Stepback
+	}
+	if len(merged.RunOn) == 0 {
+		merged.RunOn = parent.RunOn
+	}
+	if len(merged.DependsOn) == 0 {
+		merged.DependsOn = parent.DependsOn
+	}
+	if len(merged.Requires) == 0 {
+		merged.Requires = parent.Requires
+	}
+	if len(merged.Paths) == 0 {
+		merged.Paths = parent.Paths
+	}
+	if len(merged.IgnorePaths) == 0 {
+		merged.IgnorePaths = parent.IgnorePaths
+	}
+
+	if len(parent.Tasks) > 0 {
+		merged.Tasks = mergeBVTaskUnits(parent.Tasks, child.Tasks)
+	}
+	if len(parent.DisplayTasks) > 0 {
+		merged.DisplayTasks = mergeDisplayTasks(parent.DisplayTasks, child.DisplayTasks)
+	}
+
+	return merged
+}
+
+// mergeBVTaskUnits returns the parent's tasks, replaced by the child's tasks
+// of the same name, followed by the child's other tasks.
+func mergeBVTaskUnits(parent, child parserBVTaskUnits) parserBVTaskUnits {
+	overrides := map[string]parserBVTaskUnit{}
+	for _, t := range child {
+		overrides[t.Name] = t
+	}
+	merged := parserBVTaskUnits{}
+	for _, t := range parent {
+		if override, ok := overrides[t.Name]; ok {
+			t = override
+			delete(overrides, t.Name)
+		}
+		merged = append(merged, t)
+	}
+	for _, t := range child {
+		if _, ok := overrides[t.Name]; ok {
+			merged = append(merged, t)
+		}
+	}
+	return merged
+}
+
+// mergeDisplayTasks returns the parent's display tasks, replaced by the
+// child's display tasks of the same name, followed by the child's other
+// display tasks.
+func mergeDisplayTasks(parent, child []displayTask) []displayTask {
+	overrides := map[string]displayTask{}
+	for _, dt := range child {
+		overrides[dt.Name] = dt
+	}
+	merged := []displayTask{}
+	for _, dt := range parent {
+		if override, ok := overrides[dt.Name]; ok {
+			dt = override
+			delete(overrides, dt.Name)
+		}
+		merged = append(merged, dt)
+	}
+	for _, dt := range child {
+		if _, ok := overrides[dt.Name]; ok {
+			merged = append(merged, dt)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,124 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestVariantExtends(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	yml := `
+tasks:
+  - name: compile
+    tags: [build]
+  - name: test
+  - name: lint
+  - name: integration
+buildvariants:
+  - name: base
+    display_name: Base
+    disabled: true
+    run_on: [linux-distro]
+    modules: [enterprise]
+    tags: [linux]
+    batchtime: 60
+    expansions:
+      compiler: gcc
+      python: /usr/bin/python
+    tasks:
+      - name: ".build"
+      - name: test
+        priority: 1
+    display_tasks:
+      - name: checks
+        execution_tasks: [test]
+  - name: ubuntu
+    display_name: Ubuntu
+    extends: base
+    expansions:
+      python: /opt/python
+    tasks:
+      - name: test
+        priority: 10
+      - name: lint
+  - name: ubuntu-arm
+    extends: ubuntu
+    run_on: [arm-distro]
+    modules: [tools]
+    tasks:
+      - name: integration
+    display_tasks:
+      - name: checks
+        execution_tasks: [test, lint]
+`
+	p := &Project{}
+	require.NoError(LoadProjectInto([]byte(yml), "", p))
+
+	base := p.FindBuildVariant("base")
+	require.NotNil(base)
+	assert.True(base.Disabled)
+
+	ubuntu := p.FindBuildVariant("ubuntu")
+	require.NotNil(ubuntu)
+	assert.Equal("base", ubuntu.Extends)
+	assert.Equal("Ubuntu", ubuntu.DisplayName)
+	assert.False(ubuntu.Disabled)
+	assert.Equal([]string{"linux-distro"}, ubuntu.RunOn)
+	assert.Equal([]string{"enterprise"}, ubuntu.Modules)
+	assert.Equal([]string{"linux"}, ubuntu.Tags)
+	require.NotNil(ubuntu.BatchTime)
+	assert.Equal(60, *ubuntu.BatchTime)
+	assert.Equal("gcc", ubuntu.Expansions["compiler"])
+	assert.Equal("/opt/python", ubuntu.Expansions["python"])
+	require.Len(ubuntu.Tasks, 3)
+	assert.Equal("compile", ubuntu.Tasks[0].Name)
+	assert.Equal("test", ubuntu.Tasks[1].Name)
+	assert.EqualValues(10, ubuntu.Tasks[1].Priority)
+	assert.Equal("lint", ubuntu.Tasks[2].Name)
+	require.Len(ubuntu.DisplayTasks, 1)
+	assert.Equal([]string{"test"}, ubuntu.DisplayTasks[0].ExecutionTasks)
+
+	arm := p.FindBuildVariant("ubuntu-arm")
+	require.NotNil(arm)
+	assert.Equal("Ubuntu", arm.DisplayName)
+	assert.Equal([]string{"arm-distro"}, arm.RunOn)
+	assert.Equal([]string{"enterprise", "tools"}, arm.Modules)
+	assert.Equal("/opt/python", arm.Expansions["python"])
+	require.Len(arm.Tasks, 4)
+	assert.Equal("integration", arm.Tasks[3].Name)
+	require.Len(arm.DisplayTasks, 1)
+	assert.Equal([]string{"test", "lint"}, arm.DisplayTasks[0].ExecutionTasks)
+
+	// evaluating an evaluated project doesn't change it
+	out, err := yaml.Marshal(p)
+	require.NoError(err)
+	p2 := &Project{}
+	require.NoError(LoadProjectInto(out, "", p2))
+	assert.Equal(p.FindBuildVariant("ubuntu-arm").Tasks, p2.FindBuildVariant("ubuntu-arm").Tasks)
+	assert.Equal(p.FindBuildVariant("ubuntu-arm").Modules, p2.FindBuildVariant("ubuntu-arm").Modules)
+}
+
+func TestVariantExtendsCycle(t *testing.T) {
+	yml := `
+buildvariants:
+  - name: a
+    extends: b
+    run_on: [a-distro]
+  - name: b
+    extends: a
+  - name: c
+    extends: nonexistent
+    run_on: [c-distro]
+`
+	p := &Project{}
+	require.NoError(t, LoadProjectInto([]byte(yml), "", p))
+	require.Len(t, p.BuildVariants, 3)
+	assert.Equal(t, []string{"a-distro"}, p.FindBuildVariant("a").RunOn)
+	assert.Empty(t, p.FindBuildVariant("b").RunOn)
+	assert.Equal(t, "nonexistent", p.FindBuildVariant("c").Extends)
+}
@@ -0,0 +1,406 @@
+package model
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
+)
+
+// This file contains the logic for merging the files that a project
+// configuration includes into it. Included files are read with an
+// IncludeFetcher and merged into the intermediate parserProject before
+// selectors and matrices are evaluated, so that tags, dependencies and
+// variants may refer to definitions from any file. The rules are:
+//
+// Functions, tasks, task groups, axes and matrices must be defined in only
+// one file.
+//
+// Build variants with the same name are merged: their tasks and display tasks
+// are combined, and any other field may be set in more than one of the
+// definitions only if every definition sets it to the same value.
+//
+// Modules with the same name must be identical, and ignored files are
+// combined.
+//
+// Any other top level field, like pre, post or stepback, may be set in more
+// than one file only if every file sets it to the same value.
+//
+// Files in the project's repository are read at the same revision as the
+// configuration that includes them. Files in a module are read at the ref the
+// module is pinned to, and the files they include are read from the same
+// module unless they name another one.
+
+// Include names a file whose definitions are merged into the project
+// configuration that includes it.
+type Include struct {
+	FileName string `yaml:"filename,omitempty" bson:"filename"`
+	Module   string `yaml:"module,omitempty" bson:"module"`
+}
+
+// IncludeFetcher returns the contents of a file in the project's repository
+// or, if a module is given, in the module's repository at its pinned ref.
+type IncludeFetcher func(ctx context.Context, module *Module, path string) ([]byte, error)
+
+// LoadProjectWithIncludes loads the raw data from the config file into
+// project, like LoadProjectInto, after merging in the files that it
+// includes, which are read with fetch.
+func LoadProjectWithIncludes(ctx context.Context, data []byte, identifier string, fetch IncludeFetcher, project *Project) error {
+	pp, errs := createIntermediateProject(data)
+	if len(errs) == 0 {
+		errs = resolveIncludes(ctx, pp, fetch)
+	}
+	var p *Project
+	if len(errs) == 0 {
+		p, errs = translateProject(pp)
+	}
+	if len(errs) > 0 {
+		return projectErrors(errs)
+	}
+	*project = *p
+	project.Identifier = identifier
+	return nil
+}
+
+// GithubIncludeFetcher returns an IncludeFetcher that reads files in the
+// given repository at the given revision, and files in modules at their
+// pinned refs, from GitHub.
+func GithubIncludeFetcher(oauthToken, owner, repo, revision string) IncludeFetcher {
+	return func(ctx context.Context, module *Module, path string) ([]byte, error) {
+		fileOwner, fileRepo, fileRevision := owner, repo, revision
+		if module != nil {
+			var err error
+			fileOwner, fileRepo, err = githubRepoFromURL(module.Repo)
+			if err != nil {
+				return nil, errors.Wrapf(err, "can't read files from module '%s'", module.Name)
+			}
+			fileRevision = module.Ref
+		}
+
+		file, err := thirdparty.GetGithubFile(ctx, oauthToken, fileOwner, fileRepo, path, fileRevision)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error fetching '%s/%s'@%s: %s", fileOwner, fileRepo, fileRevision, path)
+		}
+		data, err := base64.StdEncoding.DecodeString(*file.Content)
+		if err != nil {
+			return nil, thirdparty.FileDecodeError{Message: err.Error()}
+		}
+		return data, nil
+	}
+}
+
+// githubRepoFromURL returns the owner and name of a GitHub repository from
+// its SSH or HTTPS URL.
+func githubRepoFromURL(url string) (string, string, error) {
+	path := url
+	for _, prefix := range []string{"git@github.com:", "https://github.com/", "http://github.com/", "ssh://git@github.com/"} {
+		path = strings.TrimPrefix(path, prefix)
+	}
+	parts := strings.Split(strings.TrimSuffix(path, ".git"), "/")
+	if path == url || len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", errors.Errorf("'%s' is not a GitHub repository", url)
+	}
+	return parts[0], parts[1], nil
+}
+
+// projectErrors creates a human-readable error from a list of errors
+// encountered while loading a project.
+func projectErrors(errs []error) error {
+	if len(errs) == 1 {
+		return errors.Errorf("project error: %v", errs[0])
+	}
+	msgs := make([]string, 0, len(errs))
+	for _, e := range errs {
+		msgs = append(msgs, "\n\t"+e.Error())
+	}
+	return errors.Errorf("project errors: %v", strings.Join(msgs, ""))
+}
+
+// includeResolver merges included files into a parserProject.
+type includeResolver struct {
+	fetch   IncludeFetcher
+	project *parserProject
+	// included tracks files that have already been merged, so that a file
+	// included more than once is only merged the first time.
+	included map[string]bool
+	// including tracks the files that are being merged, to detect files
+	// that include themselves.
+	including map[string]bool
+}
+
+// resolveIncludes merges the files that the project includes, and the
+// files those include, into it.
+func resolveIncludes(ctx context.Context, pp *parserProject, fetch IncludeFetcher) []error {
+	if len(pp.Include) == 0 {
+		return nil
+	}
+	if fetch == nil {
+		return []error{errors.New("project includes other files, which can't be read here")}
+	}
+
+	r := &includeResolver{
+		fetch:     fetch,
+		project:   pp,
+		included:  map[string]bool{},
+		including: map[string]bool{"": true},
+	}
+	includes := pp.Include
+	pp.Include = nil
+	return r.resolve(ctx, includes, nil)
+}
+
+func (r *includeResolver) resolve(ctx context.Context, includes []Include, from *Module) []error {
+	errs := []error{}
+	for _, inc := range includes {
+		if inc.FileName == "" {
+			errs = append(errs, errors.New("include must have a filename"))
+			continue
+		}
+
+		module := from
+		if inc.Module != "" {
+			var err error
+			module, err = r.module(inc.Module)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+		}
+
+		name := includeName(module, inc.FileName)
+		if r.including[name] {
+			errs = append(errs, errors.Errorf("%s includes itself", name))
+			continue
+		}
+		if r.included[name] {
+			continue
+		}
+		r.included[name] = true
+
+		data, err := r.fetch(ctx, module, inc.FileName)
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "error reading %s", name))
+			continue
+		}
+		pp, parseErrs := createIntermediateProject(data)
+		if len(parseErrs) > 0 {
+			for _, e := range parseErrs {
+				errs = append(errs, errors.Wrapf(e, "error parsing %s", name))
+			}
+			continue
+		}
+
+		// merge the modules first, so that the files this one includes
+		// may refer to them
+		nested := pp.Include
+		pp.Include = nil
+		errs = append(errs, mergeModules(r.project, pp, name)...)
+
+		r.including[name] = true
+		errs = append(errs, r.resolve(ctx, nested, module)...)
+		delete(r.including, name)
+
+		errs = append(errs, mergeParserProjects(r.project, pp, name)...)
+	}
+	return errs
+}
+
+// module returns the module with the given name, which must be pinned to a
+// ref so that its files are read at a fixed revision.
+func (r *includeResolver) module(name string) (*Module, error) {
+	for i := range r.project.Modules {
+		if r.project.Modules[i].Name == name {
+			m := r.project.Modules[i]
+			if m.Ref == "" {
+				return nil, errors.Errorf("module '%s' must be pinned to a ref to include files from it", name)
+			}
+			return &m, nil
+		}
+	}
+	return nil, errors.Errorf("can't include files from undefined module '%s'", name)
+}
+
+func includeName(module *Module, path string) string {
+	if module == nil {
+		return fmt.Sprintf("'%s'", path)
+	}
+	return fmt.Sprintf("'%s' in module '%s'", path, module.Name)
+}
+
+// mergeModules adds the modules from an included file to the project.
+func mergeModules(dst, src *parserProject, name string) []error {
+	errs := []error{}
+	for _, m := range src.Modules {
+		existing := -1
+		for i := range dst.Modules {
+			if dst.Modules[i].Name == m.Name {
+				existing = i
+				break
+			}
+		}
+		switch {
+		case existing == -1:
+			dst.Modules = append(dst.Modules, m)
+		case !reflect.DeepEqual(dst.Modules[existing], m):
+			errs = append(errs, errors.Errorf("module '%s' in %s conflicts with its existing definition", m.Name, name))
+		}
+	}
+	src.Modules = nil
+	return errs
+}
+
+// mergeParserProjects merges the definitions from an included file into the
+// project according to the rules at the top of this file.
+func mergeParserProjects(dst, src *parserProject, name string) []error {
+	errs := []error{}
+
+	if src.Functions != nil && dst.Functions == nil {
+		dst.Functions = map[string]*YAMLCommandSet{}
+	}
+	for fname, f := range src.Functions {
+		if _, ok := dst.Functions[fname]; ok {
+			errs = append(errs, errors.Errorf("function '%s' in %s is already defined", fname, name))
+			continue
+		}
+		dst.Functions[fname] = f
+	}
+
+	taskNames := map[string]bool{}
+	for _, t := range dst.Tasks {
+		taskNames[t.Name] = true
+	}
+	for _, t := range src.Tasks {
+		// tasks instantiated from templates are checked when they're
+		// instantiated
+		if t.Template == "" && taskNames[t.Name] {
+			errs = append(errs, errors.Errorf("task '%s' in %s is already defined", t.Name, name))
+			continue
+		}
+		dst.Tasks = append(dst.Tasks, t)
+	}
+
+	groupNames := map[string]bool{}
+	for _, tg := range dst.TaskGroups {
+		groupNames[tg.Name] = true
+	}
+	for _, tg := range src.TaskGroups {
+		if groupNames[tg.Name] {
+			errs = append(errs, errors.Errorf("task group '%s' in %s is already defined", tg.Name, name))
+			continue
+		}
+		dst.TaskGroups = append(dst.TaskGroups, tg)
+	}
+
+	templateNames := map[string]bool{}
+	for _, ptt := range dst.TaskTemplates {
+		templateNames[ptt.Name] = true
+	}
+	for _, ptt := range src.TaskTemplates {
+		if templateNames[ptt.Name] {
+			errs = append(errs, errors.Errorf("task template '%s' in %s is already defined", ptt.Name, name))
+			continue
+		}
+		dst.TaskTemplates = append(dst.TaskTemplates, ptt)
+	}
+
+	axisIds := map[string]bool{}
+	for _, a := range dst.Axes {
+		axisIds[a.Id] = true
+	}
+	for _, a := range src.Axes {
+		if axisIds[a.Id] {
+			errs = append(errs, errors.Errorf("axis '%s' in %s is already defined", a.Id, name))
+			continue
+		}
+		dst.Axes = append(dst.Axes, a)
+	}
+
+	for _, bv := range src.BuildVariants {
+		errs = append(errs, mergeBuildVariant(dst, bv, name)...)
+	}
+
+	for _, ignore := range src.Ignore {
+		found := false
+		for _, existing := range dst.Ignore {
+			if existing == ignore {
+				found = true
+				break
+			}
+		}
+		if !found {
+			dst.Ignore = append(dst.Ignore, ignore)
+		}
+	}
+
+	errs = append(errs, mergeFields(reflect.ValueOf(dst).Elem(), reflect.ValueOf(src).Elem(),
+		[]string{"Functions", "Tasks", "TaskTemplates", "TaskGroups", "Axes", "BuildVariants", "Ignore", "Modules", "Include"},
+		fmt.Sprintf("in %s", name))...)
+
+	return errs
+}
+
+// mergeBuildVariant adds a variant from an included file to the project,
+// merging it with an existing variant of the same name.
+func mergeBuildVariant(dst *parserProject, bv parserBV, name string) []error {
+	if bv.matrix != nil {
+		for _, existing := range dst.BuildVariants {
+			if existing.matrix != nil && existing.matrix.Id == bv.matrix.Id {
+				return []error{errors.Errorf("matrix '%s' in %s is already defined", bv.matrix.Id, name)}
+			}
+		}
+		dst.BuildVariants = append(dst.BuildVariants, bv)
+		return nil
+	}
+
+	for i := range dst.BuildVariants {
+		existing := &dst.BuildVariants[i]
+		if existing.matrix != nil || existing.Name != bv.Name {
+			continue
+		}
+		existing.Tasks = append(existing.Tasks, bv.Tasks...)
+		existing.DisplayTasks = append(existing.DisplayTasks, bv.DisplayTasks...)
+		return mergeFields(reflect.ValueOf(existing).Elem(), reflect.ValueOf(&bv).Elem(),
+			[]string{"Name", "Tasks", "DisplayTasks"},
+			fmt.Sprintf("of variant '%s' in %s", bv.Name, name))
+	}
+
+	dst.BuildVariants = append(dst.BuildVariants, bv)
+	return nil
+}
+
+// mergeFields sets each exported field of dst, other than the skipped ones,
+// to the value of the same field in src, unless src doesn't set it. It
+// returns an error for each field that both set to different values.
+func mergeFields(dst, src reflect.Value, skip []string, where string) []error {
+	errs := []error{}
+	t := dst.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" || util.StringSliceContains(skip, field.Name) {
+			continue
+		}
+		srcVal := src.Field(i)
+		dstVal := dst.Field(i)
+		if isZeroValue(srcVal) {
+			continue
+		}
+		if isZeroValue(dstVal) {
+			dstVal.Set(srcVal)
+			continue
+		}
+		if !reflect.DeepEqual(dstVal.Interface(), srcVal.Interface()) {
+			yamlName := strings.Split(field.Tag.Get("yaml"), ",")[0]
+			errs = append(errs, errors.Errorf("'%s' %s conflicts with its existing value", yamlName, where))
+		}
+	}
+	return errs
+}
+
+func isZeroValue(v reflect.Value) bool {
+	return reflect.DeepEqual(v.Interface(), reflect.Zero(v.Type()).Interface())
+}
@@ -0,0 +1,221 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mapIncludeFetcher reads included files from a map keyed by the module
+// name, if any, and the file name.
+func mapIncludeFetcher(files map[string]string) IncludeFetcher {
+	return func(_ context.Context, module *Module, path string) ([]byte, error) {
+		key := path
+		if module != nil {
+			key = module.Name + ":" + path
+		}
+		data, ok := files[key]
+		if !ok {
+			return nil, errors.Errorf("no file %s", key)
+		}
+		return []byte(data), nil
+	}
+}
+
+func TestLoadProjectWithIncludes(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	main := `
+include:
+  - filename: evergreen/tasks.yml
+  - filename: evergreen/variants.yml
+modules:
+  - name: enterprise
+    repo: git@github.com:evergreen-ci/enterprise.git
+    ref: abcdef
+pre:
+  - command: shell.exec
+buildvariants:
+  - name: linux
+    display_name: Linux
+    run_on: [linux-distro]
+    tasks:
+      - name: compile
+`
+	files := map[string]string{
+		"evergreen/tasks.yml": `
+include:
+  - filename: etc/tasks.yml
+    module: enterprise
+functions:
+  fetch:
+    command: git.get_project
+tasks:
+  - name: compile
+    tags: [build]
+    commands:
+      - func: fetch
+  - name: test
+    depends_on:
+      - name: compile
+`,
+		"evergreen/variants.yml": `
+include:
+  - filename: evergreen/tasks.yml
+buildvariants:
+  - name: linux
+    run_on: [linux-distro]
+    tasks:
+      - name: test
+  - name: windows
+    display_name: Windows
+    run_on: [windows-distro]
+    tasks:
+      - name: ".build"
+      - name: enterprise_test
+`,
+		"enterprise:etc/tasks.yml": `
+tasks:
+  - name: enterprise_test
+`,
+	}
+
+	p := &Project{}
+	require.NoError(LoadProjectWithIncludes(context.Background(), []byte(main), "proj", mapIncludeFetcher(files), p))
+	assert.Equal("proj", p.Identifier)
+	assert.Len(p.Functions, 1)
+	assert.NotNil(p.Pre)
+	require.Len(p.Tasks, 3)
+	require.Len(p.BuildVariants, 2)
+
+	linux := p.FindBuildVariant("linux")
+	require.NotNil(linux)
+	assert.Equal("Linux", linux.DisplayName)
+	require.Len(linux.Tasks, 2)
+	assert.Equal("compile", linux.Tasks[0].Name)
+	assert.Equal("test", linux.Tasks[1].Name)
+
+	windows := p.FindBuildVariant("windows")
+	require.NotNil(windows)
+	require.Len(windows.Tasks, 2)
+	assert.Equal("compile", windows.Tasks[0].Name)
+	assert.Equal("enterprise_test", windows.Tasks[1].Name)
+}
+
+func TestLoadProjectWithIncludesConflicts(t *testing.T) {
+	for name, test := range map[string]struct {
+		main     string
+		included string
+		err      string
+	}{
+		"DuplicateTask": {
+			main:     "tasks:\n  - name: compile\n",
+			included: "tasks:\n  - name: compile\n",
+			err:      "task 'compile' in 'other.yml' is already defined",
+		},
+		"DuplicateFunction": {
+			main:     "functions:\n  fetch:\n    command: git.get_project\n",
+			included: "functions:\n  fetch:\n    command: shell.exec\n",
+			err:      "function 'fetch' in 'other.yml' is already defined",
+		},
+		"DuplicateTaskGroup": {
+			main:     "task_groups:\n  - name: tg\n",
+			included: "task_groups:\n  - name: tg\n",
+			err:      "task group 'tg' in 'other.yml' is already defined",
+		},
+		"ConflictingVariantField": {
+			main:     "buildvariants:\n  - name: bv\n    display_name: One\n",
+			included: "buildvariants:\n  - name: bv\n    display_name: Two\n",
+			err:      "'display_name' of variant 'bv' in 'other.yml' conflicts",
+		},
+		"ConflictingTopLevelField": {
+			main:     "exec_timeout_secs: 10\n",
+			included: "exec_timeout_secs: 20\n",
+			err:      "'exec_timeout_secs' in 'other.yml' conflicts",
+		},
+		"ConflictingModule": {
+			main:     "modules:\n  - name: m\n    repo: a\n",
+			included: "modules:\n  - name: m\n    repo: b\n",
+			err:      "module 'm' in 'other.yml' conflicts",
+		},
+		"UnpinnedModule": {
+			main:     "modules:\n  - name: m\n    repo: a\n    branch: master\n",
+			included: "include:\n  - filename: x.yml\n    module: m\n",
+			err:      "module 'm' must be pinned to a ref",
+		},
+		"UndefinedModule": {
+			included: "include:\n  - filename: x.yml\n    module: m\n",
+			err:      "undefined module 'm'",
+		},
+		"Cycle": {
+			included: "include:\n  - filename: other.yml\n",
+			err:      "'other.yml' includes itself",
+		},
+		"MissingFile": {
+			included: "include:\n  - filename: missing.yml\n",
+			err:      "error reading 'missing.yml'",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			main := "include:\n  - filename: other.yml\n" + test.main
+			files := map[string]string{"other.yml": test.included}
+			err := LoadProjectWithIncludes(context.Background(), []byte(main), "", mapIncludeFetcher(files), &Project{})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.err)
+		})
+	}
+}
+
+func TestLoadProjectWithIncludesAllowsMatchingValues(t *testing.T) {
+	main := `
+include:
+  - filename: other.yml
+stepback: true
+modules:
+  - name: m
+    repo: git@github.com:owner/m.git
+    ref: abc
+ignore: ["*.md"]
+`
+	files := map[string]string{"other.yml": `
+stepback: true
+modules:
+  - name: m
+    repo: git@github.com:owner/m.git
+    ref: abc
+ignore: ["*.md", "docs/*"]
+`}
+	p := &Project{}
+	require.NoError(t, LoadProjectWithIncludes(context.Background(), []byte(main), "", mapIncludeFetcher(files), p))
+	assert.True(t, p.Stepback)
+	assert.Len(t, p.Modules, 1)
+	assert.Equal(t, []string{"*.md", "docs/*"}, []string(p.Ignore))
+}
+
+func TestLoadProjectIntoRejectsIncludes(t *testing.T) {
+	err := LoadProjectInto([]byte("include:\n  - filename: other.yml\n"), "", &Project{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "includes other files")
+}
+
+func TestGithubRepoFromURL(t *testing.T) {
+	for _, url := range []string{
+		"git@github.com:evergreen-ci/evergreen.git",
+		"https://github.com/evergreen-ci/evergreen.git",
+		"https://github.com/evergreen-ci/evergreen",
+	} {
+		owner, repo, err := githubRepoFromURL(url)
+		assert.NoError(t, err, url)
+		assert.Equal(t, "evergreen-ci", owner, url)
+		assert.Equal(t, "evergreen", repo, url)
+	}
+
+	for _, url := range []string{"", "git@gitlab.com:a/b.git", "https://github.com/evergreen-ci"} {
+		_, _, err := githubRepoFromURL(url)
+		assert.Error(t, err, url)
+	}
+}
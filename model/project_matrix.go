@@ -519,6 +519,18 @@ func expandParserBVTask(pbvt parserBVTaskUnit, exp util.Expansions) (parserBVTas
 		newReqs = append(newReqs, newReq)
 	}
 	newTask.Requires = newReqs
+	// parameters of task templates can come from the cell's axis values
+	if len(pbvt.Params) > 0 {
+		newTask.Params = map[string]interface{}{}
+		for k, v := range pbvt.Params {
+			if s, ok := v.(string); ok {
+				if v, err = exp.ExpandString(s); err != nil {
+					return parserBVTaskUnit{}, errors.Wrapf(err, "expanding params.%s", k)
+				}
+			}
+			newTask.Params[k] = v
+		}
+	}
 	return newTask, nil
 }
 
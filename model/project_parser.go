@@ -1,7 +1,7 @@
 package model
 
 import (
-	"bytes"
+	"context"
 	"fmt"
 	"reflect"
 
@@ -58,7 +58,9 @@ type parserProject struct {
 	Functions       map[string]*YAMLCommandSet `yaml:"functions,omitempty"`
 	TaskGroups      []parserTaskGroup          `yaml:"task_groups,omitempty"`
 	Tasks           []parserTask               `yaml:"tasks,omitempty"`
+	TaskTemplates   []parserTaskTemplate       `yaml:"task_templates,omitempty"`
 	ExecTimeoutSecs int                        `yaml:"exec_timeout_secs,omitempty"`
+	Include         []Include                  `yaml:"include,omitempty"`
 
 	// Matrix code
 	Axes []matrixAxis `yaml:"axes,omitempty"`
@@ -97,6 +99,12 @@ type parserTask struct {
 	Tags            parserStringSlice   `yaml:"tags,omitempty"`
 	Patchable       *bool               `yaml:"patchable,omitempty"`
 	Stepback        *bool               `yaml:"stepback,omitempty"`
+	Paths           parserStringSlice   `yaml:"paths,omitempty"`
+	IgnorePaths     parserStringSlice   `yaml:"ignore_paths,omitempty"`
+
+	// a task can instead be instantiated from a task template
+	Template string                 `yaml:"template,omitempty"`
+	Params   map[string]interface{} `yaml:"params,omitempty"`
 }
 
 type displayTask struct {
@@ -262,6 +270,9 @@ type parserBV struct {
 	DisplayTasks []displayTask      `yaml:"display_tasks,omitempty"`
 	DependsOn    parserDependencies `yaml:"depends_on,omitempty"`
 	Requires     taskSelectors      `yaml:"requires,omitempty"`
+	Paths        parserStringSlice  `yaml:"paths,omitempty"`
+	IgnorePaths  parserStringSlice  `yaml:"ignore_paths,omitempty"`
+	Extends      string             `yaml:"extends,omitempty"`
 
 	// internal matrix stuff
 	matrixId  string
@@ -314,6 +325,12 @@ type parserBVTaskUnit struct {
 	Stepback        *bool              `yaml:"stepback,omitempty"`
 	Distros         parserStringSlice  `yaml:"distros,omitempty"`
 	RunOn           parserStringSlice  `yaml:"run_on,omitempty"` // Alias for "Distros" TODO: deprecate Distros
+	Paths           parserStringSlice  `yaml:"paths,omitempty"`
+	IgnorePaths     parserStringSlice  `yaml:"ignore_paths,omitempty"`
+
+	// the variant can instead run a task instantiated from a task template
+	Template string                 `yaml:"template,omitempty"`
+	Params   map[string]interface{} `yaml:"params,omitempty"`
 }
 
 // UnmarshalYAML allows the YAML parser to read both a single selector string or
@@ -334,7 +351,7 @@ func (pbvt *parserBVTaskUnit) UnmarshalYAML(unmarshal func(interface{}) error) e
 	if err := unmarshal(&copy); err != nil {
 		return err
 	}
-	if copy.Name == "" {
+	if copy.Name == "" && copy.Template == "" {
 		return errors.New("buildvariant task selector must have a name")
 	}
 	// logic for aliasing the "run_on" field to "distros"
@@ -390,21 +407,12 @@ func (pss *parserStringSlice) UnmarshalYAML(unmarshal func(interface{}) error) e
 
 // LoadProjectInto loads the raw data from the config file into project
 // and sets the project's identifier field to identifier. Tags are evaluateed.
+// Configurations that include other files must be loaded with
+// LoadProjectWithIncludes instead.
 func LoadProjectInto(data []byte, identifier string, project *Project) error {
 	p, errs := projectFromYAML(data) // ignore warnings, for now (TODO)
 	if len(errs) > 0 {
-		// create a human-readable error list
-		buf := bytes.Buffer{}
-		for _, e := range errs {
-			if len(errs) > 1 {
-				buf.WriteString("\n\t") //only newline if we have multiple errs
-			}
-			buf.WriteString(e.Error())
-		}
-		if len(errs) > 1 {
-			return errors.Errorf("project errors: %v", buf.String())
-		}
-		return errors.Errorf("project error: %v", buf.String())
+		return projectErrors(errs)
 	}
 	*project = *p
 	project.Identifier = identifier
@@ -418,6 +426,9 @@ func projectFromYAML(yml []byte) (*Project, []error) {
 	if len(errs) > 0 {
 		return nil, errs
 	}
+	if errs = resolveIncludes(context.Background(), intermediateProject, nil); len(errs) > 0 {
+		return nil, errs
+	}
 	p, errs := translateProject(intermediateProject)
 	return p, errs
 }
@@ -461,14 +472,15 @@ func translateProject(pp *parserProject) (*Project, []error) {
 		Functions:       pp.Functions,
 		ExecTimeoutSecs: pp.ExecTimeoutSecs,
 	}
-	tse := NewParserTaskSelectorEvaluator(pp.Tasks)
-	tgse := newTaskGroupSelectorEvaluator(pp.TaskGroups)
 	ase := NewAxisSelectorEvaluator(pp.Axes)
 	regularBVs, matrices := sieveMatrixVariants(pp.BuildVariants)
 	var evalErrs, errs []error
 	matrixVariants, errs := buildMatrixVariants(pp.Axes, ase, matrices)
 	evalErrs = append(evalErrs, errs...)
-	pp.BuildVariants = append(regularBVs, matrixVariants...)
+	pp.BuildVariants = evaluateVariantExtends(append(regularBVs, matrixVariants...))
+	evalErrs = append(evalErrs, evaluateTaskTemplates(pp)...)
+	tse := NewParserTaskSelectorEvaluator(pp.Tasks)
+	tgse := newTaskGroupSelectorEvaluator(pp.TaskGroups)
 	vse := NewVariantSelectorEvaluator(pp.BuildVariants, ase)
 	proj.Tasks, proj.TaskGroups, errs = evaluateTaskUnits(tse, tgse, vse, pp.Tasks, pp.TaskGroups)
 	evalErrs = append(evalErrs, errs...)
@@ -506,6 +518,8 @@ func evaluateTaskUnits(tse *taskSelectorEvaluator, tgse *tagSelectorEvaluator, v
 			Tags:            pt.Tags,
 			Patchable:       pt.Patchable,
 			Stepback:        pt.Stepback,
+			Paths:           pt.Paths,
+			IgnorePaths:     pt.IgnorePaths,
 		}
 		t.DependsOn, errs = evaluateDependsOn(tse.tagEval, tgse, vse, pt.DependsOn)
 		evalErrs = append(evalErrs, errs...)
@@ -561,6 +575,9 @@ func evaluateBuildVariants(tse *taskSelectorEvaluator, tgse *tagSelectorEvaluato
 			Stepback:    pbv.Stepback,
 			RunOn:       pbv.RunOn,
 			Tags:        pbv.Tags,
+			Paths:       pbv.Paths,
+			IgnorePaths: pbv.IgnorePaths,
+			Extends:     pbv.Extends,
 		}
 		bv.Tasks, errs = evaluateBVTasks(tse, tgse, vse, pbv)
 		// evaluate any rules passed in during matrix construction
@@ -713,6 +730,8 @@ func evaluateBVTasks(tse *taskSelectorEvaluator, tgse *tagSelectorEvaluator, vse
 				ExecTimeoutSecs: pt.ExecTimeoutSecs,
 				Stepback:        pt.Stepback,
 				Distros:         pt.Distros,
+				Paths:           pt.Paths,
+				IgnorePaths:     pt.IgnorePaths,
 			}
 
 			// Task-level dependencies in the variant override variant-level dependencies
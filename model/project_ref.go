@@ -4,8 +4,12 @@ import (
 	"fmt"
 	"math"
 	"net/url"
+	"regexp"
+	"time"
 
+	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/util"
 	"github.com/mongodb/anser/bsonutil"
 	"github.com/pkg/errors"
 	"gopkg.in/mgo.v2"
@@ -51,6 +55,134 @@ type ProjectRef struct {
 	// RepoDetails contain the details of the status of the consistency
 	// between what is in GitHub and what is in Evergreen
 	RepotrackerError *RepositoryErrorDetails `bson:"repotracker_error" json:"repotracker_error"`
+
+	// PeriodicBuilds create versions from the latest tracked revision on a
+	// schedule, whether or not there are new commits.
+	PeriodicBuilds []PeriodicBuildDefinition `bson:"periodic_builds,omitempty" json:"periodic_builds,omitempty"`
+
+	// CommitQueue configures the project's queue of changes that are
+	// tested on the tip of the branch and merged if they pass.
+	CommitQueue CommitQueueParams `bson:"commit_queue" json:"commit_queue"`
+
+	// AutoRetry configures which task failures are known flakes that are
+	// retried automatically rather than failing the task.
+	AutoRetry AutoRetryParams `bson:"auto_retry,omitempty" json:"auto_retry"`
+
+	// AbortSupersededPatches, if true, aborts the unfinished tasks of
+	// earlier patches for the same pull request, or by the same author from
+	// the same branch, when a newer patch is created.
+	AbortSupersededPatches bool `bson:"abort_superseded_patches" json:"abort_superseded_patches"`
+}
+
+const (
+	CommitQueueMergeMethodMerge  = "merge"
+	CommitQueueMergeMethodSquash = "squash"
+	CommitQueueMergeMethodRebase = "rebase"
+)
+
+// CommitQueueParams are the settings of a project's commit queue. Changes
+// are tested with the variants and tasks of the patch alias, and pull
+// requests are merged with the merge method.
+type CommitQueueParams struct {
+	Enabled     bool   `bson:"enabled" json:"enabled"`
+	MergeMethod string `bson:"merge_method,omitempty" json:"merge_method,omitempty"`
+	PatchAlias  string `bson:"patch_alias,omitempty" json:"patch_alias,omitempty"`
+}
+
+// Validate checks that an enabled commit queue has an alias to test changes
+// with, and that the merge method is one GitHub supports.
+func (p *CommitQueueParams) Validate() error {
+	switch p.MergeMethod {
+	case "", CommitQueueMergeMethodMerge, CommitQueueMergeMethodSquash, CommitQueueMergeMethodRebase:
+	default:
+		return errors.Errorf("commit queue merge method '%s' is not one of '%s', '%s' or '%s'", p.MergeMethod,
+			CommitQueueMergeMethodMerge, CommitQueueMergeMethodSquash, CommitQueueMergeMethodRebase)
+	}
+	if p.Enabled && p.PatchAlias == "" {
+		return errors.New("commit queue must have a patch alias to test changes with")
+	}
+	return nil
+}
+
+const (
+	AutoRetrySystemFailure = "system_failure"
+	AutoRetrySetupFailure  = "setup_failure"
+	AutoRetryTestFailure   = "test_failure"
+	AutoRetryLogMatch      = "log_match"
+)
+
+// AutoRetryParams are the settings for retrying failed tasks automatically.
+// A failed task is restarted, up to Times times, if its failure matches any
+// of the conditions.
+type AutoRetryParams struct {
+	Times      int                  `bson:"times,omitempty" json:"times"`
+	Conditions []AutoRetryCondition `bson:"conditions,omitempty" json:"conditions"`
+}
+
+// AutoRetryCondition describes a kind of failure to retry. Conditions on
+// failed tests and on the task log match their Pattern, a regular
+// expression, against the names of the failed tests and the lines of the
+// log.
+type AutoRetryCondition struct {
+	Type    string `bson:"type" json:"type"`
+	Pattern string `bson:"pattern,omitempty" json:"pattern,omitempty"`
+}
+
+// Validate checks that the number of retries fits within the maximum number
+// of executions of a task, and that the conditions are valid.
+func (p *AutoRetryParams) Validate() error {
+	if p.Times < 0 || p.Times >= evergreen.MaxTaskExecution {
+		return errors.Errorf("tasks can be retried automatically between 0 and %d times", evergreen.MaxTaskExecution-1)
+	}
+	for _, c := range p.Conditions {
+		if err := c.Validate(); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// Validate checks that the condition has a known type, and a pattern if its
+// type needs one.
+func (c *AutoRetryCondition) Validate() error {
+	switch c.Type {
+	case AutoRetrySystemFailure, AutoRetrySetupFailure:
+		return nil
+	case AutoRetryTestFailure, AutoRetryLogMatch:
+		if c.Pattern == "" {
+			return errors.Errorf("auto-retry condition '%s' must have a pattern", c.Type)
+		}
+		_, err := regexp.Compile(c.Pattern)
+		return errors.Wrapf(err, "auto-retry condition '%s' has an invalid pattern", c.Type)
+	default:
+		return errors.Errorf("auto-retry condition type '%s' is not one of '%s', '%s', '%s' or '%s'", c.Type,
+			AutoRetrySystemFailure, AutoRetrySetupFailure, AutoRetryTestFailure, AutoRetryLogMatch)
+	}
+}
+
+// PeriodicBuildDefinition defines a version that is created on a schedule.
+// The version runs the variants and tasks of the alias, or of the whole
+// project if there is no alias.
+type PeriodicBuildDefinition struct {
+	ID      string `bson:"id" json:"id"`
+	Cron    string `bson:"cron" json:"cron"`
+	Alias   string `bson:"alias,omitempty" json:"alias,omitempty"`
+	Message string `bson:"message,omitempty" json:"message,omitempty"`
+
+	// NextRunTime is when the next version is due. It's set from the
+	// schedule when the definition is first seen and after each run.
+	NextRunTime time.Time `bson:"next_run_time,omitempty" json:"next_run_time,omitempty"`
+}
+
+// Validate checks that the definition has an ID and a valid schedule.
+func (d *PeriodicBuildDefinition) Validate() error {
+	if d.ID == "" {
+		return errors.New("periodic build must have an id")
+	}
+	if _, err := util.ParseCron(d.Cron); err != nil {
+		return errors.Wrapf(err, "periodic build '%s' has an invalid schedule", d.ID)
+	}
+	return nil
 }
 
 // RepositoryErrorDetails indicates whether or not there is an invalid revision and if there is one,
@@ -102,6 +234,15 @@ var (
 	projectRefTracksPushEventsKey   = bsonutil.MustHaveTag(ProjectRef{}, "TracksPushEvents")
 	projectRefPRTestingEnabledKey   = bsonutil.MustHaveTag(ProjectRef{}, "PRTestingEnabled")
 	projectRefPatchingDisabledKey   = bsonutil.MustHaveTag(ProjectRef{}, "PatchingDisabled")
+	projectRefPeriodicBuildsKey     = bsonutil.MustHaveTag(ProjectRef{}, "PeriodicBuilds")
+	projectRefCommitQueueKey        = bsonutil.MustHaveTag(ProjectRef{}, "CommitQueue")
+	projectRefAutoRetryKey          = bsonutil.MustHaveTag(ProjectRef{}, "AutoRetry")
+	projectRefAbortSupersededKey    = bsonutil.MustHaveTag(ProjectRef{}, "AbortSupersededPatches")
+
+	periodicBuildIDKey          = bsonutil.MustHaveTag(PeriodicBuildDefinition{}, "ID")
+	periodicBuildNextRunTimeKey = bsonutil.MustHaveTag(PeriodicBuildDefinition{}, "NextRunTime")
+
+	commitQueueEnabledKey = bsonutil.MustHaveTag(CommitQueueParams{}, "Enabled")
 )
 
 const (
@@ -312,12 +453,80 @@ func (projectRef *ProjectRef) Upsert() error {
 				projectRefTracksPushEventsKey:   projectRef.TracksPushEvents,
 				projectRefPRTestingEnabledKey:   projectRef.PRTestingEnabled,
 				projectRefPatchingDisabledKey:   projectRef.PatchingDisabled,
+				projectRefPeriodicBuildsKey:     projectRef.PeriodicBuilds,
+				projectRefCommitQueueKey:        projectRef.CommitQueue,
+				projectRefAutoRetryKey:          projectRef.AutoRetry,
+				projectRefAbortSupersededKey:    projectRef.AbortSupersededPatches,
 			},
 		},
 	)
 	return err
 }
 
+// FindProjectRefsWithPeriodicBuilds returns the enabled project refs that
+// have periodic builds.
+func FindProjectRefsWithPeriodicBuilds() ([]ProjectRef, error) {
+	projectRefs := []ProjectRef{}
+	err := db.FindAll(
+		ProjectRefCollection,
+		bson.M{
+			ProjectRefEnabledKey: true,
+			bsonutil.GetDottedKeyName(projectRefPeriodicBuildsKey, "0"): bson.M{"$exists": true},
+		},
+		db.NoProjection,
+		db.NoSort,
+		db.NoSkip,
+		db.NoLimit,
+		&projectRefs,
+	)
+	return projectRefs, err
+}
+
+// FindProjectRefsWithCommitQueueEnabled returns the enabled project refs
+// that have their commit queues enabled.
+func FindProjectRefsWithCommitQueueEnabled() ([]ProjectRef, error) {
+	projectRefs := []ProjectRef{}
+	err := db.FindAll(
+		ProjectRefCollection,
+		bson.M{
+			ProjectRefEnabledKey: true,
+			bsonutil.GetDottedKeyName(projectRefCommitQueueKey, commitQueueEnabledKey): true,
+		},
+		db.NoProjection,
+		db.NoSort,
+		db.NoSkip,
+		db.NoLimit,
+		&projectRefs,
+	)
+	return projectRefs, err
+}
+
+// UpdateNextPeriodicBuild sets when the project's periodic build with the
+// given ID is next due.
+func (projectRef *ProjectRef) UpdateNextPeriodicBuild(id string, nextRunTime time.Time) error {
+	err := db.Update(
+		ProjectRefCollection,
+		bson.M{
+			ProjectRefIdentifierKey: projectRef.Identifier,
+			bsonutil.GetDottedKeyName(projectRefPeriodicBuildsKey, periodicBuildIDKey): id,
+		},
+		bson.M{
+			"$set": bson.M{
+				bsonutil.GetDottedKeyName(projectRefPeriodicBuildsKey, "$", periodicBuildNextRunTimeKey): nextRunTime,
+			},
+		},
+	)
+	if err != nil {
+		return errors.Wrapf(err, "error updating periodic build '%s' of project '%s'", id, projectRef.Identifier)
+	}
+	for i := range projectRef.PeriodicBuilds {
+		if projectRef.PeriodicBuilds[i].ID == id {
+			projectRef.PeriodicBuilds[i].NextRunTime = nextRunTime
+		}
+	}
+	return nil
+}
+
 // ProjectRef returns a string representation of a ProjectRef
 func (projectRef *ProjectRef) String() string {
 	return projectRef.Identifier
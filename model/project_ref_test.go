@@ -3,7 +3,9 @@ package model
 import (
 	"math"
 	"testing"
+	"time"
 
+	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/testutil"
 	"github.com/stretchr/testify/assert"
@@ -211,3 +213,94 @@ func TestFindOneProjectRefByRepoAndBranchWithPRTesting(t *testing.T) {
 	assert.Contains(err.Error(), "found 2 project refs, when 1 was expected")
 	require.Nil(projectRef)
 }
+
+func TestPeriodicBuildDefinitionValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError((&PeriodicBuildDefinition{ID: "nightly", Cron: "0 2 * * *"}).Validate())
+	assert.NoError((&PeriodicBuildDefinition{ID: "weekly", Cron: "@weekly", Alias: "soak"}).Validate())
+	assert.Error((&PeriodicBuildDefinition{Cron: "0 2 * * *"}).Validate())
+	assert.Error((&PeriodicBuildDefinition{ID: "nightly"}).Validate())
+	assert.Error((&PeriodicBuildDefinition{ID: "nightly", Cron: "0 25 * * *"}).Validate())
+}
+
+func TestUpdateNextPeriodicBuild(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	require.NoError(db.Clear(ProjectRefCollection))
+
+	now := time.Now().Truncate(time.Second)
+	ref := &ProjectRef{
+		Identifier: "proj",
+		Enabled:    true,
+		PeriodicBuilds: []PeriodicBuildDefinition{
+			{ID: "nightly", Cron: "@daily"},
+			{ID: "weekly", Cron: "@weekly", NextRunTime: now},
+		},
+	}
+	require.NoError(ref.Insert())
+	require.NoError((&ProjectRef{Identifier: "other", Enabled: true}).Insert())
+	require.NoError((&ProjectRef{Identifier: "disabled", PeriodicBuilds: ref.PeriodicBuilds}).Insert())
+
+	refs, err := FindProjectRefsWithPeriodicBuilds()
+	require.NoError(err)
+	require.Len(refs, 1)
+	assert.Equal("proj", refs[0].Identifier)
+
+	next := now.Add(time.Hour)
+	require.NoError(ref.UpdateNextPeriodicBuild("nightly", next))
+	assert.True(next.Equal(ref.PeriodicBuilds[0].NextRunTime))
+
+	dbRef, err := FindOneProjectRef("proj")
+	require.NoError(err)
+	require.NotNil(dbRef)
+	assert.True(next.Equal(dbRef.PeriodicBuilds[0].NextRunTime))
+	assert.True(now.Equal(dbRef.PeriodicBuilds[1].NextRunTime))
+}
+
+func TestCommitQueueParamsValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError((&CommitQueueParams{}).Validate())
+	assert.NoError((&CommitQueueParams{Enabled: true, PatchAlias: "cq"}).Validate())
+	assert.NoError((&CommitQueueParams{Enabled: true, PatchAlias: "cq", MergeMethod: CommitQueueMergeMethodSquash}).Validate())
+	assert.Error((&CommitQueueParams{Enabled: true}).Validate())
+	assert.Error((&CommitQueueParams{Enabled: true, PatchAlias: "cq", MergeMethod: "octopus"}).Validate())
+}
+
+func TestFindProjectRefsWithCommitQueueEnabled(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	require.NoError(db.Clear(ProjectRefCollection))
+
+	params := CommitQueueParams{Enabled: true, PatchAlias: "cq"}
+	require.NoError((&ProjectRef{Identifier: "proj", Enabled: true, CommitQueue: params}).Insert())
+	require.NoError((&ProjectRef{Identifier: "other", Enabled: true}).Insert())
+	require.NoError((&ProjectRef{Identifier: "disabled", CommitQueue: params}).Insert())
+
+	refs, err := FindProjectRefsWithCommitQueueEnabled()
+	require.NoError(err)
+	require.Len(refs, 1)
+	assert.Equal("proj", refs[0].Identifier)
+	assert.Equal("cq", refs[0].CommitQueue.PatchAlias)
+}
+
+func TestAutoRetryParamsValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError((&AutoRetryParams{}).Validate())
+	assert.NoError((&AutoRetryParams{
+		Times: 2,
+		Conditions: []AutoRetryCondition{
+			{Type: AutoRetrySystemFailure},
+			{Type: AutoRetrySetupFailure},
+			{Type: AutoRetryTestFailure, Pattern: "^jstests/"},
+			{Type: AutoRetryLogMatch, Pattern: "connection (reset|refused)"},
+		},
+	}).Validate())
+	assert.Error((&AutoRetryParams{Times: -1}).Validate())
+	assert.Error((&AutoRetryParams{Times: evergreen.MaxTaskExecution}).Validate())
+	assert.Error((&AutoRetryParams{Times: 1, Conditions: []AutoRetryCondition{{Type: "sometimes"}}}).Validate())
+	assert.Error((&AutoRetryParams{Times: 1, Conditions: []AutoRetryCondition{{Type: AutoRetryLogMatch}}}).Validate())
+	assert.Error((&AutoRetryParams{Times: 1, Conditions: []AutoRetryCondition{{Type: AutoRetryTestFailure, Pattern: "("}}}).Validate())
+}
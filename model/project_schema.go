@@ -0,0 +1,121 @@
+package model
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/evergreen-ci/evergreen/util"
+)
+
+// projectSchemaCommandRef is where commands are defined in the project
+// schema, since they're used in many places.
+const projectSchemaCommandRef = "#/definitions/command"
+
+// ProjectSchema returns a JSON Schema for project configuration files. The
+// params of each command are checked against the schema given for the
+// command's name. Unknown keys are allowed at the top level of the
+// configuration, where they're often used to hold YAML anchors, but not
+// anywhere else.
+func ProjectSchema(commandParams map[string]*util.JSONSchema) *util.JSONSchema {
+	r := &util.JSONSchemaReflector{
+		Tag:           "yaml",
+		ScalarStrings: true,
+		Overrides:     map[reflect.Type]*util.JSONSchema{},
+	}
+	oneOrMany := func(t reflect.Type, single *util.JSONSchema) {
+		r.Overrides[t] = util.JSONSchemaOneOrMany(single)
+	}
+	objectWithFields := func(t reflect.Type) *util.JSONSchema {
+		return &util.JSONSchema{Type: "object", Properties: r.ReflectFields(t), AdditionalProperties: false}
+	}
+	stringSchema := r.Reflect(reflect.TypeOf(""))
+
+	oneOrMany(reflect.TypeOf(parserStringSlice{}), stringSchema)
+
+	r.Overrides[reflect.TypeOf(matrixDefinition{})] = &util.JSONSchema{
+		Type:                 "object",
+		AdditionalProperties: r.Reflect(reflect.TypeOf(parserStringSlice{})),
+	}
+	oneOrMany(reflect.TypeOf(matrixDefinitions{}), r.Reflect(reflect.TypeOf(matrixDefinition{})))
+	r.Overrides[reflect.TypeOf(variantSelector{})] = &util.JSONSchema{
+		AnyOf: []*util.JSONSchema{stringSchema, r.Reflect(reflect.TypeOf(matrixDefinition{}))},
+	}
+
+	r.Overrides[reflect.TypeOf(taskSelector{})] = &util.JSONSchema{
+		AnyOf: []*util.JSONSchema{stringSchema, objectWithFields(reflect.TypeOf(taskSelector{}))},
+	}
+	oneOrMany(reflect.TypeOf(taskSelectors{}), r.Reflect(reflect.TypeOf(taskSelector{})))
+	r.Overrides[reflect.TypeOf(parserDependency{})] = &util.JSONSchema{
+		AnyOf: []*util.JSONSchema{stringSchema, objectWithFields(reflect.TypeOf(parserDependency{}))},
+	}
+	oneOrMany(reflect.TypeOf(parserDependencies{}), r.Reflect(reflect.TypeOf(parserDependency{})))
+
+	r.Overrides[reflect.TypeOf(parserBVTaskUnit{})] = &util.JSONSchema{
+		AnyOf: []*util.JSONSchema{stringSchema, objectWithFields(reflect.TypeOf(parserBVTaskUnit{}))},
+	}
+	oneOrMany(reflect.TypeOf(parserBVTaskUnits{}), r.Reflect(reflect.TypeOf(parserBVTaskUnit{})))
+
+	bv := objectWithFields(reflect.TypeOf(parserBV{}))
+	bv.Required = []string{"name"}
+	m := objectWithFields(reflect.TypeOf(matrix{}))
+	m.Required = []string{"matrix_name"}
+	r.Overrides[reflect.TypeOf(parserBV{})] = &util.JSONSchema{AnyOf: []*util.JSONSchema{bv, m}}
+
+	// the rest of a task template is a task definition, which can have
+	// parameters in place of any value
+	r.Overrides[reflect.TypeOf(parserTaskTemplate{})] = &util.JSONSchema{
+		Type: "object",
+		Properties: map[string]*util.JSONSchema{
+			"name":      stringSchema,
+			"task_name": stringSchema,
+			"params":    r.Reflect(reflect.TypeOf([]taskTemplateParam{})),
+		},
+		Required: []string{"name", "task_name"},
+	}
+
+	command := &util.JSONSchema{Ref: projectSchemaCommandRef}
+	r.Overrides[reflect.TypeOf(PluginCommandConf{})] = command
+	r.Overrides[reflect.TypeOf(YAMLCommandSet{})] = util.JSONSchemaOneOrMany(command)
+
+	schema := r.Reflect(reflect.TypeOf(parserProject{}))
+	schema.Schema = util.JSONSchemaDraft
+	schema.Title = "Evergreen project configuration"
+	schema.AdditionalProperties = nil
+	schema.Definitions = map[string]*util.JSONSchema{
+		"command": commandSchema(r, commandParams),
+	}
+	return schema
+}
+
+// commandSchema returns the schema of a command, which is either a call of
+// a function or a command with params that match the command's schema.
+func commandSchema(r *util.JSONSchemaReflector, commandParams map[string]*util.JSONSchema) *util.JSONSchema {
+	schema := &util.JSONSchema{
+		Type:                 "object",
+		Properties:           r.ReflectFields(reflect.TypeOf(PluginCommandConf{})),
+		AdditionalProperties: false,
+	}
+	schema.AnyOf = []*util.JSONSchema{{Required: []string{"command"}}, {Required: []string{"func"}}}
+
+	names := []string{}
+	for name := range commandParams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	commandName := &util.JSONSchema{Type: "string"}
+	for _, name := range names {
+		commandName.Enum = append(commandName.Enum, name)
+		schema.AllOf = append(schema.AllOf, &util.JSONSchema{
+			If: &util.JSONSchema{
+				Properties: map[string]*util.JSONSchema{"command": {Const: name}},
+				Required:   []string{"command"},
+			},
+			Then: &util.JSONSchema{
+				Properties: map[string]*util.JSONSchema{"params": commandParams[name]},
+			},
+		})
+	}
+	schema.Properties["command"] = commandName
+	return schema
+}
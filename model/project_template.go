@@ -0,0 +1,322 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	templateParamString = "string"
+	templateParamInt    = "int"
+	templateParamBool   = "bool"
+)
+
+// parserTaskTemplate is a task definition that tasks are instantiated from,
+// with parameters. Parameters are referenced in the definition as
+// ${name}, and a value that is just a reference to an int or bool
+// parameter keeps its type. Other ${...} references are left for the agent
+// to expand.
+type parserTaskTemplate struct {
+	Name string
+	// TaskName is the pattern the names of the instantiated tasks are
+	// made from, such as "test_${suite}".
+	TaskName string
+	Params   []taskTemplateParam
+
+	// body is the rest of the template, which is a task definition
+	body yaml.MapSlice
+}
+
+// taskTemplateParam is a parameter of a task template. Parameters without
+// defaults must be given when the template is instantiated.
+type taskTemplateParam struct {
+	Name    string      `yaml:"name"`
+	Type    string      `yaml:"type,omitempty"`
+	Default interface{} `yaml:"default,omitempty"`
+}
+
+var taskTemplateKeys = []string{"name", "task_name", "params"}
+
+func (ptt *parserTaskTemplate) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	fields := struct {
+		Name     string              `yaml:"name"`
+		TaskName string              `yaml:"task_name"`
+		Params   []taskTemplateParam `yaml:"params"`
+	}{}
+	if err := unmarshal(&fields); err != nil {
+		return errors.WithStack(err)
+	}
+	body := yaml.MapSlice{}
+	if err := unmarshal(&body); err != nil {
+		return errors.WithStack(err)
+	}
+
+	*ptt = parserTaskTemplate{
+		Name:     fields.Name,
+		TaskName: fields.TaskName,
+		Params:   fields.Params,
+		body:     yaml.MapSlice{},
+	}
+	for _, item := range body {
+		key, _ := item.Key.(string)
+		isTemplateKey := false
+		for _, k := range taskTemplateKeys {
+			if key == k {
+				isTemplateKey = true
+			}
+		}
+		if !isTemplateKey {
+			ptt.body = append(ptt.body, item)
+		}
+	}
+	return nil
+}
+
+// validate checks that the template has a name and a task name pattern, and
+// that its parameters have names, known types and defaults of their type.
+func (ptt *parserTaskTemplate) validate() []error {
+	errs := []error{}
+	if ptt.Name == "" {
+		errs = append(errs, errors.New("task template must have a name"))
+	}
+	if ptt.TaskName == "" {
+		errs = append(errs, errors.Errorf("task template '%s' must have a task_name", ptt.Name))
+	}
+	seen := map[string]bool{}
+	for _, p := range ptt.Params {
+		if p.Name == "" {
+			errs = append(errs, errors.Errorf("parameter of task template '%s' must have a name", ptt.Name))
+			continue
+		}
+		if seen[p.Name] {
+			errs = append(errs, errors.Errorf("task template '%s' has parameter '%s' more than once", ptt.Name, p.Name))
+		}
+		seen[p.Name] = true
+		switch p.Type {
+		case "", templateParamString, templateParamInt, templateParamBool:
+		default:
+			errs = append(errs, errors.Errorf("parameter '%s' of task template '%s' has unknown type '%s'",
+				p.Name, ptt.Name, p.Type))
+			continue
+		}
+		if p.Default != nil {
+			if _, err := p.value(p.Default); err != nil {
+				errs = append(errs, errors.Wrapf(err, "default of task template '%s'", ptt.Name))
+			}
+		}
+	}
+	return errs
+}
+
+// value checks that the given value has the parameter's type. Any scalar
+// is accepted for string parameters, and strings are accepted for other
+// parameters if they can be parsed as the parameter's type, since matrix
+// axis values are strings.
+func (p taskTemplateParam) value(in interface{}) (interface{}, error) {
+	switch p.Type {
+	case templateParamInt:
+		switch v := in.(type) {
+		case int:
+			return v, nil
+		case string:
+			if i, err := strconv.Atoi(v); err == nil {
+				return i, nil
+			}
+		}
+	case templateParamBool:
+		switch v := in.(type) {
+		case bool:
+			return v, nil
+		case string:
+			if b, err := strconv.ParseBool(v); err == nil {
+				return b, nil
+			}
+		}
+	default:
+		switch in.(type) {
+		case string, int, int64, float64, bool:
+			return fmt.Sprint(in), nil
+		}
+	}
+	return nil, errors.Errorf("parameter '%s' must be of type %s, not '%v'", p.Name, p.typeName(), in)
+}
+
+func (p taskTemplateParam) typeName() string {
+	if p.Type == "" {
+		return templateParamString
+	}
+	return p.Type
+}
+
+// instantiate returns the task the template defines with the given
+// parameters, and its definition as YAML.
+func (ptt *parserTaskTemplate) instantiate(params map[string]interface{}) (parserTask, string, error) {
+	values := map[string]interface{}{}
+	for _, p := range ptt.Params {
+		in, ok := params[p.Name]
+		if !ok {
+			if p.Default == nil {
+				return parserTask{}, "", errors.Errorf("task template '%s' requires parameter '%s'", ptt.Name, p.Name)
+			}
+			in = p.Default
+		}
+		v, err := p.value(in)
+		if err != nil {
+			return parserTask{}, "", errors.Wrapf(err, "task template '%s'", ptt.Name)
+		}
+		values[p.Name] = v
+	}
+	for name := range params {
+		if _, ok := values[name]; !ok {
+			return parserTask{}, "", errors.Errorf("task template '%s' has no parameter '%s'", ptt.Name, name)
+		}
+	}
+
+	name := substituteTemplateString(ptt.TaskName, values)
+	body := append(yaml.MapSlice{{Key: "name", Value: name}}, substituteTemplateParams(ptt.body, values).(yaml.MapSlice)...)
+	out, err := yaml.Marshal(body)
+	if err != nil {
+		return parserTask{}, "", errors.Wrapf(err, "error instantiating task template '%s'", ptt.Name)
+	}
+	pt := parserTask{}
+	if err = yaml.Unmarshal(out, &pt); err != nil {
+		return parserTask{}, "", errors.Wrapf(err, "error instantiating task template '%s' as task '%s'", ptt.Name, name)
+	}
+	return pt, string(out), nil
+}
+
+// substituteTemplateParams replaces references to the parameters in the
+// keys and values of the given YAML.
+func substituteTemplateParams(in interface{}, values map[string]interface{}) interface{} {
+	switch v := in.(type) {
+	case string:
+		for name, value := range values {
+			if v == "${"+name+"}" {
+				return value
+			}
+		}
+		return substituteTemplateString(v, values)
+	case yaml.MapSlice:
+		out := yaml.MapSlice{}
+		for _, item := range v {
+			out = append(out, yaml.MapItem{
+				Key:   substituteTemplateParams(item.Key, values),
+				Value: substituteTemplateParams(item.Value, values),
+			})
+		}
+		return out
+	case map[interface{}]interface{}:
+		out := map[interface{}]interface{}{}
+		for key, value := range v {
+			out[substituteTemplateParams(key, values)] = substituteTemplateParams(value, values)
+		}
+		return out
+	case []interface{}:
+		out := []interface{}{}
+		for _, value := range v {
+			out = append(out, substituteTemplateParams(value, values))
+		}
+		return out
+	default:
+		return in
+	}
+}
+
+var templateParamRegexp = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+// substituteTemplateString replaces references to the parameters in the
+// string in a single pass, so references in the values aren't expanded.
+// Other references, such as expansions, are left alone.
+func substituteTemplateString(s string, values map[string]interface{}) string {
+	return templateParamRegexp.ReplaceAllStringFunc(s, func(ref string) string {
+		if value, ok := values[ref[2:len(ref)-1]]; ok {
+			return fmt.Sprint(value)
+		}
+		return ref
+	})
+}
+
+// evaluateTaskTemplates replaces the tasks that instantiate task templates
+// with the tasks the templates define, and adds the tasks that variants
+// instantiate to the project. The same task can be instantiated more than
+// once, as long as it's instantiated with the same definition each time.
+func evaluateTaskTemplates(pp *parserProject) []error {
+	errs := []error{}
+	templates := map[string]*parserTaskTemplate{}
+	for i, ptt := range pp.TaskTemplates {
+		errs = append(errs, ptt.validate()...)
+		if _, ok := templates[ptt.Name]; ok {
+			errs = append(errs, errors.Errorf("task template '%s' is defined more than once", ptt.Name))
+		}
+		templates[ptt.Name] = &pp.TaskTemplates[i]
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	handWritten := map[string]bool{}
+	for _, pt := range pp.Tasks {
+		if pt.Template == "" {
+			handWritten[pt.Name] = true
+		}
+	}
+
+	tasks := []parserTask{}
+	instantiated := map[string]string{}
+	instantiate := func(template string, params map[string]interface{}) (string, error) {
+		ptt, ok := templates[template]
+		if !ok {
+			return "", errors.Errorf("task template '%s' is not defined", template)
+		}
+		pt, definition, err := ptt.instantiate(params)
+		if err != nil {
+			return "", err
+		}
+		if handWritten[pt.Name] {
+			return "", errors.Errorf("task '%s' instantiated from task template '%s' is already defined", pt.Name, template)
+		}
+		if existing, ok := instantiated[pt.Name]; ok {
+			if existing != definition {
+				return "", errors.Errorf("task '%s' is instantiated from task template '%s' with different definitions",
+					pt.Name, template)
+			}
+			return pt.Name, nil
+		}
+		instantiated[pt.Name] = definition
+		tasks = append(tasks, pt)
+		return pt.Name, nil
+	}
+
+	for _, pt := range pp.Tasks {
+		if pt.Template == "" {
+			tasks = append(tasks, pt)
+			continue
+		}
+		if _, err := instantiate(pt.Template, pt.Params); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for i := range pp.BuildVariants {
+		bvTasks := pp.BuildVariants[i].Tasks
+		for j := range bvTasks {
+			if bvTasks[j].Template == "" {
+				continue
+			}
+			name, err := instantiate(bvTasks[j].Template, bvTasks[j].Params)
+			if err != nil {
+				errs = append(errs, errors.Wrapf(err, "buildvariant '%s'", pp.BuildVariants[i].Name))
+				continue
+			}
+			bvTasks[j].Name = name
+			bvTasks[j].Template = ""
+			bvTasks[j].Params = nil
+		}
+	}
+	pp.Tasks = tasks
+
+	return errs
+}
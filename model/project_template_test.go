@@ -0,0 +1,203 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskTemplates(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	yml := `
+functions:
+  run tests:
+    command: shell.exec
+task_templates:
+  - name: suite
+    task_name: test_${suite}_${shard}
+    params:
+      - name: suite
+      - name: shard
+        type: int
+        default: 0
+      - name: patchable
+        type: bool
+        default: true
+    tags: [test, "${suite}"]
+    patchable: ${patchable}
+    priority: ${shard}
+    depends_on:
+      - name: compile
+    commands:
+      - func: run tests
+        vars:
+          suite: ${suite}
+          shard: "shard ${shard}"
+          dir: ${workdir}
+tasks:
+  - name: compile
+    tags: [build]
+  - template: suite
+    params:
+      suite: core
+  - template: suite
+    params:
+      suite: sharded
+      shard: 2
+      patchable: false
+buildvariants:
+  - name: linux
+    run_on: [linux-distro]
+    tasks:
+      - name: ".test"
+      - template: suite
+        params:
+          suite: core
+      - template: suite
+        params:
+          suite: linux_only
+`
+	p := &Project{}
+	require.NoError(LoadProjectInto([]byte(yml), "", p))
+	require.Len(p.Tasks, 4)
+
+	core := p.FindProjectTask("test_core_0")
+	require.NotNil(core)
+	assert.Equal([]string{"test", "core"}, core.Tags)
+	assert.EqualValues(0, core.Priority)
+	require.NotNil(core.Patchable)
+	assert.True(*core.Patchable)
+	require.Len(core.DependsOn, 1)
+	assert.Equal("compile", core.DependsOn[0].Name)
+	require.Len(core.Commands, 1)
+	assert.Equal("run tests", core.Commands[0].Function)
+	assert.Equal("core", core.Commands[0].Vars["suite"])
+	assert.Equal("shard 0", core.Commands[0].Vars["shard"])
+	assert.Equal("${workdir}", core.Commands[0].Vars["dir"])
+
+	sharded := p.FindProjectTask("test_sharded_2")
+	require.NotNil(sharded)
+	assert.EqualValues(2, sharded.Priority)
+	require.NotNil(sharded.Patchable)
+	assert.False(*sharded.Patchable)
+
+	require.NotNil(p.FindProjectTask("test_linux_only_0"))
+
+	linux := p.FindBuildVariant("linux")
+	require.NotNil(linux)
+	names := []string{}
+	for _, t := range linux.Tasks {
+		names = append(names, t.Name)
+	}
+	assert.Equal([]string{"test_core_0", "test_sharded_2", "test_linux_only_0"}, names)
+}
+
+func TestTaskTemplatesInMatrix(t *testing.T) {
+	yml := `
+task_templates:
+  - name: suite
+    task_name: test_${suite}
+    params:
+      - name: suite
+axes:
+  - id: os
+    values:
+      - id: ubuntu
+        variables:
+          suite: ubuntu_suite
+      - id: rhel
+        variables:
+          suite: rhel_suite
+buildvariants:
+  - matrix_name: tests
+    matrix_spec:
+      os: "*"
+    run_on: [distro]
+    tasks:
+      - template: suite
+        params:
+          suite: ${suite}
+`
+	p := &Project{}
+	require.NoError(t, LoadProjectInto([]byte(yml), "", p))
+	require.Len(t, p.Tasks, 2)
+	assert.NotNil(t, p.FindProjectTask("test_ubuntu_suite"))
+	assert.NotNil(t, p.FindProjectTask("test_rhel_suite"))
+	require.Len(t, p.BuildVariants, 2)
+	for _, bv := range p.BuildVariants {
+		require.Len(t, bv.Tasks, 1)
+		assert.Contains(t, []string{"test_ubuntu_suite", "test_rhel_suite"}, bv.Tasks[0].Name)
+	}
+}
+
+func TestTaskTemplateErrors(t *testing.T) {
+	template := `
+task_templates:
+  - name: suite
+    task_name: test_${suite}
+    params:
+      - name: suite
+      - name: shard
+        type: int
+        default: 0
+    priority: ${shard}
+`
+	for name, test := range map[string]struct {
+		yml string
+		err string
+	}{
+		"MissingParam": {
+			yml: template + "tasks:\n  - template: suite\n",
+			err: "task template 'suite' requires parameter 'suite'",
+		},
+		"UnknownParam": {
+			yml: template + "tasks:\n  - template: suite\n    params: {suite: a, other: b}\n",
+			err: "task template 'suite' has no parameter 'other'",
+		},
+		"WrongType": {
+			yml: template + "tasks:\n  - template: suite\n    params: {suite: a, shard: many}\n",
+			err: "parameter 'shard' must be of type int",
+		},
+		"UndefinedTemplate": {
+			yml: "tasks:\n  - template: nonexistent\n",
+			err: "task template 'nonexistent' is not defined",
+		},
+		"ConflictsWithTask": {
+			yml: template + "tasks:\n  - name: test_a\n  - template: suite\n    params: {suite: a}\n",
+			err: "task 'test_a' instantiated from task template 'suite' is already defined",
+		},
+		"DifferentDefinitions": {
+			yml: template + "tasks:\n  - template: suite\n    params: {suite: a}\n  - template: suite\n    params: {suite: a, shard: 1}\n",
+			err: "task 'test_a' is instantiated from task template 'suite' with different definitions",
+		},
+		"UnknownType": {
+			yml: "task_templates:\n  - name: t\n    task_name: t\n    params:\n      - name: p\n        type: float\n",
+			err: "parameter 'p' of task template 't' has unknown type 'float'",
+		},
+		"MissingTaskName": {
+			yml: "task_templates:\n  - name: t\n",
+			err: "task template 't' must have a task_name",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := LoadProjectInto([]byte(test.yml), "", &Project{})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.err)
+		})
+	}
+}
+
+func TestSubstituteTemplateString(t *testing.T) {
+	assert := assert.New(t)
+
+	values := map[string]interface{}{"a": "${b}", "b": "${a}", "n": 2}
+	// values aren't expanded again, however the parameters are ordered
+	for i := 0; i < 10; i++ {
+		assert.Equal("${b}-${a}-2", substituteTemplateString("${a}-${b}-${n}", values))
+	}
+	assert.Equal("${workdir}/2", substituteTemplateString("${workdir}/${n}", values))
+	assert.Equal("no params", substituteTemplateString("no params", values))
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/evergreen-ci/evergreen/util"
 	. "github.com/smartystreets/goconvey/convey"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	yaml "gopkg.in/yaml.v2"
 )
@@ -280,6 +281,103 @@ func boolPtr(b bool) *bool {
 	return &b
 }
 
+func TestChangesActivate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(changesActivate(nil, nil, []string{"src/a.go"}))
+	assert.True(changesActivate([]string{"src/*"}, nil, nil))
+	assert.True(changesActivate([]string{"src/*"}, nil, []string{"README.md", "src/a.go"}))
+	assert.False(changesActivate([]string{"src/*"}, nil, []string{"README.md"}))
+	assert.True(changesActivate(nil, []string{"*.md"}, []string{"README.md", "src/a.go"}))
+	assert.False(changesActivate(nil, []string{"*.md"}, []string{"README.md", "docs/b.md"}))
+	assert.False(changesActivate([]string{"src/*"}, []string{"*.md"}, []string{"README.md"}))
+	assert.False(changesActivate([]string{"*"}, []string{"*.md"}, []string{"README.md"}))
+}
+
+func TestTasksSkippedByChanges(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	yml := `
+tasks:
+  - name: compile
+  - name: docs
+    paths: ["docs/*"]
+  - name: lint
+    ignore_paths: ["*.md"]
+  - name: tg_one
+  - name: tg_two
+task_groups:
+  - name: tg
+    tasks: [tg_one, tg_two]
+buildvariants:
+  - name: linux
+    tasks:
+      - name: compile
+      - name: docs
+      - name: lint
+      - name: tg
+        paths: ["src/*"]
+  - name: windows
+    paths: ["src/windows/*"]
+    tasks:
+      - name: compile
+      - name: docs
+        paths: ["*"]
+`
+	p := &Project{}
+	require.NoError(LoadProjectInto([]byte(yml), "", p))
+	assert.True(p.HasPathFilters())
+
+	assert.Nil(p.TasksSkippedByChanges("linux", nil))
+	assert.Equal([]string{"docs"}, p.TasksSkippedByChanges("linux", []string{"src/a.go"}))
+	assert.Equal([]string{"lint", "tg_one", "tg_two"}, p.TasksSkippedByChanges("linux", []string{"docs/README.md"}))
+	assert.Equal([]string{"compile", "docs"}, p.TasksSkippedByChanges("windows", []string{"src/a.go"}))
+	assert.Nil(p.TasksSkippedByChanges("windows", []string{"src/windows/a.go"}))
+	assert.Nil(p.TasksSkippedByChanges("nonexistent", []string{"src/a.go"}))
+
+	// tasks that an activated task depends on stay active, transitively and
+	// across variants
+	yml = `
+tasks:
+  - name: compile
+    paths: ["src/*"]
+  - name: package
+    paths: ["src/*"]
+    depends_on:
+      - name: compile
+  - name: docs
+    paths: ["docs/*"]
+    depends_on:
+      - name: package
+  - name: publish
+    paths: ["docs/*"]
+    depends_on:
+      - name: compile
+        variant: linux
+buildvariants:
+  - name: linux
+    tasks:
+      - name: compile
+      - name: package
+      - name: docs
+  - name: windows
+    tasks:
+      - name: compile
+      - name: publish
+`
+	p = &Project{}
+	require.NoError(LoadProjectInto([]byte(yml), "", p))
+	assert.Nil(p.TasksSkippedByChanges("linux", []string{"docs/a.md"}))
+	assert.Equal([]string{"compile"}, p.TasksSkippedByChanges("windows", []string{"docs/a.md"}))
+	assert.Equal([]string{"docs"}, p.TasksSkippedByChanges("linux", []string{"src/a.go"}))
+	assert.Equal([]string{"publish"}, p.TasksSkippedByChanges("windows", []string{"src/a.go"}))
+
+	p = &Project{}
+	require.NoError(LoadProjectInto([]byte("tasks:\n  - name: compile\nbuildvariants:\n  - name: bv\n    tasks:\n      - name: compile\n"), "", p))
+	assert.False(p.HasPathFilters())
+}
+
 func TestGetTaskGroup(t *testing.T) {
 	assert := assert.New(t)
 	testutil.HandleTestingErr(db.ClearCollections(version.Collection), t, "failed to clear collections")
@@ -445,6 +543,54 @@ func TestPopulateExpansions(t *testing.T) {
 	assert.Equal("wut?", expansions.Get("github_org"))
 }
 
+func TestPopulateExpansionsForTask(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	require.NoError(db.ClearCollections(version.Collection, ProjectVarsCollection))
+
+	d := &distro.Distro{Id: "d1", WorkDir: "/data/mci"}
+	taskDoc := &task.Task{
+		Id:           "t1",
+		Version:      "v1",
+		BuildVariant: "bv1",
+		Project:      "mci",
+	}
+
+	_, err := PopulateExpansions(taskDoc, d)
+	assert.Error(err)
+
+	v := &version.Version{
+		Id:         "v1",
+		Identifier: "mci",
+		Requester:  evergreen.RepotrackerVersionRequester,
+		Config: `
+buildvariants:
+- name: bv1
+  expansions:
+    compile_flags: "-j8"
+`,
+	}
+	require.NoError(v.Insert())
+	vars := &ProjectVars{
+		Id:          "mci",
+		Vars:        map[string]string{"bucket": "artifacts", "aws_secret": "hunter2"},
+		PrivateVars: map[string]bool{"aws_secret": true},
+	}
+	require.NoError(vars.Insert())
+
+	expansions, err := PopulateExpansions(taskDoc, d)
+	require.NoError(err)
+	assert.Equal("t1", expansions.Get("task_id"))
+	assert.Equal("/data/mci", expansions.Get("workdir"))
+	assert.Equal("-j8", expansions.Get("compile_flags"))
+	assert.Equal("artifacts", expansions.Get("bucket"))
+	assert.False(expansions.Exists("aws_secret"))
+
+	taskDoc.BuildVariant = "nonexistent"
+	_, err = PopulateExpansions(taskDoc, d)
+	assert.Error(err)
+}
+
 type projectSuite struct {
 	project *Project
 	vars    ProjectVars
@@ -43,6 +43,9 @@ var (
 	HostIdKey              = bsonutil.MustHaveTag(Task{}, "HostId")
 	ExecutionKey           = bsonutil.MustHaveTag(Task{}, "Execution")
 	RestartsKey            = bsonutil.MustHaveTag(Task{}, "Restarts")
+	PreemptionsKey         = bsonutil.MustHaveTag(Task{}, "Preemptions")
+	AutoRetriesKey         = bsonutil.MustHaveTag(Task{}, "AutoRetries")
+	RetryReasonKey         = bsonutil.MustHaveTag(Task{}, "RetryReason")
 	OldTaskIdKey           = bsonutil.MustHaveTag(Task{}, "OldTaskId")
 	ArchivedKey            = bsonutil.MustHaveTag(Task{}, "Archived")
 	RevisionOrderNumberKey = bsonutil.MustHaveTag(Task{}, "RevisionOrderNumber")
@@ -694,6 +697,34 @@ func FindAllTaskIDsFromBuild(buildId string) ([]string, error) {
 	return findAllTaskIDs(q)
 }
 
+// FindFailedProjectsOnHost returns the distinct projects that had a task, or
+// an archived execution of a task, fail on the given host after the given
+// time. Only system and setup failures count, since a project's failing
+// tests say nothing about the host.
+func FindFailedProjectsOnHost(hostId string, since time.Time) ([]string, error) {
+	q := db.Query(bson.M{
+		HostIdKey:     hostId,
+		StatusKey:     evergreen.TaskFailed,
+		FinishTimeKey: bson.M{"$gte": since},
+		bsonutil.GetDottedKeyName(DetailsKey, TaskEndDetailType): bson.M{"$in": []string{"system", "setup"}},
+	}).WithFields(ProjectKey)
+
+	projects := []string{}
+	for _, coll := range []string{Collection, OldCollection} {
+		tasks := []Task{}
+		if err := db.FindAllQ(coll, q, &tasks); err != nil && err != mgo.ErrNotFound {
+			return nil, errors.Wrapf(err, "error finding failed tasks on host %s", hostId)
+		}
+		for _, t := range tasks {
+			if !util.StringSliceContains(projects, t.Project) {
+				projects = append(projects, t.Project)
+			}
+		}
+	}
+
+	return projects, nil
+}
+
 // FindOneOld returns one task from the old tasks collection that satisfies the query.
 func FindOneOld(query db.Q) (*Task, error) {
 	task, err := FindOneOldNoMerge(query)
@@ -100,6 +100,16 @@ type Task struct {
 	Archived            bool   `bson:"archived,omitempty" json:"archived,omitempty"`
 	RevisionOrderNumber int    `bson:"order,omitempty" json:"order,omitempty"`
 
+	// the number of executions that ended because the host was preempted,
+	// which do not count towards the maximum number of executions
+	Preemptions int `bson:"preemptions,omitempty" json:"preemptions,omitempty"`
+
+	// the number of times the task was restarted automatically because its
+	// failure matched the project's auto-retry policy, and why the current
+	// execution was started, if it was one of those restarts
+	AutoRetries int    `bson:"auto_retries,omitempty" json:"auto_retries,omitempty"`
+	RetryReason string `bson:"retry_reason,omitempty" json:"retry_reason,omitempty"`
+
 	// task requester - this is used to help tell the
 	// reason this task was created. e.g. it could be
 	// because the repotracker requested it (via tracking the
@@ -827,6 +837,7 @@ func (t *Task) Reset() error {
 	t.StartTime = util.ZeroTime
 	t.ScheduledTime = util.ZeroTime
 	t.FinishTime = util.ZeroTime
+	t.RetryReason = ""
 	reset := bson.M{
 		"$set": bson.M{
 			ActivatedKey:     true,
@@ -838,7 +849,8 @@ func (t *Task) Reset() error {
 			FinishTimeKey:    util.ZeroTime,
 		},
 		"$unset": bson.M{
-			DetailsKey: "",
+			DetailsKey:     "",
+			RetryReasonKey: "",
 		},
 	}
 
@@ -977,6 +989,40 @@ func (t *Task) MarkStart(startTime time.Time) error {
 	)
 }
 
+// IncPreemptions records that the current execution of the task was
+// interrupted because its host was preempted.
+func (t *Task) IncPreemptions() error {
+	t.Preemptions++
+	return UpdateOne(
+		bson.M{IdKey: t.Id},
+		bson.M{"$inc": bson.M{PreemptionsKey: 1}},
+	)
+}
+
+// SetAutoRetry records that the task was restarted automatically for the
+// given reason. Restarts are counted from then on.
+func (t *Task) SetAutoRetry(reason string) error {
+	t.AutoRetries++
+	t.RetryReason = reason
+	if t.Restarts < 1 {
+		t.Restarts = 1
+	}
+	return UpdateOne(
+		bson.M{IdKey: t.Id},
+		bson.M{
+			"$inc": bson.M{AutoRetriesKey: 1},
+			"$set": bson.M{RetryReasonKey: reason},
+			"$max": bson.M{RestartsKey: 1},
+		},
+	)
+}
+
+// IsFlaky returns true if the task only succeeded because it was retried
+// automatically after failing.
+func (t *Task) IsFlaky() bool {
+	return t.Status == evergreen.TaskSucceeded && t.RetryReason != ""
+}
+
 // SetResults sets the results of the task in LocalTestResults
 func (t *Task) SetResults(results []TestResult) error {
 	docs := make([]testresult.TestResult, len(results))
@@ -1057,3 +1057,34 @@ func TestFindOneIdOldOrNew(t *testing.T) {
 	assert.Equal(1, task01.Execution)
 	assert.Len(task01.LocalTestResults, 1)
 }
+
+func TestFindFailedProjectsOnHost(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	require.NoError(db.ClearCollections(Collection, OldCollection))
+
+	now := time.Now()
+	tasks := []Task{
+		{Id: "t1", HostId: "h1", Project: "p1", Status: evergreen.TaskFailed, FinishTime: now,
+			Details: apimodels.TaskEndDetail{Type: "system"}},
+		{Id: "t2", HostId: "h1", Project: "p2", Status: evergreen.TaskFailed, FinishTime: now,
+			Details: apimodels.TaskEndDetail{Type: "setup"}},
+		// test failures say nothing about the host
+		{Id: "t3", HostId: "h1", Project: "p3", Status: evergreen.TaskFailed, FinishTime: now,
+			Details: apimodels.TaskEndDetail{Type: "test"}},
+		{Id: "t4", HostId: "h1", Project: "p4", Status: evergreen.TaskFailed, FinishTime: now},
+		{Id: "t5", HostId: "h1", Project: "p5", Status: evergreen.TaskFailed, FinishTime: now.Add(-time.Hour),
+			Details: apimodels.TaskEndDetail{Type: "system"}},
+		{Id: "t6", HostId: "h2", Project: "p6", Status: evergreen.TaskFailed, FinishTime: now,
+			Details: apimodels.TaskEndDetail{Type: "system"}},
+	}
+	for _, task := range tasks {
+		require.NoError(task.Insert())
+	}
+
+	projects, err := FindFailedProjectsOnHost("h1", now.Add(-time.Minute))
+	require.NoError(err)
+	assert.Len(projects, 2)
+	assert.Contains(projects, "p1")
+	assert.Contains(projects, "p2")
+}
@@ -2,6 +2,7 @@ package model
 
 import (
 	"fmt"
+	"regexp"
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
@@ -149,6 +150,94 @@ func resetTask(taskId, caller string) error {
 	return errors.WithStack(UpdateBuildAndVersionStatusForTask(t.Id, &updates))
 }
 
+// ResetPreemptedTask requeues a task whose host was reclaimed by its cloud
+// provider while the task was running. The interrupted execution does not
+// count towards the task's maximum number of executions.
+func ResetPreemptedTask(t *task.Task, origin string, detail *apimodels.TaskEndDetail) error {
+	if err := t.IncPreemptions(); err != nil {
+		return errors.Wrapf(err, "error recording preemption of task %s", t.Id)
+	}
+	return errors.WithStack(TryResetTask(t.Id, evergreen.User, origin, detail))
+}
+
+// autoRetryLogMessages is how many of the last messages of a task's log are
+// matched against the auto-retry conditions on the log.
+const autoRetryLogMessages = 1000
+
+// AutoRetryReason returns why the task's failure should be retried under the
+// project's auto-retry policy, or an empty string if it shouldn't be.
+// Aborted and undispatched tasks are never retried. Retries are limited by
+// the policy and by the maximum number of executions of a task.
+func AutoRetryReason(policy *AutoRetryParams, t *task.Task, detail *apimodels.TaskEndDetail) (string, error) {
+	// tasks that were aborted or never ran didn't fail on their own
+	if t.Aborted || detail.Status == evergreen.TaskUndispatched {
+		return "", nil
+	}
+	if detail.Status != evergreen.TaskFailed && !t.HasFailedTests() {
+		return "", nil
+	}
+	if t.AutoRetries >= policy.Times || t.Execution-t.Preemptions >= evergreen.MaxTaskExecution {
+		return "", nil
+	}
+	if t.DisplayOnly || t.IsPartOfDisplay() {
+		return "", nil
+	}
+
+	var logMessages []apimodels.LogMessage
+	for _, condition := range policy.Conditions {
+		switch condition.Type {
+		case AutoRetrySystemFailure:
+			if detail.Type == SystemCommandType {
+				return "system failure", nil
+			}
+		case AutoRetrySetupFailure:
+			if detail.Type == SetupCommandType {
+				return "setup failure", nil
+			}
+		case AutoRetryTestFailure:
+			pattern, err := regexp.Compile(condition.Pattern)
+			if err != nil {
+				return "", errors.Wrapf(err, "invalid auto-retry pattern '%s'", condition.Pattern)
+			}
+			for _, result := range t.LocalTestResults {
+				if result.Status == evergreen.TestFailedStatus && pattern.MatchString(result.TestFile) {
+					return fmt.Sprintf("test '%s' failed", result.TestFile), nil
+				}
+			}
+		case AutoRetryLogMatch:
+			pattern, err := regexp.Compile(condition.Pattern)
+			if err != nil {
+				return "", errors.Wrapf(err, "invalid auto-retry pattern '%s'", condition.Pattern)
+			}
+			if logMessages == nil {
+				logMessages, err = FindMostRecentLogMessages(t.Id, t.Execution, autoRetryLogMessages, []string{}, []string{})
+				if err != nil {
+					return "", errors.Wrapf(err, "error finding log messages for task %s", t.Id)
+				}
+			}
+			for _, msg := range logMessages {
+				if pattern.MatchString(msg.Message) {
+					return fmt.Sprintf("log matched '%s'", condition.Pattern), nil
+				}
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// AutoRetryTask restarts a task whose failure matched the project's
+// auto-retry policy, and records why on the new execution.
+func AutoRetryTask(t *task.Task, reason, origin string, detail *apimodels.TaskEndDetail) error {
+	if t.HasFailedTests() {
+		detail.Status = evergreen.TaskFailed
+	}
+	if err := TryResetTask(t.Id, evergreen.User, origin, detail); err != nil {
+		return errors.Wrapf(err, "error restarting task %s", t.Id)
+	}
+	return errors.Wrapf(t.SetAutoRetry(reason), "error recording automatic retry of task %s", t.Id)
+}
+
 // TryResetTask resets a task
 func TryResetTask(taskId, user, origin string, detail *apimodels.TaskEndDetail) error {
 	t, err := task.FindOneNoMerge(task.ById(taskId))
@@ -158,8 +247,9 @@ func TryResetTask(taskId, user, origin string, detail *apimodels.TaskEndDetail)
 	if t.IsPartOfDisplay() {
 		return fmt.Errorf("cannot restart execution task %s because it is part of a display task", t.Id)
 	}
-	// if we've reached the max number of executions for this task, mark it as finished and failed
-	if t.Execution >= evergreen.MaxTaskExecution {
+	// if we've reached the max number of executions for this task, mark it as finished and failed.
+	// executions that ended because their host was preempted are not counted.
+	if t.Execution-t.Preemptions >= evergreen.MaxTaskExecution {
 		// restarting from the UI bypasses the restart cap
 		message := fmt.Sprintf("Task '%v' reached max execution (%v):", t.Id, evergreen.MaxTaskExecution)
 		if origin == evergreen.UIPackage || origin == evergreen.RESTV2Package {
@@ -985,6 +985,176 @@ func TestTryResetTask(t *testing.T) {
 	})
 }
 
+func TestResetPreemptedTask(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	require.NoError(db.ClearCollections(task.Collection, task.OldCollection, build.Collection, version.Collection))
+	b := &build.Build{
+		Id:      "buildtest",
+		Status:  evergreen.BuildStarted,
+		Version: "abc",
+		Tasks:   []build.TaskCache{{Id: "testone", Status: evergreen.TaskStarted}},
+	}
+	require.NoError(b.Insert())
+	v := &version.Version{
+		Id:     b.Version,
+		Status: evergreen.VersionStarted,
+	}
+	require.NoError(v.Insert())
+	// the task is on its last execution, so a normal restart would be refused
+	testTask := &task.Task{
+		Id:        "testone",
+		Activated: true,
+		BuildId:   b.Id,
+		Execution: evergreen.MaxTaskExecution,
+		Project:   "sample",
+		Status:    evergreen.TaskStarted,
+		StartTime: time.Now().Add(-time.Minute),
+	}
+	require.NoError(testTask.Insert())
+
+	detail := &apimodels.TaskEndDetail{
+		Status:      evergreen.TaskFailed,
+		Type:        SystemCommandType,
+		Description: "host preempted",
+		Preempted:   true,
+	}
+	assert.NoError(ResetPreemptedTask(testTask, "", detail))
+
+	dbTask, err := task.FindOne(task.ById(testTask.Id))
+	require.NoError(err)
+	require.NotNil(dbTask)
+	assert.Equal(evergreen.TaskUndispatched, dbTask.Status)
+	assert.Equal(evergreen.MaxTaskExecution+1, dbTask.Execution)
+	assert.Equal(1, dbTask.Preemptions)
+
+	oldTask, err := task.FindOneOld(task.ById(fmt.Sprintf("%v_%v", testTask.Id, evergreen.MaxTaskExecution)))
+	require.NoError(err)
+	require.NotNil(oldTask)
+	assert.True(oldTask.Details.Preempted)
+}
+
+func TestAutoRetryReason(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	policy := &AutoRetryParams{
+		Times: 1,
+		Conditions: []AutoRetryCondition{
+			{Type: AutoRetrySystemFailure},
+			{Type: AutoRetryTestFailure, Pattern: "^jstests/flaky/"},
+		},
+	}
+	testTask := &task.Task{
+		Id: "t",
+		LocalTestResults: []task.TestResult{
+			{TestFile: "jstests/core/a.js", Status: evergreen.TestFailedStatus},
+			{TestFile: "jstests/flaky/b.js", Status: evergreen.TestSucceededStatus},
+		},
+	}
+
+	reason, err := AutoRetryReason(policy, testTask, &apimodels.TaskEndDetail{Status: evergreen.TaskFailed, Type: SystemCommandType})
+	require.NoError(err)
+	assert.Equal("system failure", reason)
+
+	// only failed tests match
+	reason, err = AutoRetryReason(policy, testTask, &apimodels.TaskEndDetail{Status: evergreen.TaskFailed})
+	require.NoError(err)
+	assert.Empty(reason)
+	testTask.LocalTestResults[1].Status = evergreen.TestFailedStatus
+	reason, err = AutoRetryReason(policy, testTask, &apimodels.TaskEndDetail{Status: evergreen.TaskFailed})
+	require.NoError(err)
+	assert.Equal("test 'jstests/flaky/b.js' failed", reason)
+
+	// setup failures aren't retried unless the policy says so
+	reason, err = AutoRetryReason(policy, &task.Task{Id: "t"}, &apimodels.TaskEndDetail{Status: evergreen.TaskFailed, Type: SetupCommandType})
+	require.NoError(err)
+	assert.Empty(reason)
+
+	// successes, tasks that have been retried enough, and tasks on their
+	// last execution aren't retried
+	reason, err = AutoRetryReason(policy, &task.Task{Id: "t"}, &apimodels.TaskEndDetail{Status: evergreen.TaskSucceeded, Type: SystemCommandType})
+	require.NoError(err)
+	assert.Empty(reason)
+	reason, err = AutoRetryReason(policy, &task.Task{Id: "t", AutoRetries: 1}, &apimodels.TaskEndDetail{Status: evergreen.TaskFailed, Type: SystemCommandType})
+	require.NoError(err)
+	assert.Empty(reason)
+	reason, err = AutoRetryReason(policy, &task.Task{Id: "t", Execution: evergreen.MaxTaskExecution}, &apimodels.TaskEndDetail{Status: evergreen.TaskFailed, Type: SystemCommandType})
+	require.NoError(err)
+	assert.Empty(reason)
+	reason, err = AutoRetryReason(&AutoRetryParams{}, &task.Task{Id: "t"}, &apimodels.TaskEndDetail{Status: evergreen.TaskFailed, Type: SystemCommandType})
+	require.NoError(err)
+	assert.Empty(reason)
+
+	// aborted tasks and tasks that never ran aren't retried, even with
+	// failed tests
+	reason, err = AutoRetryReason(policy, &task.Task{Id: "t", Aborted: true}, &apimodels.TaskEndDetail{Status: evergreen.TaskFailed, Type: SystemCommandType})
+	require.NoError(err)
+	assert.Empty(reason)
+	reason, err = AutoRetryReason(policy, testTask, &apimodels.TaskEndDetail{Status: evergreen.TaskUndispatched})
+	require.NoError(err)
+	assert.Empty(reason)
+}
+
+func TestAutoRetryTask(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	require.NoError(db.ClearCollections(task.Collection, task.OldCollection, build.Collection, version.Collection))
+	b := &build.Build{
+		Id:      "buildtest",
+		Status:  evergreen.BuildStarted,
+		Version: "abc",
+		Tasks:   []build.TaskCache{{Id: "testone", Status: evergreen.TaskStarted}},
+	}
+	require.NoError(b.Insert())
+	v := &version.Version{
+		Id:     b.Version,
+		Status: evergreen.VersionStarted,
+	}
+	require.NoError(v.Insert())
+	testTask := &task.Task{
+		Id:        "testone",
+		Activated: true,
+		BuildId:   b.Id,
+		Project:   "sample",
+		Status:    evergreen.TaskStarted,
+		StartTime: time.Now().Add(-time.Minute),
+	}
+	require.NoError(testTask.Insert())
+
+	detail := &apimodels.TaskEndDetail{
+		Status: evergreen.TaskFailed,
+		Type:   SystemCommandType,
+	}
+	assert.NoError(AutoRetryTask(testTask, "system failure", "", detail))
+
+	dbTask, err := task.FindOne(task.ById(testTask.Id))
+	require.NoError(err)
+	require.NotNil(dbTask)
+	assert.Equal(evergreen.TaskUndispatched, dbTask.Status)
+	assert.Equal(1, dbTask.Execution)
+	assert.Equal(1, dbTask.Restarts)
+	assert.Equal(1, dbTask.AutoRetries)
+	assert.Equal("system failure", dbTask.RetryReason)
+
+	oldTask, err := task.FindOneOld(task.ById(fmt.Sprintf("%v_%v", testTask.Id, 0)))
+	require.NoError(err)
+	require.NotNil(oldTask)
+	assert.Equal(evergreen.TaskFailed, oldTask.Status)
+	assert.Empty(oldTask.RetryReason)
+
+	// restarting the task again clears the reason, but keeps counting
+	assert.NoError(TryResetTask(testTask.Id, "user", "", detail))
+	dbTask, err = task.FindOne(task.ById(testTask.Id))
+	require.NoError(err)
+	require.NotNil(dbTask)
+	assert.Equal(2, dbTask.Restarts)
+	assert.Equal(1, dbTask.AutoRetries)
+	assert.Empty(dbTask.RetryReason)
+}
+
 func TestAbortTask(t *testing.T) {
 	Convey("With a task and a build", t, func() {
 		testutil.HandleTestingErr(db.ClearCollections(task.Collection, build.Collection, version.Collection), t,
@@ -49,6 +49,10 @@ type NotificationPreferences struct {
 	BuildBreakID  bson.ObjectId              `bson:"build_break_id,omitempty" json:"-"`
 	PatchFinish   UserSubscriptionPreference `bson:"patch_finish" json:"patch_finish"`
 	PatchFinishID bson.ObjectId              `bson:"patch_finish_id,omitempty" json:"-"`
+	// SpawnHostQuota is how the user is warned that they are approaching
+	// a spawn host quota. Users are emailed unless they choose otherwise,
+	// and are not warned if they choose PreferenceNone.
+	SpawnHostQuota UserSubscriptionPreference `bson:"spawn_host_quota,omitempty" json:"spawn_host_quota,omitempty"`
 }
 
 type UserSubscriptionPreference string
@@ -56,6 +60,7 @@ type UserSubscriptionPreference string
 const (
 	PreferenceEmail UserSubscriptionPreference = event.EmailSubscriberType
 	PreferenceSlack UserSubscriptionPreference = event.SlackSubscriberType
+	PreferenceNone  UserSubscriptionPreference = "none"
 )
 
 func (u *DBUser) Username() string     { return u.Id }
@@ -166,7 +171,7 @@ func (u *DBUser) IncPatchNumber() (int, error) {
 
 func IsValidSubscriptionPreference(in string) bool {
 	switch in {
-	case event.EmailSubscriberType, event.SlackSubscriberType, "":
+	case event.EmailSubscriberType, event.SlackSubscriberType, string(PreferenceNone), "":
 		return true
 	default:
 		return false
@@ -72,13 +72,17 @@ type BuildStatus struct {
 	Activated    bool      `bson:"activated" json:"activated"`
 	ActivateAt   time.Time `bson:"activate_at,omitempty" json:"activate_at,omitempty"`
 	BuildId      string    `bson:"build_id,omitempty" json:"build_id,omitempty"`
+	// SkippedTasks are the tasks in the build that aren't activated with it,
+	// because the version's changes don't touch their paths.
+	SkippedTasks []string `bson:"skipped_tasks,omitempty" json:"skipped_tasks,omitempty"`
 }
 
 var (
-	BuildStatusVariantKey    = bsonutil.MustHaveTag(BuildStatus{}, "BuildVariant")
-	BuildStatusActivatedKey  = bsonutil.MustHaveTag(BuildStatus{}, "Activated")
-	BuildStatusActivateAtKey = bsonutil.MustHaveTag(BuildStatus{}, "ActivateAt")
-	BuildStatusBuildIdKey    = bsonutil.MustHaveTag(BuildStatus{}, "BuildId")
+	BuildStatusVariantKey      = bsonutil.MustHaveTag(BuildStatus{}, "BuildVariant")
+	BuildStatusActivatedKey    = bsonutil.MustHaveTag(BuildStatus{}, "Activated")
+	BuildStatusActivateAtKey   = bsonutil.MustHaveTag(BuildStatus{}, "ActivateAt")
+	BuildStatusBuildIdKey      = bsonutil.MustHaveTag(BuildStatus{}, "BuildId")
+	BuildStatusSkippedTasksKey = bsonutil.MustHaveTag(BuildStatus{}, "SkippedTasks")
 )
 
 type DuplicateVersionsID struct {
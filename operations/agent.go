@@ -25,6 +25,7 @@ func Agent() cli.Command {
 		logPrefixFlagName        = "log_prefix"
 		statusPortFlagName       = "status_port"
 		cleanupFlagName          = "cleanup"
+		spotInterruptionFlagName = "spot_interruption_url"
 	)
 
 	return cli.Command{
@@ -61,6 +62,10 @@ func Agent() cli.Command {
 				Name:  cleanupFlagName,
 				Usage: "clean up working directory and processes (do not set for smoke tests)",
 			},
+			cli.StringFlag{
+				Name:  spotInterruptionFlagName,
+				Usage: "URL to poll for notice that the host is about to be reclaimed",
+			},
 		},
 		Before: mergeBeforeFuncs(
 			func(c *cli.Context) error {
@@ -80,6 +85,8 @@ func Agent() cli.Command {
 				LogPrefix:        c.String(logPrefixFlagName),
 				WorkingDirectory: c.String(workingDirectoryFlagName),
 				Cleanup:          c.Bool(cleanupFlagName),
+
+				SpotInterruptionURL: c.String(spotInterruptionFlagName),
 			}
 
 			if err := os.MkdirAll(opts.WorkingDirectory, 0777); err != nil {
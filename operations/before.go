@@ -19,6 +19,13 @@ var (
 		return nil
 	}
 
+	requireRefForFork = func(c *cli.Context) error {
+		if c.String(forkFlagName) != "" && c.String(refFlagName) == "" {
+			return errors.Errorf("flag '--%s' requires '--%s'", forkFlagName, refFlagName)
+		}
+		return nil
+	}
+
 	setPlainLogger = func(c *cli.Context) error {
 		grip.CatchWarning(grip.SetSender(send.MakePlainLogger()))
 		return nil
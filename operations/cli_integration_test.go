@@ -148,7 +148,7 @@ func TestCLIFetchSource(t *testing.T) {
 		So(err, ShouldBeNil)
 		_, err = ac.GetPatches(0)
 		So(err, ShouldBeNil)
-		So(ac.UpdatePatchModule(newPatch.Id.Hex(), "render-module", testModulePatch, "1e5232709595db427893826ce19289461cba3f75"),
+		So(ac.UpdatePatchModule(newPatch.Id.Hex(), "render-module", testModulePatch, "1e5232709595db427893826ce19289461cba3f75", nil),
 			ShouldBeNil)
 		So(ac.FinalizePatch(newPatch.Id.Hex()), ShouldBeNil)
 
@@ -362,7 +362,7 @@ func TestCLIFunctions(t *testing.T) {
 				})
 
 				Convey("Adding a module to the patch should work", func() {
-					err = ac.UpdatePatchModule(newPatch.Id.Hex(), "render-module", testPatch, "1e5232709595db427893826ce19289461cba3f75")
+					err = ac.UpdatePatchModule(newPatch.Id.Hex(), "render-module", testPatch, "1e5232709595db427893826ce19289461cba3f75", nil)
 					So(err, ShouldBeNil)
 					patches, err = ac.GetPatches(0)
 					So(err, ShouldBeNil)
@@ -474,7 +474,7 @@ func TestCLIFunctions(t *testing.T) {
 				})
 
 				Convey("Adding a module to the patch should still work as designed even with empty patch", func() {
-					err = ac.UpdatePatchModule(newPatch.Id.Hex(), "render-module", emptyPatch, "1e5232709595db427893826ce19289461cba3f75")
+					err = ac.UpdatePatchModule(newPatch.Id.Hex(), "render-module", emptyPatch, "1e5232709595db427893826ce19289461cba3f75", nil)
 					So(err, ShouldBeNil)
 					patches, err := ac.GetPatches(0)
 					So(err, ShouldBeNil)
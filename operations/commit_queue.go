@@ -0,0 +1,199 @@
+package operations
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/evergreen-ci/evergreen/model/commitqueue"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+const (
+	commitQueueItemFlagName = "item"
+	commitQueuePRFlagName   = "pr"
+)
+
+func CommitQueue() cli.Command {
+	return cli.Command{
+		Name:  "commit-queue",
+		Usage: "manage a project's queue of changes to test and merge",
+		Subcommands: []cli.Command{
+			commitQueueList(),
+			commitQueueMerge(),
+			commitQueueDelete(),
+		},
+	}
+}
+
+// commitQueueProject returns the project given on the command line, or the
+// default project if none was given.
+func commitQueueProject(c *cli.Context, conf *ClientSettings) (string, error) {
+	project := c.String(projectFlagName)
+	if project == "" {
+		project = conf.FindDefaultProject()
+	}
+	if project == "" {
+		return "", errors.Errorf("flag '--%s' was not specified and there is no default project", projectFlagName)
+	}
+	return project, nil
+}
+
+func commitQueueList() cli.Command {
+	return cli.Command{
+		Name:   "list",
+		Usage:  "list the changes in a project's commit queue",
+		Flags:  addProjectFlag(),
+		Before: mergeBeforeFuncs(setPlainLogger, requireClientConfig),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().String(confFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+			project, err := commitQueueProject(c, conf)
+			if err != nil {
+				return err
+			}
+			client := conf.GetRestCommunicator(ctx)
+			defer client.Close()
+
+			cq, err := client.GetCommitQueue(ctx, project)
+			if err != nil {
+				return errors.Wrap(err, "problem fetching commit queue")
+			}
+
+			if len(cq.Queue) == 0 {
+				grip.Infof("The commit queue for project '%s' is empty", project)
+				return nil
+			}
+			for i, item := range cq.Queue {
+				status := "waiting"
+				if model.FromAPIString(item.PatchID) != "" {
+					status = "testing in patch " + model.FromAPIString(item.PatchID)
+				}
+				grip.Infof("%d: %s (%s), Author: %s, Status: %s", i, model.FromAPIString(item.Issue),
+					model.FromAPIString(item.Type), model.FromAPIString(item.Author), status)
+			}
+
+			return nil
+		},
+	}
+}
+
+func commitQueueMerge() cli.Command {
+	return cli.Command{
+		Name:  "merge",
+		Usage: "add an approved pull request, or as a project admin a branch pushed to the project's repository or a patch that checks one out, to the commit queue",
+		Flags: addProjectFlag(
+			cli.IntFlag{
+				Name:  commitQueuePRFlagName,
+				Usage: "the number of a pull request",
+			},
+			cli.StringFlag{
+				Name:  refFlagName,
+				Usage: "a branch or commit in the project's repository",
+			},
+			cli.StringFlag{
+				Name:  patchIDFlagName,
+				Usage: "the id of a patch created with --ref",
+			}),
+		Before: mergeBeforeFuncs(
+			setPlainLogger,
+			requireClientConfig,
+			func(c *cli.Context) error {
+				set := 0
+				for _, isSet := range []bool{c.Int(commitQueuePRFlagName) > 0, c.String(refFlagName) != "", c.String(patchIDFlagName) != ""} {
+					if isSet {
+						set++
+					}
+				}
+				if set != 1 {
+					return errors.Errorf("must specify one and only one of: --%s, --%s, --%s",
+						commitQueuePRFlagName, refFlagName, patchIDFlagName)
+				}
+				return nil
+			}),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().String(confFlagName)
+
+			item := &model.APICommitQueueItem{}
+			if pr := c.Int(commitQueuePRFlagName); pr > 0 {
+				item.Issue = model.ToAPIString(strconv.Itoa(pr))
+				item.Type = model.ToAPIString(commitqueue.PRItemType)
+			} else if patchID := c.String(patchIDFlagName); patchID != "" {
+				item.Issue = model.ToAPIString(patchID)
+				item.Type = model.ToAPIString(commitqueue.PatchItemType)
+			} else {
+				item.Issue = model.ToAPIString(c.String(refFlagName))
+				item.Type = model.ToAPIString(commitqueue.RefItemType)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+			project, err := commitQueueProject(c, conf)
+			if err != nil {
+				return err
+			}
+			client := conf.GetRestCommunicator(ctx)
+			defer client.Close()
+
+			position, err := client.EnqueueItem(ctx, project, item)
+			if err != nil {
+				return errors.Wrap(err, "problem adding to the commit queue")
+			}
+
+			grip.Infof("Added '%s' to the commit queue for project '%s' at position %d",
+				model.FromAPIString(item.Issue), project, position)
+			return nil
+		},
+	}
+}
+
+func commitQueueDelete() cli.Command {
+	return cli.Command{
+		Name:  "delete",
+		Usage: "remove a change from a project's commit queue, aborting its patch if it is being tested",
+		Flags: addProjectFlag(cli.StringFlag{
+			Name:  joinFlagNames(commitQueueItemFlagName, "i"),
+			Usage: "the pull request number, branch, commit or patch id to remove",
+		}),
+		Before: mergeBeforeFuncs(setPlainLogger, requireClientConfig, requireStringFlag(commitQueueItemFlagName)),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().String(confFlagName)
+			item := c.String(commitQueueItemFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+			project, err := commitQueueProject(c, conf)
+			if err != nil {
+				return err
+			}
+			client := conf.GetRestCommunicator(ctx)
+			defer client.Close()
+
+			if err = client.DeleteCommitQueueItem(ctx, project, item); err != nil {
+				return errors.Wrap(err, "problem removing from the commit queue")
+			}
+
+			grip.Infof("Removed '%s' from the commit queue for project '%s'", item, project)
+			return nil
+		},
+	}
+}
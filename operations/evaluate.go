@@ -1,8 +1,9 @@
 package operations
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
-	"io/ioutil"
 
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/pkg/errors"
@@ -14,12 +15,14 @@ func Evaluate() cli.Command {
 	const (
 		taskFlagName     = "tasks"
 		variantsFlagName = "variants"
+		diffFlagName     = "diff"
+		jsonFlagName     = "json"
 	)
 
 	return cli.Command{
 		Name:  "evaluate",
 		Usage: "reads a project configuration and expands tags and matrix definitions, printing the expanded definitions",
-		Flags: addPathFlag(
+		Flags: addPathFlag(addIncludeModuleFlag(
 			cli.BoolFlag{
 				Name:  taskFlagName,
 				Usage: "only show task and function definitions",
@@ -27,16 +30,36 @@ func Evaluate() cli.Command {
 			cli.BoolFlag{
 				Name:  variantsFlagName,
 				Usage: "only show variant definitions",
-			}),
-		Before: requirePathFlag,
+			},
+			cli.BoolFlag{
+				Name:  diffFlagName,
+				Usage: "show the differences between two configurations, given as 'evaluate --diff <old> <new>'",
+			},
+			cli.BoolFlag{
+				Name:  jsonFlagName,
+				Usage: "show the differences as JSON",
+			})...),
+		Before: func(c *cli.Context) error {
+			if !c.Bool(diffFlagName) {
+				return requirePathFlag(c)
+			}
+			if c.NArg() != 2 {
+				return errors.New("must specify the paths to the old and new configurations")
+			}
+			return nil
+		},
 		Action: func(c *cli.Context) error {
 			path := c.String(pathFlagName)
 			showTasks := c.Bool(taskFlagName)
 			showVariants := c.Bool(variantsFlagName)
 
-			configBytes, err := ioutil.ReadFile(path)
+			if c.Bool(diffFlagName) {
+				return evaluateDiff(c.Args().Get(0), c.Args().Get(1), c.StringSlice(includeModuleFlagName), c.Bool(jsonFlagName))
+			}
+
+			configBytes, err := readLocalProject(context.Background(), path, c.StringSlice(includeModuleFlagName))
 			if err != nil {
-				return errors.Wrap(err, "error reading project config")
+				return errors.WithStack(err)
 			}
 
 			p := &model.Project{}
@@ -74,3 +97,72 @@ func Evaluate() cli.Command {
 		},
 	}
 }
+
+// evaluateDiff prints the differences between the configurations at the
+// given paths, once they're translated.
+func evaluateDiff(oldPath, newPath string, modulePaths []string, asJSON bool) error {
+	ctx := context.Background()
+	projects := []*model.Project{}
+	for _, path := range []string{oldPath, newPath} {
+		configBytes, err := readLocalProject(ctx, path, modulePaths)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		p := &model.Project{}
+		if err = model.LoadProjectInto(configBytes, "", p); err != nil {
+			return errors.Wrapf(err, "error loading project '%s'", path)
+		}
+		projects = append(projects, p)
+	}
+
+	diff := model.DiffProjects(projects[0], projects[1])
+	if asJSON {
+		out, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "error marshaling diff")
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+
+	if diff.IsEmpty() {
+		fmt.Println("No differences.")
+		return nil
+	}
+	printDiffList := func(indent, heading string, names []string) {
+		for _, name := range names {
+			fmt.Printf("%s%s %s\n", indent, heading, name)
+		}
+	}
+	printDiffList("", "added variant", diff.AddedVariants)
+	printDiffList("", "removed variant", diff.RemovedVariants)
+	for _, bv := range diff.ChangedVariants {
+		fmt.Printf("changed variant %s\n", bv.Name)
+		printDiffList("  ", "added distro", bv.AddedDistros)
+		printDiffList("  ", "removed distro", bv.RemovedDistros)
+		printDiffList("  ", "changed expansion", bv.ChangedExpansions)
+		printDiffList("  ", "added task", bv.AddedTasks)
+		printDiffList("  ", "removed task", bv.RemovedTasks)
+		for _, t := range bv.ChangedTasks {
+			fmt.Printf("  changed task %s\n", t.Name)
+			printDiffList("    ", "added distro", t.AddedDistros)
+			printDiffList("    ", "removed distro", t.RemovedDistros)
+			printDiffList("    ", "added dependency", t.AddedDependencies)
+			printDiffList("    ", "removed dependency", t.RemovedDependencies)
+		}
+	}
+	printDiffList("", "added task", diff.AddedTasks)
+	printDiffList("", "removed task", diff.RemovedTasks)
+	for _, t := range diff.ChangedTasks {
+		fmt.Printf("changed task %s\n", t.Name)
+		printDiffList("  ", "added dependency", t.AddedDependencies)
+		printDiffList("  ", "removed dependency", t.RemovedDependencies)
+		if len(t.Commands) > 0 {
+			fmt.Println("  commands:")
+			for _, line := range t.Commands {
+				fmt.Printf("    %s\n", line)
+			}
+		}
+	}
+	return nil
+}
@@ -17,6 +17,7 @@ import (
 	humanize "github.com/dustin/go-humanize"
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/service"
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/mongodb/grip"
@@ -299,6 +300,13 @@ func applyPatch(patch *service.RestPatch, rootCloneDir string, conf *model.Proje
 			dir = filepath.Join(rootCloneDir, module.Prefix, module.Name)
 		}
 
+		if patchPart.Head != nil {
+			if err := checkoutHead(patchPart.Head, dir); err != nil {
+				return err
+			}
+			continue
+		}
+
 		args := []string{"apply", "--whitespace=fix"}
 		applyCmd := exec.Command("git", args...)
 		applyCmd.Stdout, applyCmd.Stderr, applyCmd.Dir = os.Stdout, os.Stderr, dir
@@ -311,6 +319,33 @@ func applyPatch(patch *service.RestPatch, rootCloneDir string, conf *model.Proje
 	return nil
 }
 
+// checkoutHead checks out the commit that a patch references instead of a
+// diff, fetching it from the fork it was pushed to if it isn't in the
+// repository that was cloned.
+func checkoutHead(head *patch.PatchHead, dir string) error {
+	if err := head.Validate(); err != nil {
+		return errors.Wrap(err, "invalid head commit")
+	}
+	fmt.Printf("Checking out commit %v instead of applying a patch\n", head.HeadHash)
+
+	remote := "origin"
+	if head.IsFork() {
+		remote = fmt.Sprintf("git@github.com:%s/%s.git", head.Owner, head.Repo)
+	}
+	for _, args := range [][]string{
+		{"fetch", remote, head.HeadHash},
+		{"reset", "--hard", head.HeadHash},
+	} {
+		grip.Debug(args)
+		c := exec.Command("git", args...)
+		c.Stdout, c.Stderr, c.Dir = os.Stdout, os.Stderr, dir
+		if err := c.Run(); err != nil {
+			return errors.Wrapf(err, "problem checking out commit %s", head.HeadHash)
+		}
+	}
+	return nil
+}
+
 func fetchArtifacts(rc *legacyClient, taskId string, rootDir string, shallow bool) error {
 	task, err := rc.GetTask(taskId)
 	if err != nil {
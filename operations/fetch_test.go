@@ -0,0 +1,59 @@
+package operations
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyPatchChecksOutHead(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "apply-patch-head")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	origin := filepath.Join(dir, "origin")
+	clone := filepath.Join(dir, "clone")
+
+	git := func(dir string, args ...string) string {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(err, string(out))
+		return strings.TrimSpace(string(out))
+	}
+	require.NoError(os.Mkdir(origin, 0755))
+	git(origin, "init", "-q")
+	git(origin, "commit", "-q", "--allow-empty", "-m", "base")
+	git(dir, "clone", "-q", origin, clone)
+
+	// the head is pushed after the source was cloned, so it has to be
+	// fetched
+	git(origin, "checkout", "-q", "-b", "feature")
+	require.NoError(ioutil.WriteFile(filepath.Join(origin, "renamed.bin"), []byte{0, 1, 2}, 0644))
+	git(origin, "add", "renamed.bin")
+	git(origin, "commit", "-q", "-m", "feature")
+	head := git(origin, "rev-parse", "HEAD")
+
+	p := &service.RestPatch{Patches: []patch.ModulePatch{{Head: &patch.PatchHead{Ref: "feature", HeadHash: head}}}}
+	require.NoError(applyPatch(p, clone, &model.Project{}, &model.BuildVariant{}))
+	assert.Equal(head, git(clone, "rev-parse", "HEAD"))
+	_, err = os.Stat(filepath.Join(clone, "renamed.bin"))
+	assert.NoError(err)
+
+	// heads end up in git commands, so they're validated first
+	p.Patches[0].Head.HeadHash = "HEAD"
+	assert.Error(applyPatch(p, clone, &model.Project{}, &model.BuildVariant{}))
+	p.Patches[0].Head = &patch.PatchHead{Owner: "me", Repo: "fork.git --upload-pack=touch", HeadHash: head}
+	assert.Error(applyPatch(p, clone, &model.Project{}, &model.BuildVariant{}))
+}
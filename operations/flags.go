@@ -22,6 +22,8 @@ const (
 	hostFlagName       = "host"
 	startTimeFlagName  = "time"
 	limitFlagName      = "limit"
+	refFlagName        = "ref"
+	forkFlagName       = "fork"
 
 	anserDryRunFlagName      = "dry-run"
 	anserLimitFlagName       = "limit"
@@ -109,6 +111,19 @@ func addModuleFlag(flags ...cli.Flag) []cli.Flag {
 	})
 }
 
+func addPatchHeadFlags(flags ...cli.Flag) []cli.Flag {
+	return append(flags,
+		cli.StringFlag{
+			Name: refFlagName,
+			Usage: "instead of uploading a diff, check out a branch or commit, or the head of a " +
+				"commit range given as 'base..head', that has been pushed",
+		},
+		cli.StringFlag{
+			Name:  forkFlagName,
+			Usage: "the fork, as 'owner/repo', that the ref was pushed to, if not the tracked repository",
+		})
+}
+
 func addYesFlag(flags ...cli.Flag) []cli.Flag {
 	return append(flags, cli.BoolFlag{
 		Name:  joinFlagNames(yesFlagName, "y"),
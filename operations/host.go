@@ -10,6 +10,10 @@ func Host() cli.Command {
 			hostCreate(),
 			hostlist(),
 			hostTerminate(),
+			hostReprovision(),
+			hostStop(),
+			hostStart(),
+			hostSleepSchedule(),
 			hostStatus(),
 			hostSetup(),
 			hostTeardown(),
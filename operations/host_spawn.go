@@ -21,8 +21,11 @@ func hostStatus() cli.Command {
 
 func hostCreate() cli.Command {
 	const (
-		distroFlagName = "distro"
-		keyFlagName    = "key"
+		distroFlagName         = "distro"
+		keyFlagName            = "key"
+		taskFlagName           = "task"
+		homeVolumeFlagName     = "home-volume"
+		homeVolumeSizeFlagName = "home-volume-size"
 	)
 
 	return cli.Command{
@@ -37,11 +40,26 @@ func hostCreate() cli.Command {
 				Name:  joinFlagNames(keyFlagName, "k"),
 				Usage: "name or value of an public key to use",
 			},
+			cli.StringFlag{
+				Name:  joinFlagNames(taskFlagName, "t"),
+				Usage: "set up the host with the source, artifacts and expansions of a task, and use its distro by default",
+			},
+			cli.BoolFlag{
+				Name:  homeVolumeFlagName,
+				Usage: "attach your persistent home volume to the host, creating it if you don't have one",
+			},
+			cli.IntFlag{
+				Name:  homeVolumeSizeFlagName,
+				Usage: "size in GiB of the home volume, if it is created for this host",
+			},
 		},
 		Action: func(c *cli.Context) error {
 			confPath := c.Parent().String(confFlagName)
 			distro := c.String(distroFlagName)
 			key := c.String(keyFlagName)
+			taskID := c.String(taskFlagName)
+			homeVolume := c.Bool(homeVolumeFlagName)
+			homeVolumeSize := c.Int(homeVolumeSizeFlagName)
 
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
@@ -53,7 +71,13 @@ func hostCreate() cli.Command {
 			client := conf.GetRestCommunicator(ctx)
 			defer client.Close()
 
-			host, err := client.CreateSpawnHost(ctx, distro, key)
+			host, err := client.CreateSpawnHost(ctx, &model.HostPostRequest{
+				DistroID:       distro,
+				KeyName:        key,
+				TaskID:         taskID,
+				HomeVolume:     homeVolume || homeVolumeSize > 0,
+				HomeVolumeSize: homeVolumeSize,
+			})
 			if host == nil {
 				return errors.New("Unable to create a spawn host. Double check that the params and .evergreen.yml are correct")
 			}
@@ -165,3 +189,173 @@ func hostTerminate() cli.Command {
 		},
 	}
 }
+
+func hostReprovision() cli.Command {
+	return cli.Command{
+		Name:   "reprovision",
+		Usage:  "drain a static host, then run its distro's teardown and setup scripts again",
+		Flags:  addHostFlag(),
+		Before: mergeBeforeFuncs(setPlainLogger, requireHostFlag),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().String(confFlagName)
+			hostID := c.String(hostFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+			client := conf.GetRestCommunicator(ctx)
+			defer client.Close()
+
+			err = client.ReprovisionHost(ctx, hostID)
+			if err != nil {
+				return errors.Wrap(err, "problem reprovisioning host")
+			}
+
+			grip.Infof("Host '%s' will be reprovisioned once it finishes its current task", hostID)
+
+			return nil
+		},
+	}
+}
+
+func hostStop() cli.Command {
+	return cli.Command{
+		Name:   "stop",
+		Usage:  "stop a running spawn host, keeping its disks so that it can be started again",
+		Flags:  addHostFlag(),
+		Before: mergeBeforeFuncs(setPlainLogger, requireHostFlag),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().String(confFlagName)
+			hostID := c.String(hostFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+			client := conf.GetRestCommunicator(ctx)
+			defer client.Close()
+
+			err = client.StopSpawnHost(ctx, hostID)
+			if err != nil {
+				return errors.Wrap(err, "problem stopping host")
+			}
+
+			grip.Infof("Stopping host '%s'", hostID)
+
+			return nil
+		},
+	}
+}
+
+func hostStart() cli.Command {
+	return cli.Command{
+		Name:   "start",
+		Usage:  "start a stopped spawn host",
+		Flags:  addHostFlag(),
+		Before: mergeBeforeFuncs(setPlainLogger, requireHostFlag),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().String(confFlagName)
+			hostID := c.String(hostFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+			client := conf.GetRestCommunicator(ctx)
+			defer client.Close()
+
+			err = client.StartSpawnHost(ctx, hostID)
+			if err != nil {
+				return errors.Wrap(err, "problem starting host")
+			}
+
+			grip.Infof("Starting host '%s'", hostID)
+
+			return nil
+		},
+	}
+}
+
+func hostSleepSchedule() cli.Command {
+	const (
+		stopHourFlagName  = "stop-hour"
+		startHourFlagName = "start-hour"
+		daysOffFlagName   = "days-off"
+		timeZoneFlagName  = "time-zone"
+		removeFlagName    = "remove"
+	)
+
+	return cli.Command{
+		Name:  "sleep-schedule",
+		Usage: "stop a spawn host automatically every night and on days off, and start it again afterward",
+		Flags: addHostFlag(
+			cli.IntFlag{
+				Name:  stopHourFlagName,
+				Usage: "the hour of the day (0-23) at which to stop the host",
+			},
+			cli.IntFlag{
+				Name:  startHourFlagName,
+				Usage: "the hour of the day (0-23) at which to start the host",
+			},
+			cli.StringSliceFlag{
+				Name:  daysOffFlagName,
+				Usage: "days of the week (e.g. saturday) on which to keep the host stopped",
+			},
+			cli.StringFlag{
+				Name:  timeZoneFlagName,
+				Usage: "the time zone of the schedule's hours (e.g. America/New_York); defaults to UTC",
+			},
+			cli.BoolFlag{
+				Name:  removeFlagName,
+				Usage: "remove the host's sleep schedule",
+			}),
+		Before: mergeBeforeFuncs(setPlainLogger, requireHostFlag),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().String(confFlagName)
+			hostID := c.String(hostFlagName)
+
+			var schedule *model.APISleepSchedule
+			if !c.Bool(removeFlagName) {
+				schedule = &model.APISleepSchedule{
+					StopHour:     c.Int(stopHourFlagName),
+					StartHour:    c.Int(startHourFlagName),
+					WholeDaysOff: c.StringSlice(daysOffFlagName),
+					TimeZone:     c.String(timeZoneFlagName),
+				}
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+			client := conf.GetRestCommunicator(ctx)
+			defer client.Close()
+
+			err = client.SetSpawnHostSleepSchedule(ctx, hostID, schedule)
+			if err != nil {
+				return errors.Wrap(err, "problem setting sleep schedule")
+			}
+
+			if schedule == nil {
+				grip.Infof("Removed the sleep schedule of host '%s'", hostID)
+			} else {
+				grip.Infof("Set the sleep schedule of host '%s'", hostID)
+			}
+
+			return nil
+		},
+	}
+}
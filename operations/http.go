@@ -127,9 +127,20 @@ func (ac *legacyClient) modifyExisting(patchId, action string) error {
 	return nil
 }
 
-// ValidateLocalConfig validates the local project config with the server
-func (ac *legacyClient) ValidateLocalConfig(data []byte) ([]validator.ValidationError, error) {
-	resp, err := ac.post("validate", bytes.NewBuffer(data))
+// ValidateLocalConfig validates the local project config with the server. If
+// lint is true, lint warnings are included, checked against the given
+// project if it isn't empty.
+func (ac *legacyClient) ValidateLocalConfig(data []byte, lint bool, projectID string) ([]validator.ValidationError, error) {
+	path := "validate"
+	if lint {
+		query := url.Values{}
+		query.Set("lint", "true")
+		if projectID != "" {
+			query.Set("project", projectID)
+		}
+		path += "?" + query.Encode()
+	}
+	resp, err := ac.post(path, bytes.NewBuffer(data))
 	if err != nil {
 		return nil, err
 	}
@@ -268,12 +279,19 @@ func (ac *legacyClient) DeletePatchModule(patchId, module string) error {
 }
 
 // UpdatePatchModule makes a request to the API server to set a module patch on the given patch ID.
-func (ac *legacyClient) UpdatePatchModule(patchId, module, patch, base string) error {
+func (ac *legacyClient) UpdatePatchModule(patchId, module, patch, base string, head *patchHead) error {
 	data := struct {
-		Module  string `json:"module"`
-		Patch   string `json:"patch"`
-		Githash string `json:"githash"`
-	}{module, patch, base}
+		Module    string `json:"module"`
+		Patch     string `json:"patch"`
+		Githash   string `json:"githash"`
+		HeadOwner string `json:"head_owner,omitempty"`
+		HeadRepo  string `json:"head_repo,omitempty"`
+		HeadRef   string `json:"head_ref,omitempty"`
+		HeadHash  string `json:"head_hash,omitempty"`
+	}{Module: module, Patch: patch, Githash: base}
+	if head != nil {
+		data.HeadOwner, data.HeadRepo, data.HeadRef, data.HeadHash = head.owner, head.repo, head.ref, head.hash
+	}
 
 	rPipe, wPipe := io.Pipe()
 	encoder := json.NewEncoder(wPipe)
@@ -365,15 +383,26 @@ func (ac *legacyClient) PutPatch(incomingPatch patchSubmission) (*patch.Patch, e
 		Tasks       []string `json:"tasks"`
 		Finalize    bool     `json:"finalize"`
 		Alias       string   `json:"alias"`
+		HeadOwner   string   `json:"head_owner,omitempty"`
+		HeadRepo    string   `json:"head_repo,omitempty"`
+		HeadRef     string   `json:"head_ref,omitempty"`
+		HeadHash    string   `json:"head_hash,omitempty"`
+		LocalBranch string   `json:"local_branch,omitempty"`
+		KeepPrev    bool     `json:"keep_previous,omitempty"`
 	}{
-		incomingPatch.description,
-		incomingPatch.projectId,
-		incomingPatch.patchData,
-		incomingPatch.base,
-		incomingPatch.variants,
-		incomingPatch.tasks,
-		incomingPatch.finalize,
-		incomingPatch.alias,
+		Description: incomingPatch.description,
+		Project:     incomingPatch.projectId,
+		Patch:       incomingPatch.patchData,
+		Githash:     incomingPatch.base,
+		Variants:    incomingPatch.variants,
+		Tasks:       incomingPatch.tasks,
+		Finalize:    incomingPatch.finalize,
+		Alias:       incomingPatch.alias,
+		LocalBranch: incomingPatch.localBranch,
+		KeepPrev:    incomingPatch.keepPrevious,
+	}
+	if head := incomingPatch.head; head != nil {
+		data.HeadOwner, data.HeadRepo, data.HeadRef, data.HeadHash = head.owner, head.repo, head.ref, head.hash
 	}
 
 	rPipe, wPipe := io.Pipe()
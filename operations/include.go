@@ -0,0 +1,100 @@
+package operations
+
+import (
+	"context"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const includeModuleFlagName = "include-module"
+
+func addIncludeModuleFlag(flags ...cli.Flag) []cli.Flag {
+	return append(flags, cli.StringSliceFlag{
+		Name:  includeModuleFlagName,
+		Usage: "read files the project includes from a module in a local checkout of it, given as 'name=path'",
+	})
+}
+
+// readLocalProject reads the project configuration at the given path. If
+// the configuration includes other files, they are read from the repository
+// the configuration is in and from local checkouts of modules at their
+// pinned refs, and the configuration is returned with them merged in.
+func readLocalProject(ctx context.Context, path string, modulePaths []string) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading project config")
+	}
+
+	includes := struct {
+		Include []model.Include `yaml:"include"`
+	}{}
+	if err = yaml.Unmarshal(data, &includes); err != nil || len(includes.Include) == 0 {
+		// configurations without includes are returned as they are, so
+		// that errors in them are reported as usual
+		return data, nil
+	}
+
+	fetch, err := localIncludeFetcher(path, modulePaths)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	project := &model.Project{}
+	if err = model.LoadProjectWithIncludes(ctx, data, "", fetch, project); err != nil {
+		return nil, errors.Wrap(err, "error loading project")
+	}
+	merged, err := yaml.Marshal(project)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshaling project")
+	}
+	return merged, nil
+}
+
+// localIncludeFetcher returns an IncludeFetcher that reads files from the
+// working tree of the repository that the configuration at the given path
+// is in, so that uncommitted changes are included, and reads files from
+// modules at their pinned refs in the given local checkouts.
+func localIncludeFetcher(path string, modulePaths []string) (model.IncludeFetcher, error) {
+	checkouts := map[string]string{}
+	for _, mp := range modulePaths {
+		parts := strings.SplitN(mp, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, errors.Errorf("'%s' must be given as 'name=path'", mp)
+		}
+		checkouts[parts[0]] = parts[1]
+	}
+
+	dir := filepath.Dir(path)
+	root := dir
+	if out, err := exec.Command("git", "-C", dir, "rev-parse", "--show-toplevel").Output(); err == nil {
+		root = strings.TrimSpace(string(out))
+	}
+
+	return func(ctx context.Context, module *model.Module, file string) ([]byte, error) {
+		if module == nil {
+			data, err := ioutil.ReadFile(filepath.Join(root, file))
+			return data, errors.WithStack(err)
+		}
+
+		checkout, ok := checkouts[module.Name]
+		if !ok {
+			return nil, errors.Errorf("module '%s' has included files, but no local checkout of it was given with '--%s %s=<path>'",
+				module.Name, includeModuleFlagName, module.Name)
+		}
+		out, err := exec.CommandContext(ctx, "git", "-C", checkout, "show", module.Ref+":"+file).Output()
+		if err != nil {
+			msg := err.Error()
+			if exitErr, ok := err.(*exec.ExitError); ok {
+				msg = strings.TrimSpace(string(exitErr.Stderr))
+			}
+			return nil, errors.Errorf("'git show %s:%s' in '%s' failed: %s", module.Ref, file, checkout, msg)
+		}
+		return out, nil
+	}, nil
+}
@@ -0,0 +1,69 @@
+package operations
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadLocalProject(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "evergreen-include")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	mainPath := filepath.Join(dir, "evergreen.yml")
+	require.NoError(ioutil.WriteFile(mainPath, []byte(`
+include:
+  - filename: tasks.yml
+buildvariants:
+  - name: linux
+    run_on: [distro]
+    tasks:
+      - name: compile
+`), 0644))
+	require.NoError(ioutil.WriteFile(filepath.Join(dir, "tasks.yml"), []byte(`
+tasks:
+  - name: compile
+`), 0644))
+
+	data, err := readLocalProject(context.Background(), mainPath, nil)
+	require.NoError(err)
+	p := &model.Project{}
+	require.NoError(model.LoadProjectInto(data, "", p))
+	require.Len(p.Tasks, 1)
+	assert.Equal("compile", p.Tasks[0].Name)
+	require.Len(p.BuildVariants, 1)
+
+	// configurations without includes are returned as they are
+	plain := "tasks:\n  - name: compile\n"
+	require.NoError(ioutil.WriteFile(mainPath, []byte(plain), 0644))
+	data, err = readLocalProject(context.Background(), mainPath, nil)
+	require.NoError(err)
+	assert.Equal(plain, string(data))
+
+	// modules need a local checkout
+	require.NoError(ioutil.WriteFile(mainPath, []byte(`
+modules:
+  - name: enterprise
+    repo: git@github.com:evergreen-ci/enterprise.git
+    ref: abcdef
+include:
+  - filename: tasks.yml
+    module: enterprise
+`), 0644))
+	_, err = readLocalProject(context.Background(), mainPath, nil)
+	require.Error(err)
+	assert.Contains(err.Error(), "--include-module enterprise=<path>")
+
+	_, err = readLocalProject(context.Background(), mainPath, []string{"enterprise"})
+	assert.Error(err)
+}
@@ -3,7 +3,6 @@ package operations
 import (
 	"context"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"sort"
 	"text/tabwriter"
@@ -279,9 +278,9 @@ func listDistros(ctx context.Context, confPath string, onlyUserSpawnable bool) e
 
 // LoadLocalConfig loads the local project config into a project
 func loadLocalConfig(filepath string) (*model.Project, error) {
-	configBytes, err := ioutil.ReadFile(filepath)
+	configBytes, err := readLocalProject(context.Background(), filepath, nil)
 	if err != nil {
-		return nil, errors.Wrap(err, "error reading project config")
+		return nil, errors.WithStack(err)
 	}
 
 	project := &model.Project{}
@@ -13,6 +13,7 @@ const (
 	patchFinalizeFlagName    = "finalize"
 	patchVerboseFlagName     = "verbose"
 	patchAliasFlagName       = "alias"
+	patchKeepPrevFlagName    = "keep-previous"
 )
 
 func getPatchFlags(flags ...cli.Flag) []cli.Flag {
@@ -32,29 +33,34 @@ func getPatchFlags(flags ...cli.Flag) []cli.Flag {
 		cli.BoolFlag{
 			Name:  patchVerboseFlagName,
 			Usage: "show patch summary",
+		},
+		cli.BoolFlag{
+			Name:  patchKeepPrevFlagName,
+			Usage: "keep running your earlier patches from the same branch, if the project aborts superseded patches",
 		}))
 }
 
 func Patch() cli.Command {
 	return cli.Command{
 		Name:    "patch",
-		Before:  setPlainLogger,
 		Aliases: []string{"create-patch", "submit-patch"},
 		Usage:   "submit a new patch to evergreen",
-		Flags:   getPatchFlags(),
+		Flags:   getPatchFlags(addPatchHeadFlags()...),
+		Before:  mergeBeforeFuncs(setPlainLogger, requireRefForFork),
 		Action: func(c *cli.Context) error {
 			confPath := c.Parent().String(confFlagName)
 			args := c.Args()
 			params := &patchParams{
-				Project:     c.String(projectFlagName),
-				Variants:    c.StringSlice(variantsFlagName),
-				Tasks:       c.StringSlice(tasksFlagName),
-				SkipConfirm: c.Bool(yesFlagName),
-				Description: c.String(patchDescriptionFlagName),
-				Finalize:    c.Bool(patchFinalizeFlagName),
-				ShowSummary: c.Bool(patchVerboseFlagName),
-				Large:       c.Bool(largeFlagName),
-				Alias:       c.String(patchAliasFlagName),
+				Project:      c.String(projectFlagName),
+				Variants:     c.StringSlice(variantsFlagName),
+				Tasks:        c.StringSlice(tasksFlagName),
+				SkipConfirm:  c.Bool(yesFlagName),
+				Description:  c.String(patchDescriptionFlagName),
+				Finalize:     c.Bool(patchFinalizeFlagName),
+				ShowSummary:  c.Bool(patchVerboseFlagName),
+				Large:        c.Bool(largeFlagName),
+				Alias:        c.String(patchAliasFlagName),
+				KeepPrevious: c.Bool(patchKeepPrevFlagName),
 			}
 
 			ctx, cancel := context.WithCancel(context.Background())
@@ -77,7 +83,12 @@ func Patch() cli.Command {
 				return err
 			}
 
-			diffData, err := loadGitData(ref.Branch, args...)
+			var diffData *localDiff
+			if headRef := c.String(refFlagName); headRef != "" {
+				diffData, err = loadGitHead(ref.Branch, headRef, c.String(forkFlagName))
+			} else {
+				diffData, err = loadGitData(ref.Branch, args...)
+			}
 			if err != nil {
 				return err
 			}
@@ -110,14 +121,15 @@ func PatchFile() cli.Command {
 		Action: func(c *cli.Context) error {
 			confPath := c.Parent().String(confFlagName)
 			params := &patchParams{
-				Project:     c.String(projectFlagName),
-				Variants:    c.StringSlice(variantsFlagName),
-				Tasks:       c.StringSlice(tasksFlagName),
-				SkipConfirm: c.Bool(yesFlagName),
-				Description: c.String(patchDescriptionFlagName),
-				Finalize:    c.Bool(patchFinalizeFlagName),
-				ShowSummary: c.Bool(patchVerboseFlagName),
-				Large:       c.Bool(largeFlagName),
+				Project:      c.String(projectFlagName),
+				Variants:     c.StringSlice(variantsFlagName),
+				Tasks:        c.StringSlice(tasksFlagName),
+				SkipConfirm:  c.Bool(yesFlagName),
+				Description:  c.String(patchDescriptionFlagName),
+				Finalize:     c.Bool(patchFinalizeFlagName),
+				ShowSummary:  c.Bool(patchVerboseFlagName),
+				Large:        c.Bool(largeFlagName),
+				KeepPrevious: c.Bool(patchKeepPrevFlagName),
 			}
 			diffPath := c.String(diffPathFlagName)
 			base := c.String(baseFlagName)
@@ -146,7 +158,7 @@ func PatchFile() cli.Command {
 				return errors.Wrap(err, "problem reading diff file")
 			}
 
-			diffData := &localDiff{string(fullPatch), "", "", base}
+			diffData := &localDiff{fullPatch: string(fullPatch), base: base}
 
 			return params.createPatch(ac, conf, diffData)
 		},
@@ -18,12 +18,12 @@ func PatchSetModule() cli.Command {
 		Name:    "patch-set-module",
 		Aliases: []string{"set-module"},
 		Usage:   "update or add module to an existing patch",
-		Flags: mergeFlagSlices(addPatchIDFlag(), addPathFlag(), addModuleFlag(), addYesFlag(
+		Flags: mergeFlagSlices(addPatchIDFlag(), addPathFlag(), addModuleFlag(), addPatchHeadFlags(), addYesFlag(
 			cli.BoolFlag{
 				Name:  largeFlagName,
 				Usage: "enable submitting larger patches (>16MB)",
 			})),
-		Before: mergeBeforeFuncs(requirePatchIDFlag, requireModuleFlag),
+		Before: mergeBeforeFuncs(requirePatchIDFlag, requireModuleFlag, requireRefForFork),
 		Action: func(c *cli.Context) error {
 			confPath := c.Parent().String(confFlagName)
 			module := c.String(moduleFlagName)
@@ -69,7 +69,12 @@ func PatchSetModule() cli.Command {
 			}
 
 			// diff against the module branch.
-			diffData, err := loadGitData(moduleBranch, args...)
+			var diffData *localDiff
+			if headRef := c.String(refFlagName); headRef != "" {
+				diffData, err = loadGitHead(moduleBranch, headRef, c.String(forkFlagName))
+			} else {
+				diffData, err = loadGitData(moduleBranch, args...)
+			}
 			if err != nil {
 				return err
 			}
@@ -82,13 +87,16 @@ func PatchSetModule() cli.Command {
 				if diffData.patchSummary != "" {
 					fmt.Println(diffData.patchSummary)
 				}
+				if diffData.log != "" {
+					fmt.Println(diffData.log)
+				}
 
 				if !confirm("This is a summary of the patch to be submitted. Continue? (y/n):", true) {
 					return nil
 				}
 			}
 
-			err = ac.UpdatePatchModule(patchID, module, diffData.fullPatch, diffData.base)
+			err = ac.UpdatePatchModule(patchID, module, diffData.fullPatch, diffData.base, diffData.head)
 			if err != nil {
 				mods, err := ac.GetPatchModules(patchID, project)
 				var msg string
@@ -42,36 +42,53 @@ type localDiff struct {
 	patchSummary string
 	log          string
 	base         string
+	// head is set instead of fullPatch if the patch checks out a commit
+	head *patchHead
+	// localBranch is the local branch that the changes are on, if any
+	localBranch string
+}
+
+// patchHead is a commit that's been pushed to the project's repository, or
+// to a fork of it, that a patch checks out instead of applying a diff.
+type patchHead struct {
+	owner string
+	repo  string
+	ref   string
+	hash  string
 }
 
 type patchParams struct {
-	Project     string
-	Variants    []string
-	Tasks       []string
-	Description string
-	Alias       string
-	SkipConfirm bool
-	Finalize    bool
-	Large       bool
-	ShowSummary bool
+	Project      string
+	Variants     []string
+	Tasks        []string
+	Description  string
+	Alias        string
+	SkipConfirm  bool
+	Finalize     bool
+	Large        bool
+	ShowSummary  bool
+	KeepPrevious bool
 }
 
 type patchSubmission struct {
-	projectId   string
-	patchData   string
-	description string
-	base        string
-	alias       string
-	variants    string
-	tasks       []string
-	finalize    bool
+	projectId    string
+	patchData    string
+	description  string
+	base         string
+	alias        string
+	variants     string
+	tasks        []string
+	finalize     bool
+	head         *patchHead
+	localBranch  string
+	keepPrevious bool
 }
 
 func (p *patchParams) createPatch(ac *legacyClient, conf *ClientSettings, diffData *localDiff) error {
 	if err := validatePatchSize(diffData, p.Large); err != nil {
 		return err
 	}
-	if !p.SkipConfirm && len(diffData.fullPatch) == 0 {
+	if !p.SkipConfirm && len(diffData.fullPatch) == 0 && diffData.head == nil {
 		if !confirm("Patch submission is empty. Continue?(y/n)", true) {
 			return nil
 		}
@@ -88,14 +105,17 @@ func (p *patchParams) createPatch(ac *legacyClient, conf *ClientSettings, diffDa
 
 	variantsStr := strings.Join(p.Variants, ",")
 	patchSub := patchSubmission{
-		projectId:   p.Project,
-		patchData:   diffData.fullPatch,
-		description: p.Description,
-		base:        diffData.base,
-		variants:    variantsStr,
-		tasks:       p.Tasks,
-		finalize:    p.Finalize,
-		alias:       p.Alias,
+		projectId:    p.Project,
+		patchData:    diffData.fullPatch,
+		description:  p.Description,
+		base:         diffData.base,
+		variants:     variantsStr,
+		tasks:        p.Tasks,
+		finalize:     p.Finalize,
+		alias:        p.Alias,
+		head:         diffData.head,
+		localBranch:  diffData.localBranch,
+		keepPrevious: p.KeepPrevious,
 	}
 
 	newPatch, err := ac.PutPatch(patchSub)
@@ -308,7 +328,96 @@ func loadGitData(branch string, extraArgs ...string) (*localDiff, error) {
 	if err != nil {
 		return nil, errors.Errorf("Error getting patch: %v", err)
 	}
-	return &localDiff{patch, stat, log, mergeBase}, nil
+	return &localDiff{
+		fullPatch:    patch,
+		patchSummary: stat,
+		log:          log,
+		base:         mergeBase,
+		localBranch:  gitBranchName("HEAD"),
+	}, nil
+}
+
+// loadGitHead resolves a branch or commit, or a commit range written as
+// "base..head", in the current git working directory to a patch that checks
+// out the head commit instead of applying a diff. The head must be pushed to
+// the project's repository, or to the fork given as "owner/repo". If there is
+// no range, the base is the merge base with the given branch's upstream, as
+// with diffs.
+func loadGitHead(branch, ref, fork string) (*localDiff, error) {
+	head := &patchHead{ref: ref}
+	if fork != "" {
+		parts := strings.Split(fork, "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, errors.Errorf("fork '%s' is not of the form 'owner/repo'", fork)
+		}
+		head.owner, head.repo = parts[0], parts[1]
+	}
+
+	var err error
+	var base string
+	headRef := ref
+	if parts := strings.SplitN(ref, "..", 2); len(parts) == 2 {
+		if parts[0] == "" || parts[1] == "" || strings.HasPrefix(parts[1], ".") {
+			return nil, errors.Errorf("commit range '%s' is not of the form 'base..head'", ref)
+		}
+		base, err = gitRevParse(parts[0])
+		if err != nil {
+			return nil, errors.Wrap(err, "Error getting base commit")
+		}
+		headRef = parts[1]
+	}
+
+	head.hash, err = gitRevParse(headRef)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error getting head commit")
+	}
+	if base == "" {
+		base, err = gitMergeBase(branch+"@{upstream}", head.hash)
+		if err != nil {
+			return nil, errors.Errorf("Error getting merge base: %v", err)
+		}
+	}
+
+	stat, err := gitDiff(base, "--stat", head.hash)
+	if err != nil {
+		return nil, errors.Errorf("Error getting diff summary: %v", err)
+	}
+	log, err := gitCmd("log", fmt.Sprintf("%s..%s", base, head.hash), "--oneline")
+	if err != nil {
+		return nil, errors.Errorf("git log: %v", err)
+	}
+
+	return &localDiff{
+		patchSummary: stat,
+		log:          log,
+		base:         base,
+		head:         head,
+		localBranch:  gitBranchName(headRef),
+	}, nil
+}
+
+// gitRevParse returns the hash of the commit that the ref refers to.
+func gitRevParse(ref string) (string, error) {
+	out, err := gitCmd("rev-parse", "", "--verify", ref+"^{commit}")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// gitBranchName returns the name of the local branch that the ref refers
+// to, or an empty string if it isn't a branch, like a commit hash or a
+// detached HEAD.
+func gitBranchName(ref string) string {
+	out, err := gitCmd("rev-parse", "", "--abbrev-ref", ref)
+	if err != nil {
+		return ""
+	}
+	name := strings.TrimSpace(out)
+	if name == "HEAD" {
+		return ""
+	}
+	return name
 }
 
 // gitMergeBase runs "git merge-base <branch1> <branch2>" and returns the
@@ -0,0 +1,68 @@
+package operations
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadGitHead(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "load-git-head")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	cwd, err := os.Getwd()
+	require.NoError(err)
+	require.NoError(os.Chdir(dir))
+	defer func() { assert.NoError(os.Chdir(cwd)) }()
+
+	git := func(args ...string) {
+		out, err := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...).CombinedOutput()
+		require.NoError(err, string(out))
+	}
+	git("init", "-q")
+	git("symbolic-ref", "HEAD", "refs/heads/master")
+	require.NoError(ioutil.WriteFile(filepath.Join(dir, "a.txt"), []byte("a\n"), 0644))
+	git("add", "a.txt")
+	git("commit", "-q", "-m", "base")
+	base, err := gitRevParse("HEAD")
+	require.NoError(err)
+	require.NoError(ioutil.WriteFile(filepath.Join(dir, "b.bin"), []byte{0, 1, 2}, 0644))
+	git("add", "b.bin")
+	git("commit", "-q", "-m", "binary file")
+	head, err := gitRevParse("HEAD")
+	require.NoError(err)
+
+	diffData, err := loadGitHead("master", "HEAD~1..HEAD", "me/fork")
+	require.NoError(err)
+	assert.Equal(base, diffData.base)
+	assert.Empty(diffData.fullPatch)
+	assert.Contains(diffData.patchSummary, "b.bin")
+	assert.Contains(diffData.log, "binary file")
+	require.NotNil(diffData.head)
+	assert.Equal(head, diffData.head.hash)
+	assert.Equal("HEAD~1..HEAD", diffData.head.ref)
+	assert.Equal("me", diffData.head.owner)
+	assert.Equal("fork", diffData.head.repo)
+	assert.Equal("master", diffData.localBranch)
+
+	diffData, err = loadGitHead("master", base+".."+head, "")
+	require.NoError(err)
+	assert.Empty(diffData.localBranch)
+
+	_, err = loadGitHead("master", "HEAD~1..HEAD", "fork")
+	assert.Error(err)
+	_, err = loadGitHead("master", "HEAD~1...HEAD", "")
+	assert.Error(err)
+	_, err = loadGitHead("master", "HEAD~1..", "")
+	assert.Error(err)
+	_, err = loadGitHead("master", "nonexistent..HEAD", "")
+	assert.Error(err)
+}
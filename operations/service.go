@@ -79,6 +79,12 @@ func startSystemCronJobs(ctx context.Context, env evergreen.Environment) {
 		units.PopulateIdleHostJobs(env),
 		units.PopulateHostTerminationJobs(env),
 		units.PopulateHostMonitoring(env),
+		units.PopulateHostQuarantineJobs(env),
+		units.PopulateHostReprovisionJobs(env),
+		units.PopulateSpawnhostSleepScheduleJobs(),
+		units.PopulateSpawnhostUsageJobs(),
+		units.PopulateSpawnhostQuotaWarningJobs(),
+		units.PopulateCommitQueueJobs(env),
 		units.PopulateTaskMonitoring(),
 		units.PopulateEventAlertProcessing(1),
 		units.PopulateBackgroundStatsJobs(env, 0),
@@ -91,7 +97,8 @@ func startSystemCronJobs(ctx context.Context, env evergreen.Environment) {
 
 	amboy.IntervalQueueOperation(ctx, env.RemoteQueue(), 150*time.Second, time.Now(), opts, amboy.GroupQueueOperationFactory(
 		units.PopulateLegacyRunnerJobs(env, 5),
-		units.PopulateRepotrackerPollingJobs(5)))
+		units.PopulateRepotrackerPollingJobs(5),
+		units.PopulatePeriodicBuildJobs()))
 
 	amboy.IntervalQueueOperation(ctx, env.RemoteQueue(), 3*time.Minute, time.Now(), opts, units.PopulateActivationJobs(6))
 
@@ -2,23 +2,53 @@ package operations
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"io/ioutil"
 
+	"github.com/evergreen-ci/evergreen/command"
 	"github.com/evergreen-ci/evergreen/validator"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli"
 )
 
+const (
+	lintFlagName   = "lint"
+	schemaFlagName = "schema"
+)
+
 func Validate() cli.Command {
 	return cli.Command{
-		Name:   "validate",
-		Usage:  "verify that an evergreen project config is valid",
-		Flags:  addPathFlag(),
-		Before: requirePathFlag,
+		Name:  "validate",
+		Usage: "verify that an evergreen project config is valid",
+		Flags: addPathFlag(addProjectFlag(addIncludeModuleFlag(
+			cli.BoolFlag{
+				Name:  lintFlagName,
+				Usage: "also warn about likely mistakes, checked against the project's variables and task history if --project is given",
+			},
+			cli.BoolFlag{
+				Name:  schemaFlagName,
+				Usage: "print the JSON Schema for project configurations, for editors to check them with",
+			})...)...),
+		Before: func(c *cli.Context) error {
+			if c.Bool(schemaFlagName) {
+				return nil
+			}
+			return requirePathFlag(c)
+		},
 		Action: func(c *cli.Context) error {
+			if c.Bool(schemaFlagName) {
+				out, err := json.MarshalIndent(command.ProjectSchema(), "", "  ")
+				if err != nil {
+					return errors.Wrap(err, "error marshaling schema")
+				}
+				fmt.Println(string(out))
+				return nil
+			}
+
 			confPath := c.Parent().String(confFlagName)
 			path := c.String(pathFlagName)
+			lint := c.Bool(lintFlagName)
+			projectID := c.String(projectFlagName)
 
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
@@ -35,12 +65,12 @@ func Validate() cli.Command {
 				return errors.Wrap(err, "problem accessing evergreen service")
 			}
 
-			confFile, err := ioutil.ReadFile(path)
+			confFile, err := readLocalProject(ctx, path, c.StringSlice(includeModuleFlagName))
 			if err != nil {
 				return err
 			}
 
-			projErrors, err := ac.ValidateLocalConfig(confFile)
+			projErrors, err := ac.ValidateLocalConfig(confFile, lint, projectID)
 			if err != nil {
 				return nil
 			}
@@ -0,0 +1,148 @@
+package operations
+
+import (
+	"context"
+
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+const volumeFlagName = "volume"
+
+func Volume() cli.Command {
+	return cli.Command{
+		Name:    "volume",
+		Aliases: []string{"volumes"},
+		Usage:   "manage the persistent home volumes attached to your spawn hosts",
+		Subcommands: []cli.Command{
+			volumeList(),
+			volumeResize(),
+			volumeDelete(),
+		},
+	}
+}
+
+func addVolumeFlag(flags ...cli.Flag) []cli.Flag {
+	return append(flags, cli.StringFlag{
+		Name:  joinFlagNames(volumeFlagName, "v"),
+		Usage: "specify the id of a volume",
+	})
+}
+
+func volumeList() cli.Command {
+	return cli.Command{
+		Name:   "list",
+		Usage:  "list your volumes",
+		Before: mergeBeforeFuncs(setPlainLogger, requireClientConfig),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().String(confFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+			client := conf.GetRestCommunicator(ctx)
+			defer client.Close()
+
+			volumes, err := client.GetVolumes(ctx)
+			if err != nil {
+				return errors.Wrap(err, "problem fetching volumes")
+			}
+
+			if len(volumes) == 0 {
+				grip.Info("No volumes found")
+				return nil
+			}
+			for _, v := range volumes {
+				attachedTo := model.FromAPIString(v.Host)
+				if attachedTo == "" {
+					attachedTo = "no host"
+				}
+				grip.Infof("ID: %s, Size: %d GiB, Zone: %s, Status: %s, Attached to: %s",
+					model.FromAPIString(v.ID), v.Size, model.FromAPIString(v.Zone), model.FromAPIString(v.Status), attachedTo)
+			}
+
+			return nil
+		},
+	}
+}
+
+func volumeResize() cli.Command {
+	const sizeFlagName = "size"
+
+	return cli.Command{
+		Name:  "resize",
+		Usage: "grow a volume; its file system grows the next time it is mounted",
+		Flags: addVolumeFlag(cli.IntFlag{
+			Name:  joinFlagNames(sizeFlagName, "s"),
+			Usage: "the new size of the volume in GiB",
+		}),
+		Before: mergeBeforeFuncs(
+			setPlainLogger,
+			requireClientConfig,
+			requireStringFlag(volumeFlagName),
+			func(c *cli.Context) error {
+				if c.Int(sizeFlagName) <= 0 {
+					return errors.Errorf("flag '--%s' must be positive", sizeFlagName)
+				}
+				return nil
+			}),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().String(confFlagName)
+			volumeID := c.String(volumeFlagName)
+			size := c.Int(sizeFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+			client := conf.GetRestCommunicator(ctx)
+			defer client.Close()
+
+			if _, err = client.ResizeVolume(ctx, volumeID, size); err != nil {
+				return errors.Wrap(err, "problem resizing volume")
+			}
+
+			grip.Infof("Resized volume '%s' to %d GiB", volumeID, size)
+			return nil
+		},
+	}
+}
+
+func volumeDelete() cli.Command {
+	return cli.Command{
+		Name:   "delete",
+		Usage:  "delete a volume that is not attached to a host, losing its contents",
+		Flags:  addVolumeFlag(),
+		Before: mergeBeforeFuncs(setPlainLogger, requireClientConfig, requireStringFlag(volumeFlagName)),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().String(confFlagName)
+			volumeID := c.String(volumeFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "problem loading configuration")
+			}
+			client := conf.GetRestCommunicator(ctx)
+			defer client.Close()
+
+			if err = client.DeleteVolume(ctx, volumeID); err != nil {
+				return errors.Wrap(err, "problem deleting volume")
+			}
+
+			grip.Infof("Deleted volume '%s'", volumeID)
+			return nil
+		},
+	}
+}
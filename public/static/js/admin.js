@@ -114,6 +114,7 @@ mciModule.controller('AdminSettingsController', ['$scope','$window', 'mciAdminRe
     cli_updates_disabled: "cli_updates",
     background_stats_disabled: "background stats",
     "task_logging_disabled": "task logging",
+    commit_queue_disabled: "commit queue",
     event_processing_disabled: "event_processing",
     jira_notifications_disabled: "jira_notifications",
     slack_notifications_disabled: "slack_notifications",
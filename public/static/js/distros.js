@@ -31,6 +31,15 @@ mciModule.controller('DistrosCtrl', function($scope, $window, $location, $anchor
   }, {
     'id': 'vsphere',
     'display': 'VMware vSphere'
+  }, {
+    'id': 'kubernetes',
+    'display': 'Kubernetes'
+  }, {
+    'id': 'libvirt',
+    'display': 'Libvirt/QEMU'
+  }, {
+    'id': 'plugin',
+    'display': 'External Plugin'
   }];
 
   $scope.architectures = [{
@@ -312,10 +321,16 @@ mciModule.controller('DistrosCtrl', function($scope, $window, $location, $anchor
 	'user': $scope.activeDistro.user,
 	'ssh_key': $scope.activeDistro.ssh_key,
 	'ssh_options': $scope.activeDistro.ssh_options,
+	'ssh_proxy': _.clone($scope.activeDistro.ssh_proxy),
 	'setup': $scope.activeDistro.setup,
 	'setup': $scope.activeDistro.teardown,
 	'setup': $scope.activeDistro.user_data,
+	'health_check': $scope.activeDistro.health_check,
+	'bootstrap_method': $scope.activeDistro.bootstrap_method,
 	'pool_size': $scope.activeDistro.pool_size,
+	'min_hosts': $scope.activeDistro.min_hosts,
+	'hot_standby': _.clone($scope.activeDistro.hot_standby),
+	'reprovision_after_tasks': $scope.activeDistro.reprovision_after_tasks,
 	'setup_as_sudo' : $scope.activeDistro.setup_as_sudo,
 
       }
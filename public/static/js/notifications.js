@@ -6,6 +6,10 @@ mciModule.controller('NotificationsController', function($scope, $window, mciUse
   $scope.getData = function() {
     var success = function(resp) {
       $scope.settings = resp.data;
+      // spawn host quota warnings are emailed unless the user chooses otherwise
+      if (!$scope.settings.notifications.spawn_host_quota) {
+        $scope.settings.notifications.spawn_host_quota = "email";
+      }
       $scope.getSubscriptions();
     };
     var failure = function(resp) {
@@ -172,6 +172,35 @@ mciModule.controller('ProjectCtrl', function($scope, $window, $http, $location,
   }
 
 
+  // addPeriodicBuild adds the periodic build being edited to the
+  // settingsFormData's list of periodic builds
+  $scope.addPeriodicBuild = function(){
+    $scope.settingsFormData.periodic_builds.push($scope.periodic_build);
+    $scope.periodic_build = {};
+    $scope.isDirty = true;
+  }
+
+  // removePeriodicBuild removes the periodic build located at index
+  $scope.removePeriodicBuild = function(index){
+    $scope.settingsFormData.periodic_builds.splice(index, 1);
+    $scope.isDirty = true;
+  }
+
+  // addAutoRetryCondition adds the auto-retry condition being edited to
+  // the settingsFormData's auto-retry policy
+  $scope.addAutoRetryCondition = function(){
+    $scope.settingsFormData.auto_retry.conditions.push($scope.auto_retry_condition);
+    $scope.auto_retry_condition = {};
+    $scope.isDirty = true;
+  }
+
+  // removeAutoRetryCondition removes the auto-retry condition located at index
+  $scope.removeAutoRetryCondition = function(index){
+    $scope.settingsFormData.auto_retry.conditions.splice(index, 1);
+    $scope.isDirty = true;
+  }
+
+
   $scope.addProject = function() {
     $scope.modalOpen = false;
     $('#admin-modal').modal('hide');
@@ -278,6 +307,13 @@ mciModule.controller('ProjectCtrl', function($scope, $window, $http, $location,
           alert_config: $scope.projectRef.alert_config || {},
           repotracker_error: $scope.projectRef.repotracker_error || {},
           admins : $scope.projectRef.admins || [],
+          periodic_builds: $scope.projectRef.periodic_builds || [],
+          commit_queue: $scope.projectRef.commit_queue || {},
+          auto_retry: {
+            times: ($scope.projectRef.auto_retry || {}).times || 0,
+            conditions: ($scope.projectRef.auto_retry || {}).conditions || [],
+          },
+          abort_superseded_patches: data.ProjectRef.abort_superseded_patches || false,
           setup_github_hook: $scope.githubHookID != 0,
           tracks_push_events: data.ProjectRef.tracks_push_events || false,
           pr_testing_enabled: data.ProjectRef.pr_testing_enabled || false,
@@ -364,6 +400,12 @@ mciModule.controller('ProjectCtrl', function($scope, $window, $http, $location,
     if ($scope.admin_name) {
       $scope.addAdmin();
     }
+    if ($scope.periodic_build && $scope.periodic_build.id && $scope.periodic_build.cron) {
+      $scope.addPeriodicBuild();
+    }
+    if ($scope.auto_retry_condition && $scope.auto_retry_condition.type) {
+      $scope.addAutoRetryCondition();
+    }
     $http.post('/project/' + $scope.settingsFormData.identifier, $scope.settingsFormData).then(
       function(resp) {
         var data = resp.data;
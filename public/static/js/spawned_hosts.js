@@ -107,7 +107,7 @@ mciModule.controller('SpawnedHostsCtrl', ['$scope','$window', '$timeout', 'mciSp
     // Returns true if the user can spawn another host. If hosts has not been initialized it
     // assumes true.
     $scope.availableHosts = function() {
-      return ($scope.hosts == null) || ($scope.hosts.length < $scope.maxHostsPerUser)
+      return ($scope.hosts == null) || !$scope.maxHostsPerUser || ($scope.hosts.length < $scope.maxHostsPerUser)
     }
 
     $scope.fetchSpawnableDistros = function(selectDistro, cb) {
@@ -76,8 +76,12 @@ func (gRepoPoller *GithubRepositoryPoller) GetRemoteConfig(ctx context.Context,
 		return nil, thirdparty.FileDecodeError{err.Error()}
 	}
 
+	// files the configuration includes are read at the same revision
+	includes := model.GithubIncludeFetcher(gRepoPoller.OauthToken, projectRef.Owner,
+		projectRef.Repo, projectFileRevision)
+
 	projectConfig = &model.Project{}
-	err = model.LoadProjectInto(projectFileBytes, projectRef.Identifier, projectConfig)
+	err = model.LoadProjectWithIncludes(ctx, projectFileBytes, projectRef.Identifier, includes, projectConfig)
 	if err != nil {
 		return nil, thirdparty.YAMLFormatError{err.Error()}
 	}
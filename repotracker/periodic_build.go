@@ -0,0 +1,117 @@
+package repotracker
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+// CreatePeriodicVersion creates and activates a version of the project's
+// most recent tracked revision with a valid configuration, whether or not
+// there have been commits since its last version. The version runs the
+// variants and tasks of the definition's alias, or of the whole project if
+// it has no alias. It returns nil if the project has no such revision.
+func CreatePeriodicVersion(ref *model.ProjectRef, definition model.PeriodicBuildDefinition, createTime time.Time) (*version.Version, error) {
+	base, err := version.FindOne(version.ByLastKnownGoodConfig(ref.Identifier))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error finding latest version of project '%s'", ref.Identifier)
+	}
+	if base == nil || base.Config == "" {
+		return nil, nil
+	}
+
+	project := &model.Project{}
+	if err = model.LoadProjectInto([]byte(base.Config), ref.Identifier, project); err != nil {
+		return nil, errors.Wrapf(err, "error loading configuration of version '%s'", base.Id)
+	}
+
+	msg := definition.Message
+	if msg == "" {
+		msg = fmt.Sprintf("Periodic build '%s'", definition.ID)
+	}
+	v := &version.Version{
+		Id: util.CleanName(fmt.Sprintf("%s_%s_%s_%s", ref.String(), definition.ID, base.Revision,
+			createTime.Format(build.IdTimeLayout))),
+		Author:              evergreen.User,
+		Branch:              ref.Branch,
+		Config:              base.Config,
+		CreateTime:          createTime,
+		Identifier:          ref.Identifier,
+		Message:             msg,
+		Owner:               ref.Owner,
+		RemotePath:          ref.RemotePath,
+		Repo:                ref.Repo,
+		RepoKind:            ref.RepoKind,
+		Requester:           evergreen.PeriodicBuildRequester,
+		Revision:            base.Revision,
+		RevisionOrderNumber: base.RevisionOrderNumber,
+		Status:              evergreen.VersionCreated,
+	}
+
+	// without an alias, every task of every variant runs
+	var tasks *model.TaskVariantPairs
+	if definition.Alias != "" {
+		pairs, err := project.TaskVariantPairsForAlias(definition.Alias)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		tasks = &pairs
+	}
+
+	taskIds := model.NewTaskIdTable(project, v)
+	for _, bv := range project.BuildVariants {
+		if bv.Disabled {
+			continue
+		}
+		var taskNames, displayNames []string
+		if tasks != nil {
+			taskNames = tasks.ExecTasks.TaskNames(bv.Name)
+			displayNames = tasks.DisplayTasks.TaskNames(bv.Name)
+			if len(taskNames) == 0 && len(displayNames) == 0 {
+				continue
+			}
+		}
+
+		buildId, err := model.CreateBuildFromVersion(project, v, taskIds, bv.Name, true, taskNames, displayNames, "")
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		v.BuildIds = append(v.BuildIds, buildId)
+		v.BuildVariants = append(v.BuildVariants, version.BuildStatus{
+			BuildVariant: bv.Name,
+			Activated:    true,
+			ActivateAt:   createTime,
+			BuildId:      buildId,
+		})
+	}
+
+	if err = v.Insert(); err != nil && !db.IsDuplicateKey(err) {
+		for _, buildStatus := range v.BuildVariants {
+			grip.Error(message.WrapError(model.DeleteBuild(buildStatus.BuildId), message.Fields{
+				"message":  "issue deleting build",
+				"version":  v.Id,
+				"build_id": buildStatus.BuildId,
+			}))
+		}
+		return nil, errors.Wrapf(err, "error inserting version '%s'", v.Id)
+	}
+
+	grip.Info(message.Fields{
+		"message":  "created periodic build",
+		"project":  ref.Identifier,
+		"id":       definition.ID,
+		"version":  v.Id,
+		"revision": v.Revision,
+		"builds":   len(v.BuildIds),
+	})
+	return v, nil
+}
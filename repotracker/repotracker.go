@@ -347,19 +347,21 @@ func (repoTracker *RepoTracker) StoreRevisions(ctx context.Context, revisions []
 		}
 		v.Config = string(projectYamlBytes)
 
-		// "Ignore" a version if all changes are to ignored files
-		if len(project.Ignore) > 0 {
-			filenames, err := repoTracker.GetChangedFiles(ctx, revision)
+		var filenames []string
+		if len(project.Ignore) > 0 || project.HasPathFilters() {
+			filenames, err = repoTracker.GetChangedFiles(ctx, revision)
 			if err != nil {
-				return nil, errors.Wrap(err, "error checking GitHub for ignored files")
-			}
-			if project.IgnoresAllFiles(filenames) {
-				v.Ignored = true
+				return nil, errors.Wrap(err, "error checking GitHub for changed files")
 			}
 		}
 
+		// "Ignore" a version if all changes are to ignored files
+		if project.IgnoresAllFiles(filenames) {
+			v.Ignored = true
+		}
+
 		// We rebind newestVersion each iteration, so the last binding will be the newest version
-		err = errors.Wrapf(createVersionItems(v, ref, project),
+		err = errors.Wrapf(createVersionItems(v, ref, project, filenames),
 			"Error creating version items for %s in project %s",
 			v.Id, ref.Identifier)
 		if err != nil {
@@ -505,9 +507,10 @@ func NewVersionFromRevision(ref *model.ProjectRef, rev model.Revision) (*version
 	return v, nil
 }
 
-// createVersionItems populates and stores all the tasks and builds for a version according to
-// the given project config.
-func createVersionItems(v *version.Version, ref *model.ProjectRef, project *model.Project) error {
+// createVersionItems creates the builds for a version and inserts it. Tasks
+// that the changed files don't activate are recorded on the version, so that
+// they are skipped when their builds are activated.
+func createVersionItems(v *version.Version, ref *model.ProjectRef, project *model.Project, changedFiles []string) error {
 	// generate all task Ids so that we can easily reference them for dependencies
 	taskIds := model.NewTaskIdTable(project, v)
 
@@ -559,12 +562,23 @@ func createVersionItems(v *version.Version, ref *model.ProjectRef, project *mode
 			"runner":  RunnerName,
 		})
 
+		skipped := project.TasksSkippedByChanges(buildvariant.Name, changedFiles)
+		grip.InfoWhen(len(skipped) > 0, message.Fields{
+			"message": "skipping tasks whose paths the changes don't touch",
+			"name":    buildvariant.Name,
+			"project": ref.Identifier,
+			"version": v.Id,
+			"tasks":   skipped,
+			"runner":  RunnerName,
+		})
+
 		v.BuildIds = append(v.BuildIds, buildId)
 		v.BuildVariants = append(v.BuildVariants, version.BuildStatus{
 			BuildVariant: buildvariant.Name,
 			Activated:    false,
 			ActivateAt:   activateAt,
 			BuildId:      buildId,
+			SkippedTasks: skipped,
 		})
 	}
 
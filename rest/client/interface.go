@@ -130,12 +130,26 @@ type Communicator interface {
 
 	// Spawnhost methods
 	//
-	CreateSpawnHost(context.Context, string, string) (*restmodel.APIHost, error)
+	CreateSpawnHost(context.Context, *restmodel.HostPostRequest) (*restmodel.APIHost, error)
 	TerminateSpawnHost(context.Context, string) error
+	ReprovisionHost(context.Context, string) error
+	StopSpawnHost(context.Context, string) error
+	StartSpawnHost(context.Context, string) error
+	SetSpawnHostSleepSchedule(context.Context, string, *restmodel.APISleepSchedule) error
 	ChangeSpawnHostPassword(context.Context, string, string) error
 	ExtendSpawnHostExpiration(context.Context, string, int) error
 	GetHosts(context.Context, func([]*restmodel.APIHost) error) error
 
+	// Home volume methods
+	GetVolumes(context.Context) ([]restmodel.APIVolume, error)
+	ResizeVolume(context.Context, string, int) (*restmodel.APIVolume, error)
+	DeleteVolume(context.Context, string) error
+
+	// Commit queue methods
+	GetCommitQueue(context.Context, string) (*restmodel.APICommitQueue, error)
+	EnqueueItem(context.Context, string, *restmodel.APICommitQueueItem) (int, error)
+	DeleteCommitQueueItem(context.Context, string, string) error
+
 	// Fetch list of distributions evergreen can spawn
 	GetDistrosList(context.Context) ([]restmodel.APIDistro, error)
 
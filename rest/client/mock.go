@@ -296,18 +296,18 @@ func (c *Mock) GetTaskPatch(ctx context.Context, td TaskData) (*patchmodel.Patch
 // GetHostsByUser will return an array with a single mock host
 func (c *Mock) GetHostsByUser(ctx context.Context, user string) ([]*model.APIHost, error) {
 	hosts := make([]*model.APIHost, 1)
-	host, _ := c.CreateSpawnHost(ctx, "mock_distro", "mock_key")
+	host, _ := c.CreateSpawnHost(ctx, &model.HostPostRequest{DistroID: "mock_distro", KeyName: "mock_key"})
 	hosts = append(hosts, host)
 	return hosts, nil
 }
 
 // CreateSpawnHost will return a mock host that would have been intended
-func (*Mock) CreateSpawnHost(ctx context.Context, distroID string, keyName string) (*model.APIHost, error) {
+func (*Mock) CreateSpawnHost(ctx context.Context, spawnRequest *model.HostPostRequest) (*model.APIHost, error) {
 	mockHost := &model.APIHost{
 		Id:      model.ToAPIString("mock_host_id"),
 		HostURL: model.ToAPIString("mock_url"),
 		Distro: model.DistroInfo{
-			Id:       model.ToAPIString(distroID),
+			Id:       model.ToAPIString(spawnRequest.DistroID),
 			Provider: model.ToAPIString(evergreen.ProviderNameMock),
 		},
 		Type:        model.ToAPIString("mock_type"),
@@ -323,6 +323,46 @@ func (*Mock) TerminateSpawnHost(ctx context.Context, hostID string) error {
 	return errors.New("(*Mock) TerminateSpawnHost is not implemented")
 }
 
+func (*Mock) ReprovisionHost(ctx context.Context, hostID string) error {
+	return errors.New("(*Mock) ReprovisionHost is not implemented")
+}
+
+func (*Mock) StopSpawnHost(ctx context.Context, hostID string) error {
+	return errors.New("(*Mock) StopSpawnHost is not implemented")
+}
+
+func (*Mock) StartSpawnHost(ctx context.Context, hostID string) error {
+	return errors.New("(*Mock) StartSpawnHost is not implemented")
+}
+
+func (*Mock) SetSpawnHostSleepSchedule(ctx context.Context, hostID string, schedule *model.APISleepSchedule) error {
+	return errors.New("(*Mock) SetSpawnHostSleepSchedule is not implemented")
+}
+
+func (*Mock) GetVolumes(context.Context) ([]model.APIVolume, error) {
+	return nil, errors.New("(*Mock) GetVolumes is not implemented")
+}
+
+func (*Mock) ResizeVolume(context.Context, string, int) (*model.APIVolume, error) {
+	return nil, errors.New("(*Mock) ResizeVolume is not implemented")
+}
+
+func (*Mock) DeleteVolume(context.Context, string) error {
+	return errors.New("(*Mock) DeleteVolume is not implemented")
+}
+
+func (*Mock) GetCommitQueue(context.Context, string) (*model.APICommitQueue, error) {
+	return nil, errors.New("(*Mock) GetCommitQueue is not implemented")
+}
+
+func (*Mock) EnqueueItem(context.Context, string, *model.APICommitQueueItem) (int, error) {
+	return 0, errors.New("(*Mock) EnqueueItem is not implemented")
+}
+
+func (*Mock) DeleteCommitQueueItem(context.Context, string, string) error {
+	return errors.New("(*Mock) DeleteCommitQueueItem is not implemented")
+}
+
 func (*Mock) ChangeSpawnHostPassword(context.Context, string, string) error {
 	return errors.New("(*Mock) ChangeSpawnHostPassword is not implemented")
 }
@@ -334,7 +374,7 @@ func (*Mock) ExtendSpawnHostExpiration(context.Context, string, int) error {
 // GetHosts will return an array with a single mock host
 func (c *Mock) GetHosts(ctx context.Context, f func([]*model.APIHost) error) error {
 	hosts := make([]*model.APIHost, 1)
-	host, _ := c.CreateSpawnHost(ctx, "mock_distro", "mock_key")
+	host, _ := c.CreateSpawnHost(ctx, &model.HostPostRequest{DistroID: "mock_distro", KeyName: "mock_key"})
 	hosts = append(hosts, host)
 	err := f(hosts)
 	return err
@@ -60,12 +60,9 @@ func (c *communicatorImpl) GetHostsByUser(ctx context.Context, user string) ([]*
 func (*communicatorImpl) SetHostStatus()   {}
 func (*communicatorImpl) SetHostStatuses() {}
 
-// CreateSpawnHost will insert an intent host into the DB that will be spawned later by the runner
-func (c *communicatorImpl) CreateSpawnHost(ctx context.Context, distroID string, keyName string) (*model.APIHost, error) {
-	spawnRequest := &model.HostPostRequest{
-		DistroID: distroID,
-		KeyName:  keyName,
-	}
+// CreateSpawnHost will insert an intent host into the DB that will be spawned later by the runner.
+// If a task ID is given, the host is set up with the task's source, artifacts and expansions.
+func (c *communicatorImpl) CreateSpawnHost(ctx context.Context, spawnRequest *model.HostPostRequest) (*model.APIHost, error) {
 	info := requestInfo{
 		method:  post,
 		path:    "hosts",
@@ -108,6 +105,267 @@ func (c *communicatorImpl) TerminateSpawnHost(ctx context.Context, hostID string
 	return nil
 }
 
+func (c *communicatorImpl) ReprovisionHost(ctx context.Context, hostID string) error {
+	info := requestInfo{
+		method:  post,
+		path:    fmt.Sprintf("hosts/%s/reprovision", hostID),
+		version: apiVersion2,
+	}
+	resp, err := c.request(ctx, info, "")
+	if err != nil {
+		return errors.Wrapf(err, "error sending request to reprovision host")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		errMsg := rest.APIError{}
+		if err := util.ReadJSONInto(resp.Body, &errMsg); err != nil {
+			return errors.Wrap(err, "problem reprovisioning host and parsing error message")
+		}
+		return errors.Wrap(errMsg, "problem reprovisioning host")
+	}
+
+	return nil
+}
+
+func (c *communicatorImpl) StopSpawnHost(ctx context.Context, hostID string) error {
+	info := requestInfo{
+		method:  post,
+		path:    fmt.Sprintf("hosts/%s/stop", hostID),
+		version: apiVersion2,
+	}
+	resp, err := c.request(ctx, info, "")
+	if err != nil {
+		return errors.Wrapf(err, "error sending request to stop host")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		errMsg := rest.APIError{}
+		if err := util.ReadJSONInto(resp.Body, &errMsg); err != nil {
+			return errors.Wrap(err, "problem stopping host and parsing error message")
+		}
+		return errors.Wrap(errMsg, "problem stopping host")
+	}
+
+	return nil
+}
+
+func (c *communicatorImpl) StartSpawnHost(ctx context.Context, hostID string) error {
+	info := requestInfo{
+		method:  post,
+		path:    fmt.Sprintf("hosts/%s/start", hostID),
+		version: apiVersion2,
+	}
+	resp, err := c.request(ctx, info, "")
+	if err != nil {
+		return errors.Wrapf(err, "error sending request to start host")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		errMsg := rest.APIError{}
+		if err := util.ReadJSONInto(resp.Body, &errMsg); err != nil {
+			return errors.Wrap(err, "problem starting host and parsing error message")
+		}
+		return errors.Wrap(errMsg, "problem starting host")
+	}
+
+	return nil
+}
+
+// SetSpawnHostSleepSchedule sets the host's sleep schedule, or removes it if
+// the schedule is nil.
+func (c *communicatorImpl) SetSpawnHostSleepSchedule(ctx context.Context, hostID string, schedule *model.APISleepSchedule) error {
+	info := requestInfo{
+		method:  put,
+		path:    fmt.Sprintf("hosts/%s/sleep_schedule", hostID),
+		version: apiVersion2,
+	}
+	var body interface{} = schedule
+	if schedule == nil {
+		info.method = delete
+		body = ""
+	}
+	resp, err := c.request(ctx, info, body)
+	if err != nil {
+		return errors.Wrapf(err, "error sending request to set sleep schedule")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		errMsg := rest.APIError{}
+		if err := util.ReadJSONInto(resp.Body, &errMsg); err != nil {
+			return errors.Wrap(err, "problem setting sleep schedule and parsing error message")
+		}
+		return errors.Wrap(errMsg, "problem setting sleep schedule")
+	}
+
+	return nil
+}
+
+// GetVolumes returns the current user's home volumes.
+func (c *communicatorImpl) GetVolumes(ctx context.Context) ([]model.APIVolume, error) {
+	info := requestInfo{
+		method:  get,
+		path:    "volumes",
+		version: apiVersion2,
+	}
+	resp, err := c.request(ctx, info, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "error sending request to get volumes")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		errMsg := rest.APIError{}
+		if err = util.ReadJSONInto(resp.Body, &errMsg); err != nil {
+			return nil, errors.Wrap(err, "problem getting volumes and parsing error message")
+		}
+		return nil, errors.Wrap(errMsg, "problem getting volumes")
+	}
+
+	volumes := []model.APIVolume{}
+	if err = util.ReadJSONInto(resp.Body, &volumes); err != nil {
+		return nil, errors.Wrap(err, "error parsing volumes")
+	}
+	return volumes, nil
+}
+
+// ResizeVolume grows the volume to the given size in GiB.
+func (c *communicatorImpl) ResizeVolume(ctx context.Context, volumeID string, size int) (*model.APIVolume, error) {
+	info := requestInfo{
+		method:  patch,
+		path:    fmt.Sprintf("volumes/%s", volumeID),
+		version: apiVersion2,
+	}
+	resp, err := c.request(ctx, info, model.VolumeModifyRequest{Size: size})
+	if err != nil {
+		return nil, errors.Wrap(err, "error sending request to resize volume")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		errMsg := rest.APIError{}
+		if err = util.ReadJSONInto(resp.Body, &errMsg); err != nil {
+			return nil, errors.Wrap(err, "problem resizing volume and parsing error message")
+		}
+		return nil, errors.Wrap(errMsg, "problem resizing volume")
+	}
+
+	volume := &model.APIVolume{}
+	if err = util.ReadJSONInto(resp.Body, volume); err != nil {
+		return nil, errors.Wrap(err, "error parsing volume")
+	}
+	return volume, nil
+}
+
+// DeleteVolume deletes a volume that is not attached to a host.
+func (c *communicatorImpl) DeleteVolume(ctx context.Context, volumeID string) error {
+	info := requestInfo{
+		method:  delete,
+		path:    fmt.Sprintf("volumes/%s", volumeID),
+		version: apiVersion2,
+	}
+	resp, err := c.request(ctx, info, "")
+	if err != nil {
+		return errors.Wrap(err, "error sending request to delete volume")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		errMsg := rest.APIError{}
+		if err = util.ReadJSONInto(resp.Body, &errMsg); err != nil {
+			return errors.Wrap(err, "problem deleting volume and parsing error message")
+		}
+		return errors.Wrap(errMsg, "problem deleting volume")
+	}
+
+	return nil
+}
+
+// GetCommitQueue returns the commit queue of the given project.
+func (c *communicatorImpl) GetCommitQueue(ctx context.Context, projectID string) (*model.APICommitQueue, error) {
+	info := requestInfo{
+		method:  get,
+		path:    fmt.Sprintf("commit_queue/%s", projectID),
+		version: apiVersion2,
+	}
+	resp, err := c.request(ctx, info, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "error sending request to get commit queue")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		errMsg := rest.APIError{}
+		if err = util.ReadJSONInto(resp.Body, &errMsg); err != nil {
+			return nil, errors.Wrap(err, "problem getting commit queue and parsing error message")
+		}
+		return nil, errors.Wrap(errMsg, "problem getting commit queue")
+	}
+
+	cq := &model.APICommitQueue{}
+	if err = util.ReadJSONInto(resp.Body, cq); err != nil {
+		return nil, errors.Wrap(err, "error parsing commit queue")
+	}
+	return cq, nil
+}
+
+// EnqueueItem adds the item to the end of the given project's commit queue
+// and returns its position in the queue.
+func (c *communicatorImpl) EnqueueItem(ctx context.Context, projectID string, item *model.APICommitQueueItem) (int, error) {
+	info := requestInfo{
+		method:  put,
+		path:    fmt.Sprintf("commit_queue/%s", projectID),
+		version: apiVersion2,
+	}
+	resp, err := c.request(ctx, info, item)
+	if err != nil {
+		return 0, errors.Wrap(err, "error sending request to enqueue item")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		errMsg := rest.APIError{}
+		if err = util.ReadJSONInto(resp.Body, &errMsg); err != nil {
+			return 0, errors.Wrap(err, "problem enqueueing item and parsing error message")
+		}
+		return 0, errors.Wrap(errMsg, "problem enqueueing item")
+	}
+
+	position := model.APICommitQueuePosition{}
+	if err = util.ReadJSONInto(resp.Body, &position); err != nil {
+		return 0, errors.Wrap(err, "error parsing commit queue position")
+	}
+	return position.Position, nil
+}
+
+// DeleteCommitQueueItem removes the item from the given project's commit
+// queue.
+func (c *communicatorImpl) DeleteCommitQueueItem(ctx context.Context, projectID, item string) error {
+	info := requestInfo{
+		method:  delete,
+		path:    fmt.Sprintf("commit_queue/%s/%s", projectID, item),
+		version: apiVersion2,
+	}
+	resp, err := c.request(ctx, info, "")
+	if err != nil {
+		return errors.Wrap(err, "error sending request to delete commit queue item")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		errMsg := rest.APIError{}
+		if err = util.ReadJSONInto(resp.Body, &errMsg); err != nil {
+			return errors.Wrap(err, "problem deleting commit queue item and parsing error message")
+		}
+		return errors.Wrap(errMsg, "problem deleting commit queue item")
+	}
+
+	return nil
+}
+
 func (c *communicatorImpl) ChangeSpawnHostPassword(ctx context.Context, hostID, rdpPassword string) error {
 	info := requestInfo{
 		method:  post,
@@ -0,0 +1,140 @@
+package data
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/commitqueue"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/rest"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// DBCommitQueueConnector is a struct that implements the commit queue
+// related methods from the Connector through interactions with the backing
+// database.
+type DBCommitQueueConnector struct{}
+
+// FindCommitQueueByID returns the commit queue of the given project, which
+// is empty if nothing has ever been enqueued.
+func (cc *DBCommitQueueConnector) FindCommitQueueByID(projectID string) (*commitqueue.CommitQueue, error) {
+	cq, err := commitqueue.FindOneId(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if cq == nil {
+		cq = &commitqueue.CommitQueue{ProjectID: projectID}
+	}
+	return cq, nil
+}
+
+// EnqueueItem adds the item to the end of the project's commit queue and
+// returns its position.
+func (cc *DBCommitQueueConnector) EnqueueItem(projectID string, item commitqueue.CommitQueueItem) (int, error) {
+	return commitqueue.Enqueue(projectID, item)
+}
+
+// CommitQueueRemoveItem removes the item with the given issue from the
+// project's commit queue, aborting the patch testing it if there is one.
+// It returns false if the item wasn't in the queue.
+func (cc *DBCommitQueueConnector) CommitQueueRemoveItem(projectID, issue, caller string) (bool, error) {
+	cq, err := commitqueue.FindOneId(projectID)
+	if err != nil {
+		return false, err
+	}
+	if cq == nil {
+		return false, nil
+	}
+	i := cq.FindItem(issue)
+	if i < 0 {
+		return false, nil
+	}
+	patchID := cq.Queue[i].PatchID
+
+	removed, err := cq.Remove(issue)
+	if err != nil || !removed {
+		return removed, err
+	}
+
+	if patchID == "" || !bson.IsObjectIdHex(patchID) {
+		return true, nil
+	}
+	p, err := patch.FindOne(patch.ById(bson.ObjectIdHex(patchID)))
+	if err != nil {
+		return true, errors.Wrapf(err, "error finding patch '%s'", patchID)
+	}
+	if p == nil {
+		return true, nil
+	}
+	return true, errors.Wrapf(model.CancelPatch(p, caller), "error aborting patch '%s'", patchID)
+}
+
+// CommitQueueGithubClient returns a GitHub client that uses Evergreen's
+// GitHub token.
+func (cc *DBCommitQueueConnector) CommitQueueGithubClient() (thirdparty.GithubClient, error) {
+	token, err := evergreen.GetEnvironment().Settings().GetGithubOauthToken()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return thirdparty.NewGithubClient(token), nil
+}
+
+// MockCommitQueueConnector is a struct that implements mock versions of
+// commit queue related methods for testing.
+type MockCommitQueueConnector struct {
+	Queues       map[string][]commitqueue.CommitQueueItem
+	GithubClient thirdparty.GithubClient
+}
+
+func (cc *MockCommitQueueConnector) FindCommitQueueByID(projectID string) (*commitqueue.CommitQueue, error) {
+	queue := cc.Queues[projectID]
+	return &commitqueue.CommitQueue{
+		ProjectID: projectID,
+		Queue:     append([]commitqueue.CommitQueueItem{}, queue...),
+	}, nil
+}
+
+func (cc *MockCommitQueueConnector) EnqueueItem(projectID string, item commitqueue.CommitQueueItem) (int, error) {
+	if err := item.Validate(); err != nil {
+		return 0, err
+	}
+	if cc.Queues == nil {
+		cc.Queues = map[string][]commitqueue.CommitQueueItem{}
+	}
+	for _, queued := range cc.Queues[projectID] {
+		if queued.Issue == item.Issue {
+			return 0, &rest.APIError{
+				StatusCode: http.StatusBadRequest,
+				Message:    fmt.Sprintf("'%s' is already in the commit queue for project '%s'", item.Issue, projectID),
+			}
+		}
+	}
+	if item.EnqueueTime.IsZero() {
+		item.EnqueueTime = time.Now()
+	}
+	cc.Queues[projectID] = append(cc.Queues[projectID], item)
+	return len(cc.Queues[projectID]) - 1, nil
+}
+
+func (cc *MockCommitQueueConnector) CommitQueueRemoveItem(projectID, issue, _ string) (bool, error) {
+	queue := cc.Queues[projectID]
+	for i := range queue {
+		if queue[i].Issue == issue {
+			cc.Queues[projectID] = append(queue[:i], queue[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (cc *MockCommitQueueConnector) CommitQueueGithubClient() (thirdparty.GithubClient, error) {
+	if cc.GithubClient == nil {
+		return nil, errors.New("no github client configured")
+	}
+	return cc.GithubClient, nil
+}
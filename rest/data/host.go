@@ -11,8 +11,11 @@ import (
 	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/evergreen-ci/evergreen/rest"
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/evergreen-ci/evergreen/spawn"
+	"github.com/evergreen-ci/evergreen/units"
 	"github.com/evergreen-ci/gimlet"
+	"github.com/mongodb/amboy"
 	"github.com/pkg/errors"
 )
 
@@ -57,21 +60,23 @@ func (dbc *DBConnector) FindHostByIdWithOwner(hostID string, user gimlet.User) (
 
 // NewIntentHost is a method to insert an intent host given a distro and a public key
 // The public key can be the name of a saved key or the actual key string
-func (hc *DBHostConnector) NewIntentHost(distroID, keyNameOrVal, taskID string, user *user.DBUser) (*host.Host, error) {
-	keyVal, err := user.GetPublicKey(keyNameOrVal)
+func (hc *DBHostConnector) NewIntentHost(options *restModel.HostPostRequest, user *user.DBUser) (*host.Host, error) {
+	keyVal, err := user.GetPublicKey(options.KeyName)
 	if err != nil {
-		keyVal = keyNameOrVal
+		keyVal = options.KeyName
 	}
 	if keyVal == "" {
 		return nil, errors.New("invalid key")
 	}
 
 	spawnOptions := spawn.Options{
-		Distro:    distroID,
-		UserName:  user.Username(),
-		PublicKey: keyVal,
-		TaskId:    taskID,
-		Owner:     user,
+		Distro:         options.DistroID,
+		UserName:       user.Username(),
+		PublicKey:      keyVal,
+		TaskId:         options.TaskID,
+		Owner:          user,
+		HomeVolume:     options.HomeVolume,
+		HomeVolumeSize: options.HomeVolumeSize,
 	}
 
 	intentHost, err := spawn.CreateHost(spawnOptions)
@@ -102,10 +107,59 @@ func (hc *DBHostConnector) TerminateHost(ctx context.Context, host *host.Host, u
 	return errors.WithStack(spawn.TerminateHost(ctx, host, evergreen.GetEnvironment().Settings(), user))
 }
 
+func (hc *DBHostConnector) ReprovisionHost(host *host.Host, user string) error {
+	return errors.WithStack(host.SetNeedsReprovision(user, "requested through the API"))
+}
+
+func (hc *DBHostConnector) StopHost(queue amboy.Queue, host *host.Host, user string) error {
+	ts := time.Now().Format(time.RFC3339)
+	return errors.Wrap(queue.Put(units.NewSpawnhostStopJob(*host, user, ts)), "error queueing job to stop host")
+}
+
+func (hc *DBHostConnector) StartHost(queue amboy.Queue, host *host.Host, user string) error {
+	ts := time.Now().Format(time.RFC3339)
+	return errors.Wrap(queue.Put(units.NewSpawnhostStartJob(*host, user, ts)), "error queueing job to start host")
+}
+
+func (hc *DBHostConnector) SetHostSleepSchedule(host *host.Host, schedule *host.SleepSchedule) error {
+	return errors.WithStack(host.SetSleepSchedule(schedule))
+}
+
+func (hc *DBHostConnector) FindVolumesByUser(user string) ([]host.Volume, error) {
+	return host.FindVolumesByUser(user)
+}
+
+func (hc *DBHostConnector) FindVolumeById(id string) (*host.Volume, error) {
+	v, err := host.FindVolumeByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil || v.Status == host.VolumeDeleted {
+		return nil, &rest.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("volume with id %s not found", id),
+		}
+	}
+	return v, nil
+}
+
+func (dbc *DBConnector) FindVolumeByIdWithOwner(volumeID string, user gimlet.User) (*host.Volume, error) {
+	return findVolumeByIdWithOwner(dbc, volumeID, user)
+}
+
+func (hc *DBHostConnector) ResizeVolume(ctx context.Context, v *host.Volume, size int) error {
+	return errors.WithStack(spawn.ResizeVolume(ctx, v, size, evergreen.GetEnvironment().Settings()))
+}
+
+func (hc *DBHostConnector) DeleteVolume(ctx context.Context, v *host.Volume) error {
+	return errors.WithStack(spawn.DeleteVolume(ctx, v, evergreen.GetEnvironment().Settings()))
+}
+
 // MockHostConnector is a struct that implements the Host related methods
 // from the Connector through interactions with he backing database.
 type MockHostConnector struct {
-	CachedHosts []host.Host
+	CachedHosts   []host.Host
+	CachedVolumes []host.Volume
 }
 
 // FindHostsById searches the mock hosts slice for hosts and returns them
@@ -192,21 +246,23 @@ func (hc *MockHostConnector) FindHostById(id string) (*host.Host, error) {
 
 // NewIntentHost is a method to mock "insert" an intent host given a distro and a public key
 // The public key can be the name of a saved key or the actual key string
-func (hc *MockHostConnector) NewIntentHost(distroID, keyNameOrVal, taskID string, user *user.DBUser) (*host.Host, error) {
-	keyVal, err := user.GetPublicKey(keyNameOrVal)
+func (hc *MockHostConnector) NewIntentHost(options *restModel.HostPostRequest, user *user.DBUser) (*host.Host, error) {
+	keyVal, err := user.GetPublicKey(options.KeyName)
 	if err != nil {
-		keyVal = keyNameOrVal
+		keyVal = options.KeyName
 	}
 	if keyVal == "" {
 		return nil, errors.New("invalid key")
 	}
 
 	spawnOptions := spawn.Options{
-		Distro:    distroID,
-		UserName:  user.Username(),
-		PublicKey: keyVal,
-		TaskId:    taskID,
-		Owner:     user,
+		Distro:         options.DistroID,
+		UserName:       user.Username(),
+		PublicKey:      keyVal,
+		TaskId:         options.TaskID,
+		Owner:          user,
+		HomeVolume:     options.HomeVolume,
+		HomeVolumeSize: options.HomeVolumeSize,
 	}
 
 	intentHost, err := spawn.CreateHost(spawnOptions)
@@ -253,10 +309,114 @@ func (hc *MockHostConnector) TerminateHost(ctx context.Context, host *host.Host,
 	return errors.New("can't find host")
 }
 
+func (hc *MockHostConnector) ReprovisionHost(host *host.Host, user string) error {
+	for i := range hc.CachedHosts {
+		if hc.CachedHosts[i].Id == host.Id {
+			hc.CachedHosts[i].NeedsReprovision = true
+			host.NeedsReprovision = true
+			return nil
+		}
+	}
+
+	return errors.New("can't find host")
+}
+
+func (hc *MockHostConnector) StopHost(queue amboy.Queue, host *host.Host, user string) error {
+	for i := range hc.CachedHosts {
+		if hc.CachedHosts[i].Id == host.Id {
+			hc.CachedHosts[i].Status = evergreen.HostStopped
+			host.Status = evergreen.HostStopped
+			return nil
+		}
+	}
+
+	return errors.New("can't find host")
+}
+
+func (hc *MockHostConnector) StartHost(queue amboy.Queue, host *host.Host, user string) error {
+	for i := range hc.CachedHosts {
+		if hc.CachedHosts[i].Id == host.Id {
+			hc.CachedHosts[i].Status = evergreen.HostRunning
+			host.Status = evergreen.HostRunning
+			return nil
+		}
+	}
+
+	return errors.New("can't find host")
+}
+
+func (hc *MockHostConnector) SetHostSleepSchedule(host *host.Host, schedule *host.SleepSchedule) error {
+	for i := range hc.CachedHosts {
+		if hc.CachedHosts[i].Id == host.Id {
+			hc.CachedHosts[i].SleepSchedule = schedule
+			host.SleepSchedule = schedule
+			return nil
+		}
+	}
+
+	return errors.New("can't find host")
+}
+
 func (dbc *MockConnector) FindHostByIdWithOwner(hostID string, user gimlet.User) (*host.Host, error) {
 	return findHostByIdWithOwner(dbc, hostID, user)
 }
 
+func (hc *MockHostConnector) FindVolumesByUser(user string) ([]host.Volume, error) {
+	volumes := []host.Volume{}
+	for _, v := range hc.CachedVolumes {
+		if v.CreatedBy == user && v.Status != host.VolumeDeleted {
+			volumes = append(volumes, v)
+		}
+	}
+	return volumes, nil
+}
+
+func (hc *MockHostConnector) FindVolumeById(id string) (*host.Volume, error) {
+	for i := range hc.CachedVolumes {
+		if hc.CachedVolumes[i].ID == id && hc.CachedVolumes[i].Status != host.VolumeDeleted {
+			return &hc.CachedVolumes[i], nil
+		}
+	}
+	return nil, &rest.APIError{
+		StatusCode: http.StatusNotFound,
+		Message:    fmt.Sprintf("volume with id %s not found", id),
+	}
+}
+
+func (dbc *MockConnector) FindVolumeByIdWithOwner(volumeID string, user gimlet.User) (*host.Volume, error) {
+	return findVolumeByIdWithOwner(dbc, volumeID, user)
+}
+
+func (hc *MockHostConnector) ResizeVolume(ctx context.Context, v *host.Volume, size int) error {
+	if size <= v.Size {
+		return errors.Errorf("volumes can only grow; volume %s is already %d GiB", v.ID, v.Size)
+	}
+	for i := range hc.CachedVolumes {
+		if hc.CachedVolumes[i].ID == v.ID {
+			hc.CachedVolumes[i].Size = size
+			v.Size = size
+			return nil
+		}
+	}
+
+	return errors.New("can't find volume")
+}
+
+func (hc *MockHostConnector) DeleteVolume(ctx context.Context, v *host.Volume) error {
+	if v.Status != host.VolumeAvailable {
+		return errors.Errorf("volume %s is %s, not available", v.ID, v.Status)
+	}
+	for i := range hc.CachedVolumes {
+		if hc.CachedVolumes[i].ID == v.ID {
+			hc.CachedVolumes[i].Status = host.VolumeDeleted
+			v.Status = host.VolumeDeleted
+			return nil
+		}
+	}
+
+	return errors.New("can't find volume")
+}
+
 func findHostByIdWithOwner(c Connector, hostID string, user gimlet.User) (*host.Host, error) {
 	host, err := c.FindHostById(hostID)
 	if err != nil {
@@ -283,3 +443,21 @@ func findHostByIdWithOwner(c Connector, hostID string, user gimlet.User) (*host.
 
 	return host, nil
 }
+
+func findVolumeByIdWithOwner(c Connector, volumeID string, user gimlet.User) (*host.Volume, error) {
+	v, err := c.FindVolumeById(volumeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Username() != v.CreatedBy {
+		if !auth.IsSuperUser(c.GetSuperUsers(), user) {
+			return nil, &rest.APIError{
+				StatusCode: http.StatusUnauthorized,
+				Message:    "not authorized to modify volume",
+			}
+		}
+	}
+
+	return v, nil
+}
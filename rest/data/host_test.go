@@ -9,7 +9,9 @@ import (
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/user"
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/evergreen-ci/evergreen/testutil"
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/stretchr/testify/suite"
@@ -30,7 +32,7 @@ func TestHostConnectorSuite(t *testing.T) {
 	db.SetGlobalSessionProvider(testConfig.SessionFactory())
 
 	s.setup = func(s *HostConnectorSuite) {
-		s.NoError(db.ClearCollections(user.Collection, host.Collection))
+		s.NoError(db.ClearCollections(user.Collection, host.Collection, task.Collection))
 		host1 := &host.Host{
 			Id:             "host1",
 			StartedBy:      testUser,
@@ -212,7 +214,10 @@ func (s *HostConnectorSuite) TestSpawnHost() {
 	s.NoError(testUser.Insert())
 
 	//note this is the real DB host connector, not the mock
-	intentHost, err := (&DBHostConnector{}).NewIntentHost(testDistroID, testPublicKeyName, "", testUser)
+	intentHost, err := (&DBHostConnector{}).NewIntentHost(&restModel.HostPostRequest{
+		DistroID: testDistroID,
+		KeyName:  testPublicKeyName,
+	}, testUser)
 	s.NotNil(intentHost)
 	s.NoError(err)
 	foundHost, err := host.FindOne(host.ById(intentHost.Id))
@@ -222,6 +227,45 @@ func (s *HostConnectorSuite) TestSpawnHost() {
 	s.Equal(testUserID, foundHost.StartedBy)
 }
 
+func (s *HostConnectorSuite) TestSpawnHostFromTask() {
+	testDistroID := util.RandomString()
+	const testPublicKey = "ssh-rsa 1234567890abcdef"
+	const testUserID = "TestSpawnHostFromTaskUser"
+
+	testutil.ConfigureIntegrationTest(s.T(), testConfig, "TestSpawnHostFromTask")
+	d := &distro.Distro{
+		Id:           testDistroID,
+		SpawnAllowed: true,
+	}
+	s.NoError(d.Insert())
+	t := &task.Task{
+		Id:       "failed_task",
+		DistroId: testDistroID,
+		Status:   evergreen.TaskFailed,
+	}
+	s.NoError(t.Insert())
+	testUser := &user.DBUser{Id: testUserID}
+	s.NoError(testUser.Insert())
+
+	// the host runs on the task's distro when no distro is requested
+	intentHost, err := (&DBHostConnector{}).NewIntentHost(&restModel.HostPostRequest{
+		KeyName: testPublicKey,
+		TaskID:  t.Id,
+	}, testUser)
+	s.NoError(err)
+	s.Require().NotNil(intentHost)
+	s.Equal(testDistroID, intentHost.Distro.Id)
+	s.Require().NotNil(intentHost.ProvisionOptions)
+	s.Equal(t.Id, intentHost.ProvisionOptions.TaskId)
+	s.True(intentHost.ProvisionOptions.LoadCLI)
+
+	_, err = (&DBHostConnector{}).NewIntentHost(&restModel.HostPostRequest{
+		KeyName: testPublicKey,
+		TaskID:  "nonexistent",
+	}, testUser)
+	s.Error(err)
+}
+
 func (s *HostConnectorSuite) TestSetHostStatus() {
 	h, err := s.ctx.FindHostById("host1")
 	s.NoError(err)
@@ -24,6 +24,7 @@ type DBConnector struct {
 	DBAdminConnector
 	DBStatusConnector
 	DBAliasConnector
+	DBCommitQueueConnector
 	RepoTrackerConnector
 	CLIUpdateConnector
 	GenerateConnector
@@ -58,6 +59,7 @@ type MockConnector struct {
 	MockAdminConnector
 	MockStatusConnector
 	MockAliasConnector
+	MockCommitQueueConnector
 	MockRepoTrackerConnector
 	MockCLIUpdateConnector
 	MockGenerateConnector
@@ -8,6 +8,7 @@ import (
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/commitqueue"
 	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/host"
@@ -16,6 +17,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model/testresult"
 	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/evergreen-ci/evergreen/model/version"
+	"github.com/evergreen-ci/evergreen/thirdparty"
 	restModel "github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/evergreen-ci/gimlet"
 	"github.com/google/go-github/github"
@@ -101,7 +103,7 @@ type Connector interface {
 	FindHostByIdWithOwner(string, gimlet.User) (*host.Host, error)
 
 	// NewIntentHost is a method to insert an intent host given a distro and the name of a saved public key
-	NewIntentHost(string, string, string, *user.DBUser) (*host.Host, error)
+	NewIntentHost(*restModel.HostPostRequest, *user.DBUser) (*host.Host, error)
 
 	// FetchContext is a method to fetch a context given a series of identifiers.
 	FetchContext(string, string, string, string, string) (model.Context, error)
@@ -171,10 +173,15 @@ type Connector interface {
 	FindRecentTasks(int) ([]task.Task, *task.ResultCounts, error)
 	// GetHostStatsByDistro returns host stats broken down by distro
 	GetHostStatsByDistro() ([]host.StatsByDistro, error)
+	// GetWarmPoolStats returns how distros' warm pools behaved since the given time
+	GetWarmPoolStats(time.Time) ([]event.WarmPoolStats, error)
 
 	AddPublicKey(*user.DBUser, string, string) error
 	DeletePublicKey(*user.DBUser, string) error
 	UpdateSettings(*user.DBUser, user.UserSettings) error
+	// FindSpawnHostUsage returns the given user's spawn host usage of each
+	// distro in the month that the given time falls in
+	FindSpawnHostUsage(string, time.Time) ([]host.SpawnHostUsage, error)
 
 	AddPatchIntent(patch.Intent, amboy.Queue) error
 
@@ -183,10 +190,50 @@ type Connector interface {
 
 	// TerminateHost terminates the given host via the cloud provider's API
 	TerminateHost(context.Context, *host.Host, string) error
+	// ReprovisionHost marks the given static host to be drained, torn down
+	// and set up again
+	ReprovisionHost(*host.Host, string) error
+	// StopHost queues a job to stop the given spawn host on behalf of the
+	// given user
+	StopHost(amboy.Queue, *host.Host, string) error
+	// StartHost queues a job to start the given stopped spawn host on
+	// behalf of the given user
+	StartHost(amboy.Queue, *host.Host, string) error
+	// SetHostSleepSchedule sets or, if the schedule is nil, removes the
+	// given spawn host's sleep schedule
+	SetHostSleepSchedule(*host.Host, *host.SleepSchedule) error
+
+	// FindVolumesByUser returns the volumes the given user has that have not
+	// been deleted
+	FindVolumesByUser(string) ([]host.Volume, error)
+	// FindVolumeById returns the volume with the given id, unless it has
+	// been deleted
+	FindVolumeById(string) (*host.Volume, error)
+	// FindVolumeByIdWithOwner returns the volume with the given id, as long
+	// as the given user created it or is a superuser
+	FindVolumeByIdWithOwner(string, gimlet.User) (*host.Volume, error)
+	// ResizeVolume grows the given volume to the given size in GiB
+	ResizeVolume(context.Context, *host.Volume, int) error
+	// DeleteVolume deletes the given volume, which must not be attached to
+	// a host
+	DeleteVolume(context.Context, *host.Volume) error
 
 	// FindProjectAliases queries the database to find all aliases.
 	FindProjectAliases(string) ([]model.ProjectAlias, error)
 
+	// FindCommitQueueByID returns the commit queue of the given project
+	FindCommitQueueByID(string) (*commitqueue.CommitQueue, error)
+	// EnqueueItem adds the item to the end of the given project's commit
+	// queue and returns its position in the queue
+	EnqueueItem(string, commitqueue.CommitQueueItem) (int, error)
+	// CommitQueueRemoveItem removes the item with the given issue from the
+	// given project's commit queue on behalf of the given user, returning
+	// false if it wasn't in the queue
+	CommitQueueRemoveItem(string, string, string) (bool, error)
+	// CommitQueueGithubClient returns the client that the commit queue
+	// uses to look up and merge pull requests
+	CommitQueueGithubClient() (thirdparty.GithubClient, error)
+
 	// TriggerRepotracker creates an amboy job to get the commits from a
 	// Github Push Event
 	TriggerRepotracker(amboy.Queue, string, *github.PushEvent) error
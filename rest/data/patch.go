@@ -96,7 +96,7 @@ func (p *DBPatchConnector) AbortPatchesFromPullRequest(event *github.PullRequest
 	}
 
 	err = model.AbortPatchesWithGithubPatchData(*event.PullRequest.ClosedAt,
-		owner, repo, *event.Number)
+		owner, repo, *event.Number, nil)
 	if err != nil {
 		return &rest.APIError{
 			StatusCode: http.StatusInternalServerError,
@@ -3,6 +3,7 @@ package data
 import (
 	"time"
 
+	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/evergreen-ci/evergreen/model/task"
 )
@@ -30,12 +31,19 @@ func (c *DBStatusConnector) GetHostStatsByDistro() ([]host.StatsByDistro, error)
 	return host.GetStatsByDistro()
 }
 
+// GetWarmPoolStats returns warm pool stats for each distro, based on the
+// scheduler runs since the given time.
+func (c *DBStatusConnector) GetWarmPoolStats(since time.Time) ([]event.WarmPoolStats, error) {
+	return event.GetWarmPoolStats(since)
+}
+
 // MockStatusConnector is a struct that implements mock versions of
 // Distro-related methods for testing.
 type MockStatusConnector struct {
 	CachedTasks     []task.Task
 	CachedResults   *task.ResultCounts
 	CachedHostStats []host.StatsByDistro
+	CachedWarmPool  []event.WarmPoolStats
 }
 
 // FindRecentTasks is a mock implementation for testing.
@@ -47,3 +55,8 @@ func (c *MockStatusConnector) FindRecentTasks(minutes int) ([]task.Task, *task.R
 func (c *MockStatusConnector) GetHostStatsByDistro() ([]host.StatsByDistro, error) {
 	return c.CachedHostStats, nil
 }
+
+// GetWarmPoolStats returns mock warm pool stats
+func (c *MockStatusConnector) GetWarmPoolStats(since time.Time) ([]event.WarmPoolStats, error) {
+	return c.CachedWarmPool, nil
+}
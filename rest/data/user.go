@@ -8,6 +8,7 @@ import (
 
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/evergreen-ci/evergreen/rest"
 	"github.com/evergreen-ci/gimlet"
@@ -36,6 +37,10 @@ func (u *DBUserConnector) DeletePublicKey(user *user.DBUser, keyName string) err
 	return user.DeletePublicKey(keyName)
 }
 
+func (u *DBUserConnector) FindSpawnHostUsage(userId string, at time.Time) ([]host.SpawnHostUsage, error) {
+	return host.FindSpawnHostUsage(userId, at)
+}
+
 func (u *DBUserConnector) UpdateSettings(dbUser *user.DBUser, settings user.UserSettings) error {
 	if strings.HasPrefix(settings.SlackUsername, "#") {
 		return &rest.APIError{
@@ -100,7 +105,8 @@ func (u *DBUserConnector) UpdateSettings(dbUser *user.DBUser, settings user.User
 // MockUserConnector stores a cached set of users that are queried against by the
 // implementations of the UserConnector interface's functions.
 type MockUserConnector struct {
-	CachedUsers map[string]*user.DBUser
+	CachedUsers          map[string]*user.DBUser
+	CachedSpawnHostUsage []host.SpawnHostUsage
 }
 
 // FindUserById provides a mock implementation of the User functions
@@ -156,3 +162,14 @@ func (muc *MockUserConnector) DeletePublicKey(u *user.DBUser, keyName string) er
 func (muc *MockUserConnector) UpdateSettings(user *user.DBUser, settings user.UserSettings) error {
 	return errors.New("UpdateSettings not implemented for mock connector")
 }
+
+func (muc *MockUserConnector) FindSpawnHostUsage(userId string, at time.Time) ([]host.SpawnHostUsage, error) {
+	month := host.MonthStart(at)
+	usage := []host.SpawnHostUsage{}
+	for _, u := range muc.CachedSpawnHostUsage {
+		if u.User == userId && u.Month.Equal(month) {
+			usage = append(usage, u)
+		}
+	}
+	return usage, nil
+}
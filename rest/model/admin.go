@@ -12,25 +12,27 @@ import (
 
 func NewConfigModel() *APIAdminSettings {
 	return &APIAdminSettings{
-		Alerts:       &APIAlertsConfig{},
-		Amboy:        &APIAmboyConfig{},
-		Api:          &APIapiConfig{},
-		AuthConfig:   &APIAuthConfig{},
-		Credentials:  map[string]string{},
-		Expansions:   map[string]string{},
-		HostInit:     &APIHostInitConfig{},
-		Jira:         &APIJiraConfig{},
-		Keys:         map[string]string{},
-		LoggerConfig: &APILoggerConfig{},
-		Notify:       &APINotifyConfig{},
-		Plugins:      map[string]map[string]interface{}{},
-		Providers:    &APICloudProviders{},
-		RepoTracker:  &APIRepoTrackerConfig{},
-		Scheduler:    &APISchedulerConfig{},
-		ServiceFlags: &APIServiceFlags{},
-		Slack:        &APISlackConfig{},
-		Splunk:       &APISplunkConnectionInfo{},
-		Ui:           &APIUIConfig{},
+		Alerts:         &APIAlertsConfig{},
+		Amboy:          &APIAmboyConfig{},
+		Api:            &APIapiConfig{},
+		AuthConfig:     &APIAuthConfig{},
+		Credentials:    map[string]string{},
+		Expansions:     map[string]string{},
+		HostInit:       &APIHostInitConfig{},
+		HostQuarantine: &APIHostQuarantineConfig{},
+		Jira:           &APIJiraConfig{},
+		Keys:           map[string]string{},
+		LoggerConfig:   &APILoggerConfig{},
+		Notify:         &APINotifyConfig{},
+		Plugins:        map[string]map[string]interface{}{},
+		Providers:      &APICloudProviders{},
+		RepoTracker:    &APIRepoTrackerConfig{},
+		Scheduler:      &APISchedulerConfig{},
+		ServiceFlags:   &APIServiceFlags{},
+		Slack:          &APISlackConfig{},
+		SpawnHost:      &APISpawnHostConfig{},
+		Splunk:         &APISplunkConnectionInfo{},
+		Ui:             &APIUIConfig{},
 	}
 }
 
@@ -49,6 +51,7 @@ type APIAdminSettings struct {
 	Expansions         map[string]string                 `json:"expansions,omitempty"`
 	GithubPRCreatorOrg APIString                         `json:"github_pr_creator_org,omitempty"`
 	HostInit           *APIHostInitConfig                `json:"hostinit,omitempty"`
+	HostQuarantine     *APIHostQuarantineConfig          `json:"host_quarantine,omitempty"`
 	IsNonProd          *bool                             `json:"isnonprod,omitempty"`
 	Jira               *APIJiraConfig                    `json:"jira,omitempty"`
 	Keys               map[string]string                 `json:"keys,omitempty"`
@@ -62,6 +65,7 @@ type APIAdminSettings struct {
 	Scheduler          *APISchedulerConfig               `json:"scheduler,omitempty"`
 	ServiceFlags       *APIServiceFlags                  `json:"service_flags,omitempty"`
 	Slack              *APISlackConfig                   `json:"slack,omitempty"`
+	SpawnHost          *APISpawnHostConfig               `json:"spawnhost,omitempty"`
 	Splunk             *APISplunkConnectionInfo          `json:"splunk,omitempty"`
 	SuperUsers         []string                          `json:"superusers,omitempty"`
 	Ui                 *APIUIConfig                      `json:"ui,omitempty"`
@@ -566,6 +570,35 @@ func (a *APIHostInitConfig) ToService() (interface{}, error) {
 	}, nil
 }
 
+type APIHostQuarantineConfig struct {
+	ConsecutiveSystemFailures   int `json:"consecutive_system_failures"`
+	ConsecutiveSetupFailures    int `json:"consecutive_setup_failures"`
+	DistinctProjectFailures     int `json:"distinct_project_failures"`
+	ProjectFailureWindowMinutes int `json:"project_failure_window_minutes"`
+}
+
+func (a *APIHostQuarantineConfig) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case evergreen.HostQuarantineConfig:
+		a.ConsecutiveSystemFailures = v.ConsecutiveSystemFailures
+		a.ConsecutiveSetupFailures = v.ConsecutiveSetupFailures
+		a.DistinctProjectFailures = v.DistinctProjectFailures
+		a.ProjectFailureWindowMinutes = v.ProjectFailureWindowMinutes
+	default:
+		return errors.Errorf("%T is not a supported type", h)
+	}
+	return nil
+}
+
+func (a *APIHostQuarantineConfig) ToService() (interface{}, error) {
+	return evergreen.HostQuarantineConfig{
+		ConsecutiveSystemFailures:   a.ConsecutiveSystemFailures,
+		ConsecutiveSetupFailures:    a.ConsecutiveSetupFailures,
+		DistinctProjectFailures:     a.DistinctProjectFailures,
+		ProjectFailureWindowMinutes: a.ProjectFailureWindowMinutes,
+	}, nil
+}
+
 type APIJiraConfig struct {
 	Host           APIString `json:"host"`
 	Username       APIString `json:"username"`
@@ -693,11 +726,14 @@ func (a *APINotifyConfig) ToService() (interface{}, error) {
 }
 
 type APICloudProviders struct {
-	AWS       *APIAWSConfig       `json:"aws"`
-	Docker    *APIDockerConfig    `json:"docker"`
-	GCE       *APIGCEConfig       `json:"gce"`
-	OpenStack *APIOpenStackConfig `json:"openstack"`
-	VSphere   *APIVSphereConfig   `json:"vsphere"`
+	AWS        *APIAWSConfig        `json:"aws"`
+	Docker     *APIDockerConfig     `json:"docker"`
+	GCE        *APIGCEConfig        `json:"gce"`
+	OpenStack  *APIOpenStackConfig  `json:"openstack"`
+	VSphere    *APIVSphereConfig    `json:"vsphere"`
+	Kubernetes *APIKubernetesConfig `json:"kubernetes"`
+	Libvirt    *APILibvirtConfig    `json:"libvirt"`
+	Plugins    []APICloudPlugin     `json:"plugins"`
 }
 
 func (a *APICloudProviders) BuildFromService(h interface{}) error {
@@ -708,6 +744,8 @@ func (a *APICloudProviders) BuildFromService(h interface{}) error {
 		a.GCE = &APIGCEConfig{}
 		a.OpenStack = &APIOpenStackConfig{}
 		a.VSphere = &APIVSphereConfig{}
+		a.Kubernetes = &APIKubernetesConfig{}
+		a.Libvirt = &APILibvirtConfig{}
 		if err := a.AWS.BuildFromService(v.AWS); err != nil {
 			return err
 		}
@@ -723,6 +761,20 @@ func (a *APICloudProviders) BuildFromService(h interface{}) error {
 		if err := a.VSphere.BuildFromService(v.VSphere); err != nil {
 			return err
 		}
+		if err := a.Kubernetes.BuildFromService(v.Kubernetes); err != nil {
+			return err
+		}
+		if err := a.Libvirt.BuildFromService(v.Libvirt); err != nil {
+			return err
+		}
+		a.Plugins = []APICloudPlugin{}
+		for _, p := range v.Plugins {
+			plugin := APICloudPlugin{}
+			if err := plugin.BuildFromService(p); err != nil {
+				return err
+			}
+			a.Plugins = append(a.Plugins, plugin)
+		}
 	default:
 		return errors.Errorf("%T is not a supported type", h)
 	}
@@ -750,12 +802,31 @@ func (a *APICloudProviders) ToService() (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	kubernetes, err := a.Kubernetes.ToService()
+	if err != nil {
+		return nil, err
+	}
+	libvirt, err := a.Libvirt.ToService()
+	if err != nil {
+		return nil, err
+	}
+	plugins := []evergreen.CloudPlugin{}
+	for _, p := range a.Plugins {
+		plugin, err := p.ToService()
+		if err != nil {
+			return nil, err
+		}
+		plugins = append(plugins, plugin.(evergreen.CloudPlugin))
+	}
 	return evergreen.CloudProviders{
-		AWS:       aws.(evergreen.AWSConfig),
-		Docker:    docker.(evergreen.DockerConfig),
-		GCE:       gce.(evergreen.GCEConfig),
-		OpenStack: openstack.(evergreen.OpenStackConfig),
-		VSphere:   vsphere.(evergreen.VSphereConfig),
+		AWS:        aws.(evergreen.AWSConfig),
+		Docker:     docker.(evergreen.DockerConfig),
+		GCE:        gce.(evergreen.GCEConfig),
+		OpenStack:  openstack.(evergreen.OpenStackConfig),
+		VSphere:    vsphere.(evergreen.VSphereConfig),
+		Kubernetes: kubernetes.(evergreen.KubernetesConfig),
+		Libvirt:    libvirt.(evergreen.LibvirtConfig),
+		Plugins:    plugins,
 	}, nil
 }
 
@@ -898,6 +969,90 @@ func (a *APIVSphereConfig) ToService() (interface{}, error) {
 	}, nil
 }
 
+type APIKubernetesConfig struct {
+	APIServer APIString `json:"api_server"`
+	Token     APIString `json:"token"`
+	CACert    APIString `json:"ca_cert"`
+	Namespace APIString `json:"namespace"`
+}
+
+func (a *APIKubernetesConfig) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case evergreen.KubernetesConfig:
+		a.APIServer = ToAPIString(v.APIServer)
+		a.Token = ToAPIString(v.Token)
+		a.CACert = ToAPIString(v.CACert)
+		a.Namespace = ToAPIString(v.Namespace)
+	default:
+		return errors.Errorf("%T is not a supported type", h)
+	}
+	return nil
+}
+
+func (a *APIKubernetesConfig) ToService() (interface{}, error) {
+	return evergreen.KubernetesConfig{
+		APIServer: FromAPIString(a.APIServer),
+		Token:     FromAPIString(a.Token),
+		CACert:    FromAPIString(a.CACert),
+		Namespace: FromAPIString(a.Namespace),
+	}, nil
+}
+
+type APILibvirtConfig struct {
+	VirshPath APIString `json:"virsh_path"`
+}
+
+func (a *APILibvirtConfig) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case evergreen.LibvirtConfig:
+		a.VirshPath = ToAPIString(v.VirshPath)
+	default:
+		return errors.Errorf("%T is not a supported type", h)
+	}
+	return nil
+}
+
+func (a *APILibvirtConfig) ToService() (interface{}, error) {
+	return evergreen.LibvirtConfig{
+		VirshPath: FromAPIString(a.VirshPath),
+	}, nil
+}
+
+type APICloudPlugin struct {
+	Name        APIString   `json:"name"`
+	Executable  APIString   `json:"executable"`
+	Args        []APIString `json:"args"`
+	TimeoutSecs int         `json:"timeout_secs"`
+}
+
+func (a *APICloudPlugin) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case evergreen.CloudPlugin:
+		a.Name = ToAPIString(v.Name)
+		a.Executable = ToAPIString(v.Executable)
+		a.Args = []APIString{}
+		for _, arg := range v.Args {
+			a.Args = append(a.Args, ToAPIString(arg))
+		}
+		a.TimeoutSecs = v.TimeoutSecs
+	default:
+		return errors.Errorf("%T is not a supported type", h)
+	}
+	return nil
+}
+
+func (a *APICloudPlugin) ToService() (interface{}, error) {
+	plugin := evergreen.CloudPlugin{
+		Name:        FromAPIString(a.Name),
+		Executable:  FromAPIString(a.Executable),
+		TimeoutSecs: a.TimeoutSecs,
+	}
+	for _, arg := range a.Args {
+		plugin.Args = append(plugin.Args, FromAPIString(arg))
+	}
+	return plugin, nil
+}
+
 type APIRepoTrackerConfig struct {
 	NumNewRepoRevisionsToFetch int `json:"revs_to_fetch"`
 	MaxRepoRevisionsToSearch   int `json:"max_revs_to_search"`
@@ -964,6 +1119,7 @@ type APIServiceFlags struct {
 	CLIUpdatesDisabled           bool `json:"cli_updates_disabled"`
 	BackgroundStatsDisabled      bool `json:"background_stats_disabled"`
 	TaskLoggingDisabled          bool `json:"task_logging_disabled"`
+	CommitQueueDisabled          bool `json:"commit_queue_disabled"`
 
 	// Notifications Flags
 	EventProcessingDisabled      bool `json:"event_processing_disabled"`
@@ -1051,6 +1207,109 @@ func (a *APISlackOptions) ToService() (interface{}, error) {
 	}, nil
 }
 
+type APISpawnHostConfig struct {
+	HomeVolumeDefaultSizeGB int                  `json:"home_volume_default_size_gb"`
+	HomeVolumeQuotaGB       int                  `json:"home_volume_quota_gb"`
+	UserHomeVolumeQuotas    []APIUserVolumeQuota `json:"user_home_volume_quotas"`
+	DefaultQuota            APISpawnHostQuota    `json:"default_quota"`
+	Quotas                  []APISpawnHostQuota  `json:"quotas"`
+	QuotaWarningPercent     int                  `json:"quota_warning_percent"`
+}
+
+type APISpawnHostQuota struct {
+	User                 APIString `json:"user"`
+	Distro               APIString `json:"distro"`
+	MaxHosts             int       `json:"max_hosts"`
+	MaxHostHoursPerMonth int       `json:"max_host_hours_per_month"`
+	MaxSpendPerMonth     float64   `json:"max_spend_per_month"`
+}
+
+func (a *APISpawnHostQuota) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case evergreen.SpawnHostQuota:
+		a.User = ToAPIString(v.User)
+		a.Distro = ToAPIString(v.Distro)
+		a.MaxHosts = v.MaxHosts
+		a.MaxHostHoursPerMonth = v.MaxHostHoursPerMonth
+		a.MaxSpendPerMonth = v.MaxSpendPerMonth
+	default:
+		return errors.Errorf("%T is not a supported type", h)
+	}
+	return nil
+}
+
+func (a *APISpawnHostQuota) ToService() (interface{}, error) {
+	return evergreen.SpawnHostQuota{
+		User:                 FromAPIString(a.User),
+		Distro:               FromAPIString(a.Distro),
+		MaxHosts:             a.MaxHosts,
+		MaxHostHoursPerMonth: a.MaxHostHoursPerMonth,
+		MaxSpendPerMonth:     a.MaxSpendPerMonth,
+	}, nil
+}
+
+type APIUserVolumeQuota struct {
+	User    APIString `json:"user"`
+	QuotaGB int       `json:"quota_gb"`
+}
+
+func (a *APISpawnHostConfig) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case evergreen.SpawnHostConfig:
+		a.HomeVolumeDefaultSizeGB = v.HomeVolumeDefaultSizeGB
+		a.HomeVolumeQuotaGB = v.HomeVolumeQuotaGB
+		a.UserHomeVolumeQuotas = []APIUserVolumeQuota{}
+		for _, q := range v.UserHomeVolumeQuotas {
+			a.UserHomeVolumeQuotas = append(a.UserHomeVolumeQuotas, APIUserVolumeQuota{
+				User:    ToAPIString(q.User),
+				QuotaGB: q.QuotaGB,
+			})
+		}
+		if err := a.DefaultQuota.BuildFromService(v.DefaultQuota); err != nil {
+			return err
+		}
+		a.Quotas = []APISpawnHostQuota{}
+		for _, q := range v.Quotas {
+			apiQuota := APISpawnHostQuota{}
+			if err := apiQuota.BuildFromService(q); err != nil {
+				return err
+			}
+			a.Quotas = append(a.Quotas, apiQuota)
+		}
+		a.QuotaWarningPercent = v.QuotaWarningPercent
+	default:
+		return errors.Errorf("%T is not a supported type", h)
+	}
+	return nil
+}
+
+func (a *APISpawnHostConfig) ToService() (interface{}, error) {
+	config := evergreen.SpawnHostConfig{
+		HomeVolumeDefaultSizeGB: a.HomeVolumeDefaultSizeGB,
+		HomeVolumeQuotaGB:       a.HomeVolumeQuotaGB,
+		QuotaWarningPercent:     a.QuotaWarningPercent,
+	}
+	for _, q := range a.UserHomeVolumeQuotas {
+		config.UserHomeVolumeQuotas = append(config.UserHomeVolumeQuotas, evergreen.UserVolumeQuota{
+			User:    FromAPIString(q.User),
+			QuotaGB: q.QuotaGB,
+		})
+	}
+	defaultQuota, err := a.DefaultQuota.ToService()
+	if err != nil {
+		return nil, err
+	}
+	config.DefaultQuota = defaultQuota.(evergreen.SpawnHostQuota)
+	for _, q := range a.Quotas {
+		quota, err := q.ToService()
+		if err != nil {
+			return nil, err
+		}
+		config.Quotas = append(config.Quotas, quota.(evergreen.SpawnHostQuota))
+	}
+	return config, nil
+}
+
 type APISplunkConnectionInfo struct {
 	ServerURL APIString `json:"url"`
 	Token     APIString `json:"token"`
@@ -1163,6 +1422,7 @@ func (as *APIServiceFlags) BuildFromService(h interface{}) error {
 		as.GithubStatusAPIDisabled = v.GithubStatusAPIDisabled
 		as.BackgroundStatsDisabled = v.BackgroundStatsDisabled
 		as.TaskLoggingDisabled = v.TaskLoggingDisabled
+		as.CommitQueueDisabled = v.CommitQueueDisabled
 	default:
 		return errors.Errorf("%T is not a supported service flags type", h)
 	}
@@ -1190,6 +1450,7 @@ func (as *APIServiceFlags) ToService() (interface{}, error) {
 		GithubStatusAPIDisabled:      as.GithubStatusAPIDisabled,
 		BackgroundStatsDisabled:      as.BackgroundStatsDisabled,
 		TaskLoggingDisabled:          as.TaskLoggingDisabled,
+		CommitQueueDisabled:          as.CommitQueueDisabled,
 	}, nil
 }
 
@@ -59,6 +59,12 @@ func TestModelConversion(t *testing.T) {
 	assert.EqualValues(testSettings.AuthConfig.Github.ClientId, FromAPIString(apiSettings.AuthConfig.Github.ClientId))
 	assert.Equal(len(testSettings.AuthConfig.Github.Users), len(apiSettings.AuthConfig.Github.Users))
 	assert.EqualValues(testSettings.HostInit.SSHTimeoutSeconds, apiSettings.HostInit.SSHTimeoutSeconds)
+	assert.EqualValues(testSettings.HostQuarantine.ConsecutiveSystemFailures, apiSettings.HostQuarantine.ConsecutiveSystemFailures)
+	assert.EqualValues(testSettings.SpawnHost.HomeVolumeQuotaGB, apiSettings.SpawnHost.HomeVolumeQuotaGB)
+	assert.EqualValues(testSettings.SpawnHost.UserHomeVolumeQuotas[0].User, FromAPIString(apiSettings.SpawnHost.UserHomeVolumeQuotas[0].User))
+	assert.EqualValues(testSettings.SpawnHost.DefaultQuota.MaxHosts, apiSettings.SpawnHost.DefaultQuota.MaxHosts)
+	assert.EqualValues(testSettings.SpawnHost.Quotas[0].Distro, FromAPIString(apiSettings.SpawnHost.Quotas[0].Distro))
+	assert.EqualValues(testSettings.SpawnHost.Quotas[0].MaxSpendPerMonth, apiSettings.SpawnHost.Quotas[0].MaxSpendPerMonth)
 	assert.EqualValues(testSettings.Jira.Username, FromAPIString(apiSettings.Jira.Username))
 	assert.EqualValues(testSettings.LoggerConfig.DefaultLevel, FromAPIString(apiSettings.LoggerConfig.DefaultLevel))
 	assert.EqualValues(testSettings.LoggerConfig.Buffer.Count, apiSettings.LoggerConfig.Buffer.Count)
@@ -93,6 +99,8 @@ func TestModelConversion(t *testing.T) {
 	assert.EqualValues(testSettings.AuthConfig.Github.ClientId, dbSettings.AuthConfig.Github.ClientId)
 	assert.Equal(len(testSettings.AuthConfig.Github.Users), len(dbSettings.AuthConfig.Github.Users))
 	assert.EqualValues(testSettings.HostInit.SSHTimeoutSeconds, dbSettings.HostInit.SSHTimeoutSeconds)
+	assert.EqualValues(testSettings.HostQuarantine, dbSettings.HostQuarantine)
+	assert.EqualValues(testSettings.SpawnHost, dbSettings.SpawnHost)
 	assert.EqualValues(testSettings.Jira.Username, dbSettings.Jira.Username)
 	assert.EqualValues(testSettings.LoggerConfig.DefaultLevel, dbSettings.LoggerConfig.DefaultLevel)
 	assert.EqualValues(testSettings.LoggerConfig.Buffer.Count, dbSettings.LoggerConfig.Buffer.Count)
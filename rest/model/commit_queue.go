@@ -0,0 +1,110 @@
+package model
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/commitqueue"
+	"github.com/pkg/errors"
+)
+
+// APICommitQueue is the model for a project's commit queue.
+type APICommitQueue struct {
+	ProjectID APIString            `json:"queue_id"`
+	Queue     []APICommitQueueItem `json:"queue"`
+}
+
+// APICommitQueueItem is the model for a change in a commit queue. It's also
+// the format of a PUT request to /commit_queue/{project_id}, which only
+// needs the issue and type.
+type APICommitQueueItem struct {
+	Issue        APIString `json:"issue"`
+	Type         APIString `json:"type"`
+	Author       APIString `json:"author"`
+	EnqueueTime  APITime   `json:"enqueue_time"`
+	ApprovedHash APIString `json:"approved_hash"`
+	PatchID      APIString `json:"patch_id"`
+	HeadHash     APIString `json:"head_hash"`
+}
+
+// APICommitQueuePosition is the position an item was added to a commit
+// queue at, counting from 0 for the head of the queue.
+type APICommitQueuePosition struct {
+	Position int `json:"position"`
+}
+
+// BuildFromService converts a service level commit queue to an
+// APICommitQueue.
+func (apiQueue *APICommitQueue) BuildFromService(h interface{}) error {
+	var cq *commitqueue.CommitQueue
+	switch v := h.(type) {
+	case commitqueue.CommitQueue:
+		cq = &v
+	case *commitqueue.CommitQueue:
+		cq = v
+	default:
+		return errors.Errorf("incorrect type '%T' when converting commit queue", h)
+	}
+
+	apiQueue.ProjectID = ToAPIString(cq.ProjectID)
+	apiQueue.Queue = []APICommitQueueItem{}
+	for _, item := range cq.Queue {
+		apiItem := APICommitQueueItem{}
+		if err := apiItem.BuildFromService(item); err != nil {
+			return err
+		}
+		apiQueue.Queue = append(apiQueue.Queue, apiItem)
+	}
+	return nil
+}
+
+// ToService is not implemented for APICommitQueue.
+func (apiQueue *APICommitQueue) ToService() (interface{}, error) {
+	return nil, errors.New("ToService() is not implemented for APICommitQueue")
+}
+
+// BuildFromService converts a service level commit queue item to an
+// APICommitQueueItem.
+func (apiItem *APICommitQueueItem) BuildFromService(h interface{}) error {
+	item, ok := h.(commitqueue.CommitQueueItem)
+	if !ok {
+		return errors.Errorf("incorrect type '%T' when converting commit queue item", h)
+	}
+
+	apiItem.Issue = ToAPIString(item.Issue)
+	apiItem.Type = ToAPIString(item.Type)
+	apiItem.Author = ToAPIString(item.Author)
+	apiItem.EnqueueTime = NewTime(item.EnqueueTime)
+	apiItem.ApprovedHash = ToAPIString(item.ApprovedHash)
+	apiItem.PatchID = ToAPIString(item.PatchID)
+	apiItem.HeadHash = ToAPIString(item.HeadHash)
+	return nil
+}
+
+// ToService returns a service level commit queue item using the data from
+// the APICommitQueueItem.
+func (apiItem *APICommitQueueItem) ToService() (interface{}, error) {
+	return commitqueue.CommitQueueItem{
+		Issue:        FromAPIString(apiItem.Issue),
+		Type:         FromAPIString(apiItem.Type),
+		Author:       FromAPIString(apiItem.Author),
+		EnqueueTime:  time.Time(apiItem.EnqueueTime),
+		ApprovedHash: FromAPIString(apiItem.ApprovedHash),
+		PatchID:      FromAPIString(apiItem.PatchID),
+		HeadHash:     FromAPIString(apiItem.HeadHash),
+	}, nil
+}
+
+// BuildFromService sets the position from an int.
+func (apiPosition *APICommitQueuePosition) BuildFromService(h interface{}) error {
+	position, ok := h.(int)
+	if !ok {
+		return errors.Errorf("incorrect type '%T' when converting commit queue position", h)
+	}
+	apiPosition.Position = position
+	return nil
+}
+
+// ToService returns the position.
+func (apiPosition *APICommitQueuePosition) ToService() (interface{}, error) {
+	return apiPosition.Position, nil
+}
@@ -2,6 +2,8 @@ package model
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/evergreen-ci/evergreen/model/task"
@@ -19,12 +21,17 @@ type APIHost struct {
 	Status      APIString  `json:"status"`
 	RunningTask taskInfo   `json:"running_task"`
 	UserHost    bool       `json:"user_host"`
+
+	SleepSchedule *APISleepSchedule `json:"sleep_schedule,omitempty"`
 }
 
 // HostPostRequest is a struct that holds the format of a POST request to /hosts
 type HostPostRequest struct {
-	DistroID string `json:"distro"`
-	KeyName  string `json:"keyname"`
+	DistroID       string `json:"distro"`
+	KeyName        string `json:"keyname"`
+	TaskID         string `json:"task_id,omitempty"`
+	HomeVolume     bool   `json:"home_volume,omitempty"`
+	HomeVolumeSize int    `json:"home_volume_size,omitempty"`
 }
 
 type DistroInfo struct {
@@ -92,6 +99,13 @@ func (apiHost *APIHost) buildFromHostStruct(h interface{}) error {
 		Provider: ToAPIString(v.Distro.Provider),
 	}
 	apiHost.Distro = di
+
+	if v.SleepSchedule != nil {
+		apiHost.SleepSchedule = &APISleepSchedule{}
+		if err := apiHost.SleepSchedule.BuildFromService(v.SleepSchedule); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -114,3 +128,124 @@ type APISpawnHostModify struct {
 	RDPPwd   APIString `json:"rdp_pwd"`
 	AddHours APIString `json:"add_hours"`
 }
+
+// APISleepSchedule is the model for a spawn host's sleep schedule. Days off
+// are given by name, e.g. "saturday".
+type APISleepSchedule struct {
+	StopHour     int      `json:"stop_hour"`
+	StartHour    int      `json:"start_hour"`
+	WholeDaysOff []string `json:"whole_days_off,omitempty"`
+	TimeZone     string   `json:"time_zone,omitempty"`
+	Asleep       bool     `json:"asleep"`
+}
+
+// BuildFromService converts a service level sleep schedule to an
+// APISleepSchedule.
+func (s *APISleepSchedule) BuildFromService(h interface{}) error {
+	var schedule *host.SleepSchedule
+	switch v := h.(type) {
+	case host.SleepSchedule:
+		schedule = &v
+	case *host.SleepSchedule:
+		schedule = v
+	default:
+		return fmt.Errorf("incorrect type when converting sleep schedule")
+	}
+
+	s.StopHour = schedule.StopHour
+	s.StartHour = schedule.StartHour
+	s.TimeZone = schedule.TimeZone
+	s.Asleep = schedule.Asleep
+	s.WholeDaysOff = nil
+	for _, day := range schedule.WholeDaysOff {
+		s.WholeDaysOff = append(s.WholeDaysOff, strings.ToLower(day.String()))
+	}
+
+	return nil
+}
+
+// ToService returns a service layer sleep schedule using the data from the
+// APISleepSchedule.
+func (s *APISleepSchedule) ToService() (interface{}, error) {
+	schedule := &host.SleepSchedule{
+		StopHour:  s.StopHour,
+		StartHour: s.StartHour,
+		TimeZone:  s.TimeZone,
+		Asleep:    s.Asleep,
+	}
+	for _, name := range s.WholeDaysOff {
+		day, ok := weekdaysByName[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("'%s' is not a day of the week", name)
+		}
+		schedule.WholeDaysOff = append(schedule.WholeDaysOff, day)
+	}
+
+	return schedule, nil
+}
+
+// APIVolume is the model for a user's persistent home volume.
+type APIVolume struct {
+	ID           APIString `json:"volume_id"`
+	CreatedBy    APIString `json:"created_by"`
+	Provider     APIString `json:"provider"`
+	Size         int       `json:"size"`
+	Zone         APIString `json:"zone"`
+	Status       APIString `json:"status"`
+	Host         APIString `json:"host_id"`
+	CreationTime APITime   `json:"creation_time"`
+}
+
+// VolumeModifyRequest is the format of a PATCH request to /volumes/{volume_id}
+type VolumeModifyRequest struct {
+	Size int `json:"size"`
+}
+
+// BuildFromService converts a service level volume to an APIVolume.
+func (apiVolume *APIVolume) BuildFromService(h interface{}) error {
+	var v *host.Volume
+	switch volume := h.(type) {
+	case host.Volume:
+		v = &volume
+	case *host.Volume:
+		v = volume
+	default:
+		return fmt.Errorf("incorrect type when converting volume")
+	}
+
+	apiVolume.ID = ToAPIString(v.ID)
+	apiVolume.CreatedBy = ToAPIString(v.CreatedBy)
+	apiVolume.Provider = ToAPIString(v.Provider)
+	apiVolume.Size = v.Size
+	apiVolume.Zone = ToAPIString(v.Zone)
+	apiVolume.Status = ToAPIString(v.Status)
+	apiVolume.Host = ToAPIString(v.Host)
+	apiVolume.CreationTime = NewTime(v.CreationTime)
+
+	return nil
+}
+
+// ToService returns a service layer volume using the data from the
+// APIVolume.
+func (apiVolume *APIVolume) ToService() (interface{}, error) {
+	return &host.Volume{
+		ID:           FromAPIString(apiVolume.ID),
+		CreatedBy:    FromAPIString(apiVolume.CreatedBy),
+		Provider:     FromAPIString(apiVolume.Provider),
+		Size:         apiVolume.Size,
+		Zone:         FromAPIString(apiVolume.Zone),
+		Status:       FromAPIString(apiVolume.Status),
+		Host:         FromAPIString(apiVolume.Host),
+		CreationTime: time.Time(apiVolume.CreationTime),
+	}, nil
+}
+
+var weekdaysByName = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/evergreen-ci/evergreen/model/task"
 	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type hostCompare struct {
@@ -86,3 +88,62 @@ func TestHostBuildFromService(t *testing.T) {
 		})
 	})
 }
+
+func TestSleepScheduleConversion(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	apiSchedule := &APISleepSchedule{
+		StopHour:     22,
+		StartHour:    8,
+		WholeDaysOff: []string{"Saturday", "sunday"},
+		TimeZone:     "America/New_York",
+	}
+	out, err := apiSchedule.ToService()
+	require.NoError(err)
+	schedule, ok := out.(*host.SleepSchedule)
+	require.True(ok)
+	assert.Equal([]time.Weekday{time.Saturday, time.Sunday}, schedule.WholeDaysOff)
+	assert.Equal("America/New_York", schedule.TimeZone)
+
+	roundTrip := &APISleepSchedule{}
+	require.NoError(roundTrip.BuildFromService(schedule))
+	assert.Equal([]string{"saturday", "sunday"}, roundTrip.WholeDaysOff)
+	assert.Equal(22, roundTrip.StopHour)
+	assert.Equal(8, roundTrip.StartHour)
+
+	apiSchedule.WholeDaysOff = []string{"caturday"}
+	_, err = apiSchedule.ToService()
+	assert.Error(err)
+}
+
+func TestVolumeConversion(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	v := host.Volume{
+		ID:           "vol-123",
+		CreatedBy:    "me",
+		Provider:     "ec2-ondemand",
+		Size:         64,
+		Zone:         "us-east-1a",
+		Status:       host.VolumeAttached,
+		Host:         "h1",
+		CreationTime: time.Now().Round(time.Second),
+	}
+	apiVolume := &APIVolume{}
+	require.NoError(apiVolume.BuildFromService(v))
+	assert.Equal("vol-123", FromAPIString(apiVolume.ID))
+	assert.Equal("h1", FromAPIString(apiVolume.Host))
+	assert.Equal(64, apiVolume.Size)
+
+	out, err := apiVolume.ToService()
+	require.NoError(err)
+	roundTrip, ok := out.(*host.Volume)
+	require.True(ok)
+	assert.Equal(v.ID, roundTrip.ID)
+	assert.Equal(v.Status, roundTrip.Status)
+	assert.True(v.CreationTime.Equal(roundTrip.CreationTime))
+
+	assert.Error(apiVolume.BuildFromService(&host.Host{}))
+}
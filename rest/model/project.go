@@ -24,6 +24,34 @@ type APIProject struct {
 	Vars               map[string]string        `json:"vars"`
 	TracksPushEvents   bool                     `json:"tracks_push_events"`
 	PRTestingEnabled   bool                     `json:"pr_testing_enabled"`
+	PeriodicBuilds     []APIPeriodicBuild       `json:"periodic_builds"`
+	CommitQueue        APICommitQueueParams     `json:"commit_queue"`
+	AutoRetry          APIAutoRetryParams       `json:"auto_retry"`
+	AbortSuperseded    bool                     `json:"abort_superseded_patches"`
+}
+
+type APICommitQueueParams struct {
+	Enabled     bool      `json:"enabled"`
+	MergeMethod APIString `json:"merge_method"`
+	PatchAlias  APIString `json:"patch_alias"`
+}
+
+type APIAutoRetryParams struct {
+	Times      int                     `json:"times"`
+	Conditions []APIAutoRetryCondition `json:"conditions"`
+}
+
+type APIAutoRetryCondition struct {
+	Type    APIString `json:"type"`
+	Pattern APIString `json:"pattern"`
+}
+
+type APIPeriodicBuild struct {
+	ID          APIString `json:"id"`
+	Cron        APIString `json:"cron"`
+	Alias       APIString `json:"alias"`
+	Message     APIString `json:"message"`
+	NextRunTime APITime   `json:"next_run_time"`
 }
 
 type alertConfig struct {
@@ -71,6 +99,37 @@ func (apiProject *APIProject) BuildFromService(p interface{}) error {
 	}
 	apiProject.Admins = admins
 
+	periodicBuilds := []APIPeriodicBuild{}
+	for _, d := range v.PeriodicBuilds {
+		periodicBuilds = append(periodicBuilds, APIPeriodicBuild{
+			ID:          ToAPIString(d.ID),
+			Cron:        ToAPIString(d.Cron),
+			Alias:       ToAPIString(d.Alias),
+			Message:     ToAPIString(d.Message),
+			NextRunTime: NewTime(d.NextRunTime),
+		})
+	}
+	apiProject.PeriodicBuilds = periodicBuilds
+
+	apiProject.CommitQueue = APICommitQueueParams{
+		Enabled:     v.CommitQueue.Enabled,
+		MergeMethod: ToAPIString(v.CommitQueue.MergeMethod),
+		PatchAlias:  ToAPIString(v.CommitQueue.PatchAlias),
+	}
+
+	conditions := []APIAutoRetryCondition{}
+	for _, c := range v.AutoRetry.Conditions {
+		conditions = append(conditions, APIAutoRetryCondition{
+			Type:    ToAPIString(c.Type),
+			Pattern: ToAPIString(c.Pattern),
+		})
+	}
+	apiProject.AutoRetry = APIAutoRetryParams{
+		Times:      v.AutoRetry.Times,
+		Conditions: conditions,
+	}
+	apiProject.AbortSuperseded = v.AbortSupersededPatches
+
 	return nil
 }
 
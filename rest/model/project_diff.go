@@ -0,0 +1,41 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/evergreen-ci/evergreen/model"
+)
+
+// APIProjectDiff is the difference between two project configurations.
+type APIProjectDiff struct {
+	AddedVariants   []string            `json:"added_variants"`
+	RemovedVariants []string            `json:"removed_variants"`
+	ChangedVariants []model.VariantDiff `json:"changed_variants"`
+	AddedTasks      []string            `json:"added_tasks"`
+	RemovedTasks    []string            `json:"removed_tasks"`
+	ChangedTasks    []model.TaskDiff    `json:"changed_tasks"`
+}
+
+// BuildFromService converts from a service level ProjectDiff to an
+// APIProjectDiff. Empty lists are kept as empty lists, so that every key is
+// present for callers checking the diff.
+func (d *APIProjectDiff) BuildFromService(h interface{}) error {
+	v, ok := h.(*model.ProjectDiff)
+	if !ok {
+		return fmt.Errorf("incorrect type when fetching converting project diff type")
+	}
+	d.AddedVariants = append([]string{}, v.AddedVariants...)
+	d.RemovedVariants = append([]string{}, v.RemovedVariants...)
+	d.ChangedVariants = append([]model.VariantDiff{}, v.ChangedVariants...)
+	d.AddedTasks = append([]string{}, v.AddedTasks...)
+	d.RemovedTasks = append([]string{}, v.RemovedTasks...)
+	d.ChangedTasks = append([]model.TaskDiff{}, v.ChangedTasks...)
+	return nil
+}
+
+// ToService returns a service layer ProjectDiff using the data from
+// APIProjectDiff.
+func (d *APIProjectDiff) ToService() (interface{}, error) {
+	return nil, errors.New("(*APIProjectDiff) ToService not implemented for read-only route")
+}
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/evergreen-ci/evergreen/util"
+)
+
+// APIProjectSchema is the JSON Schema for project configuration files.
+type APIProjectSchema struct {
+	*util.JSONSchema
+}
+
+// BuildFromService converts from a service level JSONSchema to an
+// APIProjectSchema.
+func (s *APIProjectSchema) BuildFromService(h interface{}) error {
+	v, ok := h.(*util.JSONSchema)
+	if !ok {
+		return fmt.Errorf("incorrect type when fetching converting project schema type")
+	}
+	s.JSONSchema = v
+	return nil
+}
+
+// ToService returns a service layer JSONSchema using the data from
+// APIProjectSchema.
+func (s *APIProjectSchema) ToService() (interface{}, error) {
+	return nil, errors.New("(*APIProjectSchema) ToService not implemented for read-only route")
+}
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/pkg/errors"
@@ -87,3 +88,44 @@ func (s *APIHostStatsByDistro) BuildFromService(h interface{}) error {
 func (s *APIHostStatsByDistro) ToService() (interface{}, error) {
 	return nil, errors.Errorf("ToService() is not implemented for APIHostStatsByDistro")
 }
+
+// APIWarmPoolStats reports how often the warm pools of distros absorbed
+// bursts of tasks.
+type APIWarmPoolStats struct {
+	Distros []apiWarmPoolStatsForDistro `json:"distros"`
+}
+
+type apiWarmPoolStatsForDistro struct {
+	Distro       APIString `json:"distro"`
+	Runs         int       `json:"scheduler_runs"`
+	Bursts       int       `json:"bursts"`
+	Absorbed     int       `json:"absorbed"`
+	AbsorbedRate float64   `json:"absorbed_rate"`
+	NewHosts     int       `json:"warm_hosts_started"`
+}
+
+// BuildFromService converts the warm pool stats of each distro.
+func (s *APIWarmPoolStats) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case []event.WarmPoolStats:
+		s.Distros = []apiWarmPoolStatsForDistro{}
+		for _, entry := range v {
+			s.Distros = append(s.Distros, apiWarmPoolStatsForDistro{
+				Distro:       ToAPIString(entry.Distro),
+				Runs:         entry.Runs,
+				Bursts:       entry.Bursts,
+				Absorbed:     entry.Absorbed,
+				AbsorbedRate: entry.AbsorbedRate(),
+				NewHosts:     entry.NewHosts,
+			})
+		}
+	default:
+		return errors.Errorf("incorrect type when converting warm pool stats (%T)", v)
+	}
+	return nil
+}
+
+// ToService is not implemented for APIWarmPoolStats
+func (s *APIWarmPoolStats) ToService() (interface{}, error) {
+	return nil, errors.Errorf("ToService() is not implemented for APIWarmPoolStats")
+}
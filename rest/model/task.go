@@ -36,6 +36,9 @@ type APITask struct {
 	DisplayName        APIString        `json:"display_name"`
 	HostId             APIString        `json:"host_id"`
 	Restarts           int              `json:"restarts"`
+	AutoRetries        int              `json:"auto_retries"`
+	RetryReason        APIString        `json:"retry_reason"`
+	Flaky              bool             `json:"flaky"`
 	Execution          int              `json:"execution"`
 	Order              int              `json:"order"`
 	Status             APIString        `json:"status"`
@@ -100,6 +103,9 @@ func (at *APITask) BuildFromService(t interface{}) error {
 			DisplayName:   ToAPIString(v.DisplayName),
 			HostId:        ToAPIString(v.HostId),
 			Restarts:      v.Restarts,
+			AutoRetries:   v.AutoRetries,
+			RetryReason:   ToAPIString(v.RetryReason),
+			Flaky:         v.IsFlaky(),
 			Execution:     v.Execution,
 			Order:         v.RevisionOrderNumber,
 			Details: apiTaskEndDetail{
@@ -3,6 +3,7 @@ package model
 import (
 	"reflect"
 
+	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/pkg/errors"
 )
@@ -116,6 +117,8 @@ type APINotificationPreferences struct {
 	BuildBreakID  APIString `json:"build_break_id,omitempty"`
 	PatchFinish   APIString `json:"patch_finish"`
 	PatchFinishID APIString `json:"patch_finish_id,omitempty"`
+
+	SpawnHostQuota APIString `json:"spawn_host_quota"`
 }
 
 func (n *APINotificationPreferences) BuildFromService(h interface{}) error {
@@ -126,6 +129,7 @@ func (n *APINotificationPreferences) BuildFromService(h interface{}) error {
 	case user.NotificationPreferences:
 		n.BuildBreak = ToAPIString(string(v.BuildBreak))
 		n.PatchFinish = ToAPIString(string(v.PatchFinish))
+		n.SpawnHostQuota = ToAPIString(string(v.SpawnHostQuota))
 		if v.BuildBreakID != "" {
 			n.BuildBreakID = ToAPIString(v.BuildBreakID.Hex())
 		}
@@ -150,9 +154,14 @@ func (n *APINotificationPreferences) ToService() (interface{}, error) {
 	if !user.IsValidSubscriptionPreference(patchFinish) {
 		return nil, errors.New("Patch finish preference is not a valid type")
 	}
+	spawnHostQuota := FromAPIString(n.SpawnHostQuota)
+	if !user.IsValidSubscriptionPreference(spawnHostQuota) {
+		return nil, errors.New("Spawn host quota preference is not a valid type")
+	}
 	preferences := user.NotificationPreferences{
-		BuildBreak:  user.UserSubscriptionPreference(buildbreak),
-		PatchFinish: user.UserSubscriptionPreference(patchFinish),
+		BuildBreak:     user.UserSubscriptionPreference(buildbreak),
+		PatchFinish:    user.UserSubscriptionPreference(patchFinish),
+		SpawnHostQuota: user.UserSubscriptionPreference(spawnHostQuota),
 	}
 	var err error
 	if n.BuildBreakID != nil {
@@ -190,3 +199,49 @@ func ApplyUserChanges(current user.UserSettings, changes APIUserSettings) (APIUs
 
 	return oldSettings, nil
 }
+
+// APIUserCost is how much a user's spawn hosts have run and cost in a month.
+type APIUserCost struct {
+	UserID    APIString           `json:"user_id"`
+	Month     APITime             `json:"month"`
+	HostHours float64             `json:"host_hours"`
+	Cost      float64             `json:"cost"`
+	Distros   []APIUserDistroCost `json:"distros"`
+}
+
+// APIUserDistroCost is how much a user's spawn hosts from one distro have run
+// and cost in a month.
+type APIUserDistroCost struct {
+	Distro    APIString `json:"distro"`
+	HostHours float64   `json:"host_hours"`
+	Cost      float64   `json:"cost"`
+}
+
+// BuildFromService totals a user's spawn host usage of each distro in a
+// month. The month and user must be set beforehand, since a user who hasn't
+// used any spawn hosts has no usage to take them from.
+func (c *APIUserCost) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case []host.SpawnHostUsage:
+		c.HostHours = 0
+		c.Cost = 0
+		c.Distros = []APIUserDistroCost{}
+		for _, u := range v {
+			c.HostHours += u.HostHours
+			c.Cost += u.Cost
+			c.Distros = append(c.Distros, APIUserDistroCost{
+				Distro:    ToAPIString(u.Distro),
+				HostHours: u.HostHours,
+				Cost:      u.Cost,
+			})
+		}
+	default:
+		return errors.Errorf("incorrect type when converting spawn host usage")
+	}
+	return nil
+}
+
+// ToService is not implemented for APIUserCost.
+func (c *APIUserCost) ToService() (interface{}, error) {
+	return nil, errors.Errorf("ToService() is not implemented for APIUserCost")
+}
@@ -16,8 +16,9 @@ func TestFullUserSettings(t *testing.T) {
 			LastKnownAs: "peter",
 		},
 		Notifications: user.NotificationPreferences{
-			BuildBreak:  user.PreferenceEmail,
-			PatchFinish: user.PreferenceSlack,
+			BuildBreak:     user.PreferenceEmail,
+			PatchFinish:    user.PreferenceSlack,
+			SpawnHostQuota: user.PreferenceNone,
 		},
 	}
 
@@ -0,0 +1,368 @@
+package route
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen/auth"
+	"github.com/evergreen-ci/evergreen/model/commitqueue"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/rest"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/google/go-github/github"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+////////////////////////////////////////////////////////////////////////
+//
+// GET /commit_queue/{project_id}
+// PUT /commit_queue/{project_id}
+
+func getCommitQueueRouteManager(route string, version int) *RouteManager {
+	return &RouteManager{
+		Route:   route,
+		Version: version,
+		Methods: []MethodHandler{
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+				MethodType:        http.MethodGet,
+				Authenticator:     &RequireUserAuthenticator{},
+				RequestHandler:    &commitQueueGetHandler{},
+			},
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+				MethodType:        http.MethodPut,
+				Authenticator:     &RequireUserAuthenticator{},
+				RequestHandler:    &commitQueueEnqueueHandler{},
+			},
+		},
+	}
+}
+
+type commitQueueGetHandler struct{}
+
+func (h *commitQueueGetHandler) Handler() RequestHandler {
+	return &commitQueueGetHandler{}
+}
+
+func (h *commitQueueGetHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	return nil
+}
+
+func (h *commitQueueGetHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	projectID, err := commitQueueProjectID(ctx)
+	if err != nil {
+		return ResponseData{}, err
+	}
+
+	cq, err := sc.FindCommitQueueByID(projectID)
+	if err != nil {
+		return ResponseData{}, errors.Wrap(err, "Database error")
+	}
+
+	cqModel := &model.APICommitQueue{}
+	if err = cqModel.BuildFromService(cq); err != nil {
+		return ResponseData{}, errors.Wrap(err, "API model error")
+	}
+
+	return ResponseData{
+		Result: []model.Model{cqModel},
+	}, nil
+}
+
+type commitQueueEnqueueHandler struct {
+	item commitqueue.CommitQueueItem
+}
+
+func (h *commitQueueEnqueueHandler) Handler() RequestHandler {
+	return &commitQueueEnqueueHandler{}
+}
+
+func (h *commitQueueEnqueueHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	apiItem := model.APICommitQueueItem{}
+	if err := util.ReadJSONInto(util.NewRequestReader(r), &apiItem); err != nil {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+
+	i, err := apiItem.ToService()
+	if err != nil {
+		return errors.Wrap(err, "API model error")
+	}
+	item := i.(commitqueue.CommitQueueItem)
+	item.Issue = strings.TrimSpace(item.Issue)
+	if err = item.Validate(); err != nil {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+	h.item = item
+
+	return nil
+}
+
+func (h *commitQueueEnqueueHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	projectID, err := commitQueueProjectID(ctx)
+	if err != nil {
+		return ResponseData{}, err
+	}
+
+	if err = h.authorize(ctx, sc); err != nil {
+		return ResponseData{}, err
+	}
+
+	h.item.Author = MustHaveUser(ctx).Username()
+	position, err := sc.EnqueueItem(projectID, h.item)
+	if err != nil {
+		if _, ok := errors.Cause(err).(*rest.APIError); ok {
+			return ResponseData{}, err
+		}
+		return ResponseData{}, &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+
+	positionModel := &model.APICommitQueuePosition{}
+	if err = positionModel.BuildFromService(position); err != nil {
+		return ResponseData{}, errors.Wrap(err, "API model error")
+	}
+
+	return ResponseData{
+		Result: []model.Model{positionModel},
+	}, nil
+}
+
+// authorize checks that the user may enqueue the item, since it's merged
+// with Evergreen's credentials once it passes. Project admins and
+// superusers can enqueue anything that's ready, but other users can only
+// enqueue their own pull requests. Patches must check out a commit pushed
+// to the project's repository, since that's what's merged. Pull requests must be approved and
+// mergeable, and only the approved head commit of a pull request is merged.
+func (h *commitQueueEnqueueHandler) authorize(ctx context.Context, sc data.Connector) error {
+	h.item.ApprovedHash = ""
+	u := MustHaveUser(ctx)
+	ref := MustHaveProjectContext(ctx).ProjectRef
+	isAdmin := auth.IsSuperUser(sc.GetSuperUsers(), u) || util.StringSliceContains(ref.Admins, u.Username())
+
+	if h.item.Type != commitqueue.PRItemType {
+		if !isAdmin {
+			return &rest.APIError{
+				StatusCode: http.StatusUnauthorized,
+				Message:    "only project admins can enqueue branches, commits and patches",
+			}
+		}
+		if h.item.Type == commitqueue.PatchItemType {
+			return checkMergeablePatch(sc, ref.Identifier, h.item.Issue)
+		}
+		return nil
+	}
+
+	number, err := strconv.Atoi(h.item.Issue)
+	if err != nil {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("'%s' is not a pull request number", h.item.Issue),
+		}
+	}
+	client, err := sc.CommitQueueGithubClient()
+	if err != nil {
+		return errors.Wrap(err, "can't get github client")
+	}
+	pr, err := client.GetPullRequest(ctx, ref.Owner, ref.Repo, number)
+	if err != nil {
+		return &rest.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    err.Error(),
+		}
+	}
+
+	if !isAdmin && !isPullRequestAuthor(u, pr) {
+		return &rest.APIError{
+			StatusCode: http.StatusUnauthorized,
+			Message:    fmt.Sprintf("only the author of pull request %d and project admins can enqueue it", number),
+		}
+	}
+
+	reviews, err := client.ListPullRequestReviews(ctx, ref.Owner, ref.Repo, number)
+	if err != nil {
+		return errors.Wrapf(err, "can't get reviews of pull request %d", number)
+	}
+	if !thirdparty.PullRequestApproved(reviews, pr.GetHead().GetSHA()) {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("pull request %d has not been approved", number),
+		}
+	}
+	// GitHub hasn't checked whether the pull request can be merged yet
+	// if mergeable isn't set
+	if pr.Mergeable == nil {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("GitHub is still checking whether pull request %d can be merged, try again", number),
+		}
+	}
+	if !pr.GetMergeable() {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("pull request %d can't be merged", number),
+		}
+	}
+	h.item.ApprovedHash = pr.GetHead().GetSHA()
+
+	return nil
+}
+
+// checkMergeablePatch checks that the patch is one of the project's, and
+// that it checks out a commit that the commit queue can merge.
+func checkMergeablePatch(sc data.Connector, projectID, patchID string) error {
+	if !patch.IsValidId(patchID) {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("'%s' is not a patch id", patchID),
+		}
+	}
+	p, err := sc.FindPatchById(patchID)
+	if err != nil {
+		return err
+	}
+	if p.Project != projectID {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("patch '%s' is not for project '%s'", patchID, projectID),
+		}
+	}
+	if _, err = p.MergeableHead(); err != nil {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("patch '%s' can't be merged: %s", patchID, err),
+		}
+	}
+	return nil
+}
+
+// isPullRequestAuthor returns true if the user's GitHub account opened the
+// pull request.
+func isPullRequestAuthor(u *user.DBUser, pr *github.PullRequest) bool {
+	githubUser := u.Settings.GithubUser
+	if githubUser.UID != 0 {
+		return githubUser.UID == pr.GetUser().GetID()
+	}
+	return githubUser.LastKnownAs != "" && strings.EqualFold(githubUser.LastKnownAs, pr.GetUser().GetLogin())
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// DELETE /commit_queue/{project_id}/{item}
+//
+// The item may contain slashes, since branch names can.
+
+func getCommitQueueItemRouteManager(route string, version int) *RouteManager {
+	return &RouteManager{
+		Route:   route,
+		Version: version,
+		Methods: []MethodHandler{
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser, PrefetchProjectContext},
+				MethodType:        http.MethodDelete,
+				Authenticator:     &RequireUserAuthenticator{},
+				RequestHandler:    &commitQueueDeleteItemHandler{},
+			},
+		},
+	}
+}
+
+type commitQueueDeleteItemHandler struct {
+	item string
+}
+
+func (h *commitQueueDeleteItemHandler) Handler() RequestHandler {
+	return &commitQueueDeleteItemHandler{}
+}
+
+func (h *commitQueueDeleteItemHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	h.item = strings.TrimSpace(mux.Vars(r)["item"])
+	if h.item == "" {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "missing/empty commit queue item",
+		}
+	}
+
+	return nil
+}
+
+func (h *commitQueueDeleteItemHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	projectID, err := commitQueueProjectID(ctx)
+	if err != nil {
+		return ResponseData{}, err
+	}
+	u := MustHaveUser(ctx)
+
+	cq, err := sc.FindCommitQueueByID(projectID)
+	if err != nil {
+		return ResponseData{}, errors.Wrap(err, "Database error")
+	}
+	i := cq.FindItem(h.item)
+	if i < 0 {
+		return ResponseData{}, &rest.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("'%s' is not in the commit queue for project '%s'", h.item, projectID),
+		}
+	}
+
+	// items can be removed by whoever enqueued them, the project's admins,
+	// and superusers
+	if u.Username() != cq.Queue[i].Author &&
+		!auth.IsSuperUser(sc.GetSuperUsers(), u) &&
+		!util.StringSliceContains(MustHaveProjectContext(ctx).ProjectRef.Admins, u.Username()) {
+		return ResponseData{}, &rest.APIError{
+			StatusCode: http.StatusUnauthorized,
+			Message:    fmt.Sprintf("not authorized to remove '%s' from the commit queue", h.item),
+		}
+	}
+
+	removed, err := sc.CommitQueueRemoveItem(projectID, h.item, u.Username())
+	if err != nil {
+		return ResponseData{}, errors.Wrapf(err, "can't remove '%s' from the commit queue", h.item)
+	}
+	if !removed {
+		return ResponseData{}, &rest.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("'%s' is not in the commit queue for project '%s'", h.item, projectID),
+		}
+	}
+
+	return ResponseData{}, nil
+}
+
+// commitQueueProjectID returns the id of the request's project, as long as
+// the project exists and has its commit queue enabled.
+func commitQueueProjectID(ctx context.Context) (string, error) {
+	ref := MustHaveProjectContext(ctx).ProjectRef
+	if ref == nil {
+		return "", &rest.APIError{
+			StatusCode: http.StatusNotFound,
+			Message:    "project not found",
+		}
+	}
+	if !ref.CommitQueue.Enabled {
+		return "", &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("the commit queue is not enabled for project '%s'", ref.Identifier),
+		}
+	}
+
+	return ref.Identifier, nil
+}
@@ -0,0 +1,223 @@
+package route
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	serviceModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/commitqueue"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/rest"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/google/go-github/github"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type commitQueueSuite struct {
+	sc     *data.MockConnector
+	ref    *serviceModel.ProjectRef
+	github *thirdparty.MockGithubClient
+
+	suite.Suite
+}
+
+func TestCommitQueueHandlers(t *testing.T) {
+	suite.Run(t, &commitQueueSuite{})
+}
+
+func (s *commitQueueSuite) SetupTest() {
+	s.ref = &serviceModel.ProjectRef{
+		Identifier: "proj",
+		Admins:     []string{"admin"},
+		CommitQueue: serviceModel.CommitQueueParams{
+			Enabled:    true,
+			PatchAlias: "cq",
+		},
+	}
+	s.sc = &data.MockConnector{}
+	s.sc.SetSuperUsers([]string{"root"})
+	s.github = &thirdparty.MockGithubClient{
+		PullRequests: map[int]*github.PullRequest{
+			3: {
+				User:      &github.User{ID: github.Int(1234), Login: github.String("user2-github")},
+				Head:      &github.PullRequestBranch{SHA: github.String("abcdef")},
+				Mergeable: github.Bool(true),
+			},
+		},
+		PullRequestReviews: map[int][]*github.PullRequestReview{
+			3: {{User: &github.User{ID: github.Int(1)}, State: github.String("APPROVED"), CommitID: github.String("abcdef")}},
+		},
+	}
+	s.sc.MockCommitQueueConnector.GithubClient = s.github
+	s.sc.MockCommitQueueConnector.Queues = map[string][]commitqueue.CommitQueueItem{
+		"proj": {
+			{Issue: "1", Type: commitqueue.PRItemType, Author: "user0"},
+			{Issue: "2", Type: commitqueue.PRItemType, Author: "user1"},
+		},
+	}
+}
+
+func (s *commitQueueSuite) context(userID string) context.Context {
+	u := &user.DBUser{Id: userID}
+	// user2 opened pull request 3
+	if userID == "user2" {
+		u.Settings.GithubUser = user.GithubUser{UID: 1234, LastKnownAs: "user2-github"}
+	}
+	ctx := context.WithValue(context.Background(), evergreen.RequestUser, u)
+	return context.WithValue(ctx, RequestContext, &serviceModel.Context{ProjectRef: s.ref})
+}
+
+func (s *commitQueueSuite) TestGetCommitQueue() {
+	h := getCommitQueueRouteManager("", 2).Methods[0].Handler()
+	resp, err := h.Execute(s.context("user0"), s.sc)
+	s.Require().NoError(err)
+	s.Require().Len(resp.Result, 1)
+	cq := resp.Result[0].(*model.APICommitQueue)
+	s.Equal("proj", model.FromAPIString(cq.ProjectID))
+	s.Require().Len(cq.Queue, 2)
+	s.Equal("1", model.FromAPIString(cq.Queue[0].Issue))
+	s.Equal("2", model.FromAPIString(cq.Queue[1].Issue))
+
+	s.ref.CommitQueue.Enabled = false
+	_, err = h.Execute(s.context("user0"), s.sc)
+	s.Require().Error(err)
+	s.Equal(http.StatusBadRequest, err.(*rest.APIError).StatusCode)
+}
+
+func (s *commitQueueSuite) TestEnqueueItem() {
+	h := getCommitQueueRouteManager("", 2).Methods[1].Handler().(*commitQueueEnqueueHandler)
+	s.Require().NoError(s.parse(h, http.MethodPut, "/commit_queue/proj", `{"issue": "3", "type": "pr", "author": "someone"}`))
+	s.Equal("3", h.item.Issue)
+
+	resp, err := h.Execute(s.context("user2"), s.sc)
+	s.Require().NoError(err)
+	s.Require().Len(resp.Result, 1)
+	s.Equal(2, resp.Result[0].(*model.APICommitQueuePosition).Position)
+	queue := s.sc.MockCommitQueueConnector.Queues["proj"]
+	s.Require().Len(queue, 3)
+	s.Equal("user2", queue[2].Author)
+	s.Equal("abcdef", queue[2].ApprovedHash)
+
+	// items can only be enqueued once
+	_, err = h.Execute(s.context("user2"), s.sc)
+	s.Require().Error(err)
+	s.Equal(http.StatusBadRequest, err.(*rest.APIError).StatusCode)
+
+	s.Error(s.parse(h, http.MethodPut, "/commit_queue/proj", `{"issue": "4", "type": "branch"}`))
+	s.Error(s.parse(h, http.MethodPut, "/commit_queue/proj", `{"type": "pr"}`))
+}
+
+func (s *commitQueueSuite) TestEnqueueItemAuthorization() {
+	h := getCommitQueueRouteManager("", 2).Methods[1].Handler().(*commitQueueEnqueueHandler)
+	enqueue := func(userID, body string) error {
+		s.Require().NoError(s.parse(h, http.MethodPut, "/commit_queue/proj", body))
+		_, err := h.Execute(s.context(userID), s.sc)
+		return err
+	}
+	statusCode := func(err error) int {
+		s.Require().Error(err)
+		apiErr, ok := err.(*rest.APIError)
+		s.Require().True(ok, err.Error())
+		return apiErr.StatusCode
+	}
+
+	// only the pull request's author and admins can enqueue it
+	s.Equal(http.StatusUnauthorized, statusCode(enqueue("user0", `{"issue": "3", "type": "pr"}`)))
+	s.Equal(http.StatusNotFound, statusCode(enqueue("admin", `{"issue": "5", "type": "pr"}`)))
+
+	// pull requests must be approved and mergeable
+	s.github.PullRequestReviews[3] = append(s.github.PullRequestReviews[3],
+		&github.PullRequestReview{User: &github.User{ID: github.Int(2)}, State: github.String("CHANGES_REQUESTED")})
+	s.Equal(http.StatusBadRequest, statusCode(enqueue("user2", `{"issue": "3", "type": "pr"}`)))
+	s.github.PullRequestReviews[3] = s.github.PullRequestReviews[3][:1]
+	s.github.PullRequests[3].Head.SHA = github.String("012345")
+	s.Equal(http.StatusBadRequest, statusCode(enqueue("user2", `{"issue": "3", "type": "pr"}`)))
+	s.github.PullRequests[3].Head.SHA = github.String("abcdef")
+	s.github.PullRequests[3].Mergeable = nil
+	s.Equal(http.StatusBadRequest, statusCode(enqueue("user2", `{"issue": "3", "type": "pr"}`)))
+	s.github.PullRequests[3].Mergeable = github.Bool(false)
+	s.Equal(http.StatusBadRequest, statusCode(enqueue("admin", `{"issue": "3", "type": "pr"}`)))
+	s.Len(s.sc.MockCommitQueueConnector.Queues["proj"], 2)
+
+	s.github.PullRequests[3].Mergeable = github.Bool(true)
+	s.NoError(enqueue("admin", `{"issue": "3", "type": "pr"}`))
+	s.Len(s.sc.MockCommitQueueConnector.Queues["proj"], 3)
+
+	// branches can only be enqueued by admins
+	s.Equal(http.StatusUnauthorized, statusCode(enqueue("user2", `{"issue": "feature", "type": "ref"}`)))
+	s.NoError(enqueue("root", `{"issue": "feature", "type": "ref"}`))
+	s.Len(s.sc.MockCommitQueueConnector.Queues["proj"], 4)
+
+	// patches can only be enqueued by admins, and must check out a commit
+	// pushed to the project's repository
+	head := &patch.PatchHead{Ref: "feature", HeadHash: "a4aa03d0472d8503380479b76aef96c044182822"}
+	pushed := patch.Patch{Id: bson.NewObjectId(), Project: "proj", Patches: []patch.ModulePatch{{Head: head}}}
+	diff := patch.Patch{Id: bson.NewObjectId(), Project: "proj", Patches: []patch.ModulePatch{{PatchSet: patch.PatchSet{Patch: "diff"}}}}
+	other := patch.Patch{Id: bson.NewObjectId(), Project: "other", Patches: []patch.ModulePatch{{Head: head}}}
+	s.sc.MockPatchConnector.CachedPatches = []patch.Patch{pushed, diff, other}
+	enqueuePatch := func(userID string, p patch.Patch) error {
+		return enqueue(userID, `{"issue": "`+p.Id.Hex()+`", "type": "patch"}`)
+	}
+	s.Equal(http.StatusUnauthorized, statusCode(enqueuePatch("user2", pushed)))
+	s.Equal(http.StatusBadRequest, statusCode(enqueuePatch("admin", diff)))
+	s.Equal(http.StatusBadRequest, statusCode(enqueuePatch("admin", other)))
+	s.Equal(http.StatusNotFound, statusCode(enqueuePatch("admin", patch.Patch{Id: bson.NewObjectId()})))
+	s.Equal(http.StatusBadRequest, statusCode(enqueue("admin", `{"issue": "feature", "type": "patch"}`)))
+	s.Len(s.sc.MockCommitQueueConnector.Queues["proj"], 4)
+	s.NoError(enqueuePatch("admin", pushed))
+	s.Len(s.sc.MockCommitQueueConnector.Queues["proj"], 5)
+}
+
+func (s *commitQueueSuite) TestDeleteItem() {
+	h := getCommitQueueItemRouteManager("", 2).Methods[0].Handler().(*commitQueueDeleteItemHandler)
+	s.Require().NoError(s.parse(h, http.MethodDelete, "/commit_queue/proj/feature/branch", ""))
+	s.Equal("feature/branch", h.item)
+	s.Require().NoError(s.parse(h, http.MethodDelete, "/commit_queue/proj/1", ""))
+	s.Equal("1", h.item)
+
+	// only the author, project admins and superusers can remove items
+	_, err := h.Execute(s.context("user1"), s.sc)
+	s.Require().Error(err)
+	s.Equal(http.StatusUnauthorized, err.(*rest.APIError).StatusCode)
+	s.Len(s.sc.MockCommitQueueConnector.Queues["proj"], 2)
+
+	_, err = h.Execute(s.context("user0"), s.sc)
+	s.NoError(err)
+	s.Len(s.sc.MockCommitQueueConnector.Queues["proj"], 1)
+
+	_, err = h.Execute(s.context("user0"), s.sc)
+	s.Require().Error(err)
+	s.Equal(http.StatusNotFound, err.(*rest.APIError).StatusCode)
+
+	h.item = "2"
+	_, err = h.Execute(s.context("admin"), s.sc)
+	s.NoError(err)
+	s.Empty(s.sc.MockCommitQueueConnector.Queues["proj"])
+}
+
+// parse routes a request to the given handler's ParseAndValidate, so that the
+// route variables are set.
+func (s *commitQueueSuite) parse(h RequestHandler, method, path, body string) error {
+	req, err := http.NewRequest(method, path, bytes.NewBufferString(body))
+	s.Require().NoError(err)
+
+	var parseErr error
+	r := mux.NewRouter()
+	handle := func(w http.ResponseWriter, req *http.Request) {
+		parseErr = h.ParseAndValidate(s.context("user0"), req)
+	}
+	r.HandleFunc("/commit_queue/{project_id}", handle)
+	r.HandleFunc("/commit_queue/{project_id}/{item:.+}", handle)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	return parseErr
+}
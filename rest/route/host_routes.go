@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/cloud"
 	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/rest"
@@ -17,6 +18,7 @@ import (
 	"github.com/evergreen-ci/evergreen/spawn"
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/gorilla/mux"
+	"github.com/mongodb/amboy"
 	"github.com/pkg/errors"
 )
 
@@ -25,8 +27,7 @@ type hostGetHandler struct {
 }
 
 type hostPostHandler struct {
-	Distro  string `json:"distro"`
-	KeyName string `json:"keyname"`
+	options model.HostPostRequest
 }
 
 func getHostRouteManager(route string, version int) *RouteManager {
@@ -307,13 +308,22 @@ func (hph *hostPostHandler) Handler() RequestHandler {
 }
 
 func (hph *hostPostHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
-	return errors.WithStack(util.ReadJSONInto(r.Body, hph))
+	if err := util.ReadJSONInto(r.Body, &hph.options); err != nil {
+		return errors.WithStack(err)
+	}
+	if hph.options.HomeVolumeSize < 0 {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "home volume size must not be negative",
+		}
+	}
+	return nil
 }
 
 func (hph *hostPostHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
 	user := MustHaveUser(ctx)
 
-	intentHost, err := sc.NewIntentHost(hph.Distro, hph.KeyName, "", user)
+	intentHost, err := sc.NewIntentHost(&hph.options, user)
 	if err != nil {
 		if _, ok := err.(*rest.APIError); !ok {
 			err = errors.Wrap(err, "error spawning host")
@@ -399,6 +409,301 @@ func (h *hostTerminateHandler) Execute(ctx context.Context, sc data.Connector) (
 	return ResponseData{}, nil
 }
 
+func getHostReprovisionRouteManager(route string, version int) *RouteManager {
+	return &RouteManager{
+		Route:   route,
+		Version: version,
+		Methods: []MethodHandler{
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser},
+				MethodType:        http.MethodPost,
+				Authenticator:     &RequireUserAuthenticator{},
+				RequestHandler:    &hostReprovisionHandler{},
+			},
+		},
+	}
+}
+
+type hostReprovisionHandler struct {
+	hostID string
+}
+
+func (h *hostReprovisionHandler) Handler() RequestHandler {
+	return &hostReprovisionHandler{}
+}
+
+func (h *hostReprovisionHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	var err error
+	h.hostID, err = validateHostID(mux.Vars(r)["host_id"])
+
+	return err
+}
+
+func (h *hostReprovisionHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	u := MustHaveUser(ctx)
+
+	host, err := sc.FindHostByIdWithOwner(h.hostID, u)
+	if err != nil {
+		return ResponseData{}, err
+	}
+
+	if host.Provider != evergreen.ProviderNameStatic {
+		return ResponseData{}, &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("Host %s is not a static host, so it cannot be reprovisioned", host.Id),
+		}
+	}
+	if host.Status != evergreen.HostRunning {
+		return ResponseData{}, &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("Host %s is %s, so it cannot be reprovisioned", host.Id, host.Status),
+		}
+	}
+
+	if err := sc.ReprovisionHost(host, u.Username()); err != nil {
+		return ResponseData{}, &rest.APIError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    err.Error(),
+		}
+	}
+
+	return ResponseData{}, nil
+}
+
+func getHostStopRouteManager(queue amboy.Queue) routeManagerFactory {
+	return func(route string, version int) *RouteManager {
+		return &RouteManager{
+			Route:   route,
+			Version: version,
+			Methods: []MethodHandler{
+				{
+					PrefetchFunctions: []PrefetchFunc{PrefetchUser},
+					MethodType:        http.MethodPost,
+					Authenticator:     &RequireUserAuthenticator{},
+					RequestHandler:    &hostStopHandler{queue: queue},
+				},
+			},
+		}
+	}
+}
+
+type hostStopHandler struct {
+	hostID string
+
+	queue amboy.Queue
+}
+
+func (h *hostStopHandler) Handler() RequestHandler {
+	return &hostStopHandler{queue: h.queue}
+}
+
+func (h *hostStopHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	var err error
+	h.hostID, err = validateHostID(mux.Vars(r)["host_id"])
+
+	return err
+}
+
+func (h *hostStopHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	u := MustHaveUser(ctx)
+
+	host, err := sc.FindHostByIdWithOwner(h.hostID, u)
+	if err != nil {
+		return ResponseData{}, err
+	}
+	if err = checkHostCanStop(host, evergreen.HostRunning); err != nil {
+		return ResponseData{}, err
+	}
+
+	if err = sc.StopHost(h.queue, host, u.Username()); err != nil {
+		return ResponseData{}, &rest.APIError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    err.Error(),
+		}
+	}
+
+	return ResponseData{}, nil
+}
+
+func getHostStartRouteManager(queue amboy.Queue) routeManagerFactory {
+	return func(route string, version int) *RouteManager {
+		return &RouteManager{
+			Route:   route,
+			Version: version,
+			Methods: []MethodHandler{
+				{
+					PrefetchFunctions: []PrefetchFunc{PrefetchUser},
+					MethodType:        http.MethodPost,
+					Authenticator:     &RequireUserAuthenticator{},
+					RequestHandler:    &hostStartHandler{queue: queue},
+				},
+			},
+		}
+	}
+}
+
+type hostStartHandler struct {
+	hostID string
+
+	queue amboy.Queue
+}
+
+func (h *hostStartHandler) Handler() RequestHandler {
+	return &hostStartHandler{queue: h.queue}
+}
+
+func (h *hostStartHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	var err error
+	h.hostID, err = validateHostID(mux.Vars(r)["host_id"])
+
+	return err
+}
+
+func (h *hostStartHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	u := MustHaveUser(ctx)
+
+	host, err := sc.FindHostByIdWithOwner(h.hostID, u)
+	if err != nil {
+		return ResponseData{}, err
+	}
+	if err = checkHostCanStop(host, evergreen.HostStopped); err != nil {
+		return ResponseData{}, err
+	}
+
+	if err = sc.StartHost(h.queue, host, u.Username()); err != nil {
+		return ResponseData{}, &rest.APIError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    err.Error(),
+		}
+	}
+
+	return ResponseData{}, nil
+}
+
+// checkHostCanStop returns an error if the host is not a spawn host that can
+// be stopped and started, or if it does not have the given status.
+func checkHostCanStop(host *host.Host, status string) error {
+	if host.StartedBy == evergreen.User {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("Host %s is not a spawn host, so it cannot be stopped or started", host.Id),
+		}
+	}
+	if !cloud.SupportsStopping(host.Provider) {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("Hosts from provider '%s' cannot be stopped or started", host.Provider),
+		}
+	}
+	if host.Status != status {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("Host %s is %s, not %s", host.Id, host.Status, status),
+		}
+	}
+
+	return nil
+}
+
+func getHostSleepScheduleRouteManager(route string, version int) *RouteManager {
+	return &RouteManager{
+		Route:   route,
+		Version: version,
+		Methods: []MethodHandler{
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser},
+				MethodType:        http.MethodPut,
+				Authenticator:     &RequireUserAuthenticator{},
+				RequestHandler:    &hostSleepScheduleHandler{},
+			},
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser},
+				MethodType:        http.MethodDelete,
+				Authenticator:     &RequireUserAuthenticator{},
+				RequestHandler:    &hostSleepScheduleHandler{remove: true},
+			},
+		},
+	}
+}
+
+type hostSleepScheduleHandler struct {
+	hostID   string
+	schedule *host.SleepSchedule
+	remove   bool
+}
+
+func (h *hostSleepScheduleHandler) Handler() RequestHandler {
+	return &hostSleepScheduleHandler{remove: h.remove}
+}
+
+func (h *hostSleepScheduleHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	var err error
+	h.hostID, err = validateHostID(mux.Vars(r)["host_id"])
+	if err != nil {
+		return err
+	}
+	if h.remove {
+		return nil
+	}
+
+	apiSchedule := model.APISleepSchedule{}
+	if err = util.ReadJSONInto(util.NewRequestReader(r), &apiSchedule); err != nil {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+	// the schedule has not put the host to sleep yet, whatever the request
+	// says
+	apiSchedule.Asleep = false
+
+	schedule, err := apiSchedule.ToService()
+	if err != nil {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+	h.schedule = schedule.(*host.SleepSchedule)
+	if err = h.schedule.Validate(); err != nil {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("invalid sleep schedule: %s", err.Error()),
+		}
+	}
+
+	return nil
+}
+
+func (h *hostSleepScheduleHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	u := MustHaveUser(ctx)
+
+	host, err := sc.FindHostByIdWithOwner(h.hostID, u)
+	if err != nil {
+		return ResponseData{}, err
+	}
+	if !h.remove {
+		if host.Status == evergreen.HostTerminated {
+			return ResponseData{}, &rest.APIError{
+				StatusCode: http.StatusBadRequest,
+				Message:    "cannot set the sleep schedule of a terminated host",
+			}
+		}
+		if err = checkHostCanStop(host, host.Status); err != nil {
+			return ResponseData{}, err
+		}
+	}
+
+	if err = sc.SetHostSleepSchedule(host, h.schedule); err != nil {
+		return ResponseData{}, &rest.APIError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    err.Error(),
+		}
+	}
+
+	return ResponseData{}, nil
+}
+
 func getHostChangeRDPPasswordRouteManager(route string, version int) *RouteManager {
 	return &RouteManager{
 		Route:   route,
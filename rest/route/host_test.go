@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/evergreen-ci/evergreen/rest"
 	"github.com/evergreen-ci/evergreen/rest/data"
 	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -444,6 +446,196 @@ func makeMockHostRequest(mod model.APISpawnHostModify) (*http.Request, error) {
 	return r, nil
 }
 
+type hostReprovisionHandlerSuite struct {
+	rm *RouteManager
+	sc *data.MockConnector
+
+	suite.Suite
+}
+
+func TestHostReprovisionHandler(t *testing.T) {
+	suite.Run(t, &hostReprovisionHandlerSuite{})
+}
+
+func (s *hostReprovisionHandlerSuite) SetupTest() {
+	s.rm = getHostReprovisionRouteManager("", 2)
+	s.sc = getMockHostsConnector()
+	s.sc.CachedHosts = append(s.sc.CachedHosts, host.Host{
+		Id:        "static1",
+		StartedBy: evergreen.User,
+		Host:      "static1",
+		Provider:  evergreen.ProviderNameStatic,
+		Status:    evergreen.HostRunning,
+		Distro: distro.Distro{
+			Id:       "static",
+			Arch:     "linux_amd64",
+			Provider: evergreen.ProviderNameStatic,
+		},
+	})
+}
+
+func (s *hostReprovisionHandlerSuite) execute(hostID, userID string) (ResponseData, error) {
+	h := s.rm.Methods[0].Handler().(*hostReprovisionHandler)
+	h.hostID = hostID
+
+	ctx := context.WithValue(context.Background(), evergreen.RequestUser, s.sc.MockUserConnector.CachedUsers[userID])
+	return h.Execute(ctx, s.sc)
+}
+
+func (s *hostReprovisionHandlerSuite) TestSuperUserCanReprovisionStaticHost() {
+	data, err := s.execute("static1", "root")
+	s.NoError(err)
+	s.Empty(data.Result)
+	s.True(s.sc.CachedHosts[4].NeedsReprovision)
+}
+
+func (s *hostReprovisionHandlerSuite) TestRegularUserCannotReprovisionStaticHost() {
+	_, err := s.execute("static1", "user0")
+	s.Error(err)
+	s.False(s.sc.CachedHosts[4].NeedsReprovision)
+}
+
+func (s *hostReprovisionHandlerSuite) TestCannotReprovisionDynamicHost() {
+	_, err := s.execute("host2", "root")
+	s.Require().Error(err)
+	s.IsType(new(rest.APIError), err)
+	s.Equal(http.StatusBadRequest, err.(*rest.APIError).StatusCode)
+	s.False(s.sc.CachedHosts[1].NeedsReprovision)
+}
+
+func (s *hostReprovisionHandlerSuite) TestCannotReprovisionHostThatIsNotRunning() {
+	s.sc.CachedHosts[4].Status = evergreen.HostQuarantined
+
+	_, err := s.execute("static1", "root")
+	s.Require().Error(err)
+	s.IsType(new(rest.APIError), err)
+	s.Equal(http.StatusBadRequest, err.(*rest.APIError).StatusCode)
+	s.False(s.sc.CachedHosts[4].NeedsReprovision)
+}
+
+type hostStopStartHandlerSuite struct {
+	sc *data.MockConnector
+
+	suite.Suite
+}
+
+func TestHostStopStartHandlers(t *testing.T) {
+	suite.Run(t, &hostStopStartHandlerSuite{})
+}
+
+func (s *hostStopStartHandlerSuite) SetupTest() {
+	s.sc = getMockHostsConnector()
+	s.sc.CachedHosts = append(s.sc.CachedHosts, host.Host{
+		Id:             "spawn1",
+		StartedBy:      "user0",
+		Host:           "spawn1",
+		Provider:       evergreen.ProviderNameMock,
+		Status:         evergreen.HostRunning,
+		ExpirationTime: time.Now().Add(time.Hour),
+		Distro: distro.Distro{
+			Id:       "mock",
+			Arch:     "linux_amd64",
+			Provider: evergreen.ProviderNameMock,
+		},
+	})
+}
+
+func (s *hostStopStartHandlerSuite) context(userID string) context.Context {
+	return context.WithValue(context.Background(), evergreen.RequestUser, s.sc.MockUserConnector.CachedUsers[userID])
+}
+
+func (s *hostStopStartHandlerSuite) stop(hostID, userID string) error {
+	h := getHostStopRouteManager(nil)("", 2).Methods[0].Handler().(*hostStopHandler)
+	h.hostID = hostID
+	_, err := h.Execute(s.context(userID), s.sc)
+	return err
+}
+
+func (s *hostStopStartHandlerSuite) start(hostID, userID string) error {
+	h := getHostStartRouteManager(nil)("", 2).Methods[0].Handler().(*hostStartHandler)
+	h.hostID = hostID
+	_, err := h.Execute(s.context(userID), s.sc)
+	return err
+}
+
+func (s *hostStopStartHandlerSuite) TestOwnerCanStopAndStartHost() {
+	s.NoError(s.stop("spawn1", "user0"))
+	s.Equal(evergreen.HostStopped, s.sc.CachedHosts[4].Status)
+
+	s.NoError(s.start("spawn1", "user0"))
+	s.Equal(evergreen.HostRunning, s.sc.CachedHosts[4].Status)
+}
+
+func (s *hostStopStartHandlerSuite) TestOtherUserCannotStopHost() {
+	s.Error(s.stop("spawn1", "user1"))
+	s.Equal(evergreen.HostRunning, s.sc.CachedHosts[4].Status)
+}
+
+func (s *hostStopStartHandlerSuite) TestCannotStartRunningHost() {
+	err := s.start("spawn1", "user0")
+	s.Require().Error(err)
+	s.IsType(new(rest.APIError), err)
+	s.Equal(http.StatusBadRequest, err.(*rest.APIError).StatusCode)
+}
+
+func (s *hostStopStartHandlerSuite) TestCannotStopHostWhoseProviderCannotStop() {
+	err := s.stop("host2", "user0")
+	s.Require().Error(err)
+	s.IsType(new(rest.APIError), err)
+	s.Equal(http.StatusBadRequest, err.(*rest.APIError).StatusCode)
+	s.Equal(evergreen.HostRunning, s.sc.CachedHosts[1].Status)
+}
+
+func (s *hostStopStartHandlerSuite) TestSetAndRemoveSleepSchedule() {
+	rm := getHostSleepScheduleRouteManager("", 2)
+
+	h := rm.Methods[0].Handler().(*hostSleepScheduleHandler)
+	s.Require().NoError(s.parseSleepSchedule(h, `{"stop_hour": 22, "start_hour": 8, "whole_days_off": ["saturday", "Sunday"], "time_zone": "America/New_York"}`))
+	s.Equal("spawn1", h.hostID)
+	_, err := h.Execute(s.context("user0"), s.sc)
+	s.Require().NoError(err)
+
+	schedule := s.sc.CachedHosts[4].SleepSchedule
+	s.Require().NotNil(schedule)
+	s.Equal(22, schedule.StopHour)
+	s.Equal(8, schedule.StartHour)
+	s.Equal([]time.Weekday{time.Saturday, time.Sunday}, schedule.WholeDaysOff)
+
+	h = rm.Methods[1].Handler().(*hostSleepScheduleHandler)
+	h.hostID = "spawn1"
+	_, err = h.Execute(s.context("user0"), s.sc)
+	s.Require().NoError(err)
+	s.Nil(s.sc.CachedHosts[4].SleepSchedule)
+}
+
+func (s *hostStopStartHandlerSuite) TestInvalidSleepSchedule() {
+	h := getHostSleepScheduleRouteManager("", 2).Methods[0].Handler().(*hostSleepScheduleHandler)
+
+	for _, body := range []string{
+		`{"stop_hour": 24, "start_hour": 8}`,
+		`{"stop_hour": 22, "start_hour": 8, "whole_days_off": ["someday"]}`,
+		`{"stop_hour": 22, "start_hour": 8, "time_zone": "Nowhere/Special"}`,
+	} {
+		s.Error(s.parseSleepSchedule(h, body), body)
+	}
+}
+
+// parseSleepSchedule routes a request to set spawn1's sleep schedule to the
+// given handler's ParseAndValidate, so that the host ID is set in the route.
+func (s *hostStopStartHandlerSuite) parseSleepSchedule(h *hostSleepScheduleHandler, body string) error {
+	req, err := http.NewRequest(http.MethodPut, "/hosts/spawn1/sleep_schedule", bytes.NewBufferString(body))
+	s.Require().NoError(err)
+
+	var parseErr error
+	r := mux.NewRouter()
+	r.HandleFunc("/hosts/{host_id}/sleep_schedule", func(w http.ResponseWriter, req *http.Request) {
+		parseErr = h.ParseAndValidate(s.context("user0"), req)
+	})
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	return parseErr
+}
+
 func getMockHostsConnector() *data.MockConnector {
 	windowsDistro := distro.Distro{
 		Id:   "windows",
@@ -0,0 +1,123 @@
+package route
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen/command"
+	dbModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/rest"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
+)
+
+////////////////////////////////////////////////////////////////////////
+//
+// POST /project_config/diff
+
+func getProjectConfigDiffRouteManager(route string, version int) *RouteManager {
+	return &RouteManager{
+		Route:   route,
+		Version: version,
+		Methods: []MethodHandler{
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser},
+				MethodType:        http.MethodPost,
+				Authenticator:     &RequireUserAuthenticator{},
+				RequestHandler:    &projectConfigDiffHandler{},
+			},
+		},
+	}
+}
+
+// projectConfigDiffHandler translates two project configurations, given as
+// YAML, and returns the difference between them.
+type projectConfigDiffHandler struct {
+	oldProject *dbModel.Project
+	newProject *dbModel.Project
+}
+
+func (h *projectConfigDiffHandler) Handler() RequestHandler {
+	return &projectConfigDiffHandler{}
+}
+
+func (h *projectConfigDiffHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	body := struct {
+		OldConfig string `json:"old_config"`
+		NewConfig string `json:"new_config"`
+	}{}
+	if err := util.ReadJSONInto(util.NewRequestReader(r), &body); err != nil {
+		return rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    errors.Wrap(err, "error reading request body").Error(),
+		}
+	}
+
+	h.oldProject = &dbModel.Project{}
+	if err := dbModel.LoadProjectInto([]byte(body.OldConfig), "", h.oldProject); err != nil {
+		return rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    errors.Wrap(err, "error loading old config").Error(),
+		}
+	}
+	h.newProject = &dbModel.Project{}
+	if err := dbModel.LoadProjectInto([]byte(body.NewConfig), "", h.newProject); err != nil {
+		return rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    errors.Wrap(err, "error loading new config").Error(),
+		}
+	}
+	return nil
+}
+
+func (h *projectConfigDiffHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	diff := &model.APIProjectDiff{}
+	if err := diff.BuildFromService(dbModel.DiffProjects(h.oldProject, h.newProject)); err != nil {
+		return ResponseData{}, errors.Wrap(err, "API model error")
+	}
+	return ResponseData{
+		Result: []model.Model{diff},
+	}, nil
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// GET /project_config/schema
+
+func getProjectConfigSchemaRouteManager(route string, version int) *RouteManager {
+	return &RouteManager{
+		Route:   route,
+		Version: version,
+		Methods: []MethodHandler{
+			{
+				MethodType:     http.MethodGet,
+				Authenticator:  &NoAuthAuthenticator{},
+				RequestHandler: &projectConfigSchemaHandler{},
+			},
+		},
+	}
+}
+
+// projectConfigSchemaHandler returns the JSON Schema for project
+// configuration files, so that editors can check them.
+type projectConfigSchemaHandler struct{}
+
+func (h *projectConfigSchemaHandler) Handler() RequestHandler {
+	return &projectConfigSchemaHandler{}
+}
+
+func (h *projectConfigSchemaHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	return nil
+}
+
+func (h *projectConfigSchemaHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	schema := &model.APIProjectSchema{}
+	if err := schema.BuildFromService(command.ProjectSchema()); err != nil {
+		return ResponseData{}, errors.Wrap(err, "API model error")
+	}
+	return ResponseData{
+		Result: []model.Model{schema},
+	}, nil
+}
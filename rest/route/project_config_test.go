@@ -0,0 +1,74 @@
+package route
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/rest"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectConfigDiffHandler(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	body, err := json.Marshal(map[string]string{
+		"old_config": "tasks:\n  - name: compile\n  - name: lint\nbuildvariants:\n  - name: linux\n    run_on: [ubuntu]\n    tasks:\n      - name: compile\n",
+		"new_config": "tasks:\n  - name: compile\n  - name: test\nbuildvariants:\n  - name: linux\n    run_on: [ubuntu]\n    tasks:\n      - name: compile\n",
+	})
+	require.NoError(err)
+	r, err := http.NewRequest(http.MethodPost, "/project_config/diff", bytes.NewReader(body))
+	require.NoError(err)
+
+	h := getProjectConfigDiffRouteManager("", 2).Methods[0].Handler()
+	require.NoError(h.ParseAndValidate(context.Background(), r))
+	resp, err := h.Execute(context.Background(), &data.MockConnector{})
+	require.NoError(err)
+	require.Len(resp.Result, 1)
+	diff := resp.Result[0].(*model.APIProjectDiff)
+	assert.Equal([]string{"test"}, diff.AddedTasks)
+	assert.Equal([]string{"lint"}, diff.RemovedTasks)
+	assert.Empty(diff.ChangedTasks)
+	assert.NotNil(diff.AddedVariants)
+	assert.Empty(diff.AddedVariants)
+
+	body, err = json.Marshal(map[string]string{"old_config": "tasks: [", "new_config": ""})
+	require.NoError(err)
+	r, err = http.NewRequest(http.MethodPost, "/project_config/diff", bytes.NewReader(body))
+	require.NoError(err)
+	err = h.ParseAndValidate(context.Background(), r)
+	require.Error(err)
+	apiErr, ok := err.(rest.APIError)
+	require.True(ok)
+	assert.Equal(http.StatusBadRequest, apiErr.StatusCode)
+	assert.Contains(apiErr.Message, "error loading old config")
+}
+
+func TestProjectConfigSchemaHandler(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	r, err := http.NewRequest(http.MethodGet, "/project_config/schema", nil)
+	require.NoError(err)
+
+	h := getProjectConfigSchemaRouteManager("", 2).Methods[0].Handler()
+	require.NoError(h.ParseAndValidate(context.Background(), r))
+	resp, err := h.Execute(context.Background(), &data.MockConnector{})
+	require.NoError(err)
+	require.Len(resp.Result, 1)
+	schema := resp.Result[0].(*model.APIProjectSchema)
+
+	out, err := json.Marshal(schema)
+	require.NoError(err)
+	doc := map[string]interface{}{}
+	require.NoError(json.Unmarshal(out, &doc))
+	assert.Contains(doc, "$schema")
+	assert.Contains(doc["properties"], "buildvariants")
+	assert.Contains(doc["definitions"], "command")
+}
@@ -39,6 +39,8 @@ func GetHandler(r *mux.Router, sc data.Connector, queue amboy.Queue, githubSecre
 		"/builds/{build_id}/abort":           getBuildAbortRouteManager,
 		"/builds/{build_id}/restart":         getBuildRestartManager,
 		"/builds/{build_id}/tasks":           getTasksByBuildRouteManager,
+		"/commit_queue/{project_id}":         getCommitQueueRouteManager,
+		"/commit_queue/{project_id}/{item:.+}": getCommitQueueItemRouteManager,
 		"/cost/distro/{distro_id}":           getCostByDistroIdRouteManager,
 		"/cost/project/{project_id}/tasks":   getCostTaskByProjectRouteManager,
 		"/cost/version/{version_id}":         getCostByVersionIdRouteManager,
@@ -48,12 +50,18 @@ func GetHandler(r *mux.Router, sc data.Connector, queue amboy.Queue, githubSecre
 		"/hosts/{host_id}":                   getHostIDRouteManager,
 		"/hosts/{host_id}/change_password":   getHostChangeRDPPasswordRouteManager,
 		"/hosts/{host_id}/extend_expiration": getHostExtendExpirationRouteManager,
+		"/hosts/{host_id}/reprovision":       getHostReprovisionRouteManager,
+		"/hosts/{host_id}/sleep_schedule":    getHostSleepScheduleRouteManager,
+		"/hosts/{host_id}/start":             getHostStartRouteManager(queue),
+		"/hosts/{host_id}/stop":              getHostStopRouteManager(queue),
 		"/hosts/{host_id}/terminate":         getHostTerminateRouteManager,
 		"/keys":                                                getKeysRouteManager,
 		"/keys/{key_name}":                                     getKeysDeleteRouteManager,
 		"/patches/{patch_id}":                                  getPatchByIdManager,
 		"/patches/{patch_id}/abort":                            getPatchAbortManager,
 		"/patches/{patch_id}/restart":                          getPatchRestartManager,
+		"/project_config/diff":                                 getProjectConfigDiffRouteManager,
+		"/project_config/schema":                               getProjectConfigSchemaRouteManager,
 		"/projects":                                            getProjectRouteManager,
 		"/projects/{project_id}/patches":                       getPatchesByProjectManager,
 		"/projects/{project_id}/recent_versions":               getRecentVersionsManager,
@@ -61,6 +69,7 @@ func GetHandler(r *mux.Router, sc data.Connector, queue amboy.Queue, githubSecre
 		"/status/cli_version":                                  getCLIVersionRouteManager,
 		"/status/notifications":                                getNotificationsStatusRouteManager,
 		"/status/hosts/distros":                                getHostStatsByDistroManager,
+		"/status/hosts/warm_pool":                              getWarmPoolStatsManager,
 		"/status/recent_tasks":                                 getRecentTasksRouteManager,
 		"/subscriptions":                                       getSubscriptionRouteManager,
 		"/tasks/{task_id}":                                     getTaskRouteManager,
@@ -70,6 +79,7 @@ func GetHandler(r *mux.Router, sc data.Connector, queue amboy.Queue, githubSecre
 		"/tasks/{task_id}/metrics/system":                      getTaskSystemMetricsManager,
 		"/tasks/{task_id}/restart":                             getTaskRestartRouteManager,
 		"/tasks/{task_id}/tests":                               getTestRouteManager,
+		"/users/{user_id}/cost":                                getUserCostRouteManager,
 		"/users/{user_id}/hosts":                               getHostsByUserManager,
 		"/users/{user_id}/patches":                             getPatchesByUserManager,
 		"/user/settings":                                       getUserSettingsRouteManager,
@@ -77,6 +87,8 @@ func GetHandler(r *mux.Router, sc data.Connector, queue amboy.Queue, githubSecre
 		"/versions/{version_id}/abort":                         getAbortVersionRouteManager,
 		"/versions/{version_id}/builds":                        getBuildsForVersionRouteManager,
 		"/versions/{version_id}/restart":                       getRestartVersionRouteManager,
+		"/volumes":                                             getVolumesRouteManager,
+		"/volumes/{volume_id}":                                 getVolumeIDRouteManager,
 	}
 
 	for path, getManager := range routes {
@@ -3,6 +3,7 @@ package route
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/rest"
@@ -151,3 +152,61 @@ func (h *hostStatsByDistroHandler) Execute(ctx context.Context, sc data.Connecto
 		Result: []model.Model{statsModel},
 	}, nil
 }
+
+// this is the route manager for /status/hosts/warm_pool, which reports how often
+// each distro's warm pool absorbed a burst of tasks
+type warmPoolStatsHandler struct {
+	minutes int
+}
+
+func getWarmPoolStatsManager(route string, version int) *RouteManager {
+	return &RouteManager{
+		Route: route,
+		Methods: []MethodHandler{
+			{
+				Authenticator:  &RequireUserAuthenticator{},
+				RequestHandler: &warmPoolStatsHandler{},
+				MethodType:     http.MethodGet,
+			},
+		},
+		Version: version,
+	}
+}
+
+func (h *warmPoolStatsHandler) Handler() RequestHandler {
+	return &warmPoolStatsHandler{}
+}
+
+func (h *warmPoolStatsHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	minutes, err := util.GetIntValue(r, "minutes", maxDurationStatusQueryMinutes)
+	if err != nil {
+		return err
+	}
+	if minutes > maxDurationStatusQueryMinutes {
+		return errors.Errorf("Cannot query for more than %d minutes", maxDurationStatusQueryMinutes)
+	}
+	if minutes <= 0 {
+		return errors.Errorf("Minutes must be positive")
+	}
+	h.minutes = minutes
+
+	return nil
+}
+
+func (h *warmPoolStatsHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	stats, err := sc.GetWarmPoolStats(time.Now().Add(-time.Duration(h.minutes) * time.Minute))
+	if err != nil {
+		if _, ok := err.(*rest.APIError); !ok {
+			err = errors.Wrap(err, "Database error")
+		}
+		return ResponseData{}, err
+	}
+
+	statsModel := &model.APIWarmPoolStats{}
+	if err := statsModel.BuildFromService(stats); err != nil {
+		return ResponseData{}, err
+	}
+	return ResponseData{
+		Result: []model.Model{statsModel},
+	}, nil
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/apimodels"
+	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/rest/data"
 	"github.com/evergreen-ci/evergreen/rest/model"
@@ -67,6 +68,9 @@ func (s *StatusSuite) SetupSuite() {
 			SystemTimedOut:     9,
 			TestTimedOut:       10,
 		},
+		CachedWarmPool: []event.WarmPoolStats{
+			{Distro: "warm", Runs: 10, Bursts: 4, Absorbed: 3, NewHosts: 2},
+		},
 	}
 	s.sc = &data.MockConnector{
 		MockStatusConnector: s.data,
@@ -204,3 +208,26 @@ func (s *StatusSuite) TaskTaskType() {
 	found = resp.Result[0].(*model.APITask)
 	s.Equal(model.ToAPIString("task5"), found.Id)
 }
+
+func (s *StatusSuite) TestWarmPoolStats() {
+	h := &warmPoolStatsHandler{}
+	r, err := http.NewRequest("GET", "https://evergreen.mongodb.com/rest/v2/status/hosts/warm_pool", &bytes.Buffer{})
+	s.Require().NoError(err)
+	s.NoError(h.ParseAndValidate(context.Background(), r))
+	s.Equal(maxDurationStatusQueryMinutes, h.minutes)
+
+	resp, err := h.Execute(context.Background(), s.sc)
+	s.NoError(err)
+	s.Require().Len(resp.Result, 1)
+	res := resp.Result[0].(*model.APIWarmPoolStats)
+	s.Require().Len(res.Distros, 1)
+	s.Equal(model.ToAPIString("warm"), res.Distros[0].Distro)
+	s.Equal(4, res.Distros[0].Bursts)
+	s.Equal(3, res.Distros[0].Absorbed)
+	s.Equal(0.75, res.Distros[0].AbsorbedRate)
+	s.Equal(2, res.Distros[0].NewHosts)
+
+	r, err = http.NewRequest("GET", "https://evergreen.mongodb.com/rest/v2/status/hosts/warm_pool?minutes=-1", &bytes.Buffer{})
+	s.Require().NoError(err)
+	s.Error(h.ParseAndValidate(context.Background(), r))
+}
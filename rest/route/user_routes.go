@@ -2,16 +2,21 @@ package route
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/auth"
+	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/rest"
 	"github.com/evergreen-ci/evergreen/rest/data"
 	"github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/evergreen-ci/evergreen/thirdparty"
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/google/go-github/github"
+	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 )
 
@@ -125,3 +130,82 @@ func (h *userSettingsGetHandler) Execute(ctx context.Context, sc data.Connector)
 		Result: []model.Model{&apiSettings},
 	}, nil
 }
+
+////////////////////////////////////////////////////////////////////////
+//
+// Handler for a user's spawn host usage and cost
+//
+//    /users/{user_id}/cost
+
+func getUserCostRouteManager(route string, version int) *RouteManager {
+	h := &userCostGetHandler{}
+	return &RouteManager{
+		Route:   route,
+		Version: version,
+		Methods: []MethodHandler{
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser},
+				Authenticator:     &RequireUserAuthenticator{},
+				RequestHandler:    h.Handler(),
+				MethodType:        http.MethodGet,
+			},
+		},
+	}
+}
+
+type userCostGetHandler struct {
+	userID string
+	month  time.Time
+}
+
+func (h *userCostGetHandler) Handler() RequestHandler {
+	return &userCostGetHandler{}
+}
+
+// ParseAndValidate reads the user and, from the optional "month" query
+// parameter in the form YYYY-MM, the month to report on, which defaults to
+// the current month.
+func (h *userCostGetHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	h.userID = mux.Vars(r)["user_id"]
+
+	h.month = time.Now()
+	if month := r.URL.Query().Get("month"); month != "" {
+		var err error
+		h.month, err = time.Parse("2006-01", month)
+		if err != nil {
+			return &rest.APIError{
+				StatusCode: http.StatusBadRequest,
+				Message:    fmt.Sprintf("invalid month '%s', expected the form YYYY-MM", month),
+			}
+		}
+	}
+
+	return nil
+}
+
+func (h *userCostGetHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	u := MustHaveUser(ctx)
+	if u.Id != h.userID && !auth.IsSuperUser(sc.GetSuperUsers(), u) {
+		return ResponseData{}, &rest.APIError{
+			StatusCode: http.StatusForbidden,
+			Message:    "only superusers can view other users' spawn host cost",
+		}
+	}
+
+	usage, err := sc.FindSpawnHostUsage(h.userID, h.month)
+	if err != nil {
+		return ResponseData{}, errors.Wrap(err, "Database error")
+	}
+
+	cost := &model.APIUserCost{
+		UserID: model.ToAPIString(h.userID),
+		Month:  model.NewTime(host.MonthStart(h.month)),
+	}
+	if err = cost.BuildFromService(usage); err != nil {
+		return ResponseData{}, errors.Wrap(err, "API model error")
+	}
+
+	return ResponseData{
+		Result: []model.Model{cost},
+	}, nil
+}
@@ -6,13 +6,19 @@ import (
 	"encoding/json"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/rest"
 	"github.com/evergreen-ci/evergreen/rest/data"
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -109,3 +115,53 @@ func (s *UserRouteSuite) TestUndefinedInput() {
 	s.EqualValues("something", dbUser.Settings.SlackUsername)
 	s.EqualValues("you", dbUser.Settings.GithubUser.LastKnownAs)
 }
+
+func TestUserCostHandler(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	sc := getMockHostsConnector()
+	thisMonth := host.MonthStart(time.Now())
+	sc.CachedSpawnHostUsage = []host.SpawnHostUsage{
+		{User: "user0", Distro: "d1", Month: thisMonth, HostHours: 10, Cost: 2.5},
+		{User: "user0", Distro: "d2", Month: thisMonth, HostHours: 5, Cost: 1},
+		{User: "user0", Distro: "d1", Month: thisMonth.AddDate(0, -1, 0), HostHours: 100, Cost: 30},
+		{User: "user1", Distro: "d1", Month: thisMonth, HostHours: 1, Cost: 0.5},
+	}
+	userContext := func(id string) context.Context {
+		return context.WithValue(context.Background(), evergreen.RequestUser, sc.MockUserConnector.CachedUsers[id])
+	}
+
+	h := getUserCostRouteManager("", 2).Methods[0].Handler().(*userCostGetHandler)
+	h.userID = "user0"
+	h.month = time.Now()
+	resp, err := h.Execute(userContext("user0"), sc)
+	require.NoError(err)
+	require.Len(resp.Result, 1)
+	cost := resp.Result[0].(*restModel.APIUserCost)
+	assert.Equal("user0", restModel.FromAPIString(cost.UserID))
+	assert.Equal(15.0, cost.HostHours)
+	assert.Equal(3.5, cost.Cost)
+	require.Len(cost.Distros, 2)
+	assert.Equal("d1", restModel.FromAPIString(cost.Distros[0].Distro))
+
+	h.month = thisMonth.AddDate(0, -1, 0)
+	resp, err = h.Execute(userContext("user0"), sc)
+	require.NoError(err)
+	assert.Equal(30.0, resp.Result[0].(*restModel.APIUserCost).Cost)
+
+	// only the user and superusers can see the user's cost
+	_, err = h.Execute(userContext("user1"), sc)
+	require.Error(err)
+	assert.Equal(http.StatusForbidden, err.(*rest.APIError).StatusCode)
+	_, err = h.Execute(userContext("root"), sc)
+	assert.NoError(err)
+
+	r, err := http.NewRequest(http.MethodGet, "/users/user0/cost?month=2018-13", nil)
+	require.NoError(err)
+	assert.Error(h.ParseAndValidate(context.Background(), r))
+	r, err = http.NewRequest(http.MethodGet, "/users/user0/cost?month=2018-06", nil)
+	require.NoError(err)
+	assert.NoError(h.ParseAndValidate(context.Background(), r))
+	assert.Equal(time.Date(2018, time.June, 1, 0, 0, 0, 0, time.UTC), h.month)
+}
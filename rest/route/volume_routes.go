@@ -0,0 +1,194 @@
+package route
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen/rest"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+)
+
+////////////////////////////////////////////////////////////////////////
+//
+// GET /volumes
+
+func getVolumesRouteManager(route string, version int) *RouteManager {
+	return &RouteManager{
+		Route:   route,
+		Version: version,
+		Methods: []MethodHandler{
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser},
+				MethodType:        http.MethodGet,
+				Authenticator:     &RequireUserAuthenticator{},
+				RequestHandler:    &volumesGetHandler{},
+			},
+		},
+	}
+}
+
+type volumesGetHandler struct{}
+
+func (h *volumesGetHandler) Handler() RequestHandler {
+	return &volumesGetHandler{}
+}
+
+func (h *volumesGetHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	return nil
+}
+
+func (h *volumesGetHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	u := MustHaveUser(ctx)
+
+	volumes, err := sc.FindVolumesByUser(u.Username())
+	if err != nil {
+		return ResponseData{}, errors.Wrap(err, "Database error")
+	}
+
+	result := []model.Model{}
+	for _, v := range volumes {
+		volumeModel := &model.APIVolume{}
+		if err = volumeModel.BuildFromService(v); err != nil {
+			return ResponseData{}, errors.Wrap(err, "API model error")
+		}
+		result = append(result, volumeModel)
+	}
+
+	return ResponseData{
+		Result: result,
+	}, nil
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// PATCH /volumes/{volume_id}
+// DELETE /volumes/{volume_id}
+
+func getVolumeIDRouteManager(route string, version int) *RouteManager {
+	return &RouteManager{
+		Route:   route,
+		Version: version,
+		Methods: []MethodHandler{
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser},
+				MethodType:        http.MethodPatch,
+				Authenticator:     &RequireUserAuthenticator{},
+				RequestHandler:    &volumeModifyHandler{},
+			},
+			{
+				PrefetchFunctions: []PrefetchFunc{PrefetchUser},
+				MethodType:        http.MethodDelete,
+				Authenticator:     &RequireUserAuthenticator{},
+				RequestHandler:    &volumeDeleteHandler{},
+			},
+		},
+	}
+}
+
+type volumeModifyHandler struct {
+	volumeID string
+	size     int
+}
+
+func (h *volumeModifyHandler) Handler() RequestHandler {
+	return &volumeModifyHandler{}
+}
+
+func (h *volumeModifyHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	var err error
+	h.volumeID, err = validateVolumeID(mux.Vars(r)["volume_id"])
+	if err != nil {
+		return err
+	}
+
+	options := model.VolumeModifyRequest{}
+	if err = util.ReadJSONInto(util.NewRequestReader(r), &options); err != nil {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+	if options.Size <= 0 {
+		return &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "volume size must be positive",
+		}
+	}
+	h.size = options.Size
+
+	return nil
+}
+
+func (h *volumeModifyHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	u := MustHaveUser(ctx)
+
+	v, err := sc.FindVolumeByIdWithOwner(h.volumeID, u)
+	if err != nil {
+		return ResponseData{}, err
+	}
+
+	if err = sc.ResizeVolume(ctx, v, h.size); err != nil {
+		return ResponseData{}, &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+
+	volumeModel := &model.APIVolume{}
+	if err = volumeModel.BuildFromService(v); err != nil {
+		return ResponseData{}, errors.Wrap(err, "API model error")
+	}
+
+	return ResponseData{
+		Result: []model.Model{volumeModel},
+	}, nil
+}
+
+type volumeDeleteHandler struct {
+	volumeID string
+}
+
+func (h *volumeDeleteHandler) Handler() RequestHandler {
+	return &volumeDeleteHandler{}
+}
+
+func (h *volumeDeleteHandler) ParseAndValidate(ctx context.Context, r *http.Request) error {
+	var err error
+	h.volumeID, err = validateVolumeID(mux.Vars(r)["volume_id"])
+
+	return err
+}
+
+func (h *volumeDeleteHandler) Execute(ctx context.Context, sc data.Connector) (ResponseData, error) {
+	u := MustHaveUser(ctx)
+
+	v, err := sc.FindVolumeByIdWithOwner(h.volumeID, u)
+	if err != nil {
+		return ResponseData{}, err
+	}
+
+	if err = sc.DeleteVolume(ctx, v); err != nil {
+		return ResponseData{}, &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+
+	return ResponseData{}, nil
+}
+
+func validateVolumeID(volumeID string) (string, error) {
+	if strings.TrimSpace(volumeID) == "" {
+		return "", &rest.APIError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "missing/empty volume id",
+		}
+	}
+
+	return volumeID, nil
+}
@@ -0,0 +1,147 @@
+package route
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/rest"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/suite"
+)
+
+type volumeHandlerSuite struct {
+	sc *data.MockConnector
+
+	suite.Suite
+}
+
+func TestVolumeHandlers(t *testing.T) {
+	suite.Run(t, &volumeHandlerSuite{})
+}
+
+func (s *volumeHandlerSuite) SetupTest() {
+	s.sc = getMockHostsConnector()
+	s.sc.CachedVolumes = []host.Volume{
+		{
+			ID:        "vol1",
+			CreatedBy: "user0",
+			Provider:  evergreen.ProviderNameMock,
+			Size:      32,
+			Status:    host.VolumeAvailable,
+		},
+		{
+			ID:        "vol2",
+			CreatedBy: "user0",
+			Provider:  evergreen.ProviderNameMock,
+			Size:      16,
+			Status:    host.VolumeAttached,
+			Host:      "host1",
+		},
+		{
+			ID:        "vol3",
+			CreatedBy: "user1",
+			Provider:  evergreen.ProviderNameMock,
+			Size:      8,
+			Status:    host.VolumeAvailable,
+		},
+	}
+}
+
+func (s *volumeHandlerSuite) context(userID string) context.Context {
+	return context.WithValue(context.Background(), evergreen.RequestUser, s.sc.MockUserConnector.CachedUsers[userID])
+}
+
+func (s *volumeHandlerSuite) TestListVolumes() {
+	h := getVolumesRouteManager("", 2).Methods[0].Handler()
+	resp, err := h.Execute(s.context("user0"), s.sc)
+	s.Require().NoError(err)
+	s.Require().Len(resp.Result, 2)
+	s.Equal("vol1", model.FromAPIString(resp.Result[0].(*model.APIVolume).ID))
+	s.Equal("vol2", model.FromAPIString(resp.Result[1].(*model.APIVolume).ID))
+
+	resp, err = h.Execute(s.context("root"), s.sc)
+	s.Require().NoError(err)
+	s.Empty(resp.Result)
+}
+
+func (s *volumeHandlerSuite) TestResizeVolume() {
+	h := getVolumeIDRouteManager("", 2).Methods[0].Handler().(*volumeModifyHandler)
+	s.Require().NoError(s.parse(h, http.MethodPatch, "/volumes/vol1", `{"size": 64}`))
+	s.Equal("vol1", h.volumeID)
+	s.Equal(64, h.size)
+
+	resp, err := h.Execute(s.context("user0"), s.sc)
+	s.Require().NoError(err)
+	s.Require().Len(resp.Result, 1)
+	s.Equal(64, resp.Result[0].(*model.APIVolume).Size)
+	s.Equal(64, s.sc.CachedVolumes[0].Size)
+
+	// volumes can't shrink
+	h.size = 32
+	_, err = h.Execute(s.context("user0"), s.sc)
+	s.Require().Error(err)
+	s.Equal(http.StatusBadRequest, err.(*rest.APIError).StatusCode)
+	s.Equal(64, s.sc.CachedVolumes[0].Size)
+
+	s.Error(s.parse(h, http.MethodPatch, "/volumes/vol1", `{"size": 0}`))
+}
+
+func (s *volumeHandlerSuite) TestOnlyOwnerOrSuperUserCanModifyVolume() {
+	h := getVolumeIDRouteManager("", 2).Methods[0].Handler().(*volumeModifyHandler)
+	h.volumeID = "vol1"
+	h.size = 64
+
+	_, err := h.Execute(s.context("user1"), s.sc)
+	s.Require().Error(err)
+	s.Equal(http.StatusUnauthorized, err.(*rest.APIError).StatusCode)
+	s.Equal(32, s.sc.CachedVolumes[0].Size)
+
+	_, err = h.Execute(s.context("root"), s.sc)
+	s.NoError(err)
+	s.Equal(64, s.sc.CachedVolumes[0].Size)
+}
+
+func (s *volumeHandlerSuite) TestDeleteVolume() {
+	h := getVolumeIDRouteManager("", 2).Methods[1].Handler().(*volumeDeleteHandler)
+	s.Require().NoError(s.parse(h, http.MethodDelete, "/volumes/vol1", ""))
+	s.Equal("vol1", h.volumeID)
+
+	_, err := h.Execute(s.context("user0"), s.sc)
+	s.Require().NoError(err)
+	s.Equal(host.VolumeDeleted, s.sc.CachedVolumes[0].Status)
+
+	// deleted volumes are no longer found
+	_, err = h.Execute(s.context("user0"), s.sc)
+	s.Require().Error(err)
+	s.Equal(http.StatusNotFound, err.(*rest.APIError).StatusCode)
+
+	// attached volumes can't be deleted
+	h.volumeID = "vol2"
+	_, err = h.Execute(s.context("user0"), s.sc)
+	s.Require().Error(err)
+	s.Equal(http.StatusBadRequest, err.(*rest.APIError).StatusCode)
+	s.Equal(host.VolumeAttached, s.sc.CachedVolumes[1].Status)
+}
+
+// parse routes a request to the given handler's ParseAndValidate, so that the
+// volume ID is set in the route.
+func (s *volumeHandlerSuite) parse(h RequestHandler, method, path, body string) error {
+	req, err := http.NewRequest(method, path, bytes.NewBufferString(body))
+	s.Require().NoError(err)
+
+	var parseErr error
+	r := mux.NewRouter()
+	r.HandleFunc("/volumes/{volume_id}", func(w http.ResponseWriter, req *http.Request) {
+		parseErr = h.ParseAndValidate(s.context("user0"), req)
+	})
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	return parseErr
+}
@@ -231,7 +231,7 @@ func (self *CmpBasedTaskComparator) splitTasksByRequester(
 		switch {
 		case task.Priority > evergreen.MaxTaskPriority:
 			priorityTasks = append(priorityTasks, task)
-		case task.Requester == evergreen.RepotrackerVersionRequester:
+		case task.Requester == evergreen.RepotrackerVersionRequester, task.Requester == evergreen.PeriodicBuildRequester:
 			repoTrackerTasks = append(repoTrackerTasks, task)
 		case evergreen.IsPatchRequester(task.Requester):
 			patchTasks = append(patchTasks, task)
@@ -0,0 +1,56 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/util"
+)
+
+// warmPoolNumNewHosts tops up the number of new hosts chosen by the host
+// allocator so that, once the queued tasks have started, the distro still
+// has at least its minimum number of hosts and, during the standby hours, its
+// number of hot standby hosts free. It never exceeds the pool size.
+func warmPoolNumNewHosts(d distro.Distro, existingHosts []host.Host, queueLength, numNewHosts int, now time.Time) (int, event.WarmPoolInfo) {
+	info := event.WarmPoolInfo{
+		MinHosts:     d.MinHosts,
+		StandbyHosts: d.StandbyHosts(now),
+	}
+	if !d.IsEphemeral() || !d.HasWarmPool() {
+		return numNewHosts, info
+	}
+
+	numFreeHosts := 0
+	for _, h := range existingHosts {
+		if h.RunningTask != "" {
+			continue
+		}
+		numFreeHosts++
+		if h.Status == evergreen.HostRunning {
+			info.NumReadyHosts++
+		}
+	}
+
+	// a burst is absorbed when every queued task can start on a host that
+	// was already up and waiting for it
+	info.Burst = queueLength > 0
+	info.Absorbed = info.Burst && info.NumReadyHosts >= queueLength
+
+	numTotalHosts := len(existingHosts) + numNewHosts
+	numFreeAfterQueue := numFreeHosts + numNewHosts - queueLength
+	if numFreeAfterQueue < 0 {
+		numFreeAfterQueue = 0
+	}
+
+	numWarmHosts := util.Max(d.MinHosts-numTotalHosts, info.StandbyHosts-numFreeAfterQueue)
+	numWarmHosts = util.Min(numWarmHosts, d.PoolSize-numTotalHosts)
+	if numWarmHosts < 0 {
+		numWarmHosts = 0
+	}
+	info.NumNewHosts = numWarmHosts
+
+	return numNewHosts + numWarmHosts, info
+}
@@ -0,0 +1,73 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarmPoolNumNewHosts(t *testing.T) {
+	assert := assert.New(t)
+
+	noon := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	d := distro.Distro{
+		Id:       "warm",
+		Provider: evergreen.ProviderNameEc2Auto,
+		PoolSize: 10,
+	}
+	idle := host.Host{Status: evergreen.HostRunning}
+	busy := host.Host{Status: evergreen.HostRunning, RunningTask: "t"}
+	starting := host.Host{Status: evergreen.HostStarting}
+
+	// without a warm pool the allocator's decision stands
+	num, info := warmPoolNumNewHosts(d, nil, 0, 0, noon)
+	assert.Equal(0, num)
+	assert.Equal(0, info.NumNewHosts)
+
+	// the minimum is reached with an empty queue
+	d.MinHosts = 3
+	num, info = warmPoolNumNewHosts(d, []host.Host{busy}, 0, 0, noon)
+	assert.Equal(2, num)
+	assert.Equal(2, info.NumNewHosts)
+	assert.False(info.Burst)
+
+	// hosts the allocator already asked for count towards the minimum
+	num, _ = warmPoolNumNewHosts(d, []host.Host{busy}, 2, 2, noon)
+	assert.Equal(2, num)
+
+	// standby hosts are kept free on top of the queued tasks
+	d.MinHosts = 0
+	d.HotStandby = distro.HotStandby{NumHosts: 2, StartHour: 8, EndHour: 18}
+	num, info = warmPoolNumNewHosts(d, []host.Host{idle, busy}, 3, 2, noon)
+	assert.Equal(4, num)
+	assert.Equal(2, info.StandbyHosts)
+	assert.True(info.Burst)
+	assert.False(info.Absorbed)
+
+	// outside the standby hours nothing is added
+	num, info = warmPoolNumNewHosts(d, []host.Host{idle, busy}, 0, 0, noon.Add(8*time.Hour))
+	assert.Equal(0, num)
+	assert.Equal(0, info.StandbyHosts)
+
+	// the pool size caps the warm pool
+	d.PoolSize = 3
+	num, _ = warmPoolNumNewHosts(d, []host.Host{busy, busy}, 0, 0, noon)
+	assert.Equal(1, num)
+
+	// a burst is absorbed only by hosts that are ready
+	d.PoolSize = 10
+	_, info = warmPoolNumNewHosts(d, []host.Host{idle, idle, starting}, 2, 0, noon)
+	assert.True(info.Absorbed)
+	assert.Equal(2, info.NumReadyHosts)
+	_, info = warmPoolNumNewHosts(d, []host.Host{idle, idle, starting}, 3, 0, noon)
+	assert.False(info.Absorbed)
+
+	// static distros never get warm hosts
+	d.Provider = evergreen.ProviderNameStatic
+	num, _ = warmPoolNumNewHosts(d, nil, 0, 0, noon)
+	assert.Equal(0, num)
+}
@@ -92,6 +92,14 @@ func PlanDistro(ctx context.Context, conf Configuration) error {
 		return errors.Wrap(err, "problem finding distro")
 	}
 
+	var warmPool *event.WarmPoolInfo
+	if distroSpec.HasWarmPool() {
+		var info event.WarmPoolInfo
+		newHosts[conf.DistroID], info = warmPoolNumNewHosts(distroSpec, distroHostsMap[conf.DistroID],
+			len(res.taskQueueItem), newHosts[conf.DistroID], time.Now())
+		warmPool = &info
+	}
+
 	hostsSpawned, err := spawnHosts(ctx, newHosts)
 	if err != nil {
 		return errors.Wrap(err, "Error spawning new hosts")
@@ -101,6 +109,7 @@ func PlanDistro(ctx context.Context, conf Configuration) error {
 	event.LogSchedulerEvent(event.SchedulerEventData{
 		TaskQueueInfo: res.schedulerEvent,
 		DistroId:      conf.DistroID,
+		WarmPool:      warmPool,
 	})
 
 	var makespan time.Duration
@@ -122,6 +131,7 @@ func PlanDistro(ctx context.Context, conf Configuration) error {
 		"queue":                  res.schedulerEvent,
 		"total_runtime":          res.schedulerEvent.ExpectedDuration.String(),
 		"predicted_makespan":     makespan.String(),
+		"warm_pool":              warmPool,
 		"scheduler_runtime_secs": time.Since(startAt).Seconds(),
 	})
 
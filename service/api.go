@@ -458,6 +458,19 @@ func (as *APIServer) hostReady(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// hosts that bootstrap through user data can only report once, using
+	// the secret they were created with
+	if hostObj.Distro.BootstrapsWithUserData() {
+		if hostObj.BootstrapSecret == "" || r.Header.Get(evergreen.HostBootstrapHeader) != hostObj.BootstrapSecret {
+			http.Error(w, "invalid bootstrap secret", http.StatusUnauthorized)
+			return
+		}
+		if err = hostObj.ClearBootstrapSecret(); err != nil {
+			as.LoggedError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
 	// if the host failed
 	setupSuccess := mux.Vars(r)["status"]
 	if setupSuccess == evergreen.HostStatusFailed {
@@ -516,12 +529,28 @@ func (as *APIServer) hostReady(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if hostObj.Distro.BootstrapsWithUserData() {
+		if err = cloudManager.OnUp(ctx, hostObj); err != nil {
+			as.LoggedError(w, r, http.StatusInternalServerError,
+				errors.Wrapf(err, "OnUp callback failed for host %s", hostObj.Id))
+			return
+		}
+	}
+
 	// mark host as provisioned
 	if err := hostObj.MarkAsProvisioned(); err != nil {
 		as.LoggedError(w, r, http.StatusInternalServerError, err)
 		return
 	}
 
+	// the host starts its own agent once it has reported success
+	if hostObj.Distro.BootstrapsWithUserData() {
+		if err = hostObj.SetAgentRevision(evergreen.BuildRevision); err != nil {
+			as.LoggedError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
 	grip.Infof("Successfully marked host '%s' with dns '%s' as provisioned", hostObj.Id, dns)
 }
 
@@ -569,8 +598,35 @@ func (as *APIServer) listVariants(w http.ResponseWriter, r *http.Request) {
 }
 
 // validateProjectConfig returns a slice containing a list of any errors
-// found in validating the given project configuration
+// found in validating the given project configuration. If the "lint" query
+// parameter is true, lint warnings are included as well, and are checked
+// against the project given by the "project" query parameter, if any. Since
+// linting against a project uses its variables, only superusers and the
+// project's admins, who can see them, may validate against a project.
 func (as *APIServer) validateProjectConfig(w http.ResponseWriter, r *http.Request) {
+	lint := r.URL.Query().Get("lint") == "true"
+	projectID := r.URL.Query().Get("project")
+	if projectID != "" {
+		u := GetUser(r)
+		if u == nil {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		projectRef, err := model.FindOneProjectRef(projectID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if projectRef == nil {
+			http.Error(w, fmt.Sprintf("project '%s' not found", projectID), http.StatusNotFound)
+			return
+		}
+		if !auth.IsSuperUser(as.Settings.SuperUsers, u) && !isAdmin(u, projectRef) {
+			http.Error(w, fmt.Sprintf("not authorized to view project '%s'", projectID), http.StatusForbidden)
+			return
+		}
+	}
+
 	body := util.NewRequestReader(r)
 	defer body.Close()
 	yamlBytes, err := ioutil.ReadAll(body)
@@ -581,7 +637,7 @@ func (as *APIServer) validateProjectConfig(w http.ResponseWriter, r *http.Reques
 
 	project := &model.Project{}
 	validationErr := validator.ValidationError{}
-	if err = model.LoadProjectInto(yamlBytes, "", project); err != nil {
+	if err = model.LoadProjectInto(yamlBytes, projectID, project); err != nil {
 		validationErr.Message = err.Error()
 		gimlet.WriteJSONError(w, []validator.ValidationError{validationErr})
 		return
@@ -592,8 +648,17 @@ func (as *APIServer) validateProjectConfig(w http.ResponseWriter, r *http.Reques
 		return
 	}
 	semanticErrs := validator.CheckProjectSemantics(project)
-	if len(syntaxErrs)+len(semanticErrs) != 0 {
-		gimlet.WriteJSONError(w, append(syntaxErrs, semanticErrs...))
+	validationErrs := append(syntaxErrs, semanticErrs...)
+	if lint {
+		lintErrs, err := validator.CheckProjectLint(project)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		validationErrs = append(validationErrs, lintErrs...)
+	}
+	if len(validationErrs) != 0 {
+		gimlet.WriteJSONError(w, validationErrs)
 		return
 	}
 	gimlet.WriteJSON(w, []validator.ValidationError{})
@@ -693,6 +758,7 @@ func (as *APIServer) AttachRoutes(root *mux.Router) {
 	// Hosts callback
 	host := r.PathPrefix("/host/{tag:[\\w_\\-\\@]+}/").Subrouter()
 	host.HandleFunc("/ready/{status}", as.hostReady).Methods("POST")
+	r.HandleFunc("/bootstrap", as.bootstrapHost).Methods("GET")
 
 	// Spawnhost routes - creating new hosts, listing existing hosts, listing distros
 	spawns := apiRootOld.PathPrefix("/spawns/").Subrouter()
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/cloud"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/pkg/errors"
+)
+
+// bootstrapAgentScriptName is the script that keeps the agent running on
+// hosts that bootstrap through user data.
+const bootstrapAgentScriptName = "evergreen_agent.sh"
+
+// bootstrapHost sends a host that bootstraps through user data the script
+// that sets it up, reports the result and starts its agent. The host is
+// identified by the bootstrap secret it was given when it was created,
+// which is replaced as the script is fetched, so that the script, with the
+// host secret and logging credentials in it, can only be fetched once.
+func (as *APIServer) bootstrapHost(w http.ResponseWriter, r *http.Request) {
+	secret := r.Header.Get(evergreen.HostBootstrapHeader)
+	if secret == "" {
+		http.Error(w, "missing bootstrap secret", http.StatusBadRequest)
+		return
+	}
+
+	h, err := host.FindOne(host.ByBootstrapSecret(secret))
+	if err != nil {
+		as.LoggedError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	if h == nil {
+		http.Error(w, "no host has this bootstrap secret", http.StatusNotFound)
+		return
+	}
+	rotated, err := h.RotateBootstrapSecret(secret)
+	if err != nil {
+		as.LoggedError(w, r, http.StatusInternalServerError, errors.Wrapf(err, "rotating bootstrap secret for %s", h.Id))
+		return
+	}
+	if !rotated {
+		http.Error(w, "no host has this bootstrap secret", http.StatusNotFound)
+		return
+	}
+
+	if h.Secret == "" {
+		if err = h.CreateSecret(); err != nil {
+			as.LoggedError(w, r, http.StatusInternalServerError, errors.Wrapf(err, "creating secret for %s", h.Id))
+			return
+		}
+	}
+
+	script, err := makeBootstrapScript(h, &as.Settings)
+	if err != nil {
+		as.LoggedError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	gimlet.WriteText(w, script)
+}
+
+// makeBootstrapScript returns the shell script that provisions a host in
+// place of the SSH setup: it writes the distro's scripts, downloads the agent
+// and runs the setup script, reports the outcome to the API server, and then
+// starts the agent.
+func makeBootstrapScript(h *host.Host, settings *evergreen.Settings) (string, error) {
+	exp := util.NewExpansions(settings.Expansions)
+	setup, err := exp.ExpandString(h.Distro.Setup)
+	if err != nil {
+		return "", errors.Wrap(err, "expansions error")
+	}
+
+	report := fmt.Sprintf(`curl -fsS -X POST -H '%s: %s' --data-binary "@$log" '%s/api/2/host/%s/ready/'"$1"`,
+		evergreen.HostBootstrapHeader, h.BootstrapSecret, settings.ApiUrl, h.Id)
+
+	lines := []string{
+		"#!/bin/sh",
+		"cd ~",
+		"log=$(mktemp)",
+		"report() {",
+		"  " + report,
+		"}",
+	}
+
+	scripts := []struct{ name, script string }{
+		{evergreen.SetupScriptName, setup},
+		{evergreen.TeardownScriptName, h.Distro.Teardown},
+	}
+	for _, s := range scripts {
+		if s.script == "" {
+			continue
+		}
+		lines = append(lines,
+			fmt.Sprintf("cat > ~/%s <<'EVERGREEN_SCRIPT_EOF'", s.name),
+			strings.TrimSuffix(s.script, "\n"),
+			"EVERGREEN_SCRIPT_EOF",
+			fmt.Sprintf("chmod 700 ~/%s", s.name),
+		)
+	}
+
+	setupCmds := []string{h.CurlCommand(settings.Ui.Url)}
+	if setup != "" {
+		setupCmds = append(setupCmds, h.SetupCommand())
+	}
+	lines = append(lines,
+		fmt.Sprintf(`(set -e; %s) > "$log" 2>&1 || { report %s; exit 1; }`,
+			strings.Join(setupCmds, "; "), evergreen.HostStatusFailed),
+		fmt.Sprintf("report %s || exit 1", evergreen.HostStatusSuccess),
+		`rm -f "$log"`,
+	)
+
+	env := agentEnv(settings)
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		lines = append(lines, fmt.Sprintf("export %s='%s'", k, env[k]))
+	}
+
+	// there's no SSH to deploy new agents with, so the agent runs in a loop
+	// that downloads the current binary before each start, and the agent is
+	// upgraded by telling it to exit
+	lines = append(lines,
+		fmt.Sprintf("cat > ~/%s <<'EVERGREEN_SCRIPT_EOF'", bootstrapAgentScriptName),
+		"while true; do",
+		"  "+h.CurlCommand(settings.Ui.Url),
+		"  "+strings.Join(agentCommand(h, settings), " "),
+		"  sleep 10",
+		"done",
+		"EVERGREEN_SCRIPT_EOF",
+		fmt.Sprintf("chmod 700 ~/%s", bootstrapAgentScriptName),
+	)
+	// a container runs this script as its init process, so the agent has to
+	// stay in the foreground to keep the container running
+	lines = append(lines,
+		fmt.Sprintf(`if [ "$$" -eq 1 ]; then exec sh ~/%s; fi`, bootstrapAgentScriptName),
+		fmt.Sprintf("nohup sh ~/%s > /dev/null 2>&1 &", bootstrapAgentScriptName),
+	)
+
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+func agentCommand(h *host.Host, settings *evergreen.Settings) []string {
+	parts := []string{
+		filepath.Join("~", h.Distro.BinaryName()),
+		"agent",
+		fmt.Sprintf("--api_server='%s'", settings.ApiUrl),
+		fmt.Sprintf("--host_id='%s'", h.Id),
+		fmt.Sprintf("--host_secret='%s'", h.Secret),
+		fmt.Sprintf("--log_prefix='%s'", filepath.Join(h.Distro.WorkDir, "agent")),
+		fmt.Sprintf("--working_directory='%s'", h.Distro.WorkDir),
+		"--cleanup",
+	}
+	if h.Distro.Provider == evergreen.ProviderNameEc2Spot {
+		parts = append(parts, fmt.Sprintf("--spot_interruption_url='%s'", cloud.EC2SpotInterruptionURL))
+	}
+	return parts
+}
+
+// agentEnv returns the environment the agent needs to send its logs to the
+// same places as agents started over SSH.
+func agentEnv(settings *evergreen.Settings) map[string]string {
+	env := map[string]string{}
+	if sumoEndpoint, ok := settings.Credentials["sumologic"]; ok {
+		env["GRIP_SUMO_ENDPOINT"] = sumoEndpoint
+	}
+
+	if settings.Splunk.Populated() {
+		env["GRIP_SPLUNK_SERVER_URL"] = settings.Splunk.ServerURL
+		env["GRIP_SPLUNK_CLIENT_TOKEN"] = settings.Splunk.Token
+
+		if settings.Splunk.Channel != "" {
+			env["GRIP_SPLUNK_CHANNEL"] = settings.Splunk.Channel
+		}
+	}
+	return env
+}
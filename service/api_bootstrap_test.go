@@ -0,0 +1,63 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakeBootstrapScript(t *testing.T) {
+	assert := assert.New(t)
+
+	settings := &evergreen.Settings{
+		ApiUrl:     "https://api.example.com",
+		Ui:         evergreen.UIConfig{Url: "https://ui.example.com"},
+		Expansions: map[string]string{"greeting": "hello"},
+	}
+	h := &host.Host{
+		Id:              "h1",
+		Secret:          "host-secret",
+		BootstrapSecret: "bootstrap-secret",
+		Distro: distro.Distro{
+			Id:              "d1",
+			Arch:            "linux_amd64",
+			WorkDir:         "/data/mci",
+			Provider:        evergreen.ProviderNameEc2OnDemand,
+			Setup:           "echo ${greeting}",
+			BootstrapMethod: distro.BootstrapMethodUserData,
+		},
+	}
+
+	script, err := makeBootstrapScript(h, settings)
+	assert.NoError(err)
+	assert.True(strings.HasPrefix(script, "#!/bin/sh\n"))
+	assert.Contains(script, "'https://api.example.com/api/2/host/h1/ready/'")
+	assert.Contains(script, evergreen.HostBootstrapHeader+": bootstrap-secret")
+	assert.Contains(script, "cat > ~/"+evergreen.SetupScriptName)
+	assert.Contains(script, "\necho hello\n")
+	assert.NotContains(script, evergreen.TeardownScriptName)
+	assert.Contains(script, "https://ui.example.com/clients/linux_amd64/evergreen")
+	assert.Contains(script, h.SetupCommand())
+	assert.Contains(script, "report "+evergreen.HostStatusFailed)
+	assert.Contains(script, "--host_secret='host-secret'")
+	assert.NotContains(script, "--spot_interruption_url")
+	assert.Contains(script, "cat > ~/"+bootstrapAgentScriptName)
+	assert.Contains(script, "nohup sh ~/"+bootstrapAgentScriptName)
+	// the agent is downloaded again before each start, so that it's
+	// upgraded when it exits
+	loop := script[strings.Index(script, "while true; do"):]
+	assert.Contains(loop, h.CurlCommand(settings.Ui.Url))
+	assert.True(strings.Index(loop, h.CurlCommand(settings.Ui.Url)) < strings.Index(loop, "--host_secret='host-secret'"))
+
+	// without a setup script the agent is still downloaded, but not set up
+	h.Distro.Setup = ""
+	h.Distro.Provider = evergreen.ProviderNameEc2Spot
+	script, err = makeBootstrapScript(h, settings)
+	assert.NoError(err)
+	assert.NotContains(script, h.SetupCommand())
+	assert.Contains(script, "--spot_interruption_url")
+}
@@ -37,14 +37,20 @@ func (as *APIServer) submitPatch(w http.ResponseWriter, r *http.Request) {
 	dbUser := MustHaveUser(r)
 
 	data := struct {
-		Description string   `json:"desc"`
-		Project     string   `json:"project"`
-		Patch       string   `json:"patch"`
-		Githash     string   `json:"githash"`
-		Variants    string   `json:"buildvariants"`
-		Tasks       []string `json:"tasks"`
-		Finalize    bool     `json:"finalize"`
-		Alias       string   `json:"alias"`
+		Description  string   `json:"desc"`
+		Project      string   `json:"project"`
+		Patch        string   `json:"patch"`
+		Githash      string   `json:"githash"`
+		Variants     string   `json:"buildvariants"`
+		Tasks        []string `json:"tasks"`
+		Finalize     bool     `json:"finalize"`
+		Alias        string   `json:"alias"`
+		HeadOwner    string   `json:"head_owner"`
+		HeadRepo     string   `json:"head_repo"`
+		HeadRef      string   `json:"head_ref"`
+		HeadHash     string   `json:"head_hash"`
+		LocalBranch  string   `json:"local_branch"`
+		KeepPrevious bool     `json:"keep_previous"`
 	}{}
 	if err := util.ReadJSONInto(util.NewRequestReaderWithSize(r, patch.SizeLimit), &data); err != nil {
 		as.LoggedError(w, r, http.StatusBadRequest, err)
@@ -67,7 +73,28 @@ func (as *APIServer) submitPatch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	intent, err := patch.NewCliIntent(dbUser.Id, data.Project, data.Githash, r.FormValue("module"), data.Patch, data.Description, data.Finalize, variants, data.Tasks, data.Alias)
+	var intent patch.Intent
+	if data.HeadHash != "" {
+		// the patch checks out a commit that's been pushed rather than
+		// applying a diff
+		if data.Patch != "" {
+			as.LoggedError(w, r, http.StatusBadRequest, errors.New("a patch can't have both a diff and a head commit"))
+			return
+		}
+		head := patch.PatchHead{
+			Owner:    data.HeadOwner,
+			Repo:     data.HeadRepo,
+			Ref:      data.HeadRef,
+			HeadHash: data.HeadHash,
+		}
+		if err = head.Validate(); err != nil {
+			as.LoggedError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		intent, err = patch.NewCliHeadIntent(dbUser.Id, data.Project, data.Githash, r.FormValue("module"), head, data.Description, data.Finalize, variants, data.Tasks, data.Alias, data.LocalBranch, data.KeepPrevious)
+	} else {
+		intent, err = patch.NewCliIntent(dbUser.Id, data.Project, data.Githash, r.FormValue("module"), data.Patch, data.Description, data.Finalize, variants, data.Tasks, data.Alias, data.LocalBranch, data.KeepPrevious)
+	}
 	if err != nil {
 		as.LoggedError(w, r, http.StatusBadRequest, err)
 		return
@@ -142,20 +169,43 @@ func (as *APIServer) updatePatchModule(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var moduleName, patchContent, githash string
+	var head *patch.PatchHead
 
 	if r.Header.Get("Content-Type") == formMimeType {
 		moduleName, patchContent, githash = r.FormValue("module"), r.FormValue("patch"), r.FormValue("githash")
 	} else {
 		data := struct {
-			Module  string `json:"module"`
-			Patch   string `json:"patch"`
-			Githash string `json:"githash"`
+			Module    string `json:"module"`
+			Patch     string `json:"patch"`
+			Githash   string `json:"githash"`
+			HeadOwner string `json:"head_owner"`
+			HeadRepo  string `json:"head_repo"`
+			HeadRef   string `json:"head_ref"`
+			HeadHash  string `json:"head_hash"`
 		}{}
 		if err = util.ReadJSONInto(util.NewRequestReader(r), &data); err != nil {
 			as.LoggedError(w, r, http.StatusBadRequest, err)
 			return
 		}
 		moduleName, patchContent, githash = data.Module, data.Patch, data.Githash
+		if data.HeadHash != "" {
+			head = &patch.PatchHead{
+				Owner:    data.HeadOwner,
+				Repo:     data.HeadRepo,
+				Ref:      data.HeadRef,
+				HeadHash: data.HeadHash,
+			}
+		}
+	}
+	if head != nil && patchContent != "" {
+		as.LoggedError(w, r, http.StatusBadRequest, errors.New("a module patch can't have both a diff and a head commit"))
+		return
+	}
+	if head != nil {
+		if err = head.Validate(); err != nil {
+			as.LoggedError(w, r, http.StatusBadRequest, err)
+			return
+		}
 	}
 
 	projectRef, err := model.FindOneProjectRef(p.Project)
@@ -179,11 +229,6 @@ func (as *APIServer) updatePatchModule(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	summaries, err := thirdparty.GetPatchSummaries(patchContent)
-	if err != nil {
-		as.LoggedError(w, r, http.StatusInternalServerError, err)
-		return
-	}
 	repoOwner, repo := module.GetRepoOwnerAndName()
 
 	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
@@ -195,21 +240,38 @@ func (as *APIServer) updatePatchModule(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// write the patch content into a GridFS file under a new ObjectId.
-	patchFileId := bson.NewObjectId().Hex()
-	err = db.WriteGridFile(patch.GridFSPrefix, patchFileId, strings.NewReader(patchContent))
-	if err != nil {
-		as.LoggedError(w, r, http.StatusInternalServerError, errors.Wrap(err, "failed to write patch file to db"))
-		return
-	}
-
 	modulePatch := patch.ModulePatch{
 		ModuleName: moduleName,
 		Githash:    githash,
-		PatchSet: patch.PatchSet{
-			PatchFileId: patchFileId,
-			Summary:     summaries,
-		},
+	}
+
+	if head != nil {
+		// the module checks out a commit, so there's no diff to store
+		headOwner, headRepo := repoOwner, repo
+		if head.IsFork() {
+			headOwner, headRepo = head.Owner, head.Repo
+		}
+		modulePatch.Head = head
+		modulePatch.PatchSet.Summary, err = thirdparty.GetGithubCompareSummaries(ctx, githubOauthToken, headOwner, headRepo, githash, head.HeadHash)
+		if err != nil {
+			as.LoggedError(w, r, http.StatusBadRequest, errors.Wrapf(err, "can't compare '%s' to '%s' in '%s/%s'; has it been pushed?",
+				head.HeadHash, githash, headOwner, headRepo))
+			return
+		}
+	} else {
+		modulePatch.PatchSet.Summary, err = thirdparty.GetPatchSummaries(patchContent)
+		if err != nil {
+			as.LoggedError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+
+		// write the patch content into a GridFS file under a new ObjectId.
+		modulePatch.PatchSet.PatchFileId = bson.NewObjectId().Hex()
+		err = db.WriteGridFile(patch.GridFSPrefix, modulePatch.PatchSet.PatchFileId, strings.NewReader(patchContent))
+		if err != nil {
+			as.LoggedError(w, r, http.StatusInternalServerError, errors.Wrap(err, "failed to write patch file to db"))
+			return
+		}
 	}
 
 	if err = p.UpdateModulePatch(modulePatch); err != nil {
@@ -14,6 +14,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/evergreen-ci/evergreen/notify"
 	"github.com/evergreen-ci/evergreen/rest/data"
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/evergreen-ci/gimlet"
 	"github.com/gorilla/mux"
@@ -65,7 +66,10 @@ func (as *APIServer) requestHost(w http.ResponseWriter, r *http.Request) {
 	}
 
 	hc := &data.DBHostConnector{}
-	spawnHost, err := hc.NewIntentHost(hostRequest.Distro, hostRequest.PublicKey, "", user)
+	spawnHost, err := hc.NewIntentHost(&restModel.HostPostRequest{
+		DistroID: hostRequest.Distro,
+		KeyName:  hostRequest.PublicKey,
+	}, user)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
@@ -106,6 +106,32 @@ func checkHostHealth(h *host.Host) bool {
 	return false
 }
 
+// checkHostReprovision checks whether the host is waiting to be reprovisioned,
+// marking it to be if it has run as many tasks as its distro allows between
+// reprovisionings. The agent on such a host should exit so that it can be
+// drained.
+func checkHostReprovision(h *host.Host) bool {
+	if !h.NeedsReprovision && h.ReachedReprovisionTaskLimit() {
+		reason := fmt.Sprintf("host ran %d tasks", h.TaskCount-h.ReprovisionTaskCount)
+		if err := h.SetNeedsReprovision(evergreen.User, reason); err != nil {
+			grip.Error(message.WrapError(err, message.Fields{
+				"host":      h.Id,
+				"operation": "check_host_reprovision",
+				"message":   "problem marking host to be reprovisioned",
+			}))
+			return false
+		}
+	}
+	if h.NeedsReprovision {
+		grip.Info(message.Fields{
+			"message": "host is waiting to be reprovisioned, so agent should exit",
+			"host_id": h.Id,
+		})
+		return true
+	}
+	return false
+}
+
 // checkAgentRevision checks that the agent revision is current.
 func checkAgentRevision(h *host.Host) bool {
 	if h.AgentRevision != evergreen.BuildRevision {
@@ -153,6 +179,28 @@ func (as *APIServer) EndTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// a task whose host is being reclaimed by the cloud provider is requeued
+	// immediately rather than failed
+	if details.Preempted && !t.IsPartOfDisplay() {
+		as.endPreemptedTask(w, r, t, currentHost, details)
+		return
+	}
+
+	// a failure that the project's auto-retry policy says is a known flake
+	// is restarted rather than failing the task
+	retryReason, err := model.AutoRetryReason(&projectRef.AutoRetry, t, details)
+	if err != nil {
+		grip.Error(message.WrapError(err, message.Fields{
+			"message": "problem checking whether to retry task",
+			"task_id": t.Id,
+			"project": t.Project,
+		}))
+	}
+	if retryReason != "" {
+		as.endRetriedTask(w, r, t, currentHost, details, retryReason)
+		return
+	}
+
 	// mark task as finished
 	updates := model.StatusChanges{}
 	err = model.MarkEnd(t, APIServerLockTitle, finishTime, details, projectRef.DeactivatePrevious, &updates)
@@ -229,6 +277,10 @@ func (as *APIServer) EndTask(w http.ResponseWriter, r *http.Request) {
 		endTaskResp.ShouldExit = true
 	}
 
+	if checkHostReprovision(currentHost) {
+		endTaskResp.ShouldExit = true
+	}
+
 	// we should disable hosts and prevent them from performing
 	// more work if they appear to be in a bad state
 	// (e.g. encountered 5 consecutive system failures)
@@ -257,6 +309,63 @@ func (as *APIServer) EndTask(w http.ResponseWriter, r *http.Request) {
 	gimlet.WriteJSON(w, endTaskResp)
 }
 
+// endPreemptedTask requeues a task that was interrupted because its host is
+// being reclaimed, without counting the interrupted execution against the
+// task, and stops the host from being given any more work.
+func (as *APIServer) endPreemptedTask(w http.ResponseWriter, r *http.Request, t *task.Task, currentHost *host.Host, details *apimodels.TaskEndDetail) {
+	if err := model.ResetPreemptedTask(t, APIServerLockTitle, details); err != nil {
+		as.LoggedError(w, r, http.StatusInternalServerError,
+			errors.Wrapf(err, "error requeueing preempted task %s", t.Id))
+		return
+	}
+
+	if err := currentHost.ClearRunningAndSetLastTask(t); err != nil {
+		as.LoggedError(w, r, http.StatusInternalServerError,
+			errors.Wrapf(err, "error clearing running task %s for host %s", t.Id, currentHost.Id))
+		return
+	}
+
+	if err := currentHost.SetPreempted(t.Id, APIServerLockTitle); err != nil {
+		as.LoggedError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	grip.Info(message.Fields{
+		"message":   "requeued task from preempted host",
+		"task_id":   t.Id,
+		"execution": t.Execution,
+		"host":      currentHost.Id,
+		"distro":    currentHost.Distro.Id,
+	})
+	gimlet.WriteJSON(w, &apimodels.EndTaskResponse{ShouldExit: true})
+}
+
+// endRetriedTask restarts a task whose failure matched the project's
+// auto-retry policy. The host is free to run the retry, or any other task.
+func (as *APIServer) endRetriedTask(w http.ResponseWriter, r *http.Request, t *task.Task, currentHost *host.Host, details *apimodels.TaskEndDetail, reason string) {
+	if err := model.AutoRetryTask(t, reason, APIServerLockTitle, details); err != nil {
+		as.LoggedError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := currentHost.ClearRunningAndSetLastTask(t); err != nil {
+		as.LoggedError(w, r, http.StatusInternalServerError,
+			errors.Wrapf(err, "error clearing running task %s for host %s", t.Id, currentHost.Id))
+		return
+	}
+
+	grip.Info(message.Fields{
+		"message":   "automatically retried failed task",
+		"task_id":   t.Id,
+		"execution": t.Execution,
+		"retries":   t.AutoRetries,
+		"reason":    reason,
+		"project":   t.Project,
+		"host":      currentHost.Id,
+	})
+	gimlet.WriteJSON(w, &apimodels.EndTaskResponse{})
+}
+
 // assignNextAvailableTask gets the next task from the queue and sets the running task field
 // of currentHost.
 func assignNextAvailableTask(taskQueue *model.TaskQueue, currentHost *host.Host) (*task.Task, error) {
@@ -369,6 +478,11 @@ func (as *APIServer) NextTask(w http.ResponseWriter, r *http.Request) {
 		gimlet.WriteJSON(w, response)
 		return
 	}
+	if checkHostReprovision(h) {
+		response.ShouldExit = true
+		gimlet.WriteJSON(w, response)
+		return
+	}
 	if checkAgentRevision(h) {
 		details := &apimodels.GetNextTaskDetails{}
 		if err := util.ReadJSONInto(util.NewRequestReader(r), details); err != nil {
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model"
+	serviceutil "github.com/evergreen-ci/evergreen/service/testutil"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateProjectConfigWithProject(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	testConfig := testutil.TestConfig()
+	db.SetGlobalSessionProvider(testConfig.SessionFactory())
+	require.NoError(db.Clear(model.ProjectRefCollection))
+	require.NoError((&model.ProjectRef{Identifier: "proj", Enabled: true, Private: true}).Insert())
+	require.NoError((&model.ProjectRef{Identifier: "admin-proj", Enabled: true, Private: true, Admins: []string{serviceutil.MockUser.Id}}).Insert())
+	testConfig.SuperUsers = []string{"root"}
+
+	testApiServer, err := CreateTestServer(testConfig, nil)
+	require.NoError(err)
+	defer testApiServer.Close()
+
+	yml := []byte("tasks:\n- name: compile\nbuildvariants:\n- name: bv\n  run_on: [d]\n  tasks:\n  - name: compile\n")
+	validate := func(query string, authenticated bool) int {
+		request, err := http.NewRequest("POST", testApiServer.URL+"/api/validate"+query, bytes.NewBuffer(yml))
+		require.NoError(err)
+		if authenticated {
+			request.AddCookie(&http.Cookie{Name: evergreen.AuthTokenCookie, Value: "token"})
+		}
+		resp, err := http.DefaultClient.Do(request)
+		require.NoError(err)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	// anyone may validate a configuration on its own, but only users
+	// who can see a project's variables may check it against the project
+	assert.Equal(http.StatusOK, validate("", false))
+	assert.Equal(http.StatusUnauthorized, validate("?lint=true&project=proj", false))
+	assert.Equal(http.StatusForbidden, validate("?lint=true&project=proj", true))
+	assert.Equal(http.StatusOK, validate("?lint=true&project=admin-proj", true))
+	assert.Equal(http.StatusNotFound, validate("?lint=true&project=nonexistent", true))
+}
@@ -188,24 +188,28 @@ func (uis *UIServer) modifyProject(w http.ResponseWriter, r *http.Request) {
 	}
 
 	responseRef := struct {
-		Identifier         string               `json:"id"`
-		DisplayName        string               `json:"display_name"`
-		RemotePath         string               `json:"remote_path"`
-		BatchTime          int                  `json:"batch_time"`
-		DeactivatePrevious bool                 `json:"deactivate_previous"`
-		Branch             string               `json:"branch_name"`
-		ProjVarsMap        map[string]string    `json:"project_vars"`
-		ProjectAliases     []model.ProjectAlias `json:"project_aliases"`
-		DeleteAliases      []string             `json:"delete_aliases"`
-		PrivateVars        map[string]bool      `json:"private_vars"`
-		Enabled            bool                 `json:"enabled"`
-		Private            bool                 `json:"private"`
-		Owner              string               `json:"owner_name"`
-		Repo               string               `json:"repo_name"`
-		Admins             []string             `json:"admins"`
-		TracksPushEvents   bool                 `json:"tracks_push_events"`
-		PRTestingEnabled   bool                 `json:"pr_testing_enabled"`
-		PatchingDisabled   bool                 `json:"patching_disabled"`
+		Identifier         string                          `json:"id"`
+		DisplayName        string                          `json:"display_name"`
+		RemotePath         string                          `json:"remote_path"`
+		BatchTime          int                             `json:"batch_time"`
+		DeactivatePrevious bool                            `json:"deactivate_previous"`
+		Branch             string                          `json:"branch_name"`
+		ProjVarsMap        map[string]string               `json:"project_vars"`
+		ProjectAliases     []model.ProjectAlias            `json:"project_aliases"`
+		DeleteAliases      []string                        `json:"delete_aliases"`
+		PrivateVars        map[string]bool                 `json:"private_vars"`
+		Enabled            bool                            `json:"enabled"`
+		Private            bool                            `json:"private"`
+		Owner              string                          `json:"owner_name"`
+		Repo               string                          `json:"repo_name"`
+		Admins             []string                        `json:"admins"`
+		PeriodicBuilds     []model.PeriodicBuildDefinition `json:"periodic_builds"`
+		CommitQueue        model.CommitQueueParams         `json:"commit_queue"`
+		AutoRetry          model.AutoRetryParams           `json:"auto_retry"`
+		AbortSuperseded    bool                            `json:"abort_superseded_patches"`
+		TracksPushEvents   bool                            `json:"tracks_push_events"`
+		PRTestingEnabled   bool                            `json:"pr_testing_enabled"`
+		PatchingDisabled   bool                            `json:"patching_disabled"`
 		AlertConfig        map[string][]struct {
 			Provider string                 `json:"provider"`
 			Settings map[string]interface{} `json:"settings"`
@@ -246,6 +250,22 @@ func (uis *UIServer) modifyProject(w http.ResponseWriter, r *http.Request) {
 			errs = append(errs, fmt.Sprintf("task regex #%d is invalid", i+1))
 		}
 	}
+	periodicBuildIDs := map[string]bool{}
+	for _, definition := range responseRef.PeriodicBuilds {
+		if err = definition.Validate(); err != nil {
+			errs = append(errs, err.Error())
+		}
+		if periodicBuildIDs[definition.ID] {
+			errs = append(errs, fmt.Sprintf("periodic build id '%s' is used more than once", definition.ID))
+		}
+		periodicBuildIDs[definition.ID] = true
+	}
+	if err = responseRef.CommitQueue.Validate(); err != nil {
+		errs = append(errs, err.Error())
+	}
+	if err = responseRef.AutoRetry.Validate(); err != nil {
+		errs = append(errs, err.Error())
+	}
 	if len(errs) > 0 {
 		errMsg := ""
 		for _, err := range errs {
@@ -286,6 +306,24 @@ func (uis *UIServer) modifyProject(w http.ResponseWriter, r *http.Request) {
 	projectRef.PRTestingEnabled = responseRef.PRTestingEnabled
 	projectRef.PatchingDisabled = responseRef.PatchingDisabled
 	projectRef.NotifyOnBuildFailure = responseRef.NotifyOnBuildFailure
+	projectRef.CommitQueue = responseRef.CommitQueue
+	projectRef.AutoRetry = responseRef.AutoRetry
+	projectRef.AbortSupersededPatches = responseRef.AbortSuperseded
+
+	// periodic builds whose schedule hasn't changed stay scheduled when they
+	// were, and the others are scheduled again
+	existingBuilds := map[string]model.PeriodicBuildDefinition{}
+	for _, definition := range projectRef.PeriodicBuilds {
+		existingBuilds[definition.ID] = definition
+	}
+	projectRef.PeriodicBuilds = []model.PeriodicBuildDefinition{}
+	for _, definition := range responseRef.PeriodicBuilds {
+		definition.NextRunTime = time.Time{}
+		if existing, ok := existingBuilds[definition.ID]; ok && existing.Cron == definition.Cron {
+			definition.NextRunTime = existing.NextRunTime
+		}
+		projectRef.PeriodicBuilds = append(projectRef.PeriodicBuilds, definition)
+	}
 
 	projectRef.Alerts = map[string][]model.AlertConfig{}
 	for triggerId, alerts := range responseRef.AlertConfig {
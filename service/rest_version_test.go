@@ -481,7 +481,7 @@ func TestActivateVersion(t *testing.T) {
 			Message:             "some-message",
 			Status:              "success",
 			BuildIds:            []string{build.Id},
-			BuildVariants:       []version.BuildStatus{{"some-build-variant", true, time.Now().Add(-20 * time.Minute), "some-build-id"}}, // nolint
+			BuildVariants:       []version.BuildStatus{{BuildVariant: "some-build-variant", Activated: true, ActivateAt: time.Now().Add(-20 * time.Minute), BuildId: "some-build-id"}},
 			RevisionOrderNumber: rand.Int(),
 			Owner:               "some-owner",
 			Repo:                "some-repo",
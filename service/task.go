@@ -45,6 +45,8 @@ type uiTaskData struct {
 	TaskWaiting      string                  `json:"task_waiting"`
 	Activated        bool                    `json:"activated"`
 	Restarts         int                     `json:"restarts"`
+	RetryReason      string                  `json:"retry_reason"`
+	Flaky            bool                    `json:"flaky"`
 	Execution        int                     `json:"execution"`
 	TotalExecutions  int                     `json:"total_executions"`
 	StartTime        int64                   `json:"start_time"`
@@ -209,6 +211,8 @@ func (uis *UIServer) taskPage(w http.ResponseWriter, r *http.Request) {
 		BuildId:             projCtx.Task.BuildId,
 		Activated:           projCtx.Task.Activated,
 		Restarts:            projCtx.Task.Restarts,
+		RetryReason:         projCtx.Task.RetryReason,
+		Flaky:               projCtx.Task.IsFlaky(),
 		Execution:           projCtx.Task.Execution,
 		Requester:           projCtx.Task.Requester,
 		StartTime:           projCtx.Task.StartTime.UnixNano(),
@@ -0,0 +1,108 @@
+package spawn
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/pkg/errors"
+)
+
+// QuotaUsage is how much of one of a user's spawn host quotas the user has
+// used this month.
+type QuotaUsage struct {
+	Quota     evergreen.SpawnHostQuota
+	Hosts     int
+	HostHours float64
+	Spend     float64
+}
+
+// GetQuotaUsage returns the user's usage of each quota that limits the user
+// spawning a host from the distro, as of the given time. If no distro is
+// given, only the user's overall quota is returned.
+func GetQuotaUsage(user, distroID string, conf evergreen.SpawnHostConfig, now time.Time) ([]QuotaUsage, error) {
+	hosts, err := host.Find(host.ByUserWithUnterminatedStatus(user))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error finding hosts for %s", user)
+	}
+	usage, err := host.FindSpawnHostUsage(user, now)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	quotas := conf.QuotasFor(user, distroID)
+	result := make([]QuotaUsage, 0, len(quotas))
+	for _, q := range quotas {
+		u := QuotaUsage{Quota: q}
+		for _, h := range hosts {
+			if q.Distro == "" || h.Distro.Id == q.Distro {
+				u.Hosts++
+			}
+		}
+		for _, du := range usage {
+			if q.Distro == "" || du.Distro == q.Distro {
+				u.HostHours += du.HostHours
+				u.Spend += du.Cost
+			}
+		}
+		result = append(result, u)
+	}
+
+	return result, nil
+}
+
+// CheckCanSpawn returns an error if the user has reached the quota's limit
+// on hosts, host-hours or spend.
+func (u *QuotaUsage) CheckCanSpawn() error {
+	q := u.Quota
+	switch {
+	case q.MaxHosts > 0 && u.Hosts >= q.MaxHosts:
+		return errors.Errorf("User is already running the max allowed number of spawn hosts%s (%d of %d)",
+			u.distroSuffix(), u.Hosts, q.MaxHosts)
+	case q.MaxHostHoursPerMonth > 0 && u.HostHours >= float64(q.MaxHostHoursPerMonth):
+		return errors.Errorf("User has used all of this month's spawn host hours%s (%.1f of %d)",
+			u.distroSuffix(), u.HostHours, q.MaxHostHoursPerMonth)
+	case q.MaxSpendPerMonth > 0 && u.Spend >= q.MaxSpendPerMonth:
+		return errors.Errorf("User has spent all of this month's spawn host budget%s ($%.2f of $%.2f)",
+			u.distroSuffix(), u.Spend, q.MaxSpendPerMonth)
+	}
+	return nil
+}
+
+// FractionUsed returns the largest fraction of any of the quota's limits
+// that the user has used.
+func (u *QuotaUsage) FractionUsed() float64 {
+	q := u.Quota
+	used := 0.0
+	if q.MaxHosts > 0 {
+		used = maxFloat(used, float64(u.Hosts)/float64(q.MaxHosts))
+	}
+	if q.MaxHostHoursPerMonth > 0 {
+		used = maxFloat(used, u.HostHours/float64(q.MaxHostHoursPerMonth))
+	}
+	if q.MaxSpendPerMonth > 0 {
+		used = maxFloat(used, u.Spend/q.MaxSpendPerMonth)
+	}
+	return used
+}
+
+func (u *QuotaUsage) distroSuffix() string {
+	if u.Quota.Distro == "" {
+		return ""
+	}
+	return " from distro " + u.Quota.Distro
+}
+
+func maxFloat(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// MaxHostsForUser returns how many spawn hosts the user may have at once,
+// or 0 if there is no limit.
+func MaxHostsForUser(user string) int {
+	conf := getSpawnHostConfig()
+	return conf.QuotasFor(user, "")[0].MaxHosts
+}
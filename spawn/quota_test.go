@@ -0,0 +1,55 @@
+package spawn
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuotaUsageCheckCanSpawn(t *testing.T) {
+	assert := assert.New(t)
+
+	u := QuotaUsage{
+		Quota: evergreen.SpawnHostQuota{
+			MaxHosts:             2,
+			MaxHostHoursPerMonth: 100,
+			MaxSpendPerMonth:     50,
+		},
+		Hosts:     1,
+		HostHours: 40,
+		Spend:     10,
+	}
+	assert.NoError(u.CheckCanSpawn())
+	assert.Equal(0.5, u.FractionUsed())
+
+	u.Hosts = 2
+	assert.Error(u.CheckCanSpawn())
+	assert.Equal(1.0, u.FractionUsed())
+
+	u.Hosts = 0
+	u.HostHours = 100
+	assert.Error(u.CheckCanSpawn())
+
+	u.HostHours = 0
+	u.Spend = 45
+	assert.NoError(u.CheckCanSpawn())
+	assert.Equal(0.9, u.FractionUsed())
+	u.Spend = 50
+	assert.Error(u.CheckCanSpawn())
+
+	u.Quota.Distro = "d1"
+	assert.Contains(u.CheckCanSpawn().Error(), "from distro d1")
+}
+
+func TestQuotaUsageWithoutLimits(t *testing.T) {
+	assert := assert.New(t)
+
+	u := QuotaUsage{
+		Hosts:     100,
+		HostHours: 1000,
+		Spend:     1000,
+	}
+	assert.NoError(u.CheckCanSpawn())
+	assert.Zero(u.FractionUsed())
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/evergreen-ci/evergreen/cloud"
 	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/evergreen-ci/evergreen/subprocess"
 	"github.com/evergreen-ci/evergreen/util"
@@ -32,7 +33,6 @@ var passwordRegexps = []*regexp.Regexp{
 }
 
 const (
-	MaxPerUser                 = 3
 	DefaultExpiration          = 24 * time.Hour
 	MaxExpirationDurationHours = 24 * time.Hour * 7 // 7 days
 )
@@ -44,6 +44,11 @@ type Options struct {
 	PublicKey string
 	TaskId    string
 	Owner     *user.DBUser
+
+	// HomeVolume attaches the user's home volume to the host, creating one
+	// of HomeVolumeSize GiB if the user does not have one yet.
+	HomeVolume     bool
+	HomeVolumeSize int
 }
 
 // Validate returns an instance of BadOptionsErr if the SpawnOptions object contains invalid
@@ -54,6 +59,21 @@ func (so *Options) validate() error {
 		return errors.New("spawn options include nil user")
 	}
 
+	// a host spawned from a task runs on the task's distro unless another
+	// one is requested
+	if so.TaskId != "" {
+		t, err := task.FindOne(task.ById(so.TaskId))
+		if err != nil {
+			return errors.Wrapf(err, "Error finding task %v", so.TaskId)
+		}
+		if t == nil {
+			return errors.Errorf("Invalid spawn options: task %v does not exist", so.TaskId)
+		}
+		if so.Distro == "" {
+			so.Distro = t.DistroId
+		}
+	}
+
 	d, err := distro.FindOne(distro.ById(so.Distro))
 	if err != nil {
 		return errors.Errorf("Invalid spawn options: distro %v", so.Distro)
@@ -63,15 +83,21 @@ func (so *Options) validate() error {
 		return errors.Errorf("Invalid spawn options: spawning not allowed for distro  %v", so.Distro)
 	}
 
-	// if the user already has too many active spawned hosts, deny the request
-	activeSpawnedHosts, err := host.Find(host.ByUserWithRunningStatus(so.UserName))
-	if err != nil {
-		return errors.Wrap(err, "Error occurred finding user's current hosts")
+	if so.HomeVolume {
+		if err = so.validateHomeVolume(&d); err != nil {
+			return errors.Wrap(err, "Invalid spawn options")
+		}
 	}
 
-	if len(activeSpawnedHosts) >= MaxPerUser {
-		return errors.Errorf("User is already running the max allowed number of spawn hosts (%d of %d)",
-			len(activeSpawnedHosts), MaxPerUser)
+	// if the user has reached any of their quotas, deny the request
+	quotas, err := GetQuotaUsage(so.UserName, d.Id, getSpawnHostConfig(), time.Now())
+	if err != nil {
+		return errors.Wrap(err, "Error occurred finding user's current usage")
+	}
+	for _, q := range quotas {
+		if err = q.CheckCanSpawn(); err != nil {
+			return err
+		}
 	}
 
 	// validate public key
@@ -100,6 +126,55 @@ func (so *Options) validate() error {
 	return nil
 }
 
+// validateHomeVolume checks that the user's home volume can be attached to a
+// host from the distro. If the user does not have a home volume yet, it sets
+// the size of the one to create and checks that it fits in the user's quota.
+func (so *Options) validateHomeVolume(d *distro.Distro) error {
+	provider := d.Provider
+	if provider == evergreen.ProviderNameEc2Spot {
+		provider = evergreen.ProviderNameEc2OnDemand
+	}
+	if !cloud.SupportsVolumes(provider) {
+		return errors.Errorf("hosts from distro %s can't have a home volume", d.Id)
+	}
+	if d.IsWindows() {
+		return errors.New("home volumes can't be mounted on Windows hosts")
+	}
+
+	v, err := host.FindHomeVolume(so.Owner.Id)
+	if err != nil {
+		return errors.Wrap(err, "error finding home volume")
+	}
+	if v != nil {
+		if so.HomeVolumeSize != 0 && so.HomeVolumeSize != v.Size {
+			return errors.Errorf("home volume %s already exists with size %d GiB; resize it instead", v.ID, v.Size)
+		}
+		if v.Provider != provider {
+			return errors.Errorf("home volume %s belongs to provider %s, not %s", v.ID, v.Provider, provider)
+		}
+		if v.Status == host.VolumeAttached {
+			attachedTo, err := host.FindOneId(v.Host)
+			if err != nil {
+				return errors.Wrapf(err, "error finding host %s", v.Host)
+			}
+			if attachedTo != nil && attachedTo.Status != evergreen.HostTerminated {
+				return errors.Errorf("home volume %s is attached to host %s", v.ID, v.Host)
+			}
+		}
+		so.HomeVolumeSize = v.Size
+		return nil
+	}
+
+	conf := getSpawnHostConfig()
+	if so.HomeVolumeSize == 0 {
+		so.HomeVolumeSize = conf.HomeVolumeDefaultSizeGB
+	}
+	if so.HomeVolumeSize < 0 {
+		return errors.New("home volume size must be positive")
+	}
+	return errors.WithStack(checkVolumeQuota(so.Owner.Id, so.HomeVolumeSize, conf))
+}
+
 // CreateHost spawns a host with the given options.
 func CreateHost(so Options) (*host.Host, error) {
 	if err := so.validate(); err != nil {
@@ -121,9 +196,11 @@ func CreateHost(so Options) (*host.Host, error) {
 
 	// spawn the host
 	provisionOptions := &host.ProvisionOptions{
-		LoadCLI: true,
-		TaskId:  so.TaskId,
-		OwnerId: so.Owner.Id,
+		LoadCLI:        true,
+		TaskId:         so.TaskId,
+		OwnerId:        so.Owner.Id,
+		HomeVolume:     so.HomeVolume,
+		HomeVolumeSize: so.HomeVolumeSize,
 	}
 	expiration := DefaultExpiration
 	hostOptions := cloud.HostOptions{
@@ -228,9 +305,41 @@ func TerminateHost(ctx context.Context, host *host.Host, settings *evergreen.Set
 	return nil
 }
 
+// StopHost stops a running spawn host in its cloud provider.
+func StopHost(ctx context.Context, host *host.Host, settings *evergreen.Settings, user string) error {
+	if host.Status != evergreen.HostRunning {
+		return errors.Errorf("Host is %s, not running", host.Status)
+	}
+	cloudHost, err := cloud.GetCloudHost(ctx, host, settings)
+	if err != nil {
+		return err
+	}
+	return cloudHost.StopInstance(ctx, user)
+}
+
+// StartHost starts a stopped spawn host in its cloud provider.
+func StartHost(ctx context.Context, host *host.Host, settings *evergreen.Settings, user string) error {
+	if host.Status != evergreen.HostStopped {
+		return errors.Errorf("Host is %s, not stopped", host.Status)
+	}
+	cloudHost, err := cloud.GetCloudHost(ctx, host, settings)
+	if err != nil {
+		return err
+	}
+	return cloudHost.StartInstance(ctx, user)
+}
+
+// MakeExtendedHostExpiration returns the host's expiration time extended by
+// the given duration. A host's expiration time is paused while it is stopped,
+// so the time the host has left to run is counted from when it was stopped
+// rather than from now.
 func MakeExtendedHostExpiration(host *host.Host, extendBy time.Duration) (time.Time, error) {
 	newExp := host.ExpirationTime.Add(extendBy)
-	remainingDuration := newExp.Sub(time.Now()) //nolint
+	runningSince := time.Now()
+	if host.Status == evergreen.HostStopped && !util.IsZeroTime(host.StoppedTime) {
+		runningSince = host.StoppedTime
+	}
+	remainingDuration := newExp.Sub(runningSince) //nolint
 	if remainingDuration > MaxExpirationDurationHours {
 		return time.Time{}, errors.Errorf("Can not extend host '%s' expiration by '%s'. Maximum host duration is limited to %s", host.Id, extendBy.String(), MaxExpirationDurationHours.String())
 	}
@@ -258,3 +367,73 @@ func ValidateRDPPassword(password string) bool {
 
 	return matchedCategories >= 3
 }
+
+// ResizeVolume grows a volume to the given size in GiB, as long as the
+// user's volumes stay within the user's quota. The volume's file system is
+// grown the next time the volume is mounted on a host.
+func ResizeVolume(ctx context.Context, v *host.Volume, size int, settings *evergreen.Settings) error {
+	if v.Status == host.VolumeDeleted {
+		return errors.Errorf("volume %s has been deleted", v.ID)
+	}
+	if size <= v.Size {
+		return errors.Errorf("volumes can only grow; volume %s is already %d GiB", v.ID, v.Size)
+	}
+	if err := checkVolumeQuota(v.CreatedBy, size-v.Size, getSpawnHostConfig()); err != nil {
+		return errors.WithStack(err)
+	}
+
+	mgr, err := cloud.GetVolumeManager(ctx, v.Provider, settings)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err = mgr.ModifyVolume(ctx, v, size); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return errors.WithStack(v.SetSize(size))
+}
+
+// DeleteVolume deletes a volume that is not attached to a host.
+func DeleteVolume(ctx context.Context, v *host.Volume, settings *evergreen.Settings) error {
+	if v.Status != host.VolumeAvailable {
+		return errors.Errorf("volume %s is %s, not available", v.ID, v.Status)
+	}
+
+	mgr, err := cloud.GetVolumeManager(ctx, v.Provider, settings)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err = mgr.DeleteVolume(ctx, v); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return errors.WithStack(v.SetDeleted())
+}
+
+// checkVolumeQuota returns an error if adding the given number of GiB to the
+// user's volumes would put the user over their quota.
+func checkVolumeQuota(user string, additional int, conf evergreen.SpawnHostConfig) error {
+	used, err := host.TotalVolumeSize(user)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	quota := conf.HomeVolumeQuota(user)
+	if used+additional > quota {
+		return errors.Errorf("user %s has %d GiB of volumes; another %d GiB would exceed the quota of %d GiB",
+			user, used, additional, quota)
+	}
+	return nil
+}
+
+// getSpawnHostConfig returns the admin settings for spawn hosts, with
+// defaults filled in.
+func getSpawnHostConfig() evergreen.SpawnHostConfig {
+	conf := evergreen.SpawnHostConfig{}
+	if settings := evergreen.GetEnvironment().Settings(); settings != nil {
+		conf = settings.SpawnHost
+	}
+	grip.Warning(message.WrapError(conf.ValidateAndDefault(), message.Fields{
+		"message": "invalid spawn host settings",
+	}))
+	return conf
+}
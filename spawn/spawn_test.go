@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/stretchr/testify/assert"
 )
@@ -50,3 +51,32 @@ func TestMakeExtendedHostExpirationFailsBeyondOneWeek(t *testing.T) {
 	assert.Zero(expTime)
 	assert.Error(err, expTime.Format(time.RFC3339))
 }
+
+func TestMakeExtendedHostExpirationCountsFromStopTime(t *testing.T) {
+	assert := assert.New(t)
+
+	// the host was stopped two days ago with 12 hours left, so it still has
+	// 12 hours left to run and can be extended by up to six and a half days
+	stoppedTime := time.Now().Add(-48 * time.Hour)
+	h := host.Host{
+		Status:         evergreen.HostStopped,
+		StoppedTime:    stoppedTime,
+		ExpirationTime: stoppedTime.Add(12 * time.Hour),
+	}
+
+	expTime, err := MakeExtendedHostExpiration(&h, 6*24*time.Hour)
+	assert.NoError(err)
+	assert.Equal(h.ExpirationTime.Add(6*24*time.Hour), expTime)
+
+	expTime, err = MakeExtendedHostExpiration(&h, 7*24*time.Hour)
+	assert.Error(err)
+	assert.Zero(expTime)
+
+	// a running host with the same expiration time has already expired, so
+	// it can be extended by more
+	h.Status = evergreen.HostRunning
+	h.StoppedTime = time.Time{}
+	expTime, err = MakeExtendedHostExpiration(&h, 7*24*time.Hour)
+	assert.NoError(err)
+	assert.NotZero(expTime)
+}
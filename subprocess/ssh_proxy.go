@@ -0,0 +1,112 @@
+package subprocess
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// SSHProxy is a bastion host that SSH and SCP connections to remote hosts
+// are tunneled through. HostKey is the bastion's public host key, in the
+// "<type> <base64 key>" format used by known_hosts files; the connection is
+// refused if the bastion presents any other key.
+type SSHProxy struct {
+	Hostname string
+	Port     int
+	User     string
+	KeyPath  string
+	HostKey  string
+}
+
+// Options returns the options that make ssh and scp connect through the
+// proxy. They are meant to be passed ahead of any other options, since ssh
+// uses the first value it is given for each option.
+func (p *SSHProxy) Options() ([]string, error) {
+	if p.Hostname == "" {
+		return nil, errors.New("ssh proxy must have a hostname")
+	}
+	if p.HostKey == "" {
+		return nil, errors.Errorf("ssh proxy %s must have a pinned host key", p.Hostname)
+	}
+
+	knownHosts, err := p.writeKnownHosts()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	remote := p.Hostname
+	if p.User != "" {
+		remote = fmt.Sprintf("%s@%s", p.User, remote)
+	}
+
+	proxyCmd := []string{"ssh"}
+	if p.KeyPath != "" {
+		proxyCmd = append(proxyCmd, "-i", shellQuote(p.KeyPath))
+	}
+	if p.Port != 0 {
+		proxyCmd = append(proxyCmd, "-p", fmt.Sprint(p.Port))
+	}
+	proxyCmd = append(proxyCmd,
+		"-o", "BatchMode=yes",
+		"-o", "StrictHostKeyChecking=yes",
+		"-o", "GlobalKnownHostsFile=/dev/null",
+		"-o", "UserKnownHostsFile="+shellQuote(knownHosts),
+		"-W", "%h:%p",
+		shellQuote(remote),
+	)
+
+	return []string{"-o", "ProxyCommand=" + strings.Join(proxyCmd, " ")}, nil
+}
+
+// knownHostsEntry returns the known_hosts line that pins the proxy's host key.
+func (p *SSHProxy) knownHostsEntry() string {
+	pattern := p.Hostname
+	if p.Port != 0 && p.Port != 22 {
+		pattern = fmt.Sprintf("[%s]:%d", p.Hostname, p.Port)
+	}
+	return fmt.Sprintf("%s %s\n", pattern, strings.TrimSpace(p.HostKey))
+}
+
+// writeKnownHosts writes a known_hosts file containing only the proxy's host
+// key and returns its path. The file is named after its contents, so proxies
+// with the same host and key share it.
+func (p *SSHProxy) writeKnownHosts() (string, error) {
+	entry := p.knownHostsEntry()
+	path := filepath.Join(os.TempDir(),
+		fmt.Sprintf("evergreen-ssh-proxy-%x", sha256.Sum256([]byte(entry))))
+
+	if content, err := ioutil.ReadFile(path); err == nil && string(content) == entry {
+		return path, nil
+	}
+
+	file, err := ioutil.TempFile(os.TempDir(), "evergreen-ssh-proxy")
+	if err != nil {
+		return "", errors.Wrap(err, "error creating known hosts file")
+	}
+	defer os.Remove(file.Name())
+
+	if _, err = file.WriteString(entry); err != nil {
+		file.Close()
+		return "", errors.Wrap(err, "error writing known hosts file")
+	}
+	if err = file.Close(); err != nil {
+		return "", errors.Wrap(err, "error closing known hosts file")
+	}
+
+	// renaming the complete file into place keeps concurrent readers from
+	// seeing a partially written one
+	if err = os.Rename(file.Name(), path); err != nil {
+		return "", errors.Wrap(err, "error moving known hosts file into place")
+	}
+
+	return path, nil
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
@@ -0,0 +1,261 @@
+package subprocess
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// sshServerStandIn is a minimal SSH server that listens on localhost and
+// passes each channel that is opened to it to handle.
+type sshServerStandIn struct {
+	listener net.Listener
+	config   *ssh.ServerConfig
+	hostKey  ssh.PublicKey
+	handle   func(ssh.NewChannel)
+}
+
+func newSSHServerStandIn(t *testing.T, handle func(ssh.NewChannel)) *sshServerStandIn {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(key)
+	require.NoError(t, err)
+
+	config := &ssh.ServerConfig{NoClientAuth: true}
+	config.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &sshServerStandIn{
+		listener: listener,
+		config:   config,
+		hostKey:  signer.PublicKey(),
+		handle:   handle,
+	}
+	go s.serve()
+
+	return s
+}
+
+func (s *sshServerStandIn) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			_, chans, reqs, err := ssh.NewServerConn(conn, s.config)
+			if err != nil {
+				conn.Close()
+				return
+			}
+			go ssh.DiscardRequests(reqs)
+			for ch := range chans {
+				go s.handle(ch)
+			}
+		}()
+	}
+}
+
+func (s *sshServerStandIn) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *sshServerStandIn) knownHostsKey() string {
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(s.hostKey)))
+}
+
+func (s *sshServerStandIn) Close() {
+	s.listener.Close()
+}
+
+// handleExec answers exec requests by echoing the command it was asked to run.
+func handleExec(newCh ssh.NewChannel) {
+	if newCh.ChannelType() != "session" {
+		_ = newCh.Reject(ssh.UnknownChannelType, "only sessions are supported")
+		return
+	}
+	ch, reqs, err := newCh.Accept()
+	if err != nil {
+		return
+	}
+	defer ch.Close()
+
+	for req := range reqs {
+		if req.Type != "exec" {
+			if req.WantReply {
+				_ = req.Reply(false, nil)
+			}
+			continue
+		}
+
+		payload := struct{ Command string }{}
+		if err = ssh.Unmarshal(req.Payload, &payload); err != nil {
+			_ = req.Reply(false, nil)
+			return
+		}
+		_ = req.Reply(true, nil)
+		fmt.Fprintf(ch, "ran: %s\n", payload.Command)
+		_, _ = ch.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{0}))
+		return
+	}
+}
+
+// handleForward acts as a bastion, forwarding connections to the address
+// the client asks for.
+func handleForward(newCh ssh.NewChannel) {
+	if newCh.ChannelType() != "direct-tcpip" {
+		_ = newCh.Reject(ssh.UnknownChannelType, "only forwarding is supported")
+		return
+	}
+
+	payload := struct {
+		Host       string
+		Port       uint32
+		OriginHost string
+		OriginPort uint32
+	}{}
+	if err := ssh.Unmarshal(newCh.ExtraData(), &payload); err != nil {
+		_ = newCh.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+
+	conn, err := net.Dial("tcp", net.JoinHostPort(payload.Host, strconv.Itoa(int(payload.Port))))
+	if err != nil {
+		_ = newCh.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+	ch, reqs, err := newCh.Accept()
+	if err != nil {
+		conn.Close()
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+
+	go func() {
+		_, _ = io.Copy(ch, conn)
+		ch.Close()
+	}()
+	go func() {
+		_, _ = io.Copy(conn, ch)
+		conn.Close()
+	}()
+}
+
+func TestSSHProxyOptions(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, err := (&SSHProxy{HostKey: "ssh-ed25519 AAAA"}).Options()
+	assert.Error(err)
+	_, err = (&SSHProxy{Hostname: "bastion"}).Options()
+	assert.Error(err)
+
+	proxy := &SSHProxy{
+		Hostname: "bastion.example.com",
+		Port:     2222,
+		User:     "jump",
+		KeyPath:  "/keys/bastion key",
+		HostKey:  "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIB\n",
+	}
+	opts, err := proxy.Options()
+	require.NoError(err)
+	require.Len(opts, 2)
+	assert.Equal("-o", opts[0])
+	assert.True(strings.HasPrefix(opts[1], "ProxyCommand=ssh -i '/keys/bastion key' -p 2222 "))
+	assert.Contains(opts[1], "-o StrictHostKeyChecking=yes")
+	assert.True(strings.HasSuffix(opts[1], "-W %h:%p 'jump@bastion.example.com'"))
+
+	prefix := "UserKnownHostsFile='"
+	start := strings.Index(opts[1], prefix) + len(prefix)
+	path := opts[1][start : start+strings.Index(opts[1][start:], "'")]
+	content, err := ioutil.ReadFile(path)
+	require.NoError(err)
+	assert.Equal("[bastion.example.com]:2222 ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIB\n", string(content))
+
+	// the same proxy reuses the known hosts file
+	again, err := proxy.Options()
+	require.NoError(err)
+	assert.Equal(opts, again)
+
+	proxy.Port = 22
+	assert.Equal("bastion.example.com ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIB\n", proxy.knownHostsEntry())
+}
+
+func TestCommandsThroughSSHProxy(t *testing.T) {
+	if _, err := exec.LookPath("ssh"); err != nil {
+		t.Skip("ssh is not installed")
+	}
+
+	target := newSSHServerStandIn(t, handleExec)
+	defer target.Close()
+	bastion := newSSHServerStandIn(t, handleForward)
+	defer bastion.Close()
+
+	run := func(proxy *SSHProxy) (string, error) {
+		proxyOpts, err := proxy.Options()
+		if err != nil {
+			return "", err
+		}
+		opts := append(proxyOpts,
+			"-F", "/dev/null",
+			"-p", strconv.Itoa(target.port()),
+			"-o", "BatchMode=yes",
+			"-o", "StrictHostKeyChecking=no",
+			"-o", "UserKnownHostsFile=/dev/null",
+			"-o", "LogLevel=ERROR",
+		)
+
+		out := &bytes.Buffer{}
+		cmd := NewRemoteCommand("echo hi", "127.0.0.1", "", nil, false, opts, false)
+		if err = cmd.SetOutput(OutputOptions{Output: out, SendErrorToOutput: true}); err != nil {
+			return "", err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		err = cmd.Run(ctx)
+		return out.String(), err
+	}
+
+	t.Run("ConnectsThroughPinnedProxy", func(t *testing.T) {
+		out, err := run(&SSHProxy{Hostname: "127.0.0.1", Port: bastion.port(), HostKey: bastion.knownHostsKey()})
+		require.NoError(t, err, out)
+		assert.Contains(t, out, "ran: echo hi")
+	})
+
+	t.Run("RefusesProxyWithWrongHostKey", func(t *testing.T) {
+		out, err := run(&SSHProxy{Hostname: "127.0.0.1", Port: bastion.port(), HostKey: target.knownHostsKey()})
+		assert.Error(t, err)
+		assert.NotContains(t, out, "ran: echo hi")
+	})
+
+	t.Run("PassesProxyToSCP", func(t *testing.T) {
+		proxy := &SSHProxy{Hostname: "127.0.0.1", Port: bastion.port(), HostKey: bastion.knownHostsKey()}
+		opts, err := proxy.Options()
+		require.NoError(t, err)
+
+		cmd := NewSCPCommand("/dev/null", "/tmp/dest", "127.0.0.1", "", opts).(*scpCommand)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_ = cmd.Start(ctx)
+		require.NotNil(t, cmd.Cmd)
+		assert.Equal(t, opts, cmd.Cmd.Args[1:len(opts)+1])
+	})
+}
@@ -88,6 +88,12 @@ func MockConfig() *evergreen.Settings {
 		HostInit: evergreen.HostInitConfig{
 			SSHTimeoutSeconds: 10,
 		},
+		HostQuarantine: evergreen.HostQuarantineConfig{
+			ConsecutiveSystemFailures:   4,
+			ConsecutiveSetupFailures:    2,
+			DistinctProjectFailures:     3,
+			ProjectFailureWindowMinutes: 45,
+		},
 		IsNonProd: true,
 		Jira: evergreen.JiraConfig{
 			Host:           "host",
@@ -164,6 +170,7 @@ func MockConfig() *evergreen.Settings {
 			EmailNotificationsDisabled:   true,
 			WebhookNotificationsDisabled: true,
 			GithubStatusAPIDisabled:      true,
+			CommitQueueDisabled:          true,
 		},
 		Slack: evergreen.SlackConfig{
 			Options: &send.SlackOptions{
@@ -174,6 +181,21 @@ func MockConfig() *evergreen.Settings {
 			Token: "token",
 			Level: "info",
 		},
+		SpawnHost: evergreen.SpawnHostConfig{
+			HomeVolumeDefaultSizeGB: 32,
+			HomeVolumeQuotaGB:       128,
+			UserHomeVolumeQuotas: []evergreen.UserVolumeQuota{
+				{User: "user", QuotaGB: 512},
+			},
+			DefaultQuota: evergreen.SpawnHostQuota{
+				MaxHosts:             2,
+				MaxHostHoursPerMonth: 200,
+			},
+			Quotas: []evergreen.SpawnHostQuota{
+				{Distro: "distro", MaxHosts: 1, MaxSpendPerMonth: 50},
+			},
+			QuotaWarningPercent: 75,
+		},
 		Splunk: send.SplunkConnectionInfo{
 			ServerURL: "server",
 			Token:     "token",
@@ -228,6 +228,88 @@ func GetGithubMergeBaseRevision(ctx context.Context, oauthToken, repoOwner, repo
 	return *compare.MergeBaseCommit.SHA, nil
 }
 
+// GetGithubCompareSummaries returns summaries of the files that change
+// between the merge base of baseRevision and headRevision, and headRevision,
+// without downloading the diff. GitHub lists at most 300 files.
+func GetGithubCompareSummaries(ctx context.Context, oauthToken, repoOwner, repo, baseRevision, headRevision string) ([]patch.Summary, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	httpClient, err := getGithubClient(oauthToken)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't fetch data from github")
+	}
+	defer util.PutHTTPClient(httpClient)
+	client := github.NewClient(httpClient)
+
+	compare, resp, err := client.Repositories.CompareCommits(ctx,
+		repoOwner, repo, baseRevision, headRevision)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if err != nil {
+		errMsg := fmt.Sprintf("error comparing '%s/%s'@%s..%s: %v", repoOwner, repo, baseRevision, headRevision, err)
+		grip.Error(errMsg)
+		return nil, APIResponseError{errMsg}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, ResponseReadError{err.Error()}
+		}
+		requestError := APIRequestError{}
+		if err = json.Unmarshal(respBody, &requestError); err != nil {
+			return nil, APIRequestError{Message: string(respBody)}
+		}
+		return nil, requestError
+	}
+
+	if compare == nil {
+		return nil, APIRequestError{Message: "missing data from github compare response"}
+	}
+
+	summaries := []patch.Summary{}
+	for _, file := range compare.Files {
+		summaries = append(summaries, patch.Summary{
+			Name:      file.GetFilename(),
+			Additions: file.GetAdditions(),
+			Deletions: file.GetDeletions(),
+		})
+	}
+	return summaries, nil
+}
+
+// GetGithubPullRequestLabels returns the names of the labels on the pull
+// request.
+func GetGithubPullRequestLabels(ctx context.Context, oauthToken, repoOwner, repo string, prNumber int) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	httpClient, err := getGithubClient(oauthToken)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't fetch data from github")
+	}
+	defer util.PutHTTPClient(httpClient)
+	client := github.NewClient(httpClient)
+
+	labels, resp, err := client.Issues.ListLabelsByIssue(ctx, repoOwner, repo, prNumber, nil)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if err != nil {
+		errMsg := fmt.Sprintf("error listing labels of '%s/%s' pull request #%d: %v", repoOwner, repo, prNumber, err)
+		grip.Error(errMsg)
+		return nil, APIResponseError{errMsg}
+	}
+
+	names := []string{}
+	for _, label := range labels {
+		names = append(names, label.GetName())
+	}
+	return names, nil
+}
+
 func GetCommitEvent(ctx context.Context, oauthToken, repoOwner, repo, githash string) (*github.RepositoryCommit, error) {
 	httpClient, err := getGithubClient(oauthToken)
 	if err != nil {
@@ -0,0 +1,195 @@
+package thirdparty
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/google/go-github/github"
+	"github.com/pkg/errors"
+)
+
+const (
+	githubAcceptDiff = "application/vnd.github.v3.diff"
+
+	githubReviewApproved         = "APPROVED"
+	githubReviewChangesRequested = "CHANGES_REQUESTED"
+	githubReviewDismissed        = "DISMISSED"
+)
+
+// GithubClient is the part of the GitHub API that the commit queue uses to
+// test and merge changes. It's an interface so that tests can use
+// MockGithubClient instead of GitHub.
+type GithubClient interface {
+	// GetPullRequest returns the pull request with the given number.
+	GetPullRequest(ctx context.Context, owner, repo string, number int) (*github.PullRequest, error)
+	// ListPullRequestReviews returns the reviews of the pull request with
+	// the given number, oldest first.
+	ListPullRequestReviews(ctx context.Context, owner, repo string, number int) ([]*github.PullRequestReview, error)
+	// GetPullRequestDiff returns the diff of the pull request with the
+	// given number from its merge base.
+	GetPullRequestDiff(ctx context.Context, owner, repo string, number int) (string, error)
+	// GetCommitHash returns the hash of the commit that the given branch,
+	// tag or commit refers to.
+	GetCommitHash(ctx context.Context, owner, repo, ref string) (string, error)
+	// GetCompareDiff returns the diff of head from its merge base with base.
+	GetCompareDiff(ctx context.Context, owner, repo, base, head string) (string, error)
+	// MergePullRequest merges the pull request with the given number, as
+	// long as its head is still the given commit.
+	MergePullRequest(ctx context.Context, owner, repo string, number int, sha, commitMessage, mergeMethod string) error
+	// MergeCommit merges head into the base branch.
+	MergeCommit(ctx context.Context, owner, repo, base, head, commitMessage string) error
+}
+
+type githubClientImpl struct {
+	token string
+}
+
+// NewGithubClient returns a GithubClient that makes requests to GitHub with
+// the given OAuth token.
+func NewGithubClient(token string) GithubClient {
+	return &githubClientImpl{token: token}
+}
+
+// withClient calls f with a GitHub client that retries failed requests.
+func (c *githubClientImpl) withClient(f func(*github.Client) error) error {
+	httpClient, err := getGithubClient(c.token)
+	if err != nil {
+		return errors.Wrap(err, "can't fetch data from github")
+	}
+	defer util.PutHTTPClient(httpClient)
+
+	return f(github.NewClient(httpClient))
+}
+
+func (c *githubClientImpl) GetPullRequest(ctx context.Context, owner, repo string, number int) (*github.PullRequest, error) {
+	var pr *github.PullRequest
+	err := c.withClient(func(client *github.Client) error {
+		var err error
+		pr, _, err = client.PullRequests.Get(ctx, owner, repo, number)
+		return errors.Wrapf(err, "error getting pull request %d of '%s/%s'", number, owner, repo)
+	})
+	return pr, err
+}
+
+func (c *githubClientImpl) ListPullRequestReviews(ctx context.Context, owner, repo string, number int) ([]*github.PullRequestReview, error) {
+	reviews := []*github.PullRequestReview{}
+	err := c.withClient(func(client *github.Client) error {
+		opts := &github.ListOptions{PerPage: 100}
+		for {
+			page, resp, err := client.PullRequests.ListReviews(ctx, owner, repo, number, opts)
+			if err != nil {
+				return errors.Wrapf(err, "error listing reviews of pull request %d of '%s/%s'", number, owner, repo)
+			}
+			reviews = append(reviews, page...)
+			if resp.NextPage == 0 {
+				return nil
+			}
+			opts.Page = resp.NextPage
+		}
+	})
+	return reviews, err
+}
+
+func (c *githubClientImpl) GetPullRequestDiff(ctx context.Context, owner, repo string, number int) (string, error) {
+	var diff string
+	err := c.withClient(func(client *github.Client) error {
+		var err error
+		diff, _, err = client.PullRequests.GetRaw(ctx, owner, repo, number, github.RawOptions{Type: github.Diff})
+		return errors.Wrapf(err, "error getting diff of pull request %d of '%s/%s'", number, owner, repo)
+	})
+	return diff, err
+}
+
+func (c *githubClientImpl) GetCommitHash(ctx context.Context, owner, repo, ref string) (string, error) {
+	var sha string
+	err := c.withClient(func(client *github.Client) error {
+		var err error
+		sha, _, err = client.Repositories.GetCommitSHA1(ctx, owner, repo, ref, "")
+		return errors.Wrapf(err, "error getting commit of '%s' in '%s/%s'", ref, owner, repo)
+	})
+	return sha, err
+}
+
+func (c *githubClientImpl) GetCompareDiff(ctx context.Context, owner, repo, base, head string) (string, error) {
+	var diff string
+	err := c.withClient(func(client *github.Client) error {
+		// go-github can't get comparisons as diffs, so the request is
+		// made by hand
+		req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("repos/%s/%s/compare/%s...%s", owner, repo, base, head), nil)
+		if err != nil {
+			return errors.Wrap(err, "error creating github request")
+		}
+		req.Header.Set("Accept", githubAcceptDiff)
+		buf := &bytes.Buffer{}
+		if _, err = client.Do(ctx, req, buf); err != nil {
+			return errors.Wrapf(err, "error comparing '%s' to '%s' in '%s/%s'", head, base, owner, repo)
+		}
+		diff = buf.String()
+		return nil
+	})
+	return diff, err
+}
+
+func (c *githubClientImpl) MergePullRequest(ctx context.Context, owner, repo string, number int, sha, commitMessage, mergeMethod string) error {
+	return c.withClient(func(client *github.Client) error {
+		result, _, err := client.PullRequests.Merge(ctx, owner, repo, number, commitMessage, &github.PullRequestOptions{
+			SHA:         sha,
+			MergeMethod: mergeMethod,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "error merging pull request %d of '%s/%s'", number, owner, repo)
+		}
+		if !result.GetMerged() {
+			return errors.Errorf("pull request %d of '%s/%s' was not merged: %s", number, owner, repo, result.GetMessage())
+		}
+		return nil
+	})
+}
+
+func (c *githubClientImpl) MergeCommit(ctx context.Context, owner, repo, base, head, commitMessage string) error {
+	return c.withClient(func(client *github.Client) error {
+		_, resp, err := client.Repositories.Merge(ctx, owner, repo, &github.RepositoryMergeRequest{
+			Base:          github.String(base),
+			Head:          github.String(head),
+			CommitMessage: github.String(commitMessage),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "error merging '%s' into '%s' in '%s/%s'", head, base, owner, repo)
+		}
+		// GitHub responds with no content if the base already has the head
+		if resp.StatusCode == http.StatusNoContent {
+			return errors.Errorf("'%s' is already merged into '%s' in '%s/%s'", head, base, owner, repo)
+		}
+		return nil
+	})
+}
+
+// PullRequestApproved returns true if a reviewer's latest review of the pull
+// request approves its head commit, and no reviewer's latest review requests
+// changes. Comments don't change a reviewer's verdict, and approvals of
+// earlier commits don't approve the head.
+func PullRequestApproved(reviews []*github.PullRequestReview, headSHA string) bool {
+	verdicts := map[int]*github.PullRequestReview{}
+	for _, review := range reviews {
+		switch review.GetState() {
+		case githubReviewApproved, githubReviewChangesRequested, githubReviewDismissed:
+			verdicts[review.GetUser().GetID()] = review
+		}
+	}
+
+	approved := false
+	for _, verdict := range verdicts {
+		switch verdict.GetState() {
+		case githubReviewChangesRequested:
+			return false
+		case githubReviewApproved:
+			if verdict.GetCommitID() == headSHA {
+				approved = true
+			}
+		}
+	}
+	return approved
+}
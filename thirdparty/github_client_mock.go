@@ -0,0 +1,105 @@
+package thirdparty
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/google/go-github/github"
+	"github.com/pkg/errors"
+)
+
+// MockGithubClient is a GithubClient for tests. Pull requests, refs and
+// diffs are looked up in its maps, and merges are recorded rather than
+// made.
+type MockGithubClient struct {
+	// PullRequests and PullRequestDiffs are keyed by pull request number.
+	PullRequests       map[int]*github.PullRequest
+	PullRequestReviews map[int][]*github.PullRequestReview
+	PullRequestDiffs   map[int]string
+	// CommitHashes maps refs to the commits they refer to.
+	CommitHashes map[string]string
+	// CompareDiffs are keyed by "base...head".
+	CompareDiffs map[string]string
+
+	// FailMerges makes merges fail.
+	FailMerges bool
+	// MergedPullRequests are the numbers of the pull requests that have been
+	// merged, and MergedCommits are the heads of the merged commits.
+	MergedPullRequests []int
+	MergedCommits      []string
+
+	mu sync.Mutex
+}
+
+func (c *MockGithubClient) GetPullRequest(_ context.Context, owner, repo string, number int) (*github.PullRequest, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	pr, ok := c.PullRequests[number]
+	if !ok {
+		return nil, errors.Errorf("pull request %d of '%s/%s' does not exist", number, owner, repo)
+	}
+	return pr, nil
+}
+
+func (c *MockGithubClient) ListPullRequestReviews(_ context.Context, owner, repo string, number int) ([]*github.PullRequestReview, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.PullRequests[number]; !ok {
+		return nil, errors.Errorf("pull request %d of '%s/%s' does not exist", number, owner, repo)
+	}
+	return c.PullRequestReviews[number], nil
+}
+
+func (c *MockGithubClient) GetPullRequestDiff(_ context.Context, owner, repo string, number int) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	diff, ok := c.PullRequestDiffs[number]
+	if !ok {
+		return "", errors.Errorf("pull request %d of '%s/%s' does not exist", number, owner, repo)
+	}
+	return diff, nil
+}
+
+func (c *MockGithubClient) GetCommitHash(_ context.Context, owner, repo, ref string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	sha, ok := c.CommitHashes[ref]
+	if !ok {
+		return "", errors.Errorf("'%s' does not exist in '%s/%s'", ref, owner, repo)
+	}
+	return sha, nil
+}
+
+func (c *MockGithubClient) GetCompareDiff(_ context.Context, owner, repo, base, head string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	diff, ok := c.CompareDiffs[fmt.Sprintf("%s...%s", base, head)]
+	if !ok {
+		return "", errors.Errorf("can't compare '%s' to '%s' in '%s/%s'", head, base, owner, repo)
+	}
+	return diff, nil
+}
+
+func (c *MockGithubClient) MergePullRequest(_ context.Context, owner, repo string, number int, sha, _, _ string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.FailMerges {
+		return errors.Errorf("pull request %d of '%s/%s' was not merged", number, owner, repo)
+	}
+	if pr, ok := c.PullRequests[number]; ok && sha != "" && pr.GetHead().GetSHA() != sha {
+		return errors.Errorf("head of pull request %d of '%s/%s' is not '%s'", number, owner, repo, sha)
+	}
+	c.MergedPullRequests = append(c.MergedPullRequests, number)
+	return nil
+}
+
+func (c *MockGithubClient) MergeCommit(_ context.Context, owner, repo, base, head, _ string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.FailMerges {
+		return errors.Errorf("'%s' was not merged into '%s' in '%s/%s'", head, base, owner, repo)
+	}
+	c.MergedCommits = append(c.MergedCommits, head)
+	return nil
+}
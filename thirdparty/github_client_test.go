@@ -0,0 +1,38 @@
+package thirdparty
+
+import (
+	"testing"
+
+	"github.com/google/go-github/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPullRequestApproved(t *testing.T) {
+	assert := assert.New(t)
+
+	const head = "abcdef"
+	review := func(reviewer int, state string) *github.PullRequestReview {
+		return &github.PullRequestReview{
+			User:     &github.User{ID: github.Int(reviewer)},
+			State:    github.String(state),
+			CommitID: github.String(head),
+		}
+	}
+
+	assert.False(PullRequestApproved(nil, head))
+	assert.False(PullRequestApproved([]*github.PullRequestReview{review(1, "COMMENTED")}, head))
+	assert.True(PullRequestApproved([]*github.PullRequestReview{review(1, "APPROVED"), review(1, "COMMENTED")}, head))
+
+	// a reviewer's latest verdict counts
+	assert.True(PullRequestApproved([]*github.PullRequestReview{review(1, "CHANGES_REQUESTED"), review(1, "APPROVED")}, head))
+	assert.False(PullRequestApproved([]*github.PullRequestReview{review(1, "APPROVED"), review(1, "DISMISSED")}, head))
+
+	// any reviewer can block the pull request
+	assert.False(PullRequestApproved([]*github.PullRequestReview{review(1, "APPROVED"), review(2, "CHANGES_REQUESTED")}, head))
+
+	// approving an earlier commit doesn't approve the head
+	stale := review(1, "APPROVED")
+	stale.CommitID = github.String("012345")
+	assert.False(PullRequestApproved([]*github.PullRequestReview{stale}, head))
+	assert.True(PullRequestApproved([]*github.PullRequestReview{stale, review(2, "APPROVED")}, head))
+}
@@ -0,0 +1,492 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/commitqueue"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/google/go-github/github"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/dependency"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/mongodb/grip/send"
+	"github.com/mongodb/grip/sometimes"
+	"github.com/pkg/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	commitQueueJobName = "commit-queue"
+
+	commitQueueStatusContext = "evergreen/commit-queue"
+
+	commitQueueMergedSubject  = "Commit queue: merged %s"
+	commitQueueRemovedSubject = "Commit queue: removed %s"
+	commitQueueRemovedBody    = "%s was removed from the commit queue of project '%s' because %s."
+)
+
+func init() {
+	registry.AddJobType(commitQueueJobName, func() amboy.Job { return makeCommitQueueJob() })
+}
+
+type commitQueueJob struct {
+	QueueID  string `bson:"queue_id" json:"queue_id" yaml:"queue_id"`
+	job.Base `bson:"job_base" json:"job_base" yaml:"job_base"`
+
+	env          evergreen.Environment
+	githubClient thirdparty.GithubClient
+	sender       send.Sender
+	statusSender send.Sender
+}
+
+func makeCommitQueueJob() *commitQueueJob {
+	j := &commitQueueJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    commitQueueJobName,
+				Version: 0,
+			},
+		},
+	}
+	j.SetDependency(dependency.NewAlways())
+	return j
+}
+
+// NewCommitQueueJob creates a job that moves a project's commit queue
+// along. If the change at the head of the queue has been tested, it's
+// merged if its patch passed, and removed from the queue either way. Then
+// a patch is created to test the change at the head of the queue on the tip
+// of the project's branch, unless one is already running.
+func NewCommitQueueJob(env evergreen.Environment, queueID, id string) amboy.Job {
+	j := makeCommitQueueJob()
+	j.env = env
+	j.QueueID = queueID
+	j.SetID(fmt.Sprintf("%s:%s_%s", commitQueueJobName, queueID, id))
+	return j
+}
+
+func (j *commitQueueJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+
+	flags, err := evergreen.GetServiceFlags()
+	if err != nil {
+		j.AddError(errors.Wrap(err, "error retrieving admin settings"))
+		return
+	}
+	if flags.CommitQueueDisabled {
+		grip.InfoWhen(sometimes.Percent(evergreen.DegradedLoggingPercent), message.Fields{
+			"job":     commitQueueJobName,
+			"message": "commit queue is disabled, not processing queue",
+			"queue":   j.QueueID,
+		})
+		return
+	}
+
+	ref, err := model.FindOneProjectRef(j.QueueID)
+	if err != nil {
+		j.AddError(errors.Wrapf(err, "error finding project '%s'", j.QueueID))
+		return
+	}
+	if ref == nil || !ref.Enabled || !ref.CommitQueue.Enabled {
+		return
+	}
+
+	cq, err := commitqueue.FindOneId(j.QueueID)
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+	if cq == nil {
+		return
+	}
+	item, ok := cq.Next()
+	if !ok {
+		return
+	}
+
+	if j.githubClient == nil {
+		var token string
+		token, err = j.env.Settings().GetGithubOauthToken()
+		if err != nil {
+			j.AddError(err)
+			return
+		}
+		j.githubClient = thirdparty.NewGithubClient(token)
+	}
+
+	if item.PatchID != "" {
+		var finished bool
+		finished, err = j.finishItem(ctx, ref, cq, item)
+		if err != nil {
+			j.AddError(err)
+			return
+		}
+		if !finished {
+			return
+		}
+		if item, ok = cq.Next(); !ok {
+			return
+		}
+	}
+
+	j.AddError(j.startItem(ctx, ref, cq, item))
+}
+
+// startItem creates a patch that tests the item on the tip of the
+// project's branch with the commit queue's alias. Items that can't be
+// tested are removed from the queue. The item is claimed before its patch is
+// created, so if another job has started it since the queue was read,
+// nothing is done.
+func (j *commitQueueJob) startItem(ctx context.Context, ref *model.ProjectRef, cq *commitqueue.CommitQueue, item commitqueue.CommitQueueItem) error {
+	head, diff, description, err := j.getChange(ctx, ref, item)
+	if err != nil {
+		return j.dequeue(cq, item, fmt.Sprintf("it could not be tested: %s", err))
+	}
+	if strings.TrimSpace(diff) == "" {
+		return j.dequeue(cq, item, "it has no changes to merge")
+	}
+
+	base, err := j.githubClient.GetCommitHash(ctx, ref.Owner, ref.Repo, ref.Branch)
+	if err != nil {
+		return errors.Wrapf(err, "error finding the tip of branch '%s' of project '%s'", ref.Branch, ref.Identifier)
+	}
+
+	intent, err := patch.NewCliIntent(item.Author, ref.Identifier, base, "", diff, description, true, nil, nil,
+		ref.CommitQueue.PatchAlias, "", false)
+	if err != nil {
+		return j.dequeue(cq, item, fmt.Sprintf("it could not be tested: %s", err))
+	}
+
+	patchID := bson.NewObjectId()
+	claimed, err := cq.SetProcessing(item.Issue, patchID.Hex(), head)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		grip.Info(message.Fields{
+			"message": "commit queue item was started by another job",
+			"job":     j.ID(),
+			"queue":   cq.ProjectID,
+			"item":    item.Issue,
+			"type":    item.Type,
+		})
+		return nil
+	}
+	item = cq.Queue[0]
+
+	if err = intent.Insert(); err != nil {
+		return j.dequeue(cq, item, fmt.Sprintf("a patch could not be created to test it: %s", err))
+	}
+	processor := NewPatchIntentProcessor(patchID, intent)
+	processor.Run(ctx)
+	if err = processor.Error(); err != nil {
+		return j.dequeue(cq, item, fmt.Sprintf("a patch could not be created to test it: %s", err))
+	}
+
+	grip.Info(message.Fields{
+		"message":   "testing commit queue item",
+		"job":       j.ID(),
+		"queue":     cq.ProjectID,
+		"item":      item.Issue,
+		"type":      item.Type,
+		"patch_id":  patchID.Hex(),
+		"base_hash": base,
+		"head_hash": head,
+	})
+	return nil
+}
+
+// getChange returns the commit that merging the item merges, the diff of
+// the item from its merge base with the project's branch, and a description
+// of the item.
+func (j *commitQueueJob) getChange(ctx context.Context, ref *model.ProjectRef, item commitqueue.CommitQueueItem) (string, string, string, error) {
+	switch item.Type {
+	case commitqueue.PRItemType:
+		number, pr, err := j.getApprovedPullRequest(ctx, ref, item)
+		if err != nil {
+			return "", "", "", err
+		}
+		diff, err := j.githubClient.GetPullRequestDiff(ctx, ref.Owner, ref.Repo, number)
+		if err != nil {
+			return "", "", "", err
+		}
+		return pr.GetHead().GetSHA(), diff, fmt.Sprintf("Commit queue: #%d %s", number, pr.GetTitle()), nil
+
+	case commitqueue.RefItemType:
+		head, err := j.githubClient.GetCommitHash(ctx, ref.Owner, ref.Repo, item.Issue)
+		if err != nil {
+			return "", "", "", err
+		}
+		diff, err := j.githubClient.GetCompareDiff(ctx, ref.Owner, ref.Repo, ref.Branch, head)
+		if err != nil {
+			return "", "", "", err
+		}
+		return head, diff, fmt.Sprintf("Commit queue: %s", item.Issue), nil
+
+	case commitqueue.PatchItemType:
+		if !patch.IsValidId(item.Issue) {
+			return "", "", "", errors.Errorf("'%s' is not a patch id", item.Issue)
+		}
+		p, err := patch.FindOne(patch.ById(patch.NewId(item.Issue)))
+		if err != nil {
+			return "", "", "", errors.Wrapf(err, "error finding patch '%s'", item.Issue)
+		}
+		if p == nil {
+			return "", "", "", errors.Errorf("patch '%s' no longer exists", item.Issue)
+		}
+		head, err := p.MergeableHead()
+		if err != nil {
+			return "", "", "", err
+		}
+		diff, err := j.githubClient.GetCompareDiff(ctx, ref.Owner, ref.Repo, ref.Branch, head.HeadHash)
+		if err != nil {
+			return "", "", "", err
+		}
+		return head.HeadHash, diff, fmt.Sprintf("Commit queue: patch %s %s", item.Issue, p.Description), nil
+
+	default:
+		return "", "", "", errors.Errorf("unknown item type '%s'", item.Type)
+	}
+}
+
+// getApprovedPullRequest returns the number of the item's pull request and
+// the pull request, as long as it's still open against the project's
+// branch and its head is the commit that was approved when it was enqueued.
+// If the pull request has changed since, the commit queue's status on its
+// new head says why it can't be merged.
+func (j *commitQueueJob) getApprovedPullRequest(ctx context.Context, ref *model.ProjectRef, item commitqueue.CommitQueueItem) (int, *github.PullRequest, error) {
+	number, err := strconv.Atoi(item.Issue)
+	if err != nil {
+		return 0, nil, errors.Errorf("'%s' is not a pull request number", item.Issue)
+	}
+	pr, err := j.githubClient.GetPullRequest(ctx, ref.Owner, ref.Repo, number)
+	if err != nil {
+		return 0, nil, err
+	}
+	if pr.GetState() != "open" {
+		return 0, nil, errors.Errorf("pull request %d is %s", number, pr.GetState())
+	}
+	if pr.GetBase().GetRef() != ref.Branch {
+		return 0, nil, errors.Errorf("pull request %d is against branch '%s', not '%s'",
+			number, pr.GetBase().GetRef(), ref.Branch)
+	}
+	if pr.GetHead().GetSHA() != item.ApprovedHash {
+		j.postStatus(ref, pr.GetHead().GetSHA(), "changed since it was approved, enqueue it again to merge it")
+		return 0, nil, errors.Errorf("pull request %d has changed since it was approved", number)
+	}
+	return number, pr, nil
+}
+
+// finishItem merges the item if its patch succeeded, and removes it from
+// the queue if its patch is finished. An item is only merged onto the
+// commit it was tested on, so if the branch has moved since, the item is
+// tested again on the new tip. It returns whether the item is finished.
+func (j *commitQueueJob) finishItem(ctx context.Context, ref *model.ProjectRef, cq *commitqueue.CommitQueue, item commitqueue.CommitQueueItem) (bool, error) {
+	if !patch.IsValidId(item.PatchID) {
+		return true, j.dequeue(cq, item, fmt.Sprintf("its patch id '%s' is invalid", item.PatchID))
+	}
+	p, err := patch.FindOne(patch.ById(patch.NewId(item.PatchID)))
+	if err != nil {
+		return false, errors.Wrapf(err, "error finding patch '%s'", item.PatchID)
+	}
+	if p == nil {
+		return true, j.dequeue(cq, item, "the patch testing it no longer exists")
+	}
+
+	switch p.Status {
+	case evergreen.PatchSucceeded:
+		var tip string
+		tip, err = j.githubClient.GetCommitHash(ctx, ref.Owner, ref.Repo, ref.Branch)
+		if err != nil {
+			return false, errors.Wrapf(err, "error finding the tip of branch '%s' of project '%s'", ref.Branch, ref.Identifier)
+		}
+		if tip != p.Githash {
+			grip.Info(message.Fields{
+				"message":   "branch moved since commit queue item was tested, testing it again",
+				"job":       j.ID(),
+				"queue":     cq.ProjectID,
+				"item":      item.Issue,
+				"type":      item.Type,
+				"patch_id":  item.PatchID,
+				"base_hash": p.Githash,
+				"tip_hash":  tip,
+			})
+			return false, j.startItem(ctx, ref, cq, item)
+		}
+		if err = j.merge(ctx, ref, item); err != nil {
+			return true, j.dequeue(cq, item, fmt.Sprintf("it could not be merged: %s", err))
+		}
+		if _, err = cq.Remove(item.Issue); err != nil {
+			return false, err
+		}
+		j.notify(item, fmt.Sprintf(commitQueueMergedSubject, itemDescription(item)),
+			fmt.Sprintf("%s passed %s and was merged into branch '%s' of project '%s'.",
+				itemDescription(item), j.patchURL(item.PatchID), ref.Branch, ref.Identifier))
+		return true, nil
+
+	case evergreen.PatchFailed:
+		return true, j.dequeue(cq, item, fmt.Sprintf("%s failed", j.patchURL(item.PatchID)))
+
+	default:
+		return false, nil
+	}
+}
+
+// merge merges the commit that was tested through the GitHub API.
+func (j *commitQueueJob) merge(ctx context.Context, ref *model.ProjectRef, item commitqueue.CommitQueueItem) error {
+	switch item.Type {
+	case commitqueue.PRItemType:
+		number, _, err := j.getApprovedPullRequest(ctx, ref, item)
+		if err != nil {
+			return err
+		}
+		mergeMethod := ref.CommitQueue.MergeMethod
+		if mergeMethod == "" {
+			mergeMethod = model.CommitQueueMergeMethodMerge
+		}
+		return j.githubClient.MergePullRequest(ctx, ref.Owner, ref.Repo, number, item.HeadHash, "", mergeMethod)
+
+	case commitqueue.RefItemType:
+		return j.githubClient.MergeCommit(ctx, ref.Owner, ref.Repo, ref.Branch, item.HeadHash,
+			fmt.Sprintf("Merge '%s' from the commit queue", item.Issue))
+
+	case commitqueue.PatchItemType:
+		return j.githubClient.MergeCommit(ctx, ref.Owner, ref.Repo, ref.Branch, item.HeadHash,
+			fmt.Sprintf("Merge patch %s from the commit queue", item.Issue))
+
+	default:
+		return errors.Errorf("unknown item type '%s'", item.Type)
+	}
+}
+
+// dequeue removes the item from the queue and tells its author why.
+func (j *commitQueueJob) dequeue(cq *commitqueue.CommitQueue, item commitqueue.CommitQueueItem, reason string) error {
+	if _, err := cq.Remove(item.Issue); err != nil {
+		return err
+	}
+	grip.Info(message.Fields{
+		"message":  "removed commit queue item",
+		"job":      j.ID(),
+		"queue":    cq.ProjectID,
+		"item":     item.Issue,
+		"type":     item.Type,
+		"patch_id": item.PatchID,
+		"reason":   reason,
+	})
+	j.notify(item, fmt.Sprintf(commitQueueRemovedSubject, itemDescription(item)),
+		fmt.Sprintf(commitQueueRemovedBody, itemDescription(item), cq.ProjectID, reason))
+	return nil
+}
+
+// notify emails the author of the item. Failing to notify the author isn't
+// an error of the job, since the queue has already moved on.
+func (j *commitQueueJob) notify(item commitqueue.CommitQueueItem, subject, body string) {
+	flags, err := evergreen.GetServiceFlags()
+	if err != nil {
+		grip.Error(message.WrapError(err, message.Fields{
+			"message": "error retrieving admin settings",
+			"job":     j.ID(),
+		}))
+		return
+	}
+	if flags.EmailNotificationsDisabled {
+		return
+	}
+
+	author, err := user.FindOne(user.ById(item.Author))
+	if err != nil || author == nil {
+		grip.Error(message.WrapError(err, message.Fields{
+			"message": "can't find author of commit queue item to notify",
+			"job":     j.ID(),
+			"author":  item.Author,
+			"item":    item.Issue,
+		}))
+		return
+	}
+
+	if j.sender == nil {
+		j.sender, err = j.env.GetSender(evergreen.SenderEmail)
+		if err != nil {
+			grip.Error(message.WrapError(err, message.Fields{
+				"message": "can't get email sender",
+				"job":     j.ID(),
+			}))
+			return
+		}
+	}
+
+	j.sender.Send(message.MakeEmailMessage(message.Email{
+		Recipients:        []string{author.Email()},
+		Subject:           subject,
+		Body:              body,
+		PlainTextContents: true,
+	}))
+}
+
+// postStatus sets the commit queue's status on the commit on GitHub to
+// failed with the given description, so that the pull request shows why it
+// was removed from the queue. Failing to post the status isn't an error of
+// the job.
+func (j *commitQueueJob) postStatus(ref *model.ProjectRef, sha, description string) {
+	flags, err := evergreen.GetServiceFlags()
+	if err != nil {
+		grip.Error(message.WrapError(err, message.Fields{
+			"message": "error retrieving admin settings",
+			"job":     j.ID(),
+		}))
+		return
+	}
+	if flags.GithubStatusAPIDisabled {
+		return
+	}
+
+	if j.statusSender == nil {
+		j.statusSender, err = j.env.GetSender(evergreen.SenderGithubStatus)
+		if err != nil {
+			grip.Error(message.WrapError(err, message.Fields{
+				"message": "can't get github status sender",
+				"job":     j.ID(),
+			}))
+			return
+		}
+	}
+
+	j.statusSender.Send(message.MakeGithubStatusMessageWithRepo(message.GithubStatus{
+		Owner:       ref.Owner,
+		Repo:        ref.Repo,
+		Ref:         sha,
+		Context:     commitQueueStatusContext,
+		State:       message.GithubStateFailure,
+		URL:         fmt.Sprintf("%s/waterfall/%s", j.env.Settings().Ui.Url, ref.Identifier),
+		Description: description,
+	}))
+}
+
+func (j *commitQueueJob) patchURL(patchID string) string {
+	return fmt.Sprintf("patch %s/patch/%s", j.env.Settings().Ui.Url, patchID)
+}
+
+func itemDescription(item commitqueue.CommitQueueItem) string {
+	switch item.Type {
+	case commitqueue.PRItemType:
+		return fmt.Sprintf("pull request #%s", item.Issue)
+	case commitqueue.PatchItemType:
+		return fmt.Sprintf("patch %s", item.Issue)
+	default:
+		return fmt.Sprintf("'%s'", item.Issue)
+	}
+}
@@ -0,0 +1,255 @@
+package units
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/mock"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/commitqueue"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/google/go-github/github"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip/message"
+	"github.com/mongodb/grip/send"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestCommitQueueJobFactory(t *testing.T) {
+	assert := assert.New(t)
+
+	factory, err := registry.GetJobFactory(commitQueueJobName)
+	assert.NoError(err)
+	assert.NotNil(factory)
+
+	j, ok := factory().(*commitQueueJob)
+	assert.True(ok)
+	assert.NotNil(j)
+
+	jOne := NewCommitQueueJob(nil, "proj", "ts")
+	jTwo := NewCommitQueueJob(nil, "proj", "ts")
+	jThree := NewCommitQueueJob(nil, "other", "ts")
+	assert.Equal(jOne.ID(), jTwo.ID())
+	assert.NotEqual(jOne.ID(), jThree.ID())
+}
+
+func TestCommitQueueJob(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	testConfig := testutil.TestConfig()
+	db.SetGlobalSessionProvider(testConfig.SessionFactory())
+
+	env := &mock.Environment{}
+	require.NoError(t, env.Configure(ctx, filepath.Join(evergreen.FindEvergreenHome(), testutil.TestDir, testutil.TestSettings), nil))
+
+	ref := &model.ProjectRef{
+		Identifier: "proj",
+		Owner:      "evergreen-ci",
+		Repo:       "evergreen",
+		Branch:     "master",
+		Enabled:    true,
+		CommitQueue: model.CommitQueueParams{
+			Enabled:     true,
+			PatchAlias:  "cq",
+			MergeMethod: model.CommitQueueMergeMethodSquash,
+		},
+	}
+
+	// setup enqueues a pull request that's being tested by a patch with
+	// the given status, followed by a pull request that has been closed
+	setup := func(t *testing.T, status string) (*thirdparty.MockGithubClient, *send.InternalSender, *commitQueueJob) {
+		require := require.New(t)
+		require.NoError(db.ClearCollections(model.ProjectRefCollection, commitqueue.Collection, patch.Collection,
+			user.Collection, evergreen.ConfigCollection))
+		require.NoError(ref.Insert())
+		require.NoError((&user.DBUser{Id: "me", EmailAddress: "me@example.com"}).Insert())
+
+		patchID := bson.NewObjectId()
+		require.NoError((&patch.Patch{Id: patchID, Project: ref.Identifier, Githash: "base", Status: status}).Insert())
+		_, err := commitqueue.Enqueue(ref.Identifier, commitqueue.CommitQueueItem{Issue: "12", Type: commitqueue.PRItemType, Author: "me", ApprovedHash: "abcdef"})
+		require.NoError(err)
+		_, err = commitqueue.Enqueue(ref.Identifier, commitqueue.CommitQueueItem{Issue: "13", Type: commitqueue.PRItemType, Author: "me", ApprovedHash: "123456"})
+		require.NoError(err)
+		cq, err := commitqueue.FindOneId(ref.Identifier)
+		require.NoError(err)
+		set, err := cq.SetProcessing("12", patchID.Hex(), "abcdef")
+		require.NoError(err)
+		require.True(set)
+
+		client := &thirdparty.MockGithubClient{
+			PullRequests: map[int]*github.PullRequest{
+				12: {State: github.String("open"), Head: &github.PullRequestBranch{SHA: github.String("abcdef")},
+					Base: &github.PullRequestBranch{Ref: github.String("master")}},
+				13: {State: github.String("closed")},
+			},
+			CommitHashes: map[string]string{"master": "base"},
+		}
+		sender := send.MakeInternalLogger()
+		j := NewCommitQueueJob(env, ref.Identifier, "ts").(*commitQueueJob)
+		j.githubClient = client
+		j.sender = sender
+		j.statusSender = send.MakeInternalLogger()
+		return client, sender, j
+	}
+
+	t.Run("MergesSucceededPatch", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+
+		client, sender, j := setup(t, evergreen.PatchSucceeded)
+		j.Run(ctx)
+		require.NoError(j.Error())
+		assert.Equal([]int{12}, client.MergedPullRequests)
+
+		// the next pull request is started, but it's closed so it's
+		// removed too
+		cq, err := commitqueue.FindOneId(ref.Identifier)
+		require.NoError(err)
+		assert.Empty(cq.Queue)
+
+		msg, ok := sender.GetMessageSafe()
+		require.True(ok)
+		assert.Contains(msg.Message.Raw().(*message.Email).Subject, "merged pull request #12")
+		msg, ok = sender.GetMessageSafe()
+		require.True(ok)
+		assert.Contains(msg.Message.Raw().(*message.Email).Body, "pull request 13 is closed")
+	})
+
+	t.Run("MergesPatch", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+
+		client, sender, j := setup(t, evergreen.PatchSucceeded)
+		require.NoError(db.Clear(commitqueue.Collection))
+		head := "a4aa03d0472d8503380479b76aef96c044182822"
+		cliPatch := &patch.Patch{
+			Id:      bson.NewObjectId(),
+			Project: ref.Identifier,
+			Patches: []patch.ModulePatch{{Head: &patch.PatchHead{Ref: "feature", HeadHash: head}}},
+		}
+		require.NoError(cliPatch.Insert())
+		testPatchID := bson.NewObjectId()
+		require.NoError((&patch.Patch{Id: testPatchID, Project: ref.Identifier, Githash: "base", Status: evergreen.PatchSucceeded}).Insert())
+		_, err := commitqueue.Enqueue(ref.Identifier, commitqueue.CommitQueueItem{Issue: cliPatch.Id.Hex(), Type: commitqueue.PatchItemType, Author: "me"})
+		require.NoError(err)
+		cq, err := commitqueue.FindOneId(ref.Identifier)
+		require.NoError(err)
+		set, err := cq.SetProcessing(cliPatch.Id.Hex(), testPatchID.Hex(), head)
+		require.NoError(err)
+		require.True(set)
+
+		j.Run(ctx)
+		require.NoError(j.Error())
+		assert.Equal([]string{head}, client.MergedCommits)
+		cq, err = commitqueue.FindOneId(ref.Identifier)
+		require.NoError(err)
+		assert.Empty(cq.Queue)
+		msg, ok := sender.GetMessageSafe()
+		require.True(ok)
+		assert.Contains(msg.Message.Raw().(*message.Email).Subject, "merged patch "+cliPatch.Id.Hex())
+	})
+
+	t.Run("RetestsWhenBranchMoved", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+
+		client, sender, j := setup(t, evergreen.PatchSucceeded)
+		client.CommitHashes["master"] = "newer"
+		j.Run(ctx)
+		require.NoError(j.Error())
+		assert.Empty(client.MergedPullRequests)
+
+		// the pull request is tested again rather than merged, which fails
+		// since its diff can't be found
+		cq, err := commitqueue.FindOneId(ref.Identifier)
+		require.NoError(err)
+		require.Len(cq.Queue, 1)
+		assert.Equal("13", cq.Queue[0].Issue)
+		msg, ok := sender.GetMessageSafe()
+		require.True(ok)
+		assert.Contains(msg.Message.Raw().(*message.Email).Body, "could not be tested")
+	})
+
+	t.Run("DequeuesChangedPullRequest", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+
+		client, sender, j := setup(t, evergreen.PatchSucceeded)
+		client.PullRequests[12].Head.SHA = github.String("012345")
+		j.Run(ctx)
+		require.NoError(j.Error())
+		assert.Empty(client.MergedPullRequests)
+
+		cq, err := commitqueue.FindOneId(ref.Identifier)
+		require.NoError(err)
+		require.Len(cq.Queue, 0)
+		msg, ok := sender.GetMessageSafe()
+		require.True(ok)
+		assert.Contains(msg.Message.Raw().(*message.Email).Body, "pull request 12 has changed since it was approved")
+
+		msg, ok = j.statusSender.(*send.InternalSender).GetMessageSafe()
+		require.True(ok)
+		status := msg.Message.Raw().(*message.GithubStatus)
+		assert.Equal("012345", status.Ref)
+		assert.Equal(message.GithubStateFailure, status.State)
+	})
+
+	t.Run("DequeuesFailedPatch", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+
+		client, sender, j := setup(t, evergreen.PatchFailed)
+		j.Run(ctx)
+		require.NoError(j.Error())
+		assert.Empty(client.MergedPullRequests)
+
+		cq, err := commitqueue.FindOneId(ref.Identifier)
+		require.NoError(err)
+		assert.Empty(cq.Queue)
+		msg, ok := sender.GetMessageSafe()
+		require.True(ok)
+		assert.Contains(msg.Message.Raw().(*message.Email).Subject, "removed pull request #12")
+	})
+
+	t.Run("DequeuesFailedMerge", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+
+		client, sender, j := setup(t, evergreen.PatchSucceeded)
+		client.FailMerges = true
+		j.Run(ctx)
+		require.NoError(j.Error())
+		assert.Empty(client.MergedPullRequests)
+
+		cq, err := commitqueue.FindOneId(ref.Identifier)
+		require.NoError(err)
+		assert.Empty(cq.Queue)
+		msg, ok := sender.GetMessageSafe()
+		require.True(ok)
+		assert.Contains(msg.Message.Raw().(*message.Email).Body, "could not be merged")
+	})
+
+	t.Run("WaitsForRunningPatch", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+
+		client, sender, j := setup(t, evergreen.PatchStarted)
+		j.Run(ctx)
+		require.NoError(j.Error())
+		assert.Empty(client.MergedPullRequests)
+
+		cq, err := commitqueue.FindOneId(ref.Identifier)
+		require.NoError(err)
+		assert.Len(cq.Queue, 2)
+		assert.False(sender.HasMessage())
+	})
+}
@@ -72,6 +72,87 @@ func PopulateCatchupJobs(part int) amboy.QueueOperation {
 	}
 }
 
+// PopulatePeriodicBuildJobs creates the versions of projects' periodic
+// builds that are due. Periodic builds that haven't been scheduled yet are
+// scheduled for the next time their schedule matches.
+func PopulatePeriodicBuildJobs() amboy.QueueOperation {
+	return func(queue amboy.Queue) error {
+		flags, err := evergreen.GetServiceFlags()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if flags.RepotrackerDisabled {
+			grip.InfoWhen(sometimes.Percent(evergreen.DegradedLoggingPercent), message.Fields{
+				"message": "repotracker is disabled",
+				"impact":  "periodic builds disabled",
+				"mode":    "degraded",
+			})
+			return nil
+		}
+
+		projects, err := model.FindProjectRefsWithPeriodicBuilds()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		now := time.Now()
+		catcher := grip.NewBasicCatcher()
+		for i := range projects {
+			proj := &projects[i]
+			for _, definition := range proj.PeriodicBuilds {
+				if definition.NextRunTime.IsZero() {
+					schedule, err := util.ParseCron(definition.Cron)
+					if err != nil {
+						catcher.Add(errors.Wrapf(err, "invalid schedule for periodic build '%s' of project '%s'",
+							definition.ID, proj.Identifier))
+						continue
+					}
+					catcher.Add(proj.UpdateNextPeriodicBuild(definition.ID, schedule.Next(now)))
+					continue
+				}
+				if definition.NextRunTime.After(now) {
+					continue
+				}
+				catcher.Add(queue.Put(NewPeriodicBuildJob(proj.Identifier, definition.ID, definition.NextRunTime)))
+			}
+		}
+
+		return catcher.Resolve()
+	}
+}
+
+// PopulateCommitQueueJobs moves the commit queues of the projects that have
+// them enabled along.
+func PopulateCommitQueueJobs(env evergreen.Environment) amboy.QueueOperation {
+	return func(queue amboy.Queue) error {
+		flags, err := evergreen.GetServiceFlags()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if flags.CommitQueueDisabled {
+			grip.InfoWhen(sometimes.Percent(evergreen.DegradedLoggingPercent), message.Fields{
+				"message": "commit queue is disabled",
+				"impact":  "changes are not tested or merged",
+				"mode":    "degraded",
+			})
+			return nil
+		}
+
+		projects, err := model.FindProjectRefsWithCommitQueueEnabled()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		ts := util.RoundPartOfHour(1).Format(tsFormat)
+		catcher := grip.NewBasicCatcher()
+		for _, proj := range projects {
+			catcher.Add(queue.Put(NewCommitQueueJob(env, proj.Identifier, ts)))
+		}
+
+		return catcher.Resolve()
+	}
+}
+
 func PopulateRepotrackerPollingJobs(part int) amboy.QueueOperation {
 	return func(queue amboy.Queue) error {
 		flags, err := evergreen.GetServiceFlags()
@@ -309,6 +390,176 @@ func PopulateIdleHostJobs(env evergreen.Environment) amboy.QueueOperation {
 	}
 }
 
+func PopulateHostQuarantineJobs(env evergreen.Environment) amboy.QueueOperation {
+	return func(queue amboy.Queue) error {
+		flags, err := evergreen.GetServiceFlags()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		if flags.MonitorDisabled {
+			grip.InfoWhen(sometimes.Percent(evergreen.DegradedLoggingPercent), message.Fields{
+				"message": "monitor is disabled",
+				"impact":  "not quarantining failing hosts",
+				"mode":    "degraded",
+			})
+			return nil
+		}
+
+		hosts, err := host.Find(host.NeedsQuarantineCheck())
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		ts := util.RoundPartOfHour(5).Format(tsFormat)
+		catcher := grip.NewBasicCatcher()
+		for _, h := range hosts {
+			catcher.Add(queue.Put(NewHostQuarantineJob(env, h, ts)))
+		}
+
+		return catcher.Resolve()
+	}
+}
+
+func PopulateHostReprovisionJobs(env evergreen.Environment) amboy.QueueOperation {
+	return func(queue amboy.Queue) error {
+		flags, err := evergreen.GetServiceFlags()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		if flags.HostinitDisabled {
+			grip.InfoWhen(sometimes.Percent(evergreen.DegradedLoggingPercent), message.Fields{
+				"message": "host init disabled",
+				"impact":  "static hosts are not reprovisioned",
+				"mode":    "degraded",
+			})
+			return nil
+		}
+
+		hosts, err := host.Find(host.NeedsReprovisionCheck())
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		ts := util.RoundPartOfMinute(0).Format(tsFormat)
+		catcher := grip.NewBasicCatcher()
+		for _, h := range hosts {
+			catcher.Add(queue.Put(NewHostReprovisionJob(env, h, ts)))
+		}
+
+		return catcher.Resolve()
+	}
+}
+
+// PopulateSpawnhostSleepScheduleJobs stops spawn hosts when their sleep
+// schedules put them to sleep and starts them again when the schedules wake
+// them up.
+func PopulateSpawnhostSleepScheduleJobs() amboy.QueueOperation {
+	return func(queue amboy.Queue) error {
+		flags, err := evergreen.GetServiceFlags()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		if flags.MonitorDisabled {
+			grip.InfoWhen(sometimes.Percent(evergreen.DegradedLoggingPercent), message.Fields{
+				"message": "monitor is disabled",
+				"impact":  "spawn host sleep schedules are not enforced",
+				"mode":    "degraded",
+			})
+			return nil
+		}
+
+		hosts, err := host.Find(host.NeedsSleepScheduleCheck())
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		now := time.Now()
+		ts := util.RoundPartOfHour(5).Format(tsFormat)
+		catcher := grip.NewBasicCatcher()
+		for _, h := range hosts {
+			asleep, err := h.SleepSchedule.ShouldBeAsleep(now)
+			if err != nil {
+				catcher.Add(errors.Wrapf(err, "error checking sleep schedule for host %s", h.Id))
+				continue
+			}
+			// the schedule only acts when it changes, so that hosts that
+			// their owners stopped or started by hand are left alone
+			if asleep == h.SleepSchedule.Asleep {
+				continue
+			}
+
+			// the jobs record the change once they've stopped or started
+			// the host, so that the schedule is retried if they fail
+			switch {
+			case asleep && h.Status == evergreen.HostRunning:
+				catcher.Add(queue.Put(NewSpawnhostStopJob(h, evergreen.User, ts)))
+			case !asleep && h.Status == evergreen.HostStopped:
+				catcher.Add(queue.Put(NewSpawnhostStartJob(h, evergreen.User, ts)))
+			default:
+				catcher.Add(h.SetAsleepBySchedule(asleep))
+			}
+		}
+
+		return catcher.Resolve()
+	}
+}
+
+func PopulateSpawnhostUsageJobs() amboy.QueueOperation {
+	return func(queue amboy.Queue) error {
+		flags, err := evergreen.GetServiceFlags()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		if flags.MonitorDisabled {
+			grip.InfoWhen(sometimes.Percent(evergreen.DegradedLoggingPercent), message.Fields{
+				"message": "monitor is disabled",
+				"impact":  "spawn host usage is not recorded",
+				"mode":    "degraded",
+			})
+			return nil
+		}
+
+		// hosts terminated since the last run still need their final
+		// usage recorded
+		hosts, err := host.Find(host.NeedsUsageRecorded(time.Now().Add(-2 * time.Hour)))
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		ts := util.RoundPartOfHour(0).Format(tsFormat)
+		catcher := grip.NewBasicCatcher()
+		for _, h := range hosts {
+			catcher.Add(queue.Put(NewSpawnhostUsageJob(h, ts)))
+		}
+
+		return catcher.Resolve()
+	}
+}
+
+func PopulateSpawnhostQuotaWarningJobs() amboy.QueueOperation {
+	return func(queue amboy.Queue) error {
+		flags, err := evergreen.GetServiceFlags()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		if flags.AlertsDisabled {
+			grip.InfoWhen(sometimes.Percent(evergreen.DegradedLoggingPercent), message.Fields{
+				"message": "alerts are disabled",
+				"impact":  "users are not warned about spawn host quotas",
+				"mode":    "degraded",
+			})
+			return nil
+		}
+
+		return queue.Put(NewSpawnhostQuotaWarningJob(queue, quotaWarningWeek(time.Now())))
+	}
+}
+
 func PopulateLastContainerFinishTimeJobs() amboy.QueueOperation {
 	return func(queue amboy.Queue) error {
 		catcher := grip.NewBasicCatcher()
@@ -7,6 +7,7 @@ import (
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/cloud"
+	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/mongodb/amboy"
 	"github.com/mongodb/amboy/dependency"
@@ -15,6 +16,7 @@ import (
 	"github.com/mongodb/grip"
 	"github.com/mongodb/grip/message"
 	"github.com/pkg/errors"
+	mgo "gopkg.in/mgo.v2"
 )
 
 const (
@@ -136,9 +138,108 @@ func (j *idleHostJob) Run(ctx context.Context) {
 
 	// if we haven't heard from the host or it's been idle for longer than the cutoff, we should terminate
 	if communicationTime >= idleTimeCutoff || idleTime >= idleTimeCutoff {
+		// hosts that are still in contact may be needed to keep the
+		// distro's warm pool from dropping below its floor
+		if communicationTime < idleTimeCutoff {
+			keep, err := j.keepInWarmPool()
+			if err != nil {
+				j.AddError(err)
+				return
+			}
+			if keep {
+				grip.Info(message.Fields{
+					"op":      j.Type().Name,
+					"id":      j.ID(),
+					"message": "not terminating idle host, distro warm pool would drop below its floor",
+					"host":    j.host.Id,
+					"distro":  j.host.Distro.Id,
+					"idle":    idleTime.String(),
+				})
+				return
+			}
+		}
+
 		j.Terminated = true
 		tjob := NewHostTerminationJob(j.env, *j.host)
 		tjob.Run(ctx)
 		j.AddError(tjob.Error())
 	}
 }
+
+// keepInWarmPool returns true if terminating the host would leave its distro
+// with fewer hosts than its minimum, or, during the standby hours, fewer idle
+// hosts than its hot standby count.
+//
+// Several idle host jobs for the same distro can run at once, so the host is
+// first taken out of the pool by atomically decommissioning it, and only then
+// are the remaining hosts counted; a host that has to stay is put back. Jobs
+// racing each other therefore each see the others' hosts as gone, and can't
+// together drain the pool below its floor.
+func (j *idleHostJob) keepInWarmPool() (bool, error) {
+	d, err := distro.FindOne(distro.ById(j.host.Distro.Id))
+	if err == mgo.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "error finding distro %s", j.host.Distro.Id)
+	}
+	if !d.HasWarmPool() {
+		return false, nil
+	}
+
+	claimed, err := j.host.SetStatusIfCurrent(evergreen.HostRunning, evergreen.HostDecommissioned,
+		evergreen.User, "idle host leaving the warm pool")
+	if err != nil {
+		return false, errors.Wrapf(err, "error decommissioning host %s", j.host.Id)
+	}
+	if !claimed {
+		// the host's status changed underneath us, so leave it for
+		// a later pass
+		return true, nil
+	}
+
+	keep, err := warmPoolNeedsHost(&d)
+	if err != nil || keep {
+		_, restoreErr := j.host.SetStatusIfCurrent(evergreen.HostDecommissioned, evergreen.HostRunning,
+			evergreen.User, "idle host kept in the warm pool")
+		if restoreErr != nil {
+			grip.Error(message.WrapError(restoreErr, message.Fields{
+				"op":      j.Type().Name,
+				"id":      j.ID(),
+				"message": "problem returning host to the warm pool",
+				"host":    j.host.Id,
+				"distro":  d.Id,
+			}))
+		}
+		return true, err
+	}
+
+	return false, nil
+}
+
+// warmPoolNeedsHost returns true if the distro's remaining hosts are fewer
+// than its minimum or, during the standby hours, its remaining idle hosts
+// are fewer than its hot standby count.
+func warmPoolNeedsHost(d *distro.Distro) (bool, error) {
+	if d.MinHosts > 0 {
+		numHosts, err := host.Count(host.ByDistroId(d.Id))
+		if err != nil {
+			return false, errors.Wrapf(err, "error counting hosts for distro %s", d.Id)
+		}
+		if numHosts < d.MinHosts {
+			return true, nil
+		}
+	}
+
+	if standby := d.StandbyHosts(time.Now()); standby > 0 {
+		numIdle, err := host.Count(host.IdleByDistroId(d.Id))
+		if err != nil {
+			return false, errors.Wrapf(err, "error counting idle hosts for distro %s", d.Id)
+		}
+		if numIdle < standby {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/host"
 	modelUtil "github.com/evergreen-ci/evergreen/model/testutil"
 	"github.com/evergreen-ci/evergreen/testutil"
@@ -57,7 +58,7 @@ func TestFlaggingIdleHosts(t *testing.T) {
 	Convey("When flagging idle hosts to be terminated", t, func() {
 
 		// reset the db
-		testutil.HandleTestingErr(db.ClearCollections(host.Collection),
+		testutil.HandleTestingErr(db.ClearCollections(host.Collection, distro.Collection),
 			t, "error clearing hosts collection")
 		testutil.HandleTestingErr(modelUtil.AddTestIndexes(host.Collection,
 			true, true, host.RunningTaskKey), t, "error adding host index")
@@ -173,5 +174,56 @@ func TestFlaggingIdleHosts(t *testing.T) {
 			So(err, ShouldBeNil)
 			So(len(idle), ShouldEqual, 0)
 		})
+		Convey("idle hosts should not be flagged if the distro would drop below its minimum", func() {
+			d := distro.Distro{Id: "warm", Provider: evergreen.ProviderNameMock, PoolSize: 5, MinHosts: 2}
+			So(d.Insert(), ShouldBeNil)
+
+			for _, id := range []string{"h6", "h7"} {
+				h := host.Host{
+					Id:                    id,
+					Distro:                d,
+					Provider:              evergreen.ProviderNameMock,
+					LastTask:              "t1",
+					LastTaskCompletedTime: time.Now().Add(-time.Minute * 20),
+					LastCommunicationTime: time.Now(),
+					Status:                evergreen.HostRunning,
+					StartedBy:             evergreen.User,
+					Provisioned:           true,
+				}
+				So(h.Insert(), ShouldBeNil)
+			}
+
+			idle, err := flagIdleHosts(ctx, env)
+			So(err, ShouldBeNil)
+			So(len(idle), ShouldEqual, 0)
+		})
+		Convey("idle hosts above the distro's minimum should be flagged until it is reached", func() {
+			d := distro.Distro{Id: "warm", Provider: evergreen.ProviderNameMock, PoolSize: 5, MinHosts: 1}
+			So(d.Insert(), ShouldBeNil)
+
+			for _, id := range []string{"h8", "h9"} {
+				h := host.Host{
+					Id:                    id,
+					Distro:                d,
+					Provider:              evergreen.ProviderNameMock,
+					LastTask:              "t1",
+					LastTaskCompletedTime: time.Now().Add(-time.Minute * 20),
+					LastCommunicationTime: time.Now(),
+					Status:                evergreen.HostRunning,
+					StartedBy:             evergreen.User,
+					Provisioned:           true,
+				}
+				So(h.Insert(), ShouldBeNil)
+			}
+
+			idle, err := flagIdleHosts(ctx, env)
+			So(err, ShouldBeNil)
+			So(len(idle), ShouldEqual, 1)
+
+			// the host that was kept is back in the pool
+			numRunning, err := host.Count(host.IdleByDistroId(d.Id))
+			So(err, ShouldBeNil)
+			So(numRunning, ShouldEqual, 1)
+		})
 	})
 }
@@ -0,0 +1,229 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/cloud"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/dependency"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const (
+	hostQuarantineJobName = "host-quarantine"
+
+	// quarantineMonitorUser is recorded as the user that changed the
+	// status of hosts quarantined or released by the job.
+	quarantineMonitorUser = "quarantine-monitor"
+)
+
+func init() {
+	registry.AddJobType(hostQuarantineJobName, func() amboy.Job {
+		return makeHostQuarantineJob()
+	})
+}
+
+type hostQuarantineJob struct {
+	HostID   string `bson:"host_id" json:"host_id" yaml:"host_id"`
+	job.Base `bson:"metadata" json:"metadata" yaml:"metadata"`
+
+	host     *host.Host
+	env      evergreen.Environment
+	settings *evergreen.Settings
+}
+
+func makeHostQuarantineJob() *hostQuarantineJob {
+	j := &hostQuarantineJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    hostQuarantineJobName,
+				Version: 0,
+			},
+		},
+	}
+	j.SetDependency(dependency.NewAlways())
+
+	return j
+}
+
+// NewHostQuarantineJob checks whether tasks keep failing on a running host
+// and, if so, quarantines it so that it is given no more work. For a host it
+// quarantined earlier, the job instead runs the distro's health check script,
+// if it has one, and returns the host to the pool if the script succeeds.
+func NewHostQuarantineJob(env evergreen.Environment, h host.Host, id string) amboy.Job {
+	j := makeHostQuarantineJob()
+	j.host = &h
+	j.HostID = h.Id
+	j.env = env
+	j.SetID(fmt.Sprintf("%s.%s.%s", hostQuarantineJobName, j.HostID, id))
+	return j
+}
+
+func (j *hostQuarantineJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+
+	flags, err := evergreen.GetServiceFlags()
+	if err != nil {
+		j.AddError(errors.Wrap(err, "error retrieving admin settings"))
+		return
+	}
+	if flags.MonitorDisabled {
+		j.AddError(errors.New("monitor is disabled"))
+		return
+	}
+
+	if j.host == nil {
+		j.host, err = host.FindOneId(j.HostID)
+		if err != nil {
+			j.AddError(err)
+			return
+		}
+		if j.host == nil {
+			j.AddError(errors.Errorf("could not find host %s", j.HostID))
+			return
+		}
+	}
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+	if j.settings == nil {
+		j.settings = j.env.Settings()
+	}
+
+	switch j.host.Status {
+	case evergreen.HostRunning:
+		j.AddError(j.checkHost())
+	case evergreen.HostQuarantined:
+		if j.host.AutoQuarantined {
+			j.AddError(j.runHealthCheck(ctx))
+		}
+	}
+}
+
+// checkHost quarantines the host if there is evidence that it causes the tasks
+// that run on it to fail.
+func (j *hostQuarantineJob) checkHost() error {
+	conf := j.settings.HostQuarantine
+	if err := conf.ValidateAndDefault(); err != nil {
+		return errors.Wrap(err, "invalid host quarantine settings")
+	}
+
+	since := j.host.QuarantineReleaseTime
+	statuses, err := event.RecentHostTaskStatuses(j.host.Id,
+		util.Max(conf.ConsecutiveSystemFailures, conf.ConsecutiveSetupFailures), since)
+	if err != nil {
+		return errors.Wrapf(err, "error finding recent tasks on host %s", j.host.Id)
+	}
+
+	windowStart := time.Now().Add(-time.Duration(conf.ProjectFailureWindowMinutes) * time.Minute)
+	if windowStart.Before(since) {
+		windowStart = since
+	}
+	projects, err := task.FindFailedProjectsOnHost(j.host.Id, windowStart)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	evidence := quarantineEvidence(conf, statuses, projects)
+	if evidence == "" {
+		return nil
+	}
+
+	grip.Warning(message.Fields{
+		"message":  "quarantining host",
+		"job":      j.ID(),
+		"host":     j.host.Id,
+		"distro":   j.host.Distro.Id,
+		"provider": j.host.Provider,
+		"evidence": evidence,
+	})
+
+	return errors.Wrapf(j.host.AutoQuarantine(quarantineMonitorUser, evidence),
+		"error quarantining host %s", j.host.Id)
+}
+
+// runHealthCheck releases the host from quarantine if its distro's health
+// check script succeeds on it. Hosts whose distro has no health check stay
+// quarantined until an admin releases them.
+func (j *hostQuarantineJob) runHealthCheck(ctx context.Context) error {
+	if j.host.Distro.HealthCheck == "" {
+		return nil
+	}
+
+	cloudHost, err := cloud.GetCloudHost(ctx, j.host, j.settings)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get cloud host for %s", j.host.Id)
+	}
+	sshOptions, err := cloudHost.GetSSHOptions()
+	if err != nil {
+		return errors.Wrapf(err, "error getting ssh options for host %s", j.host.Id)
+	}
+
+	logs, err := j.host.RunSSHCommand(ctx, j.host.Distro.HealthCheck, sshOptions)
+	if err != nil {
+		grip.Info(message.WrapError(err, message.Fields{
+			"message": "quarantined host failed health check",
+			"job":     j.ID(),
+			"host":    j.host.Id,
+			"distro":  j.host.Distro.Id,
+			"logs":    logs,
+		}))
+		return nil
+	}
+
+	grip.Info(message.Fields{
+		"message": "releasing host from quarantine",
+		"job":     j.ID(),
+		"host":    j.host.Id,
+		"distro":  j.host.Distro.Id,
+	})
+
+	return errors.WithStack(j.host.ReleaseFromQuarantine(quarantineMonitorUser, logs))
+}
+
+// quarantineEvidence describes why a host should be quarantined given the
+// statuses of the last tasks to run on it, most recent first, and the
+// projects whose tasks failed on it recently. It returns an empty string if
+// there is no reason to quarantine the host.
+func quarantineEvidence(conf evergreen.HostQuarantineConfig, statuses []string, projects []string) string {
+	evidence := []string{}
+
+	systemFailures := countLeadingStatuses(statuses, evergreen.TaskSystemFailed,
+		evergreen.TaskSystemTimedOut, evergreen.TaskSystemUnresponse)
+	if systemFailures >= conf.ConsecutiveSystemFailures {
+		evidence = append(evidence, fmt.Sprintf("the last %d tasks had system failures", systemFailures))
+	}
+
+	setupFailures := countLeadingStatuses(statuses, evergreen.TaskSetupFailed)
+	if setupFailures >= conf.ConsecutiveSetupFailures {
+		evidence = append(evidence, fmt.Sprintf("the last %d tasks failed during setup", setupFailures))
+	}
+
+	if len(projects) >= conf.DistinctProjectFailures {
+		evidence = append(evidence, fmt.Sprintf("tasks from %d projects (%s) failed in the last %d minutes",
+			len(projects), strings.Join(projects, ", "), conf.ProjectFailureWindowMinutes))
+	}
+
+	return strings.Join(evidence, "\n")
+}
+
+func countLeadingStatuses(statuses []string, matching ...string) int {
+	for i, status := range statuses {
+		if !util.StringSliceContains(matching, status) {
+			return i
+		}
+	}
+	return len(statuses)
+}
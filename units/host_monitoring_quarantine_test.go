@@ -0,0 +1,118 @@
+package units
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/mock"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuarantineEvidence(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := evergreen.HostQuarantineConfig{}
+	assert.NoError(conf.ValidateAndDefault())
+	conf.ConsecutiveSystemFailures = 3
+	conf.ConsecutiveSetupFailures = 2
+
+	assert.Empty(quarantineEvidence(conf, nil, nil))
+
+	// failures must be consecutive and the most recent tasks
+	assert.Empty(quarantineEvidence(conf, []string{evergreen.TaskSystemFailed, evergreen.TaskSucceeded,
+		evergreen.TaskSystemFailed, evergreen.TaskSystemFailed}, nil))
+	assert.Empty(quarantineEvidence(conf, []string{evergreen.TaskSucceeded, evergreen.TaskSystemFailed,
+		evergreen.TaskSystemFailed, evergreen.TaskSystemFailed}, nil))
+
+	// all kinds of system failure count
+	evidence := quarantineEvidence(conf, []string{evergreen.TaskSystemFailed, evergreen.TaskSystemUnresponse,
+		evergreen.TaskSystemTimedOut}, nil)
+	assert.Contains(evidence, "last 3 tasks had system failures")
+
+	evidence = quarantineEvidence(conf, []string{evergreen.TaskSetupFailed, evergreen.TaskSetupFailed}, nil)
+	assert.Contains(evidence, "last 2 tasks failed during setup")
+
+	assert.Empty(quarantineEvidence(conf, nil, []string{"p1", "p2"}))
+	evidence = quarantineEvidence(conf, nil, []string{"p1", "p2", "p3"})
+	assert.Contains(evidence, "3 projects (p1, p2, p3)")
+}
+
+func TestHostQuarantineJob(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	testConfig := testutil.TestConfig()
+	db.SetGlobalSessionProvider(testConfig.SessionFactory())
+	require.NoError(db.ClearCollections(host.Collection, task.Collection, task.OldCollection, event.AllLogCollection))
+
+	env := &mock.Environment{
+		EvergreenSettings: testConfig,
+	}
+	env.EvergreenSettings.HostQuarantine = evergreen.HostQuarantineConfig{ConsecutiveSystemFailures: 2}
+
+	h := &host.Host{
+		Id:        "h1",
+		Status:    evergreen.HostRunning,
+		Provider:  evergreen.ProviderNameStatic,
+		StartedBy: evergreen.User,
+	}
+	require.NoError(h.Insert())
+
+	// a single failure is not enough
+	event.LogTaskFinished("t1", 0, h.Id, evergreen.TaskSystemFailed)
+	j := NewHostQuarantineJob(env, *h, "one")
+	j.Run(context.Background())
+	assert.NoError(j.Error())
+	dbHost, err := host.FindOneId(h.Id)
+	require.NoError(err)
+	assert.Equal(evergreen.HostRunning, dbHost.Status)
+
+	time.Sleep(10 * time.Millisecond)
+	event.LogTaskFinished("t2", 0, h.Id, evergreen.TaskSystemFailed)
+	j = NewHostQuarantineJob(env, *h, "two")
+	j.Run(context.Background())
+	assert.NoError(j.Error())
+	dbHost, err = host.FindOneId(h.Id)
+	require.NoError(err)
+	assert.Equal(evergreen.HostQuarantined, dbHost.Status)
+	assert.True(dbHost.AutoQuarantined)
+
+	// released hosts are not quarantined again for the same failures
+	require.NoError(dbHost.ReleaseFromQuarantine(evergreen.User, "ok"))
+	j = NewHostQuarantineJob(env, *dbHost, "three")
+	j.Run(context.Background())
+	assert.NoError(j.Error())
+	dbHost, err = host.FindOneId(h.Id)
+	require.NoError(err)
+	assert.Equal(evergreen.HostRunning, dbHost.Status)
+	assert.False(dbHost.AutoQuarantined)
+
+	// quarantined hosts without a health check stay quarantined
+	require.NoError(dbHost.AutoQuarantine(evergreen.User, "evidence"))
+	j = NewHostQuarantineJob(env, *dbHost, "four")
+	j.Run(context.Background())
+	assert.NoError(j.Error())
+	dbHost, err = host.FindOneId(h.Id)
+	require.NoError(err)
+	assert.Equal(evergreen.HostQuarantined, dbHost.Status)
+
+	// hosts that an admin takes out of quarantine by hand aren't quarantined
+	// again for the same failures either
+	require.NoError(dbHost.SetStatus(evergreen.HostRunning, "admin", "manual release"))
+	assert.False(dbHost.AutoQuarantined)
+	j = NewHostQuarantineJob(env, *dbHost, "five")
+	j.Run(context.Background())
+	assert.NoError(j.Error())
+	dbHost, err = host.FindOneId(h.Id)
+	require.NoError(err)
+	assert.Equal(evergreen.HostRunning, dbHost.Status)
+	assert.False(dbHost.AutoQuarantined)
+	assert.False(dbHost.QuarantineReleaseTime.IsZero())
+}
@@ -0,0 +1,167 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/cloud"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/dependency"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const (
+	hostReprovisionJobName = "host-reprovision"
+
+	// reprovisionDrainWait is how long a host that needs to be reprovisioned
+	// has to go without hearing from its agent before it is torn down, which
+	// gives the agent time to exit after being told to.
+	reprovisionDrainWait = 2 * time.Minute
+)
+
+func init() {
+	registry.AddJobType(hostReprovisionJobName, func() amboy.Job {
+		return makeHostReprovisionJob()
+	})
+}
+
+type hostReprovisionJob struct {
+	HostID   string `bson:"host_id" json:"host_id" yaml:"host_id"`
+	job.Base `bson:"metadata" json:"metadata" yaml:"metadata"`
+
+	host *host.Host
+	env  evergreen.Environment
+}
+
+func makeHostReprovisionJob() *hostReprovisionJob {
+	j := &hostReprovisionJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    hostReprovisionJobName,
+				Version: 0,
+			},
+		},
+	}
+	j.SetDependency(dependency.NewAlways())
+
+	return j
+}
+
+// NewHostReprovisionJob reprovisions a static host that has been marked to
+// be reprovisioned, or that has run as many tasks as its distro allows
+// between reprovisionings. Once the host has finished its task and its agent
+// has exited, the job runs the distro's teardown script on it and returns it
+// to the provisioning state, from which it is set up and given a new agent
+// like a newly started host. A host whose teardown fails is quarantined.
+func NewHostReprovisionJob(env evergreen.Environment, h host.Host, id string) amboy.Job {
+	j := makeHostReprovisionJob()
+	j.host = &h
+	j.HostID = h.Id
+	j.env = env
+	j.SetID(fmt.Sprintf("%s.%s.%s", hostReprovisionJobName, j.HostID, id))
+	return j
+}
+
+func (j *hostReprovisionJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+
+	flags, err := evergreen.GetServiceFlags()
+	if err != nil {
+		j.AddError(errors.Wrap(err, "error retrieving admin settings"))
+		return
+	}
+	if flags.HostinitDisabled {
+		j.AddError(errors.New("host init is disabled"))
+		return
+	}
+
+	if j.host == nil {
+		j.host, err = host.FindOneId(j.HostID)
+		if err != nil {
+			j.AddError(err)
+			return
+		}
+		if j.host == nil {
+			j.AddError(errors.Errorf("could not find host %s", j.HostID))
+			return
+		}
+	}
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+
+	if j.host.Status != evergreen.HostRunning || j.host.Provider != evergreen.ProviderNameStatic {
+		return
+	}
+
+	if !j.host.NeedsReprovision {
+		if !j.host.ReachedReprovisionTaskLimit() {
+			return
+		}
+		reason := fmt.Sprintf("host ran %d tasks", j.host.TaskCount-j.host.ReprovisionTaskCount)
+		if err = j.host.SetNeedsReprovision(evergreen.User, reason); err != nil {
+			j.AddError(err)
+			return
+		}
+	}
+
+	if j.host.RunningTask != "" || time.Since(j.host.LastCommunicationTime) < reprovisionDrainWait {
+		grip.Debug(message.Fields{
+			"message":      "waiting for host to drain before reprovisioning",
+			"job":          j.ID(),
+			"host":         j.host.Id,
+			"distro":       j.host.Distro.Id,
+			"running_task": j.host.RunningTask,
+		})
+		return
+	}
+
+	j.AddError(j.reprovision(ctx))
+}
+
+// reprovision tears the drained host down and starts setting it up again.
+func (j *hostReprovisionJob) reprovision(ctx context.Context) error {
+	settings := j.env.Settings()
+
+	cloudHost, err := cloud.GetCloudHost(ctx, j.host, settings)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get cloud host for %s", j.host.Id)
+	}
+	sshOptions, err := cloudHost.GetSSHOptions()
+	if err != nil {
+		return errors.Wrapf(err, "error getting ssh options for host %s", j.host.Id)
+	}
+
+	startTime := time.Now()
+	logs, err := j.host.RunSSHCommand(ctx, j.host.TearDownCommand(), sshOptions)
+	event.LogHostTeardown(j.host.Id, logs, err == nil, time.Since(startTime))
+	if err != nil {
+		grip.Warning(message.WrapError(err, message.Fields{
+			"message": "teardown failed while reprovisioning host, quarantining it",
+			"job":     j.ID(),
+			"host":    j.host.Id,
+			"distro":  j.host.Distro.Id,
+			"logs":    logs,
+		}))
+		event.LogHostReprovisionFailed(j.host.Id, logs)
+		return errors.Wrapf(j.host.SetQuarantined(evergreen.User, logs),
+			"error quarantining host %s", j.host.Id)
+	}
+
+	grip.Info(message.Fields{
+		"message": "reprovisioning host",
+		"job":     j.ID(),
+		"host":    j.host.Id,
+		"distro":  j.host.Distro.Id,
+	})
+
+	return errors.WithStack(j.host.StartReprovisioning(evergreen.User, logs))
+}
@@ -139,7 +139,7 @@ func (j *patchIntentProcessor) Run(ctx context.Context) {
 
 		j.AddError(model.AbortPatchesWithGithubPatchData(patchDoc.CreateTime,
 			patchDoc.GithubPatchData.BaseOwner, patchDoc.GithubPatchData.BaseRepo,
-			patchDoc.GithubPatchData.PRNumber))
+			patchDoc.GithubPatchData.PRNumber, patchDoc))
 	}
 }
 
@@ -385,6 +385,24 @@ func (j *patchIntentProcessor) buildCliPatchDoc(ctx context.Context, patchDoc *p
 			patchDoc.Githash, projectRef.Identifier)
 	}
 
+	if head := patchDoc.Patches[0].Head; head != nil {
+		// the patch checks out a commit, so the files it changes come
+		// from comparing the commit to the base
+		owner, repo := projectRef.Owner, projectRef.Repo
+		if head.IsFork() {
+			owner, repo = head.Owner, head.Repo
+		}
+		var summaries []patch.Summary
+		summaries, err = thirdparty.GetGithubCompareSummaries(ctx, githubOauthToken, owner, repo, patchDoc.Githash, head.HeadHash)
+		if err != nil {
+			return errors.Wrapf(err, "could not compare '%s' to base revision '%s' in '%s/%s'; has it been pushed?",
+				head.HeadHash, patchDoc.Githash, owner, repo)
+		}
+		patchDoc.Patches[0].ModuleName = ""
+		patchDoc.Patches[0].PatchSet.Summary = summaries
+		return nil
+	}
+
 	var reader io.ReadCloser
 	reader, err = db.GetGridFile(patch.GridFSPrefix, patchDoc.Patches[0].PatchSet.PatchFileId)
 	if err != nil {
@@ -449,6 +467,24 @@ func (j *patchIntentProcessor) buildGithubPatchDoc(ctx context.Context, patchDoc
 		return false, errors.Errorf("Could not find project vars for project '%s'", projectRef.Identifier)
 	}
 
+	// the pull request's earlier patches are left running if it's labeled
+	// to keep them, or if its labels can't be read
+	if projectRef.AbortSupersededPatches {
+		var labels []string
+		labels, err = thirdparty.GetGithubPullRequestLabels(ctx, githubOauthToken, patchDoc.GithubPatchData.BaseOwner,
+			patchDoc.GithubPatchData.BaseRepo, patchDoc.GithubPatchData.PRNumber)
+		grip.Error(message.WrapError(err, message.Fields{
+			"message":     "can't fetch pull request labels",
+			"source":      "patch intents",
+			"job":         j.ID(),
+			"patch_id":    j.PatchID,
+			"pr_number":   patchDoc.GithubPatchData.PRNumber,
+			"intent_type": j.IntentType,
+			"intent_id":   j.IntentID,
+		}))
+		patchDoc.KeepPrevious = err != nil || util.StringSliceContains(labels, patch.KeepPreviousPatchesLabel)
+	}
+
 	isMember, err := authAndFetchPRMergeBase(ctx, patchDoc, mustBeMemberOfOrg,
 		patchDoc.GithubPatchData.Author, githubOauthToken)
 	if err != nil {
@@ -0,0 +1,135 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/repotracker"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/dependency"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const (
+	periodicBuildJobName = "periodic-build"
+)
+
+func init() {
+	registry.AddJobType(periodicBuildJobName, func() amboy.Job { return makePeriodicBuildJob() })
+}
+
+type periodicBuildJob struct {
+	ProjectID    string    `bson:"project_id" json:"project_id" yaml:"project_id"`
+	DefinitionID string    `bson:"definition_id" json:"definition_id" yaml:"definition_id"`
+	DueTime      time.Time `bson:"due_time" json:"due_time" yaml:"due_time"`
+	job.Base     `bson:"job_base" json:"job_base" yaml:"job_base"`
+
+	now time.Time
+}
+
+func makePeriodicBuildJob() *periodicBuildJob {
+	j := &periodicBuildJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    periodicBuildJobName,
+				Version: 0,
+			},
+		},
+	}
+	j.SetDependency(dependency.NewAlways())
+	return j
+}
+
+// NewPeriodicBuildJob creates a job that creates the version of a project's
+// periodic build that is due at the given time, and schedules the next one.
+func NewPeriodicBuildJob(projectID, definitionID string, dueTime time.Time) amboy.Job {
+	j := makePeriodicBuildJob()
+	j.ProjectID = projectID
+	j.DefinitionID = definitionID
+	j.DueTime = dueTime
+	j.SetID(fmt.Sprintf("%s:%s:%s:%s", periodicBuildJobName, projectID, definitionID, dueTime.Format(tsFormat)))
+	return j
+}
+
+func (j *periodicBuildJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+
+	if util.IsZeroTime(j.now) {
+		j.now = time.Now()
+	}
+
+	// a job with the same ID can't run again, so the next run is scheduled
+	// however this one ends, unless the periodic build was removed or
+	// rescheduled since the job was created. When the schedule can't be
+	// determined, the next run time is cleared so the cron schedules it again.
+	reschedule := true
+	var nextRunTime time.Time
+	defer func() {
+		if reschedule {
+			ref := &model.ProjectRef{Identifier: j.ProjectID}
+			j.AddError(ref.UpdateNextPeriodicBuild(j.DefinitionID, nextRunTime))
+		}
+	}()
+
+	flags, err := evergreen.GetServiceFlags()
+	if err != nil {
+		j.AddError(errors.Wrap(err, "error retrieving admin settings"))
+		return
+	}
+	if flags.RepotrackerDisabled {
+		j.AddError(errors.New("repotracker is disabled"))
+		return
+	}
+
+	ref, err := model.FindOneProjectRef(j.ProjectID)
+	if err != nil {
+		j.AddError(errors.Wrapf(err, "error finding project '%s'", j.ProjectID))
+		return
+	}
+	if ref == nil {
+		reschedule = false
+		return
+	}
+	var definition *model.PeriodicBuildDefinition
+	for i := range ref.PeriodicBuilds {
+		if ref.PeriodicBuilds[i].ID == j.DefinitionID {
+			definition = &ref.PeriodicBuilds[i]
+			break
+		}
+	}
+	// the definition was removed or rescheduled since the job was created
+	if definition == nil || !definition.NextRunTime.Equal(j.DueTime) {
+		reschedule = false
+		return
+	}
+	if !ref.Enabled {
+		return
+	}
+
+	schedule, err := util.ParseCron(definition.Cron)
+	if err != nil {
+		j.AddError(errors.Wrapf(err, "invalid schedule for periodic build '%s' of project '%s'", definition.ID, ref.Identifier))
+		return
+	}
+	nextRunTime = schedule.Next(j.now)
+
+	v, err := repotracker.CreatePeriodicVersion(ref, *definition, j.now)
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+	grip.WarningWhen(v == nil, message.Fields{
+		"message":    "no tracked version to create periodic build from",
+		"job":        j.ID(),
+		"project":    ref.Identifier,
+		"definition": definition.ID,
+	})
+}
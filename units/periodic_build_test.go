@@ -0,0 +1,85 @@
+package units
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/mongodb/amboy/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeriodicBuildJob(t *testing.T) {
+	assert := assert.New(t)
+
+	factory, err := registry.GetJobFactory(periodicBuildJobName)
+	assert.NoError(err)
+	assert.NotNil(factory)
+
+	j, ok := factory().(*periodicBuildJob)
+	assert.True(ok)
+	assert.NotNil(j)
+
+	due := time.Date(2018, time.June, 13, 2, 0, 0, 0, time.UTC)
+	jOne := NewPeriodicBuildJob("proj", "nightly", due)
+	jTwo := NewPeriodicBuildJob("proj", "nightly", due)
+	jThree := NewPeriodicBuildJob("proj", "nightly", due.AddDate(0, 0, 1))
+	jFour := NewPeriodicBuildJob("proj", "weekly", due)
+	assert.Equal(jOne.ID(), jTwo.ID())
+	assert.NotEqual(jOne.ID(), jThree.ID())
+	assert.NotEqual(jOne.ID(), jFour.ID())
+}
+
+func TestPeriodicBuildJobReschedulesOnFailure(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	db.SetGlobalSessionProvider(testutil.TestConfig().SessionFactory())
+	require.NoError(db.ClearCollections(model.ProjectRefCollection, evergreen.ConfigCollection))
+	defer func() {
+		assert.NoError(db.ClearCollections(model.ProjectRefCollection, evergreen.ConfigCollection))
+	}()
+
+	due := time.Date(2018, time.June, 13, 2, 0, 0, 0, time.UTC)
+	ref := &model.ProjectRef{
+		Identifier: "proj",
+		Enabled:    true,
+		PeriodicBuilds: []model.PeriodicBuildDefinition{
+			{ID: "nightly", Cron: "@daily", NextRunTime: due},
+			{ID: "invalid", Cron: "not a schedule", NextRunTime: due},
+		},
+	}
+	require.NoError(ref.Insert())
+
+	// a job that can't run clears the next run time so the cron schedules
+	// the periodic build again
+	j := NewPeriodicBuildJob("proj", "invalid", due)
+	j.Run(context.Background())
+	assert.Error(j.Error())
+
+	require.NoError(evergreen.SetServiceFlags(evergreen.ServiceFlags{RepotrackerDisabled: true}))
+	j = NewPeriodicBuildJob("proj", "nightly", due)
+	j.Run(context.Background())
+	assert.Error(j.Error())
+
+	dbRef, err := model.FindOneProjectRef("proj")
+	require.NoError(err)
+	require.NotNil(dbRef)
+	require.Len(dbRef.PeriodicBuilds, 2)
+	for _, definition := range dbRef.PeriodicBuilds {
+		assert.True(definition.NextRunTime.IsZero(), definition.ID)
+	}
+
+	// a periodic build rescheduled since the job was created is left alone
+	require.NoError(dbRef.UpdateNextPeriodicBuild("nightly", due))
+	j = NewPeriodicBuildJob("proj", "nightly", due.Add(-time.Hour))
+	j.Run(context.Background())
+	dbRef, err = model.FindOneProjectRef("proj")
+	require.NoError(err)
+	require.NotNil(dbRef)
+	assert.True(due.Equal(dbRef.PeriodicBuilds[0].NextRunTime))
+}
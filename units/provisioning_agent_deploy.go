@@ -90,6 +90,11 @@ func (j *agentDeployJob) Run(ctx context.Context) {
 
 	settings := j.env.Settings()
 
+	if j.host.Distro.BootstrapsWithUserData() {
+		j.AddError(j.recordRestartedAgent())
+		return
+	}
+
 	err = j.startAgentOnHost(ctx, settings, *j.host)
 	j.AddError(err)
 	if err != nil {
@@ -113,6 +118,17 @@ func (j *agentDeployJob) Run(ctx context.Context) {
 	}
 }
 
+// recordRestartedAgent records that a host that bootstraps through user data
+// runs the current agent. Such hosts can't be reached over SSH, but download
+// the current agent every time the old one exits, which it does once the
+// host is flagged for a new agent.
+func (j *agentDeployJob) recordRestartedAgent() error {
+	if err := j.host.SetAgentRevision(evergreen.BuildRevision); err != nil {
+		return errors.Wrapf(err, "error setting agent revision on host %s", j.host.Id)
+	}
+	return errors.Wrapf(j.host.SetNeedsNewAgent(false), "error setting needs agent flag on host %s", j.host.Id)
+}
+
 // SSHTimeout defines the timeout for the SSH commands in this package.
 const sshTimeout = 25 * time.Second
 
@@ -265,6 +281,9 @@ func (j *agentDeployJob) startAgentOnRemote(ctx context.Context, settings *everg
 		fmt.Sprintf("--working_directory='%s'", hostObj.Distro.WorkDir),
 		"--cleanup",
 	}
+	if hostObj.Distro.Provider == evergreen.ProviderNameEc2Spot {
+		agentCmdParts = append(agentCmdParts, fmt.Sprintf("--spot_interruption_url='%s'", cloud.EC2SpotInterruptionURL))
+	}
 
 	// build the command to run on the remote machine
 	remoteCmd := strings.Join(agentCmdParts, " ")
@@ -14,9 +14,11 @@ import (
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/alerts"
 	"github.com/evergreen-ci/evergreen/cloud"
+	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/evergreen-ci/evergreen/notify"
 	"github.com/evergreen-ci/evergreen/subprocess"
@@ -28,6 +30,7 @@ import (
 	"github.com/mongodb/grip"
 	"github.com/mongodb/grip/message"
 	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
 )
 
 const setupHostJobName = "provisioning-setup-host"
@@ -125,6 +128,31 @@ func (j *setupHostJob) setupHost(ctx context.Context, h *host.Host, settings *ev
 		return errors.Wrapf(err, "hostinit canceled during setup for host %s", h.Id)
 	}
 
+	// hosts that start their own agent have nothing to set up over SSH
+	if util.StringSliceContains(evergreen.ProviderAgentSelfStarting, h.Provider) {
+		grip.Info(message.Fields{
+			"message":  "skipping setup for host that starts its own agent",
+			"runner":   HostInit,
+			"distro":   h.Distro.Id,
+			"hostid":   h.Id,
+			"provider": h.Provider,
+		})
+		return errors.Wrapf(h.MarkAsProvisioned(), "error marking host %s as provisioned", h.Id)
+	}
+
+	// hosts that bootstrap through user data set themselves up and report
+	// the result to the API server
+	if h.Distro.BootstrapsWithUserData() {
+		grip.Info(message.Fields{
+			"message":  "waiting for host to bootstrap through user data",
+			"runner":   HostInit,
+			"distro":   h.Distro.Id,
+			"hostid":   h.Id,
+			"provider": h.Provider,
+		})
+		return nil
+	}
+
 	setupStartTime := time.Now()
 	grip.Info(message.Fields{
 		"message": "provisioning host",
@@ -183,6 +211,10 @@ func (j *setupHostJob) setupHost(ctx context.Context, h *host.Host, settings *ev
 const (
 	HostInit   = "hostinit"
 	scpTimeout = time.Minute
+
+	// spawnHostExpansionsFileName is the file in the user's home directory
+	// that a host spawned from a task has the task's expansions written to.
+	spawnHostExpansionsFileName = "task_expansions.yml"
 )
 
 func (j *setupHostJob) setDNSName(ctx context.Context, host *host.Host, settings *evergreen.Settings) error {
@@ -486,6 +518,16 @@ func (j *setupHostJob) provisionHost(ctx context.Context, h *host.Host, settings
 			return errors.Wrapf(err, "error running setup script on remote host: %s", logs)
 		}
 
+		if h.ProvisionOptions.HomeVolume {
+			grip.Error(message.WrapError(j.attachHomeVolume(ctx, cloudHost, sshOptions),
+				message.Fields{
+					"message": "failed to attach home volume to host",
+					"owner":   h.ProvisionOptions.OwnerId,
+					"host":    h.Id,
+					"runner":  HostInit,
+				}))
+		}
+
 		if h.ProvisionOptions.OwnerId != "" && len(h.ProvisionOptions.TaskId) > 0 {
 			grip.Info(message.Fields{
 				"message": "fetching data for task on host",
@@ -501,6 +543,14 @@ func (j *setupHostJob) provisionHost(ctx context.Context, h *host.Host, settings
 					"host":    h.Id,
 					"runner":  HostInit,
 				}))
+
+			grip.Error(message.WrapError(j.writeTaskExpansions(ctx, h.ProvisionOptions.TaskId, h, settings),
+				message.Fields{
+					"message": "failed to write task expansions onto host",
+					"task":    h.ProvisionOptions.TaskId,
+					"host":    h.Id,
+					"runner":  HostInit,
+				}))
 		}
 	}
 
@@ -751,3 +801,88 @@ func (j *setupHostJob) fetchRemoteTaskData(ctx context.Context, taskId, cliPath,
 	}
 	return nil
 }
+
+// writeTaskExpansions writes the expansions of the task the host was spawned
+// from to a YAML file in the home directory of the host's user, so that the
+// task's commands can be run by hand.
+func (j *setupHostJob) writeTaskExpansions(ctx context.Context, taskId string, target *host.Host, settings *evergreen.Settings) error {
+	t, err := task.FindOne(task.ById(taskId))
+	if err != nil {
+		return errors.Wrapf(err, "error finding task %s", taskId)
+	}
+	if t == nil {
+		return errors.Errorf("could not find task %s", taskId)
+	}
+
+	expansions, err := model.PopulateExpansions(t, &target.Distro)
+	if err != nil {
+		return errors.Wrapf(err, "error populating expansions for task %s", taskId)
+	}
+	expansionsYAML, err := yaml.Marshal(expansions.Map())
+	if err != nil {
+		return errors.Wrap(err, "error marshalling expansions")
+	}
+
+	tempFileName, err := util.WriteTempFile("", expansionsYAML)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer os.Remove(tempFileName)
+
+	hostSSHInfo, err := util.ParseSSHInfo(target.Host)
+	if err != nil {
+		return errors.Wrapf(err, "error parsing ssh info %s", target.Host)
+	}
+	cloudHost, err := cloud.GetCloudHost(ctx, target, settings)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to get cloud host for %v", target.Id)
+	}
+	sshOptions, err := cloudHost.GetSSHOptions()
+	if err != nil {
+		return errors.Wrapf(err, "Error getting ssh options for host %v", target.Id)
+	}
+	sshOptions = append(sshOptions, "-o", "UserKnownHostsFile=/dev/null")
+
+	scpOut := &util.CappedWriter{&bytes.Buffer{}, 1024 * 1024}
+	output := subprocess.OutputOptions{Output: scpOut, SendErrorToOutput: true}
+	scpCmd := subprocess.NewSCPCommand(
+		tempFileName,
+		fmt.Sprintf("~/%s", spawnHostExpansionsFileName),
+		hostSSHInfo.Hostname,
+		target.User,
+		append([]string{"-P", hostSSHInfo.Port}, sshOptions...))
+	if err = scpCmd.SetOutput(output); err != nil {
+		return errors.Wrap(err, "problem configuring output")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, scpTimeout)
+	defer cancel()
+
+	if err = scpCmd.Run(ctx); err != nil {
+		return errors.Wrapf(err, "error copying expansions to host, %v", scpOut.Buffer.String())
+	}
+
+	return nil
+}
+
+// attachHomeVolume attaches the home volume of the host's owner to the host
+// and mounts it. A host whose volume can't be attached is still usable, so
+// failures are recorded in the host's event log rather than failing the
+// host's provisioning.
+func (j *setupHostJob) attachHomeVolume(ctx context.Context, cloudHost *cloud.CloudHost, sshOptions []string) error {
+	h := cloudHost.Host
+	v, err := cloudHost.AttachHomeVolume(ctx)
+	if err != nil {
+		event.LogHostVolumeFailed(h.Id, err.Error())
+		return errors.WithStack(err)
+	}
+
+	logs, err := h.RunSSHCommand(ctx, cloud.HomeVolumeMountCommand(v), sshOptions)
+	if err != nil {
+		event.LogHostVolumeFailed(h.Id, logs)
+		return errors.Wrapf(err, "error mounting home volume %s: %s", v.ID, logs)
+	}
+	event.LogHostVolumeAttached(h.Id, v.ID)
+
+	return nil
+}
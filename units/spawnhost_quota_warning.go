@@ -0,0 +1,244 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/spawn"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/dependency"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const (
+	spawnhostQuotaWarningJobName = "spawnhost-quota-warning"
+
+	spawnhostQuotaWarningSubject = "Approaching your spawn host quota"
+)
+
+func init() {
+	registry.AddJobType(spawnhostQuotaWarningJobName, func() amboy.Job {
+		return makeSpawnhostQuotaWarningJob()
+	})
+}
+
+type spawnhostQuotaWarningJob struct {
+	// Week identifies the week the job warns users for, so that each user
+	// is warned at most once a week.
+	Week     string `bson:"week" json:"week" yaml:"week"`
+	job.Base `bson:"metadata" json:"metadata" yaml:"metadata"`
+
+	q     amboy.Queue
+	env   evergreen.Environment
+	flags *evergreen.ServiceFlags
+	now   time.Time
+}
+
+func makeSpawnhostQuotaWarningJob() *spawnhostQuotaWarningJob {
+	j := &spawnhostQuotaWarningJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    spawnhostQuotaWarningJobName,
+				Version: 0,
+			},
+		},
+	}
+	j.SetDependency(dependency.NewAlways())
+
+	return j
+}
+
+// NewSpawnhostQuotaWarningJob warns the users who have used most of one of
+// their spawn host quotas this month, through the subscriber they chose in
+// their notification settings.
+func NewSpawnhostQuotaWarningJob(q amboy.Queue, week string) amboy.Job {
+	j := makeSpawnhostQuotaWarningJob()
+	j.q = q
+	j.Week = week
+	j.SetID(fmt.Sprintf("%s.%s", spawnhostQuotaWarningJobName, week))
+	return j
+}
+
+// quotaWarningWeek returns the ISO week that the given time falls in, such
+// as "2018-W24".
+func quotaWarningWeek(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+func (j *spawnhostQuotaWarningJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+	if j.q == nil {
+		j.q = j.env.RemoteQueue()
+	}
+	if util.IsZeroTime(j.now) {
+		j.now = time.Now()
+	}
+	if j.flags == nil {
+		var err error
+		j.flags, err = evergreen.GetServiceFlags()
+		if err != nil {
+			j.AddError(errors.Wrap(err, "error retrieving service flags"))
+			return
+		}
+	}
+
+	conf := j.env.Settings().SpawnHost
+	if err := conf.ValidateAndDefault(); err != nil {
+		j.AddError(errors.Wrap(err, "invalid spawn host settings"))
+		return
+	}
+
+	usage, err := host.FindSpawnHostUsageForMonth(j.now)
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+	distrosByUser := map[string][]string{}
+	for _, u := range usage {
+		distrosByUser[u.User] = append(distrosByUser[u.User], u.Distro)
+	}
+
+	for userID, distros := range distrosByUser {
+		quotas, err := j.quotasNearLimit(userID, distros, conf)
+		if err != nil {
+			j.AddError(err)
+			continue
+		}
+		if len(quotas) == 0 {
+			continue
+		}
+		j.AddError(j.warn(userID, quotas))
+	}
+}
+
+// quotasNearLimit returns the user's quotas, overall and for the distros the
+// user has used this month, that the user has used enough of to be warned.
+func (j *spawnhostQuotaWarningJob) quotasNearLimit(userID string, distros []string, conf evergreen.SpawnHostConfig) ([]spawn.QuotaUsage, error) {
+	threshold := float64(conf.QuotaWarningPercent) / 100
+	nearLimit := []spawn.QuotaUsage{}
+
+	overall, err := spawn.GetQuotaUsage(userID, "", conf, j.now)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if overall[0].FractionUsed() >= threshold {
+		nearLimit = append(nearLimit, overall[0])
+	}
+
+	for _, distroID := range distros {
+		quotas, err := spawn.GetQuotaUsage(userID, distroID, conf, j.now)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		// the first quota is the overall quota, which is checked above
+		for _, q := range quotas[1:] {
+			if q.FractionUsed() >= threshold {
+				nearLimit = append(nearLimit, q)
+			}
+		}
+	}
+
+	return nearLimit, nil
+}
+
+func (j *spawnhostQuotaWarningJob) warn(userID string, quotas []spawn.QuotaUsage) error {
+	id := fmt.Sprintf("%s-%s-%s", spawnhostQuotaWarningJobName, userID, j.Week)
+	existing, err := notification.Find(id)
+	if err != nil {
+		return errors.Wrapf(err, "error finding notification %s", id)
+	}
+	if existing != nil {
+		return nil
+	}
+
+	dbUser, err := user.FindOne(user.ById(userID))
+	if err != nil {
+		return errors.Wrapf(err, "error finding user %s", userID)
+	}
+	if dbUser == nil {
+		return nil
+	}
+
+	body := spawnhostQuotaWarningBody(quotas)
+	n := notification.Notification{ID: id}
+	switch dbUser.Settings.Notifications.SpawnHostQuota {
+	case user.PreferenceNone:
+		return nil
+	case user.PreferenceSlack:
+		if dbUser.Settings.SlackUsername == "" {
+			return nil
+		}
+		n.Subscriber = event.NewSlackSubscriber(fmt.Sprintf("@%s", dbUser.Settings.SlackUsername))
+		n.Payload = &notification.SlackPayload{Body: body}
+	default:
+		if dbUser.Email() == "" {
+			return nil
+		}
+		n.Subscriber = event.NewEmailSubscriber(dbUser.Email())
+		n.Payload = &message.Email{
+			Subject:           spawnhostQuotaWarningSubject,
+			Body:              body,
+			PlainTextContents: true,
+		}
+	}
+
+	if err = notification.InsertMany(n); err != nil {
+		return errors.Wrapf(err, "error saving notification %s", id)
+	}
+	if !notificationIsEnabled(j.flags, &n) {
+		return errors.WithStack(n.MarkError(errors.New("sender disabled")))
+	}
+
+	grip.Info(message.Fields{
+		"message": "warning user approaching spawn host quota",
+		"job":     j.ID(),
+		"user":    userID,
+		"quotas":  len(quotas),
+	})
+	return errors.WithStack(j.q.Put(newEventNotificationJob(n.ID)))
+}
+
+// spawnhostQuotaWarningBody describes how much of each quota the user has
+// used.
+func spawnhostQuotaWarningBody(quotas []spawn.QuotaUsage) string {
+	lines := []string{"You are approaching your spawn host quota for this month:"}
+	for _, u := range quotas {
+		scope := "all distros"
+		if u.Quota.Distro != "" {
+			scope = fmt.Sprintf("distro '%s'", u.Quota.Distro)
+		}
+		limits := []string{}
+		if u.Quota.MaxHosts > 0 {
+			limits = append(limits, fmt.Sprintf("%d of %d hosts", u.Hosts, u.Quota.MaxHosts))
+		}
+		if u.Quota.MaxHostHoursPerMonth > 0 {
+			limits = append(limits, fmt.Sprintf("%.1f of %d host-hours", u.HostHours, u.Quota.MaxHostHoursPerMonth))
+		}
+		if u.Quota.MaxSpendPerMonth > 0 {
+			limits = append(limits, fmt.Sprintf("$%.2f of $%.2f", u.Spend, u.Quota.MaxSpendPerMonth))
+		}
+		sort.Strings(limits)
+		lines = append(lines, fmt.Sprintf("  %s: %s", scope, strings.Join(limits, ", ")))
+	}
+	lines = append(lines, "Once you reach a quota, you can't spawn more hosts until your usage falls below it or the month ends.")
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,103 @@
+package units
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/spawn"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/dependency"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const spawnhostStartJobName = "spawnhost-start"
+
+func init() {
+	registry.AddJobType(spawnhostStartJobName, func() amboy.Job {
+		return makeSpawnhostStartJob()
+	})
+}
+
+type spawnhostStartJob struct {
+	HostID   string `bson:"host_id" json:"host_id" yaml:"host_id"`
+	UserID   string `bson:"user_id" json:"user_id" yaml:"user_id"`
+	job.Base `bson:"metadata" json:"metadata" yaml:"metadata"`
+
+	host *host.Host
+	env  evergreen.Environment
+}
+
+func makeSpawnhostStartJob() *spawnhostStartJob {
+	j := &spawnhostStartJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    spawnhostStartJobName,
+				Version: 0,
+			},
+		},
+	}
+	j.SetDependency(dependency.NewAlways())
+
+	return j
+}
+
+// NewSpawnhostStartJob starts a stopped spawn host in its cloud provider on
+// behalf of the given user, who is either the host's owner or Evergreen
+// enforcing the host's sleep schedule.
+func NewSpawnhostStartJob(h host.Host, user, id string) amboy.Job {
+	j := makeSpawnhostStartJob()
+	j.host = &h
+	j.HostID = h.Id
+	j.UserID = user
+	j.SetID(fmt.Sprintf("%s.%s.%s", spawnhostStartJobName, j.HostID, id))
+	return j
+}
+
+func (j *spawnhostStartJob) Run(ctx context.Context) {
+	var err error
+	defer j.MarkComplete()
+
+	if j.host == nil {
+		j.host, err = host.FindOneId(j.HostID)
+		if err != nil {
+			j.AddError(err)
+			return
+		}
+		if j.host == nil {
+			j.AddError(errors.Errorf("could not find host %s", j.HostID))
+			return
+		}
+	}
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+
+	if j.host.Status == evergreen.HostRunning {
+		return
+	}
+
+	if err = spawn.StartHost(ctx, j.host, j.env.Settings(), j.UserID); err != nil {
+		grip.Error(message.WrapError(err, message.Fields{
+			"message": "failed to start spawn host",
+			"job":     j.ID(),
+			"host":    j.host.Id,
+			"user":    j.UserID,
+			"distro":  j.host.Distro.Id,
+		}))
+		event.LogHostStartFailed(j.host.Id, j.UserID, err.Error())
+		j.AddError(err)
+		return
+	}
+
+	if j.UserID == evergreen.User && j.host.SleepSchedule != nil {
+		j.AddError(errors.Wrapf(j.host.SetAsleepBySchedule(false),
+			"error recording that host %s is awake", j.host.Id))
+	}
+}
@@ -0,0 +1,75 @@
+package units
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/cloud"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/mock"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpawnhostSleepScheduleJobs(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	db.SetGlobalSessionProvider(testutil.TestConfig().SessionFactory())
+	require.NoError(db.ClearCollections(host.Collection, event.AllLogCollection))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	env := &mock.Environment{}
+	require.NoError(env.Configure(ctx, "", nil))
+
+	mockCloud := cloud.GetMockProvider()
+	mockCloud.Reset()
+	defer mockCloud.Reset()
+
+	h := host.Host{
+		Id:            "h1",
+		Distro:        distro.Distro{Id: "d1"},
+		Provider:      evergreen.ProviderNameMock,
+		Status:        evergreen.HostRunning,
+		StartedBy:     "user0",
+		UserHost:      true,
+		SleepSchedule: &host.SleepSchedule{StopHour: 20, StartHour: 8},
+	}
+	require.NoError(h.Insert())
+
+	// the host can't be stopped, so the schedule hasn't put it to sleep
+	stop := NewSpawnhostStopJob(h, evergreen.User, "one").(*spawnhostStopJob)
+	stop.env = env
+	stop.Run(ctx)
+	assert.Error(stop.Error())
+	dbHost, err := host.FindOneId(h.Id)
+	require.NoError(err)
+	require.NotNil(dbHost)
+	assert.False(dbHost.SleepSchedule.Asleep)
+
+	mockCloud.Set(h.Id, cloud.MockInstance{Status: cloud.StatusRunning})
+	stop = NewSpawnhostStopJob(h, evergreen.User, "two").(*spawnhostStopJob)
+	stop.env = env
+	stop.Run(ctx)
+	require.NoError(stop.Error())
+	dbHost, err = host.FindOneId(h.Id)
+	require.NoError(err)
+	require.NotNil(dbHost)
+	assert.Equal(evergreen.HostStopped, dbHost.Status)
+	assert.True(dbHost.SleepSchedule.Asleep)
+
+	start := NewSpawnhostStartJob(*dbHost, evergreen.User, "three").(*spawnhostStartJob)
+	start.env = env
+	start.Run(ctx)
+	require.NoError(start.Error())
+	dbHost, err = host.FindOneId(h.Id)
+	require.NoError(err)
+	require.NotNil(dbHost)
+	assert.Equal(evergreen.HostRunning, dbHost.Status)
+	assert.False(dbHost.SleepSchedule.Asleep)
+}
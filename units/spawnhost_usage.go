@@ -0,0 +1,160 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/cloud"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/dependency"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const spawnhostUsageJobName = "spawnhost-usage"
+
+func init() {
+	registry.AddJobType(spawnhostUsageJobName, func() amboy.Job {
+		return makeSpawnhostUsageJob()
+	})
+}
+
+type spawnhostUsageJob struct {
+	HostID   string `bson:"host_id" json:"host_id" yaml:"host_id"`
+	job.Base `bson:"metadata" json:"metadata" yaml:"metadata"`
+
+	host    *host.Host
+	env     evergreen.Environment
+	manager cloud.Manager
+	now     time.Time
+}
+
+func makeSpawnhostUsageJob() *spawnhostUsageJob {
+	j := &spawnhostUsageJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    spawnhostUsageJobName,
+				Version: 0,
+			},
+		},
+	}
+	j.SetDependency(dependency.NewAlways())
+
+	return j
+}
+
+// NewSpawnhostUsageJob adds the time a spawn host has run, and what that
+// time cost, to its owner's usage since the last time the job ran for the
+// host.
+func NewSpawnhostUsageJob(h host.Host, id string) amboy.Job {
+	j := makeSpawnhostUsageJob()
+	j.host = &h
+	j.HostID = h.Id
+	j.SetID(fmt.Sprintf("%s.%s.%s", spawnhostUsageJobName, j.HostID, id))
+	return j
+}
+
+func (j *spawnhostUsageJob) Run(ctx context.Context) {
+	var err error
+	defer j.MarkComplete()
+
+	if j.host == nil {
+		j.host, err = host.FindOneId(j.HostID)
+		if err != nil {
+			j.AddError(err)
+			return
+		}
+		if j.host == nil {
+			j.AddError(errors.Errorf("could not find host %s", j.HostID))
+			return
+		}
+	}
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+	if util.IsZeroTime(j.now) {
+		j.now = time.Now()
+	}
+
+	start := j.host.UsageRecordedUntil
+	if util.IsZeroTime(start) {
+		start = j.host.StartTime
+	}
+	if util.IsZeroTime(start) {
+		start = j.host.CreationTime
+	}
+	end := j.now
+	if j.host.Status == evergreen.HostTerminated && !util.IsZeroTime(j.host.TerminationTime) {
+		end = j.host.TerminationTime
+	}
+	if !end.After(start) {
+		return
+	}
+
+	// a stopped host isn't charged for the time since the job last ran,
+	// which may slightly undercount hosts that stopped in the meantime
+	if j.host.Status != evergreen.HostStopped {
+		if err = j.recordUsage(ctx, start, end); err != nil {
+			j.AddError(err)
+			return
+		}
+	}
+
+	j.AddError(j.host.SetUsageRecordedUntil(end))
+}
+
+// recordUsage adds the time between start and end to the owner's usage,
+// splitting it at the start of each month so that it counts toward the
+// right month's quota.
+func (j *spawnhostUsageJob) recordUsage(ctx context.Context, start, end time.Time) error {
+	if j.manager == nil {
+		var err error
+		j.manager, err = cloud.GetManager(ctx, j.host.Provider, j.env.Settings())
+		if err != nil {
+			return errors.Wrapf(err, "error getting cloud manager for host %s", j.host.Id)
+		}
+	}
+	calc, canCalculateCost := j.manager.(cloud.CostCalculator)
+
+	catcher := grip.NewBasicCatcher()
+	for chunkStart := start; chunkStart.Before(end); {
+		chunkEnd := host.MonthStart(chunkStart).AddDate(0, 1, 0)
+		if chunkEnd.After(end) {
+			chunkEnd = end
+		}
+
+		var cost float64
+		if canCalculateCost {
+			var err error
+			cost, err = calc.CostForDuration(ctx, j.host, chunkStart, chunkEnd)
+			if err != nil {
+				// the time is still counted when its cost can't be
+				grip.Warning(message.WrapError(err, message.Fields{
+					"message": "problem calculating spawn host cost",
+					"job":     j.ID(),
+					"host":    j.host.Id,
+					"user":    j.host.StartedBy,
+				}))
+				cost = 0
+			}
+		}
+
+		hours := chunkEnd.Sub(chunkStart).Hours()
+		if err := host.IncSpawnHostUsage(j.host.StartedBy, j.host.Distro.Id, chunkStart, hours, cost); err != nil {
+			return errors.WithStack(err)
+		}
+		if cost > 0 {
+			catcher.Add(j.host.IncCost(cost))
+		}
+		chunkStart = chunkEnd
+	}
+
+	return catcher.Resolve()
+}
@@ -0,0 +1,79 @@
+package units
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/cloud"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/mock"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpawnhostUsageJob(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	db.SetGlobalSessionProvider(testutil.TestConfig().SessionFactory())
+	require.NoError(db.ClearCollections(host.Collection, host.SpawnHostUsageCollection))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	env := &mock.Environment{}
+	require.NoError(env.Configure(ctx, "", nil))
+
+	mockCloud := cloud.GetMockProvider()
+	mockCloud.Reset()
+	defer mockCloud.Reset()
+
+	// the host ran for two hours across the start of a month
+	monthStart := time.Date(2018, time.June, 1, 0, 0, 0, 0, time.UTC)
+	h := host.Host{
+		Id:           "h1",
+		Distro:       distro.Distro{Id: "d1"},
+		Provider:     evergreen.ProviderNameMock,
+		Status:       evergreen.HostRunning,
+		StartedBy:    "user0",
+		UserHost:     true,
+		StartTime:    monthStart.Add(-time.Hour),
+		CreationTime: monthStart.Add(-time.Hour),
+	}
+	require.NoError(h.Insert())
+	mockCloud.Set(h.Id, cloud.MockInstance{Status: cloud.StatusRunning, HourlyCost: 0.5})
+
+	j := NewSpawnhostUsageJob(h, "ts").(*spawnhostUsageJob)
+	j.env = env
+	j.now = monthStart.Add(time.Hour)
+	j.Run(ctx)
+	require.NoError(j.Error())
+
+	for _, month := range []time.Time{monthStart.Add(-time.Hour), monthStart} {
+		usage, err := host.FindSpawnHostUsage("user0", month)
+		require.NoError(err)
+		require.Len(usage, 1)
+		assert.Equal("d1", usage[0].Distro)
+		assert.InDelta(1.0, usage[0].HostHours, 0.001)
+		assert.InDelta(0.5, usage[0].Cost, 0.001)
+	}
+
+	dbHost, err := host.FindOneId(h.Id)
+	require.NoError(err)
+	assert.True(j.now.Equal(dbHost.UsageRecordedUntil))
+	assert.InDelta(1.0, dbHost.TotalCost, 0.001)
+
+	// time that was already recorded isn't recorded again
+	j = NewSpawnhostUsageJob(*dbHost, "ts2").(*spawnhostUsageJob)
+	j.env = env
+	j.now = monthStart.Add(2 * time.Hour)
+	j.Run(ctx)
+	require.NoError(j.Error())
+	usage, err := host.FindSpawnHostUsage("user0", monthStart)
+	require.NoError(err)
+	require.Len(usage, 1)
+	assert.InDelta(2.0, usage[0].HostHours, 0.001)
+}
@@ -0,0 +1,57 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, expr := range []string{"* * * * *", "0 2 * * 1-5", "*/15 0,12 1 */3 7", "@daily", " @hourly "} {
+		_, err := ParseCron(expr)
+		assert.NoError(err, expr)
+	}
+
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8",
+		"5-1 * * * *", "*/0 * * * *", "a * * * *", "@sometimes"} {
+		_, err := ParseCron(expr)
+		assert.Error(err, expr)
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	// a Wednesday
+	start := time.Date(2018, time.June, 13, 10, 30, 15, 0, time.UTC)
+
+	for expr, expected := range map[string]time.Time{
+		"* * * * *":       time.Date(2018, time.June, 13, 10, 31, 0, 0, time.UTC),
+		"@hourly":         time.Date(2018, time.June, 13, 11, 0, 0, 0, time.UTC),
+		"@daily":          time.Date(2018, time.June, 14, 0, 0, 0, 0, time.UTC),
+		"@weekly":         time.Date(2018, time.June, 17, 0, 0, 0, 0, time.UTC),
+		"@monthly":        time.Date(2018, time.July, 1, 0, 0, 0, 0, time.UTC),
+		"@yearly":         time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC),
+		"*/20 * * * *":    time.Date(2018, time.June, 13, 10, 40, 0, 0, time.UTC),
+		"0 2 * * 1-5":     time.Date(2018, time.June, 14, 2, 0, 0, 0, time.UTC),
+		"0 2 * * 6":       time.Date(2018, time.June, 16, 2, 0, 0, 0, time.UTC),
+		"30 10 * * 3":     time.Date(2018, time.June, 20, 10, 30, 0, 0, time.UTC),
+		"0 0 29 2 *":      time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC),
+		"0 0 20 * 1":      time.Date(2018, time.June, 18, 0, 0, 0, 0, time.UTC),
+		"15 9,18 * Jun *": {},
+	} {
+		schedule, err := ParseCron(expr)
+		if expected.IsZero() {
+			assert.Error(t, err, expr)
+			continue
+		}
+		require.NoError(t, err, expr)
+		assert.Equal(t, expected, schedule.Next(start), expr)
+	}
+
+	schedule, err := ParseCron("0 0 31 2 *")
+	require.NoError(t, err)
+	assert.True(t, schedule.Next(start).IsZero())
+}
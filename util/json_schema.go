@@ -0,0 +1,135 @@
+package util
+
+import (
+	"reflect"
+	"strings"
+)
+
+// JSONSchemaDraft is the version of JSON Schema that schemas are written in.
+const JSONSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// JSONSchema is a JSON Schema document, or a part of one. Type is either a
+// single type name or a list of them, and AdditionalProperties is either a
+// bool or a schema.
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 interface{}            `json:"type,omitempty"`
+	Enum                 []interface{}          `json:"enum,omitempty"`
+	Const                interface{}            `json:"const,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties interface{}            `json:"additionalProperties,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	AnyOf                []*JSONSchema          `json:"anyOf,omitempty"`
+	AllOf                []*JSONSchema          `json:"allOf,omitempty"`
+	If                   *JSONSchema            `json:"if,omitempty"`
+	Then                 *JSONSchema            `json:"then,omitempty"`
+	Definitions          map[string]*JSONSchema `json:"definitions,omitempty"`
+}
+
+// JSONSchemaOneOrMany returns a schema that accepts either a single value
+// matching the given schema or a list of them.
+func JSONSchemaOneOrMany(s *JSONSchema) *JSONSchema {
+	return &JSONSchema{AnyOf: []*JSONSchema{s, {Type: "array", Items: s}}}
+}
+
+// JSONSchemaReflector builds JSON schemas from Go types, the way the
+// decoder that uses the struct tag Tag reads them. As in YAML and
+// mapstructure, fields without names in their tags are named after the
+// field in lowercase, and fields tagged "-" are skipped.
+type JSONSchemaReflector struct {
+	Tag string
+
+	// Overrides are the schemas of types that aren't decoded from their
+	// fields, such as those with custom unmarshalers.
+	Overrides map[reflect.Type]*JSONSchema
+
+	// ScalarStrings allows strings to be given as any scalar, since YAML
+	// decodes numbers and bools into strings.
+	ScalarStrings bool
+
+	// StringScalars allows any scalar to be given as a string, for values
+	// that are decoded only after their expansions are expanded.
+	StringScalars bool
+
+	// OpenStructs allows structs to have properties that aren't fields,
+	// rather than rejecting them.
+	OpenStructs bool
+}
+
+// Reflect returns the schema of the given type.
+func (r *JSONSchemaReflector) Reflect(t reflect.Type) *JSONSchema {
+	if s, ok := r.Overrides[t]; ok {
+		return s
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return r.Reflect(t.Elem())
+	case reflect.Struct:
+		s := &JSONSchema{Type: "object", Properties: r.ReflectFields(t)}
+		if !r.OpenStructs {
+			s.AdditionalProperties = false
+		}
+		return s
+	case reflect.Map:
+		return &JSONSchema{Type: "object", AdditionalProperties: r.Reflect(t.Elem())}
+	case reflect.Slice, reflect.Array:
+		return &JSONSchema{Type: "array", Items: r.Reflect(t.Elem())}
+	case reflect.String:
+		if r.ScalarStrings {
+			return &JSONSchema{Type: []string{"string", "number", "boolean"}}
+		}
+		return &JSONSchema{Type: "string"}
+	case reflect.Bool:
+		return r.scalar("boolean")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return r.scalar("integer")
+	case reflect.Float32, reflect.Float64:
+		return r.scalar("number")
+	default:
+		// interfaces can hold anything
+		return &JSONSchema{}
+	}
+}
+
+func (r *JSONSchemaReflector) scalar(typeName string) *JSONSchema {
+	if r.StringScalars {
+		return &JSONSchema{Type: []string{typeName, "string"}}
+	}
+	return &JSONSchema{Type: typeName}
+}
+
+// ReflectFields returns the schemas of the fields of the given struct type,
+// by name. The fields of inlined structs are included.
+func (r *JSONSchemaReflector) ReflectFields(t reflect.Type) map[string]*JSONSchema {
+	properties := map[string]*JSONSchema{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			// unexported fields, including embedded unexported structs,
+			// aren't decoded
+			continue
+		}
+		tag := strings.Split(field.Tag.Get(r.Tag), ",")
+		name := tag[0]
+		if name == "-" {
+			continue
+		}
+		if StringSliceContains(tag[1:], "inline") || StringSliceContains(tag[1:], "squash") {
+			for k, v := range r.ReflectFields(field.Type) {
+				properties[k] = v
+			}
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		properties[name] = r.Reflect(field.Type)
+	}
+	return properties
+}
@@ -0,0 +1,79 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type schemaTestInner struct {
+	Inner string `yaml:"inner"`
+}
+
+type schemaTestStruct struct {
+	Name     string            `yaml:"name"`
+	Count    int               `yaml:"count,omitempty"`
+	Enabled  bool              `yaml:"enabled"`
+	Tags     []string          `yaml:"tags"`
+	Vars     map[string]string `yaml:"vars"`
+	Skipped  string            `yaml:"-"`
+	Untagged float64
+	Any      interface{}      `yaml:"any"`
+	Nested   *schemaTestInner `yaml:"nested"`
+	Inlined  schemaTestInner  `yaml:",inline"`
+	hidden   string
+}
+
+func TestJSONSchemaReflector(t *testing.T) {
+	assert := assert.New(t)
+
+	r := &JSONSchemaReflector{Tag: "yaml"}
+	s := r.Reflect(reflect.TypeOf(schemaTestStruct{}))
+	assert.Equal("object", s.Type)
+	assert.Equal(false, s.AdditionalProperties)
+	assert.Len(s.Properties, 9)
+
+	assert.Equal("string", s.Properties["name"].Type)
+	assert.Equal("integer", s.Properties["count"].Type)
+	assert.Equal("boolean", s.Properties["enabled"].Type)
+	assert.Equal("array", s.Properties["tags"].Type)
+	assert.Equal("string", s.Properties["tags"].Items.Type)
+	assert.Equal("object", s.Properties["vars"].Type)
+	assert.Equal("string", s.Properties["vars"].AdditionalProperties.(*JSONSchema).Type)
+	assert.Equal("number", s.Properties["untagged"].Type)
+	assert.Nil(s.Properties["any"].Type)
+	assert.Equal("string", s.Properties["nested"].Properties["inner"].Type)
+	assert.Equal("string", s.Properties["inner"].Type)
+	assert.NotContains(s.Properties, "skipped")
+	assert.NotContains(s.Properties, "-")
+	assert.NotContains(s.Properties, "hidden")
+
+	r.ScalarStrings = true
+	r.Overrides = map[reflect.Type]*JSONSchema{
+		reflect.TypeOf(schemaTestInner{}): {Type: "string"},
+	}
+	s = r.Reflect(reflect.TypeOf(schemaTestStruct{}))
+	assert.Equal([]string{"string", "number", "boolean"}, s.Properties["name"].Type)
+	assert.Equal("string", s.Properties["nested"].Type)
+
+	r = &JSONSchemaReflector{Tag: "yaml", StringScalars: true, OpenStructs: true}
+	s = r.Reflect(reflect.TypeOf(schemaTestStruct{}))
+	assert.Nil(s.AdditionalProperties)
+	assert.Nil(s.Properties["nested"].AdditionalProperties)
+	assert.Equal("string", s.Properties["name"].Type)
+	assert.Equal([]string{"integer", "string"}, s.Properties["count"].Type)
+	assert.Equal([]string{"boolean", "string"}, s.Properties["enabled"].Type)
+	assert.Equal([]string{"number", "string"}, s.Properties["untagged"].Type)
+}
+
+func TestJSONSchemaOneOrMany(t *testing.T) {
+	assert := assert.New(t)
+
+	single := &JSONSchema{Type: "string"}
+	s := JSONSchemaOneOrMany(single)
+	assert.Len(s.AnyOf, 2)
+	assert.Equal(single, s.AnyOf[0])
+	assert.Equal("array", s.AnyOf[1].Type)
+	assert.Equal(single, s.AnyOf[1].Items)
+}
@@ -18,6 +18,17 @@ func Min(a ...int) int {
 	return min
 }
 
+// max function for ints
+func Max(a ...int) int {
+	max := -int(^uint(0)>>1) - 1 // smallest int
+	for _, i := range a {
+		if i > max {
+			max = i
+		}
+	}
+	return max
+}
+
 // TryParseFloat takes an input string and validates that it is a valid finite
 // floating point number. The number is returned if valid, NaN if not
 func TryParseFloat(s string) (float64, error) {
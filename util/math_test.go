@@ -13,3 +13,11 @@ func TestMinInts(t *testing.T) {
 		So(Min(1, 5, 2, -10, 0), ShouldEqual, -10)
 	})
 }
+
+func TestMaxInts(t *testing.T) {
+	Convey("Max should return the maximum of the inputs passed in, or the smallest possible int with no inputs", t, func() {
+		So(Max(), ShouldEqual, -int(^uint(0)>>1)-1)
+		So(Max(1), ShouldEqual, 1)
+		So(Max(1, 5, 2, -10, 0), ShouldEqual, 5)
+	})
+}
@@ -3,6 +3,7 @@ package validator
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/cloud"
@@ -20,6 +21,10 @@ var distroSyntaxValidators = []distroValidator{
 	ensureValidSSHOptions,
 	ensureValidExpansions,
 	ensureStaticHostsAreNotSpawnable,
+	ensureValidWarmPool,
+	ensureValidBootstrapMethod,
+	ensureValidSSHProxy,
+	ensureValidReprovisioning,
 }
 
 // CheckDistro checks if the distro configuration syntax is valid. Returns
@@ -57,6 +62,113 @@ func ensureStaticHostsAreNotSpawnable(ctx context.Context, d *distro.Distro, s *
 	return nil
 }
 
+// ensureValidWarmPool checks that the minimum and hot standby host counts fit
+// in the distro's pool, and that the standby hours are hours of the day.
+func ensureValidWarmPool(ctx context.Context, d *distro.Distro, s *evergreen.Settings) []ValidationError {
+	if !d.HasWarmPool() {
+		return nil
+	}
+
+	errs := []ValidationError{}
+	if !d.IsEphemeral() {
+		errs = append(errs, ValidationError{Error,
+			fmt.Sprintf("distro %s cannot keep a warm pool because its provider is %s", d.Id, d.Provider)})
+	}
+
+	if d.MinHosts < 0 || d.MinHosts > d.PoolSize {
+		errs = append(errs, ValidationError{Error,
+			fmt.Sprintf("distro '%v' must be between 0 and the pool size", distro.MinHostsKey)})
+	}
+
+	if d.HotStandby.NumHosts < 0 || d.HotStandby.NumHosts > d.PoolSize {
+		errs = append(errs, ValidationError{Error,
+			"number of hot standby hosts must be between 0 and the pool size"})
+	}
+
+	for _, hour := range []int{d.HotStandby.StartHour, d.HotStandby.EndHour} {
+		if hour < 0 || hour > 23 {
+			errs = append(errs, ValidationError{Error,
+				fmt.Sprintf("hot standby hour %d must be between 0 and 23", hour)})
+		}
+	}
+
+	return errs
+}
+
+// ensureValidBootstrapMethod checks that the distro's bootstrap method is
+// known and, for user data, that the provider can pass user data to its hosts.
+func ensureValidBootstrapMethod(ctx context.Context, d *distro.Distro, s *evergreen.Settings) []ValidationError {
+	if d.BootstrapMethod == "" {
+		return nil
+	}
+
+	if !util.StringSliceContains(distro.ValidBootstrapMethods, d.BootstrapMethod) {
+		return []ValidationError{{Error,
+			fmt.Sprintf("'%s' is not a valid bootstrap method, must be one of %v", d.BootstrapMethod, distro.ValidBootstrapMethods)}}
+	}
+
+	if !d.BootstrapsWithUserData() {
+		return nil
+	}
+
+	errs := []ValidationError{}
+	if !util.StringSliceContains(evergreen.ProviderUserDataBootstrap, d.Provider) {
+		errs = append(errs, ValidationError{Error,
+			fmt.Sprintf("distro %s cannot bootstrap with user data because its provider is %s", d.Id, d.Provider)})
+	}
+	if d.IsWindows() {
+		errs = append(errs, ValidationError{Error,
+			fmt.Sprintf("distro %s cannot bootstrap with user data because it runs windows", d.Id)})
+	}
+
+	return errs
+}
+
+// ensureValidSSHProxy checks that a distro's SSH proxy has a pinned host key
+// and that its port and key are usable.
+func ensureValidSSHProxy(ctx context.Context, d *distro.Distro, s *evergreen.Settings) []ValidationError {
+	if d.SSHProxy == nil || d.SSHProxy.Host == "" {
+		return nil
+	}
+
+	errs := []ValidationError{}
+	if d.SSHProxy.HostKey == "" {
+		errs = append(errs, ValidationError{Error,
+			fmt.Sprintf("ssh proxy %s for distro %s must have a pinned host key", d.SSHProxy.Host, d.Id)})
+	} else if len(strings.Fields(d.SSHProxy.HostKey)) < 2 {
+		errs = append(errs, ValidationError{Error,
+			fmt.Sprintf("ssh proxy host key '%s' must be of the form '<type> <key>'", d.SSHProxy.HostKey)})
+	}
+
+	if d.SSHProxy.Port < 0 || d.SSHProxy.Port > 65535 {
+		errs = append(errs, ValidationError{Error,
+			fmt.Sprintf("ssh proxy port %d must be between 0 and 65535", d.SSHProxy.Port)})
+	}
+
+	if d.SSHProxy.SSHKey != "" {
+		if _, ok := s.Keys[d.SSHProxy.SSHKey]; !ok {
+			errs = append(errs, ValidationError{Error,
+				fmt.Sprintf("ssh proxy key '%s' is not one of the configured keys", d.SSHProxy.SSHKey)})
+		}
+	}
+
+	return errs
+}
+
+// ensureValidReprovisioning checks that only static distros reprovision their
+// hosts after a number of tasks, since other hosts are replaced instead.
+func ensureValidReprovisioning(ctx context.Context, d *distro.Distro, s *evergreen.Settings) []ValidationError {
+	if d.ReprovisionAfterTasks < 0 {
+		return []ValidationError{{Error,
+			fmt.Sprintf("distro %s cannot reprovision its hosts after a negative number of tasks", d.Id)}}
+	}
+	if d.ReprovisionAfterTasks > 0 && d.Provider != evergreen.ProviderNameStatic {
+		return []ValidationError{{Error,
+			fmt.Sprintf("distro %s cannot reprovision its hosts because its provider is %s, not static", d.Id, d.Provider)}}
+	}
+	return nil
+}
+
 // ensureHasRequiredFields check that the distro configuration has all the required fields
 func ensureHasRequiredFields(ctx context.Context, d *distro.Distro, s *evergreen.Settings) []ValidationError {
 	errs := []ValidationError{}
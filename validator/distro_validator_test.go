@@ -266,3 +266,105 @@ func TestEnsureNonZeroID(t *testing.T) {
 	assert.Nil(ensureHasNonZeroID(ctx, &distro.Distro{Id: "foo"}, conf))
 	assert.Nil(ensureHasNonZeroID(ctx, &distro.Distro{Id: " "}, conf))
 }
+
+func TestEnsureValidWarmPool(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := &distro.Distro{Id: "d", Provider: evergreen.ProviderNameEc2Auto, PoolSize: 10}
+	assert.Nil(ensureValidWarmPool(ctx, d, conf))
+
+	d.MinHosts = 2
+	d.HotStandby = distro.HotStandby{NumHosts: 4, StartHour: 22, EndHour: 6}
+	assert.Len(ensureValidWarmPool(ctx, d, conf), 0)
+
+	d.MinHosts = 11
+	assert.Len(ensureValidWarmPool(ctx, d, conf), 1)
+
+	d.MinHosts = 2
+	d.HotStandby.NumHosts = -1
+	assert.Len(ensureValidWarmPool(ctx, d, conf), 1)
+
+	d.HotStandby = distro.HotStandby{NumHosts: 4, StartHour: 24, EndHour: -1}
+	assert.Len(ensureValidWarmPool(ctx, d, conf), 2)
+
+	d.HotStandby = distro.HotStandby{}
+	d.Provider = evergreen.ProviderNameStatic
+	assert.Len(ensureValidWarmPool(ctx, d, conf), 1)
+}
+
+func TestEnsureValidBootstrapMethod(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := &distro.Distro{Id: "d", Arch: "linux_amd64", Provider: evergreen.ProviderNameStatic}
+	assert.Nil(ensureValidBootstrapMethod(ctx, d, conf))
+
+	d.BootstrapMethod = distro.BootstrapMethodSSH
+	assert.Nil(ensureValidBootstrapMethod(ctx, d, conf))
+
+	d.BootstrapMethod = "carrier-pigeon"
+	assert.Len(ensureValidBootstrapMethod(ctx, d, conf), 1)
+
+	d.BootstrapMethod = distro.BootstrapMethodUserData
+	assert.Len(ensureValidBootstrapMethod(ctx, d, conf), 1)
+
+	d.Provider = evergreen.ProviderNameEc2OnDemand
+	assert.Len(ensureValidBootstrapMethod(ctx, d, conf), 0)
+
+	d.Arch = "windows_amd64"
+	assert.Len(ensureValidBootstrapMethod(ctx, d, conf), 1)
+}
+
+func TestEnsureValidSSHProxy(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	settings := &evergreen.Settings{Keys: map[string]string{"bastion": "/keys/bastion"}}
+
+	d := &distro.Distro{Id: "d", Provider: evergreen.ProviderNameStatic}
+	assert.Nil(ensureValidSSHProxy(ctx, d, settings))
+
+	d.SSHProxy = &distro.SSHProxy{Host: "bastion.example.com", HostKey: "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIB"}
+	assert.Len(ensureValidSSHProxy(ctx, d, settings), 0)
+
+	d.SSHProxy.SSHKey = "bastion"
+	d.SSHProxy.Port = 2222
+	assert.Len(ensureValidSSHProxy(ctx, d, settings), 0)
+
+	d.SSHProxy.SSHKey = "unknown"
+	d.SSHProxy.Port = 70000
+	assert.Len(ensureValidSSHProxy(ctx, d, settings), 2)
+
+	d.SSHProxy = &distro.SSHProxy{Host: "bastion.example.com"}
+	assert.Len(ensureValidSSHProxy(ctx, d, settings), 1)
+
+	d.SSHProxy.HostKey = "AAAAC3NzaC1lZDI1NTE5AAAAIB"
+	assert.Len(ensureValidSSHProxy(ctx, d, settings), 1)
+}
+
+func TestEnsureValidReprovisioning(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := &distro.Distro{Id: "d", Provider: evergreen.ProviderNameStatic}
+	assert.Nil(ensureValidReprovisioning(ctx, d, conf))
+
+	d.ReprovisionAfterTasks = 50
+	assert.Nil(ensureValidReprovisioning(ctx, d, conf))
+
+	d.ReprovisionAfterTasks = -1
+	assert.Len(ensureValidReprovisioning(ctx, d, conf), 1)
+
+	d.ReprovisionAfterTasks = 50
+	d.Provider = evergreen.ProviderNameEc2OnDemand
+	assert.Len(ensureValidReprovisioning(ctx, d, conf), 1)
+}
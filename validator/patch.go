@@ -30,31 +30,40 @@ func GetPatchedProject(ctx context.Context, p *patch.Patch, githubOauthToken str
 	// try to get the remote project file data at the requested revision
 	var projectFileBytes []byte
 	hash := p.Githash
+	owner, repo := projectRef.Owner, projectRef.Repo
 
 	if p.IsGithubPRPatch() {
 		hash = p.GithubPatchData.HeadHash
+	} else if head := p.GetHead(); head != nil {
+		// the patch checks out a commit, so the config is read there
+		hash = head.HeadHash
+		if head.IsFork() {
+			owner, repo = head.Owner, head.Repo
+		}
 	}
 
-	githubFile, err := thirdparty.GetGithubFile(ctx, githubOauthToken, projectRef.Owner,
-		projectRef.Repo, projectRef.RemotePath, hash)
+	githubFile, err := thirdparty.GetGithubFile(ctx, githubOauthToken, owner,
+		repo, projectRef.RemotePath, hash)
 	if err != nil {
 		// if the project file doesn't exist, but our patch includes a project file,
 		// we try to apply the diff and proceed.
-		if !(p.ConfigChanged(projectRef.RemotePath) && thirdparty.IsFileNotFound(err)) {
+		if !(appliesDiff(p) && p.ConfigChanged(projectRef.RemotePath) && thirdparty.IsFileNotFound(err)) {
 			// return an error if the github error is network/auth-related or we aren't patching the config
-			return nil, errors.Wrapf(err, "Could not get github file at '%s/%s'@%s: %s", projectRef.Owner,
-				projectRef.Repo, projectRef.RemotePath, hash)
+			return nil, errors.Wrapf(err, "Could not get github file at '%s/%s'@%s: %s", owner,
+				repo, projectRef.RemotePath, hash)
 		}
 	} else {
 		// we successfully got the project file in base64, so we decode it
 		projectFileBytes, err = base64.StdEncoding.DecodeString(*githubFile.Content)
 		if err != nil {
-			return nil, errors.Wrapf(err, "Could not decode github file at '%s/%s'@%s: %s", projectRef.Owner,
-				projectRef.Repo, projectRef.RemotePath, hash)
+			return nil, errors.Wrapf(err, "Could not decode github file at '%s/%s'@%s: %s", owner,
+				repo, projectRef.RemotePath, hash)
 		}
 	}
 
 	project := &model.Project{}
+	includes := patchedIncludeFetcher(p, model.GithubIncludeFetcher(githubOauthToken,
+		owner, repo, hash))
 
 	// if the patched config exists, use that as the project file bytes.
 	if p.PatchedConfig != "" {
@@ -62,8 +71,8 @@ func GetPatchedProject(ctx context.Context, p *patch.Patch, githubOauthToken str
 	}
 
 	// apply remote configuration patch if needed
-	if !p.IsGithubPRPatch() && p.ConfigChanged(projectRef.RemotePath) && p.PatchedConfig == "" {
-		project, err = model.MakePatchedConfig(ctx, p, projectRef.RemotePath, string(projectFileBytes))
+	if appliesDiff(p) && p.ConfigChanged(projectRef.RemotePath) && p.PatchedConfig == "" {
+		project, err = model.MakePatchedConfig(ctx, p, projectRef.RemotePath, string(projectFileBytes), includes)
 		if err != nil {
 			return nil, errors.Wrapf(err, "Could not patch remote configuration file")
 		}
@@ -83,9 +92,37 @@ func GetPatchedProject(ctx context.Context, p *patch.Patch, githubOauthToken str
 		}
 	} else {
 		// configuration is not patched
-		if err = model.LoadProjectInto(projectFileBytes, projectRef.Identifier, project); err != nil {
+		if err = model.LoadProjectWithIncludes(ctx, projectFileBytes, projectRef.Identifier, includes, project); err != nil {
 			return nil, errors.WithStack(err)
 		}
 	}
 	return project, nil
 }
+
+// patchedIncludeFetcher returns an IncludeFetcher that applies the patch to
+// the files from the project's repository that it changes. Files in modules
+// are read at the modules' pinned refs, so module patches don't apply to
+// them.
+func patchedIncludeFetcher(p *patch.Patch, fetch model.IncludeFetcher) model.IncludeFetcher {
+	return func(ctx context.Context, module *model.Module, path string) ([]byte, error) {
+		changed := module == nil && appliesDiff(p) && p.ConfigChanged(path)
+		data, err := fetch(ctx, module, path)
+		if err != nil {
+			// the patch may add the file
+			if !(changed && thirdparty.IsFileNotFound(errors.Cause(err))) {
+				return nil, errors.WithStack(err)
+			}
+		}
+		if !changed {
+			return data, nil
+		}
+		return model.MakePatchedFile(ctx, p, path, string(data))
+	}
+}
+
+// appliesDiff returns true if the patch changes the project's repository by
+// applying a diff, rather than by checking out a pull request or a commit,
+// where the files are read as they are.
+func appliesDiff(p *patch.Patch) bool {
+	return !p.IsGithubPRPatch() && p.GetHead() == nil
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model/version"
 	"github.com/evergreen-ci/evergreen/testutil"
 	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/yaml.v2"
 )
@@ -259,3 +260,27 @@ func TestFinalizePatch(t *testing.T) {
 			})
 		})
 }
+
+func TestPatchedIncludeFetcherReadsHeadFilesAsTheyAre(t *testing.T) {
+	assert := assert.New(t)
+	fetch := func(ctx context.Context, module *model.Module, path string) ([]byte, error) {
+		return []byte("tasks: []"), nil
+	}
+	p := &patch.Patch{
+		Patches: []patch.ModulePatch{{
+			PatchSet: patch.PatchSet{Summary: []patch.Summary{{Name: "include.yml"}}},
+			Head:     &patch.PatchHead{Ref: "feature", HeadHash: "abcdef"},
+		}},
+	}
+	assert.False(appliesDiff(p))
+
+	// the file changes, but it's already changed at the head commit
+	data, err := patchedIncludeFetcher(p, fetch)(context.Background(), nil, "include.yml")
+	assert.NoError(err)
+	assert.Equal("tasks: []", string(data))
+
+	p.Patches[0].Head = nil
+	assert.True(appliesDiff(p))
+	p.GithubPatchData.PRNumber = 1
+	assert.False(appliesDiff(p))
+}
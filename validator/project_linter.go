@@ -0,0 +1,409 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/command"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/pkg/errors"
+)
+
+const (
+	// exec timeouts are only reported if they're more than
+	// lintExecTimeoutFactor times a task's longest average runtime over
+	// lintRuntimeWindow, and longer than lintExecTimeoutMinimum
+	lintExecTimeoutFactor  = 10
+	lintExecTimeoutMinimum = time.Hour
+	lintRuntimeWindow      = 30 * 24 * time.Hour
+)
+
+// Functions used to lint a project configuration file. Lint warnings point
+// out things that aren't errors, but are likely to be mistakes or leftovers.
+var projectLinters = []projectValidator{
+	lintUnusedFunctions,
+	lintUnreferencedTasks,
+	lintDuplicatePrePostCommands,
+	lintDeprecatedCommands,
+}
+
+// builtinExpansions are the expansions that are set for every task, by
+// model.PopulateExpansions and the agent.
+var builtinExpansions = []string{
+	"execution", "version_id", "task_id", "task_name", "build_id", "build_variant",
+	"workdir", "revision", "project", "branch_name", "author", "distro_id", "created_at",
+	"is_patch", "revision_order_id", "github_pr_number", "github_org", "github_repo",
+	"github_author",
+}
+
+// expansionRefRegex matches references to expansions without defaults,
+// such as "${name}", but not "${name|default}".
+var expansionRefRegex = regexp.MustCompile(`\$\{([^|{}]+)\}`)
+
+// CheckProjectLint checks the project configuration for smells. If the
+// project has an identifier, its project variables and the runtimes of its
+// recent tasks are checked against as well.
+func CheckProjectLint(project *model.Project) ([]ValidationError, error) {
+	validationErrs := []ValidationError{}
+	for _, projectLinter := range projectLinters {
+		validationErrs = append(validationErrs, projectLinter(project)...)
+	}
+
+	defined, err := getDefinedExpansions(project)
+	if err != nil {
+		return nil, errors.Wrap(err, "error finding defined expansions")
+	}
+	validationErrs = append(validationErrs, lintUndefinedExpansions(project, defined)...)
+
+	runtimes, err := getTaskRuntimes(project)
+	if err != nil {
+		return nil, errors.Wrap(err, "error finding task runtimes")
+	}
+	validationErrs = append(validationErrs, lintExecTimeouts(project, runtimes)...)
+
+	return validationErrs, nil
+}
+
+// getDefinedExpansions returns the names of the expansions that are defined
+// outside of the project configuration, by distros and project variables.
+func getDefinedExpansions(project *model.Project) (map[string]bool, error) {
+	defined := map[string]bool{}
+	distros, err := distro.Find(distro.All)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range distros {
+		for _, e := range d.Expansions {
+			defined[e.Key] = true
+		}
+	}
+
+	if project.Identifier == "" {
+		return defined, nil
+	}
+	vars, err := model.FindOneProjectVars(project.Identifier)
+	if err != nil {
+		return nil, err
+	}
+	if vars != nil {
+		for k := range vars.Vars {
+			defined[k] = true
+		}
+	}
+	return defined, nil
+}
+
+// getTaskRuntimes returns the longest average runtime of each of the
+// project's tasks on any of its variants.
+func getTaskRuntimes(project *model.Project) (map[string]time.Duration, error) {
+	runtimes := map[string]time.Duration{}
+	if project.Identifier == "" {
+		return runtimes, nil
+	}
+	for _, bv := range project.BuildVariants {
+		durations, err := task.ExpectedTaskDuration(project.Identifier, bv.Name, lintRuntimeWindow)
+		if err != nil {
+			return nil, err
+		}
+		for name, d := range durations {
+			if d > runtimes[name] {
+				runtimes[name] = d
+			}
+		}
+	}
+	return runtimes, nil
+}
+
+// sectionCommands is a list of commands and the part of the project they
+// are in.
+type sectionCommands struct {
+	section  string
+	commands []model.PluginCommandConf
+}
+
+// projectCommands returns all of the commands in the project, other than
+// those in function definitions.
+func projectCommands(project *model.Project) []sectionCommands {
+	sections := []sectionCommands{}
+	addSet := func(section string, set *model.YAMLCommandSet) {
+		if set != nil {
+			sections = append(sections, sectionCommands{section: section, commands: set.List()})
+		}
+	}
+	addSet("pre", project.Pre)
+	addSet("post", project.Post)
+	addSet("timeout", project.Timeout)
+	for _, tg := range project.TaskGroups {
+		addSet(fmt.Sprintf("task group '%s' setup_group", tg.Name), tg.SetupGroup)
+		addSet(fmt.Sprintf("task group '%s' setup_task", tg.Name), tg.SetupTask)
+		addSet(fmt.Sprintf("task group '%s' teardown_task", tg.Name), tg.TeardownTask)
+		addSet(fmt.Sprintf("task group '%s' teardown_group", tg.Name), tg.TeardownGroup)
+		addSet(fmt.Sprintf("task group '%s' timeout", tg.Name), tg.Timeout)
+	}
+	for _, t := range project.Tasks {
+		sections = append(sections, sectionCommands{section: fmt.Sprintf("task '%s'", t.Name), commands: t.Commands})
+	}
+	return sections
+}
+
+// functionNames returns the names of the project's functions in order.
+func functionNames(project *model.Project) []string {
+	names := []string{}
+	for name := range project.Functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Warns about functions that are never called
+func lintUnusedFunctions(project *model.Project) []ValidationError {
+	called := map[string]bool{}
+	for _, section := range projectCommands(project) {
+		for _, cmd := range section.commands {
+			if cmd.Function != "" {
+				called[cmd.Function] = true
+			}
+		}
+	}
+
+	errs := []ValidationError{}
+	for _, name := range functionNames(project) {
+		if !called[name] {
+			errs = append(errs, ValidationError{
+				Level:   Warning,
+				Message: fmt.Sprintf("function '%s' is never called", name),
+			})
+		}
+	}
+	return errs
+}
+
+// Warns about tasks that aren't run by any buildvariant
+func lintUnreferencedTasks(project *model.Project) []ValidationError {
+	groups := map[string][]string{}
+	for _, tg := range project.TaskGroups {
+		groups[tg.Name] = tg.Tasks
+	}
+	referenced := map[string]bool{}
+	for _, bv := range project.BuildVariants {
+		for _, t := range bv.Tasks {
+			referenced[t.Name] = true
+			for _, name := range groups[t.Name] {
+				referenced[name] = true
+			}
+		}
+	}
+
+	errs := []ValidationError{}
+	for _, t := range project.Tasks {
+		if !referenced[t.Name] {
+			errs = append(errs, ValidationError{
+				Level:   Warning,
+				Message: fmt.Sprintf("task '%s' is not in any buildvariant", t.Name),
+			})
+		}
+	}
+	return errs
+}
+
+// Warns about commands that appear more than once in the pre or post section
+func lintDuplicatePrePostCommands(project *model.Project) []ValidationError {
+	errs := []ValidationError{}
+	for _, section := range []sectionCommands{
+		{section: "pre", commands: commandSetList(project.Pre)},
+		{section: "post", commands: commandSetList(project.Post)},
+	} {
+		for i, cmd := range section.commands {
+			for _, prev := range section.commands[:i] {
+				if reflect.DeepEqual(cmd, prev) {
+					errs = append(errs, ValidationError{
+						Level:   Warning,
+						Message: fmt.Sprintf("%s section has duplicate %s", section.section, commandDescription(cmd)),
+					})
+					break
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// Warns about commands that are deprecated
+func lintDeprecatedCommands(project *model.Project) []ValidationError {
+	sections := projectCommands(project)
+	for _, name := range functionNames(project) {
+		sections = append(sections, sectionCommands{
+			section:  fmt.Sprintf("function '%s'", name),
+			commands: commandSetList(project.Functions[name]),
+		})
+	}
+
+	errs := []ValidationError{}
+	for _, section := range sections {
+		for _, cmd := range section.commands {
+			if note, ok := command.IsDeprecated(cmd.Command); ok {
+				errs = append(errs, ValidationError{
+					Level:   Warning,
+					Message: fmt.Sprintf("%s uses deprecated command '%s': %s", section.section, cmd.Command, note),
+				})
+			}
+		}
+	}
+	return errs
+}
+
+// Warns about expansions that are referenced without a default, but that
+// aren't defined by the project, its variants, distros or project
+// variables. Expansions can be read from files with expansions.update, so
+// projects that do that aren't checked.
+func lintUndefinedExpansions(project *model.Project, defined map[string]bool) []ValidationError {
+	isDefined := map[string]bool{}
+	for k := range defined {
+		isDefined[k] = true
+	}
+	for _, name := range builtinExpansions {
+		isDefined[name] = true
+	}
+	for _, bv := range project.BuildVariants {
+		for k := range bv.Expansions {
+			isDefined[k] = true
+		}
+	}
+
+	sections := projectCommands(project)
+	for _, name := range functionNames(project) {
+		sections = append(sections, sectionCommands{
+			section:  fmt.Sprintf("function '%s'", name),
+			commands: commandSetList(project.Functions[name]),
+		})
+	}
+	for _, section := range sections {
+		for _, cmd := range section.commands {
+			for k := range cmd.Vars {
+				isDefined[k] = true
+			}
+			if cmd.Command != "expansions.update" {
+				continue
+			}
+			if _, ok := cmd.Params["file"]; ok {
+				return nil
+			}
+			updates, _ := cmd.Params["updates"].([]interface{})
+			for _, update := range updates {
+				if u, ok := update.(map[interface{}]interface{}); ok {
+					isDefined[fmt.Sprint(u["key"])] = true
+				}
+			}
+		}
+	}
+
+	errs := []ValidationError{}
+	reported := map[string]bool{}
+	for _, section := range sections {
+		for _, cmd := range section.commands {
+			refs := append(expansionRefs(cmd.Params), expansionRefs(cmd.Vars)...)
+			for _, name := range refs {
+				if isDefined[name] || reported[section.section+name] {
+					continue
+				}
+				reported[section.section+name] = true
+				errs = append(errs, ValidationError{
+					Level:   Warning,
+					Message: fmt.Sprintf("%s references expansion '%s', which is never defined", section.section, name),
+				})
+			}
+		}
+	}
+	return errs
+}
+
+// Warns about tasks whose exec timeouts are far longer than they've taken
+// to run
+func lintExecTimeouts(project *model.Project, runtimes map[string]time.Duration) []ValidationError {
+	errs := []ValidationError{}
+	for _, t := range project.Tasks {
+		timeoutSecs := t.ExecTimeoutSecs
+		if timeoutSecs == 0 {
+			timeoutSecs = project.ExecTimeoutSecs
+		}
+		runtime, ok := runtimes[t.Name]
+		if timeoutSecs == 0 || !ok || runtime <= 0 {
+			continue
+		}
+		timeout := time.Duration(timeoutSecs) * time.Second
+		if timeout > lintExecTimeoutMinimum && timeout > lintExecTimeoutFactor*runtime {
+			errs = append(errs, ValidationError{
+				Level: Warning,
+				Message: fmt.Sprintf("task '%s' has an exec timeout of %s, but has taken %s on average",
+					t.Name, timeout, runtime.Round(time.Second)),
+			})
+		}
+	}
+	return errs
+}
+
+// expansionRefs returns the names of the expansions that are referenced
+// without defaults in the given command parameters, in order.
+func expansionRefs(in interface{}) []string {
+	names := []string{}
+	switch v := in.(type) {
+	case string:
+		for _, match := range expansionRefRegex.FindAllStringSubmatch(v, -1) {
+			names = append(names, match[1])
+		}
+	case map[string]string:
+		keys := []string{}
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			names = append(names, expansionRefs(v[k])...)
+		}
+	case map[string]interface{}:
+		keys := []string{}
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			names = append(names, expansionRefs(v[k])...)
+		}
+	case map[interface{}]interface{}:
+		keys := []string{}
+		values := map[string]interface{}{}
+		for k, value := range v {
+			keys = append(keys, fmt.Sprint(k))
+			values[fmt.Sprint(k)] = value
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			names = append(names, expansionRefs(values[k])...)
+		}
+	case []interface{}:
+		for _, value := range v {
+			names = append(names, expansionRefs(value)...)
+		}
+	}
+	return names
+}
+
+func commandSetList(set *model.YAMLCommandSet) []model.PluginCommandConf {
+	if set == nil {
+		return nil
+	}
+	return set.List()
+}
+
+func commandDescription(cmd model.PluginCommandConf) string {
+	if cmd.Function != "" {
+		return fmt.Sprintf("call of function '%s'", cmd.Function)
+	}
+	return fmt.Sprintf("command '%s'", cmd.Command)
+}
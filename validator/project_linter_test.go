@@ -0,0 +1,164 @@
+package validator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintUnusedFunctions(t *testing.T) {
+	yml := `
+functions:
+  used:
+    command: shell.exec
+  used_in_group:
+    command: shell.exec
+  unused:
+    command: shell.exec
+task_groups:
+  - name: tg
+    setup_group:
+      - func: used_in_group
+    tasks: [compile]
+tasks:
+  - name: compile
+    commands:
+      - func: used
+`
+	project := &model.Project{}
+	require.NoError(t, model.LoadProjectInto([]byte(yml), "", project))
+	errs := lintUnusedFunctions(project)
+	require.Len(t, errs, 1)
+	assert.Equal(t, Warning, errs[0].Level)
+	assert.Equal(t, "function 'unused' is never called", errs[0].Message)
+}
+
+func TestLintUnreferencedTasks(t *testing.T) {
+	project := &model.Project{
+		Tasks: []model.ProjectTask{{Name: "compile"}, {Name: "grouped"}, {Name: "orphan"}},
+		TaskGroups: []model.TaskGroup{
+			{Name: "tg", Tasks: []string{"grouped"}},
+		},
+		BuildVariants: []model.BuildVariant{
+			{Name: "bv", Tasks: []model.BuildVariantTaskUnit{{Name: "compile"}, {Name: "tg", IsGroup: true}}},
+		},
+	}
+	errs := lintUnreferencedTasks(project)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "task 'orphan' is not in any buildvariant", errs[0].Message)
+}
+
+func TestLintDuplicatePrePostCommands(t *testing.T) {
+	yml := `
+pre:
+  - command: shell.exec
+    params:
+      script: echo one
+  - command: shell.exec
+    params:
+      script: echo two
+  - command: shell.exec
+    params:
+      script: echo one
+post:
+  - func: cleanup
+  - func: cleanup
+`
+	project := &model.Project{}
+	require.NoError(t, model.LoadProjectInto([]byte(yml), "", project))
+	errs := lintDuplicatePrePostCommands(project)
+	require.Len(t, errs, 2)
+	assert.Equal(t, "pre section has duplicate command 'shell.exec'", errs[0].Message)
+	assert.Equal(t, "post section has duplicate call of function 'cleanup'", errs[1].Message)
+}
+
+func TestLintDeprecatedCommands(t *testing.T) {
+	yml := `
+functions:
+  fetch:
+    command: expansions.fetch_vars
+pre:
+  - command: shell.track
+tasks:
+  - name: compile
+    commands:
+      - command: git.get_project
+      - command: git.apply_patch
+`
+	project := &model.Project{}
+	require.NoError(t, model.LoadProjectInto([]byte(yml), "", project))
+	errs := lintDeprecatedCommands(project)
+	require.Len(t, errs, 3)
+	assert.Contains(t, errs[0].Message, "pre uses deprecated command 'shell.track'")
+	assert.Contains(t, errs[1].Message, "task 'compile' uses deprecated command 'git.apply_patch'")
+	assert.Contains(t, errs[2].Message, "function 'fetch' uses deprecated command 'expansions.fetch_vars'")
+}
+
+func TestLintUndefinedExpansions(t *testing.T) {
+	assert := assert.New(t)
+	yml := `
+functions:
+  run:
+    command: shell.exec
+    params:
+      script: ${script_dir}/run.sh ${suite} ${undefined_in_function}
+tasks:
+  - name: compile
+    commands:
+      - command: expansions.update
+        params:
+          updates:
+            - key: updated
+              value: foo
+      - command: shell.exec
+        params:
+          working_dir: ${workdir}/src
+          script: make ${target} ${updated} ${optional|} ${from_var} ${distro_var} ${undefined} ${undefined}
+      - func: run
+        vars:
+          suite: ${target}
+          extra: ${undefined_in_vars}
+buildvariants:
+  - name: bv
+    expansions:
+      target: all
+      script_dir: scripts
+`
+	project := &model.Project{}
+	require.NoError(t, model.LoadProjectInto([]byte(yml), "", project))
+	errs := lintUndefinedExpansions(project, map[string]bool{"from_var": true, "distro_var": true})
+	require.Len(t, errs, 3)
+	assert.Equal("task 'compile' references expansion 'undefined', which is never defined", errs[0].Message)
+	assert.Equal("task 'compile' references expansion 'undefined_in_vars', which is never defined", errs[1].Message)
+	assert.Equal("function 'run' references expansion 'undefined_in_function', which is never defined", errs[2].Message)
+
+	// expansions can come from anywhere when they're read from a file
+	project.Tasks[0].Commands = append(project.Tasks[0].Commands, model.PluginCommandConf{
+		Command: "expansions.update",
+		Params:  map[string]interface{}{"file": "expansions.yml"},
+	})
+	assert.Empty(lintUndefinedExpansions(project, nil))
+}
+
+func TestLintExecTimeouts(t *testing.T) {
+	project := &model.Project{
+		ExecTimeoutSecs: 4 * 60 * 60,
+		Tasks: []model.ProjectTask{
+			{Name: "quick"},
+			{Name: "slow"},
+			{Name: "short_timeout", ExecTimeoutSecs: 30 * 60},
+			{Name: "no_history"},
+		},
+	}
+	runtimes := map[string]time.Duration{
+		"quick":         5 * time.Minute,
+		"slow":          2 * time.Hour,
+		"short_timeout": time.Minute,
+	}
+	errs := lintExecTimeouts(project, runtimes)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "task 'quick' has an exec timeout of 4h0m0s, but has taken 5m0s on average", errs[0].Message)
+}
@@ -50,6 +50,7 @@ var projectSyntaxValidators = []projectValidator{
 	verifyTaskDependencies,
 	verifyTaskRequirements,
 	validateBVNames,
+	validateBVExtends,
 	validateDisplayTaskNames,
 	validateBVTaskNames,
 	checkAllDependenciesSpec,
@@ -483,6 +484,52 @@ func validateBVNames(project *model.Project) []ValidationError {
 	return errs
 }
 
+// validateBVExtends ensures that every variant that extends another variant
+// extends one that exists, and that no variant extends itself through the
+// variants it extends.
+func validateBVExtends(project *model.Project) []ValidationError {
+	errs := []ValidationError{}
+	parents := map[string]string{}
+	for _, buildVariant := range project.BuildVariants {
+		parents[buildVariant.Name] = buildVariant.Extends
+	}
+
+	for _, buildVariant := range project.BuildVariants {
+		if buildVariant.Extends == "" {
+			continue
+		}
+		if _, ok := parents[buildVariant.Extends]; !ok {
+			errs = append(errs,
+				ValidationError{
+					Message: fmt.Sprintf("buildvariant '%s' extends nonexistent buildvariant '%s'",
+						buildVariant.Name, buildVariant.Extends),
+				},
+			)
+			continue
+		}
+
+		chain := []string{buildVariant.Name}
+		for name := parents[buildVariant.Name]; name != ""; name = parents[name] {
+			chain = append(chain, name)
+			if name == buildVariant.Name {
+				errs = append(errs,
+					ValidationError{
+						Message: fmt.Sprintf("buildvariant '%s' extends itself: %s",
+							buildVariant.Name, strings.Join(chain, " -> ")),
+					},
+				)
+				break
+			}
+			// the cycle doesn't include this variant, and is reported
+			// for the variants it does include
+			if util.StringSliceContains(chain[:len(chain)-1], name) {
+				break
+			}
+		}
+	}
+	return errs
+}
+
 // produce a deprecation warning for specifying more than one distro for a task or build variant.
 func checkRunOnOnlyOneDistro(project *model.Project) []ValidationError {
 	errs := []ValidationError{}
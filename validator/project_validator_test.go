@@ -1840,3 +1840,42 @@ buildvariants:
 	assert.NoError(err)
 	assert.Len(semanticErrs, 0)
 }
+
+func TestValidateBVExtends(t *testing.T) {
+	assert := assert.New(t)
+
+	project := &model.Project{
+		BuildVariants: []model.BuildVariant{
+			{Name: "base"},
+			{Name: "linux", Extends: "base"},
+			{Name: "missing", Extends: "nonexistent"},
+			{Name: "a", Extends: "b"},
+			{Name: "b", Extends: "a"},
+			{Name: "self", Extends: "self"},
+			{Name: "c", Extends: "a"},
+		},
+	}
+	errs := validateBVExtends(project)
+	require.Len(t, errs, 4)
+	assert.Equal("buildvariant 'missing' extends nonexistent buildvariant 'nonexistent'", errs[0].Message)
+	assert.Equal("buildvariant 'a' extends itself: a -> b -> a", errs[1].Message)
+	assert.Equal("buildvariant 'b' extends itself: b -> a -> b", errs[2].Message)
+	assert.Equal("buildvariant 'self' extends itself: self -> self", errs[3].Message)
+
+	yml := `
+tasks:
+- name: compile
+buildvariants:
+- name: a
+  extends: b
+  tasks:
+  - name: compile
+- name: b
+  extends: a
+`
+	proj := model.Project{}
+	require.NoError(t, model.LoadProjectInto([]byte(yml), "", &proj))
+	errs = validateBVExtends(&proj)
+	require.Len(t, errs, 2)
+	assert.Equal("buildvariant 'a' extends itself: a -> b -> a", errs[0].Message)
+}